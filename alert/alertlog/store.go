@@ -372,6 +372,16 @@ func (s *Store) logEntry(ctx context.Context, tx *sql.Tx, _type Type, meta inter
 				r.subject.classifier = "Site24x7"
 			case integrationkey.TypeEmail:
 				r.subject.classifier = "Email"
+			case integrationkey.TypeAmazonSNS:
+				r.subject.classifier = "Amazon SNS"
+			case integrationkey.TypeZabbix:
+				r.subject.classifier = "Zabbix"
+			case integrationkey.TypeNagios:
+				r.subject.classifier = "Nagios"
+			case integrationkey.TypeIcinga:
+				r.subject.classifier = "Icinga"
+			case integrationkey.TypeCloudEvents:
+				r.subject.classifier = "CloudEvents"
 			}
 			r.subject.integrationKeyID.Valid = true
 			r.subject.integrationKeyID.UUID = uuid.MustParse(src.ID)
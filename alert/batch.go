@@ -0,0 +1,130 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/event"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// A BatchItem is an alert to create or update with CreateOrUpdateBatch.
+type BatchItem struct {
+	Alert *Alert
+
+	// Meta is set on the alert if it is new.
+	Meta map[string]string
+}
+
+// A BatchResult is the result of a single BatchItem.
+type BatchResult struct {
+	// Alert is the current alert, or nil if the alert was already closed or doesn't exist.
+	Alert *Alert
+	IsNew bool
+
+	// Err is set if the item was invalid or exceeded a limit (e.g., too many unacknowledged alerts).
+	Err error
+}
+
+// CreateOrUpdateBatch behaves like CreateOrUpdateWithMeta for each item, but
+// all items are processed in a single transaction.
+//
+// Items that fail validation or exceed a limit will have Err set, without affecting
+// the other items. Any other error aborts the transaction and is returned.
+func (s *Store) CreateOrUpdateBatch(ctx context.Context, items []BatchItem) ([]BatchResult, error) {
+	err := permission.LimitCheckAny(ctx,
+		permission.System,
+		permission.Admin,
+		permission.User,
+		permission.Service,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.Range("Items", len(items), 1, maxBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlutil.Rollback(ctx, "alert: upsert batch", tx)
+
+	results := make([]BatchResult, len(items))
+	for i, item := range items {
+		res := &results[i]
+		res.Err = ValidateMetadata(item.Meta)
+		if res.Err != nil {
+			continue
+		}
+		_, res.Err = item.Alert.Normalize()
+		if res.Err != nil {
+			continue
+		}
+
+		res.Alert, res.IsNew, res.Err = s.createOrUpdateBatchItemTx(ctx, tx, item)
+		if res.Err != nil && !validation.IsClientError(res.Err) {
+			return nil, res.Err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, res := range results {
+		if res.Alert == nil {
+			continue
+		}
+		if res.IsNew {
+			ctx := log.WithFields(ctx, log.Fields{"AlertID": res.Alert.ID, "ServiceID": res.Alert.ServiceID})
+			log.Logf(ctx, "Alert created.")
+			metricCreatedTotal.WithLabelValues(res.Alert.ServiceID).Inc()
+		}
+
+		event.Send(ctx, s.evt, EventAlertStatusUpdate{AlertID: int64(res.Alert.ID), Status: res.Alert.Status, Created: res.IsNew})
+	}
+
+	return results, nil
+}
+
+// createOrUpdateBatchItemTx calls CreateOrUpdateTx within a savepoint, so that a
+// limit error only rolls back the single item.
+func (s *Store) createOrUpdateBatchItemTx(ctx context.Context, tx *sql.Tx, item BatchItem) (*Alert, bool, error) {
+	_, err := tx.ExecContext(ctx, "savepoint batch_item")
+	if err != nil {
+		return nil, false, err
+	}
+
+	n, isNew, err := s.CreateOrUpdateTx(ctx, tx, item.Alert)
+	if err == nil && item.Meta != nil && isNew {
+		err = s.SetMetadataTx(ctx, tx, n.ID, item.Meta)
+	}
+	if err != nil {
+		err = errutil.MapDBError(err)
+		if !validation.IsClientError(err) {
+			return nil, false, err
+		}
+
+		_, rbErr := tx.ExecContext(ctx, "rollback to savepoint batch_item")
+		if rbErr != nil {
+			return nil, false, rbErr
+		}
+		return nil, false, err
+	}
+
+	_, err = tx.ExecContext(ctx, "release savepoint batch_item")
+	if err != nil {
+		return nil, false, err
+	}
+
+	return n, isNew, nil
+}
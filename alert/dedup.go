@@ -44,9 +44,14 @@ func ParseDedupString(s string) (*DedupID, error) {
 	}, nil
 }
 
+// String returns the string representation of the DedupID, as stored in the database.
+func (d DedupID) String() string {
+	return fmt.Sprintf("%s:%d:%s", d.Type, d.Version, d.Payload)
+}
+
 // Value implements the driver.Valuer interface.
 func (d DedupID) Value() (driver.Value, error) {
-	return fmt.Sprintf("%s:%d:%s", d.Type, d.Version, d.Payload), nil
+	return d.String(), nil
 }
 
 // Scan implements the sql.Scanner interface.
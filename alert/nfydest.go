@@ -14,6 +14,13 @@ const (
 	ParamDetails = "details"
 	ParamDedup   = "dedup"
 	ParamClose   = "close"
+	ParamAction  = "action"
+	ParamMeta    = "meta"
+
+	ActionTrigger  = "trigger"
+	ActionAck      = "ack"
+	ActionEscalate = "escalate"
+	ActionClose    = "close"
 
 	FallbackIconURL = "builtin://alert"
 )
@@ -42,7 +49,15 @@ func (s *Store) TypeInfo(ctx context.Context) (*nfydest.TypeInfo, error) {
 		}, {
 			ParamID: ParamClose,
 			Label:   "Close",
-			Hint:    "If true, close an existing alert.",
+			Hint:    "If true, close an existing alert (same as an action of close).",
+		}, {
+			ParamID: ParamAction,
+			Label:   "Action",
+			Hint:    "One of trigger (default), ack, escalate, or close. Existing alerts are matched by dedup, or by summary and details if dedup is empty.",
+		}, {
+			ParamID: ParamMeta,
+			Label:   "Metadata",
+			Hint:    "JSON object of metadata to set on the alert, e.g., toJSON({team: req.body.team}). Replaces any existing metadata.",
 		}},
 	}, nil
 }
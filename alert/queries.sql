@@ -158,3 +158,13 @@ FROM
 WHERE
     a.id = @id::bigint;
 
+
+-- name: Alert_FindOpenByDedup :one
+-- Returns the ID of the open alert of a service with the given dedup key.
+SELECT
+    id
+FROM
+    alerts
+WHERE
+    service_id = @service_id::uuid
+    AND dedup_key = @dedup_key::text;
@@ -17,6 +17,11 @@ const (
 	SourceManual                 Source = "manual"                 // manually triggered
 	SourceGeneric                Source = "generic"                // generic API
 	SourceUniversal              Source = "universal"              // universal integration
+	SourceAmazonSNS              Source = "amazonSNS"              // amazon sns (e.g., cloudwatch alarms)
+	SourceZabbix                 Source = "zabbix"                 // zabbix alert
+	SourceNagios                 Source = "nagios"                 // nagios alert
+	SourceIcinga                 Source = "icinga"                 // icinga alert
+	SourceCloudEvents            Source = "cloudEvents"            // cloudevents API
 )
 
 func (s Source) Value() (driver.Value, error) {
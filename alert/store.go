@@ -740,6 +740,37 @@ func (s *Store) UpdateStatus(ctx context.Context, id int, stat Status) error {
 	return tx.Commit()
 }
 
+// FindOpenByDedup returns the ID of the open alert of a service with the given
+// dedup ID, or 0 if there is none.
+func (s *Store) FindOpenByDedup(ctx context.Context, serviceID string, dedup *DedupID) (int, error) {
+	err := permission.LimitCheckAny(ctx,
+		permission.System,
+		permission.Admin,
+		permission.User,
+		permission.MatchService(serviceID),
+	)
+	if err != nil {
+		return 0, err
+	}
+	svcID, err := validate.ParseUUID("ServiceID", serviceID)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := gadb.New(s.db).Alert_FindOpenByDedup(ctx, gadb.Alert_FindOpenByDedupParams{
+		ServiceID: svcID,
+		DedupKey:  dedup.String(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
 func (s *Store) FindOne(ctx context.Context, id int) (*Alert, error) {
 	alerts, err := s.FindMany(ctx, []int{id})
 	if err != nil {
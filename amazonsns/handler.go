@@ -0,0 +1,195 @@
+package amazonsns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// maxBodySize is the maximum size of an SNS message body (SNS messages are limited to 256KiB, plus the envelope).
+const maxBodySize = 512 * 1024
+
+// Config configures the Amazon SNS integration.
+type Config struct {
+	AlertStore *alert.Store
+
+	// Client is used to confirm subscriptions.
+	Client *http.Client
+
+	// Verifier validates message signatures.
+	Verifier *Verifier
+}
+
+// Handler accepts SNS messages, confirming subscriptions and mapping CloudWatch alarms to alerts.
+type Handler struct {
+	c Config
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(c Config) *Handler { return &Handler{c: c} }
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// confirm will confirm a subscription by visiting the SubscribeURL of the message.
+func (h *Handler) confirm(ctx context.Context, m Message) error {
+	u, err := validateSNSURL("SubscribeURL", m.SubscribeURL)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := h.c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// alarmURL returns the console URL of a CloudWatch alarm.
+func alarmURL(a Alarm) string {
+	// arn:aws:cloudwatch:<region>:<account>:alarm:<name>
+	parts := strings.SplitN(a.AlarmArn, ":", 7)
+	if len(parts) != 7 || parts[3] == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("https://console.aws.amazon.com/cloudwatch/home?region=%s#alarmsV2:alarm/%s", url.QueryEscape(parts[3]), url.PathEscape(a.AlarmName))
+}
+
+// newAlert returns the alert for a notification, or nil if it should be ignored.
+func newAlert(serviceID string, m Message) *alert.Alert {
+	var a Alarm
+	err := json.Unmarshal([]byte(m.Message), &a)
+	if err != nil || a.AlarmArn == "" {
+		// not a CloudWatch alarm, alert with the notification itself
+		summary := m.Subject
+		if summary == "" {
+			summary, _, _ = strings.Cut(m.Message, "\n")
+		}
+		return &alert.Alert{
+			Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(m.Message, alert.MaxDetailsLength),
+			Status:    alert.StatusTriggered,
+			Source:    alert.SourceAmazonSNS,
+			ServiceID: serviceID,
+		}
+	}
+
+	var status alert.Status
+	switch a.NewStateValue {
+	case StateAlarm:
+		status = alert.StatusTriggered
+	case StateOK:
+		status = alert.StatusClosed
+	default:
+		return nil
+	}
+
+	details := strings.TrimSpace(strings.Join([]string{a.AlarmDescription, a.NewStateReason, a.Region, alarmURL(a)}, "\n\n"))
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(a.AlarmName, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(details, alert.MaxDetailsLength),
+		Status:    status,
+		Source:    alert.SourceAmazonSNS,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup(a.AlarmArn),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := permission.LimitCheckAny(ctx, permission.Service)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	serviceID := permission.ServiceID(ctx)
+
+	var m Message
+	err = json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&m)
+	if clientError(w, http.StatusBadRequest, err) {
+		log.Logf(ctx, "bad request from amazon sns: %v", err)
+		return
+	}
+
+	ctx = log.WithFields(ctx, log.Fields{
+		"Type":      m.Type,
+		"TopicArn":  m.TopicArn,
+		"MessageId": m.MessageId,
+	})
+
+	err = h.c.Verifier.Verify(ctx, m, time.Now())
+	if clientError(w, http.StatusForbidden, err) {
+		log.Logf(ctx, "bad request from amazon sns: %v", err)
+		return
+	}
+
+	switch m.Type {
+	case TypeSubscriptionConfirmation:
+		err = h.confirm(ctx, m)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "confirm amazon sns subscription")) {
+			return
+		}
+		log.Logf(ctx, "confirmed amazon sns subscription")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case TypeUnsubscribeConfirmation:
+		log.Logf(ctx, "amazon sns subscription removed")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case TypeNotification:
+	default:
+		log.Logf(ctx, "bad request from amazon sns: unknown message type")
+		http.Error(w, "unknown message type", http.StatusBadRequest)
+		return
+	}
+
+	msg := newAlert(serviceID, m)
+	if msg == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	err = retry.DoTemporaryError(func(int) error {
+		_, _, err = h.c.AlertStore.CreateOrUpdate(ctx, msg)
+		return err
+	},
+		retry.Log(ctx),
+		retry.Limit(10),
+		retry.FibBackoff(time.Second),
+	)
+	if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for amazon sns")) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,42 @@
+package amazonsns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestNewAlert(t *testing.T) {
+	const arn = "arn:aws:cloudwatch:us-east-1:123456789012:alarm:HighLatency"
+	m := Message{
+		Type:    TypeNotification,
+		Subject: `ALARM: "HighLatency" in US East (N. Virginia)`,
+		Message: `{"AlarmName":"HighLatency","AlarmDescription":"p99 latency above 2s","NewStateValue":"ALARM","NewStateReason":"Threshold Crossed","Region":"US East (N. Virginia)","AlarmArn":"` + arn + `"}`,
+	}
+
+	a := newAlert("svc", m)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, "HighLatency", a.Summary)
+	assert.Equal(t, alert.NewUserDedup(arn), a.Dedup)
+	assert.Contains(t, a.Details, "https://console.aws.amazon.com/cloudwatch/home?region=us-east-1#alarmsV2:alarm/HighLatency")
+
+	m.Message = `{"AlarmName":"HighLatency","NewStateValue":"OK","AlarmArn":"` + arn + `"}`
+	a = newAlert("svc", m)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusClosed, a.Status)
+	assert.Equal(t, alert.NewUserDedup(arn), a.Dedup)
+
+	m.Message = `{"AlarmName":"HighLatency","NewStateValue":"INSUFFICIENT_DATA","AlarmArn":"` + arn + `"}`
+	assert.Nil(t, newAlert("svc", m))
+
+	m.Subject = ""
+	m.Message = "Backup failed\nJob 42 exited with status 1"
+	a = newAlert("svc", m)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, "Backup failed", a.Summary)
+	assert.Nil(t, a.Dedup)
+}
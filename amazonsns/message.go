@@ -0,0 +1,73 @@
+package amazonsns
+
+import (
+	"strings"
+)
+
+// Message types sent by SNS.
+const (
+	TypeNotification             = "Notification"
+	TypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	TypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// Message is an HTTP(S) message delivered by SNS.
+type Message struct {
+	Type             string
+	MessageId        string
+	Token            string
+	TopicArn         string
+	Subject          string
+	Message          string
+	SubscribeURL     string
+	Timestamp        string
+	SignatureVersion string
+	Signature        string
+	SigningCertURL   string
+}
+
+// signingString returns the canonical string that is signed by SNS for the message.
+func (m Message) signingString() string {
+	var b strings.Builder
+	add := func(name, value string) {
+		b.WriteString(name)
+		b.WriteString("\n")
+		b.WriteString(value)
+		b.WriteString("\n")
+	}
+
+	add("Message", m.Message)
+	add("MessageId", m.MessageId)
+	if m.Type == TypeNotification {
+		if m.Subject != "" {
+			add("Subject", m.Subject)
+		}
+	} else {
+		add("SubscribeURL", m.SubscribeURL)
+	}
+	add("Timestamp", m.Timestamp)
+	if m.Type != TypeNotification {
+		add("Token", m.Token)
+	}
+	add("TopicArn", m.TopicArn)
+	add("Type", m.Type)
+
+	return b.String()
+}
+
+// Alarm is a CloudWatch alarm state change, delivered as the message of an SNS notification.
+type Alarm struct {
+	AlarmName        string
+	AlarmDescription string
+	AlarmArn         string
+	NewStateValue    string
+	NewStateReason   string
+	Region           string
+}
+
+// Alarm states.
+const (
+	StateAlarm            = "ALARM"
+	StateOK               = "OK"
+	StateInsufficientData = "INSUFFICIENT_DATA"
+)
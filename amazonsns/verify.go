@@ -0,0 +1,158 @@
+package amazonsns
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+)
+
+// snsHost matches the hostname of SNS endpoints, which serve signing certificates and subscription confirmations.
+var snsHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// CertFetcher returns the PEM-encoded signing certificate at the given URL.
+type CertFetcher func(ctx context.Context, certURL string) ([]byte, error)
+
+// HTTPCertFetcher returns a CertFetcher that downloads certificates using the provided client.
+func HTTPCertFetcher(c *http.Client) CertFetcher {
+	return func(ctx context.Context, certURL string) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", certURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected response status: %s", resp.Status)
+		}
+
+		return io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	}
+}
+
+// validateSNSURL returns an error if u is not an HTTPS URL of an SNS endpoint.
+func validateSNSURL(name, u string) (*url.URL, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if parsed.Scheme != "https" || !snsHost.MatchString(parsed.Hostname()) || parsed.Port() != "" {
+		return nil, fmt.Errorf("invalid %s: must be an https URL of an SNS endpoint", name)
+	}
+
+	return parsed, nil
+}
+
+// Verifier validates the signatures of SNS messages.
+type Verifier struct {
+	fetch CertFetcher
+
+	mx    sync.Mutex
+	certs *lru.Cache
+}
+
+// NewVerifier creates a new Verifier that uses fetch to download signing certificates. Certificates
+// are cached by URL.
+func NewVerifier(fetch CertFetcher) *Verifier {
+	return &Verifier{fetch: fetch, certs: lru.New(100)}
+}
+
+func (v *Verifier) cert(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	u, err := validateSNSURL("SigningCertURL", certURL)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(u.Path, ".pem") {
+		return nil, errors.New("invalid SigningCertURL: must be a .pem file")
+	}
+
+	v.mx.Lock()
+	c, ok := v.certs.Get(certURL)
+	v.mx.Unlock()
+	if ok {
+		return c.(*x509.Certificate), nil
+	}
+
+	data, err := v.fetch(ctx, certURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch signing cert: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("signing cert: no PEM certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse signing cert: %w", err)
+	}
+	if _, ok := cert.PublicKey.(*rsa.PublicKey); !ok {
+		return nil, errors.New("signing cert: unsupported public key type")
+	}
+
+	v.mx.Lock()
+	v.certs.Add(certURL, cert)
+	v.mx.Unlock()
+
+	return cert, nil
+}
+
+// Verify will return an error if the message signature is invalid, or the signing certificate
+// is not valid at the time t.
+func (v *Verifier) Verify(ctx context.Context, m Message, t time.Time) error {
+	var hash crypto.Hash
+	switch m.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return fmt.Errorf("unsupported signature version '%s'", m.SignatureVersion)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+
+	cert, err := v.cert(ctx, m.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	if t.Before(cert.NotBefore) || t.After(cert.NotAfter) {
+		return errors.New("signing cert is expired or not yet valid")
+	}
+
+	var sum []byte
+	if hash == crypto.SHA1 {
+		s := sha1.Sum([]byte(m.signingString()))
+		sum = s[:]
+	} else {
+		s := sha256.Sum256([]byte(m.signingString()))
+		sum = s[:]
+	}
+
+	err = rsa.VerifyPKCS1v15(cert.PublicKey.(*rsa.PublicKey), hash, sum, sig)
+	if err != nil {
+		return errors.New("invalid message signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package amazonsns
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCertURL = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-test.pem"
+
+func newTestCert(t *testing.T, notAfter time.Time) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func sign(t *testing.T, key *rsa.PrivateKey, m *Message) {
+	t.Helper()
+	m.SignatureVersion = "2"
+	m.SigningCertURL = testCertURL
+	sum := sha256.Sum256([]byte(m.signingString()))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	require.NoError(t, err)
+	m.Signature = base64.StdEncoding.EncodeToString(sig)
+}
+
+func TestMessage_SigningString(t *testing.T) {
+	m := Message{
+		Type:      TypeNotification,
+		MessageId: "id",
+		TopicArn:  "arn",
+		Message:   "msg",
+		Timestamp: "ts",
+	}
+	assert.Equal(t, "Message\nmsg\nMessageId\nid\nTimestamp\nts\nTopicArn\narn\nType\nNotification\n", m.signingString())
+
+	m.Subject = "subj"
+	assert.Equal(t, "Message\nmsg\nMessageId\nid\nSubject\nsubj\nTimestamp\nts\nTopicArn\narn\nType\nNotification\n", m.signingString())
+
+	m.Type = TypeSubscriptionConfirmation
+	m.SubscribeURL = "url"
+	m.Token = "tok"
+	assert.Equal(t, "Message\nmsg\nMessageId\nid\nSubscribeURL\nurl\nTimestamp\nts\nToken\ntok\nTopicArn\narn\nType\nSubscriptionConfirmation\n", m.signingString())
+}
+
+func TestVerifier(t *testing.T) {
+	key, certPEM := newTestCert(t, time.Now().Add(time.Hour))
+	var fetched int
+	v := NewVerifier(func(ctx context.Context, certURL string) ([]byte, error) {
+		fetched++
+		if certURL != testCertURL {
+			return nil, errors.New("unexpected cert URL")
+		}
+		return certPEM, nil
+	})
+
+	m := Message{Type: TypeNotification, MessageId: "1", TopicArn: "arn:aws:sns:us-east-1:123456789012:alarms", Message: "hello", Timestamp: "2026-10-18T12:00:00.000Z"}
+	sign(t, key, &m)
+	require.NoError(t, v.Verify(context.Background(), m, time.Now()))
+	require.NoError(t, v.Verify(context.Background(), m, time.Now()))
+	assert.Equal(t, 1, fetched, "cert should be cached")
+
+	tampered := m
+	tampered.Message = "goodbye"
+	assert.Error(t, v.Verify(context.Background(), tampered, time.Now()))
+
+	assert.Error(t, v.Verify(context.Background(), m, time.Now().Add(2*time.Hour)), "expired cert")
+
+	for _, u := range []string{
+		"http://sns.us-east-1.amazonaws.com/cert.pem",
+		"https://sns.us-east-1.amazonaws.com.evil.example/cert.pem",
+		"https://evil.example/sns.us-east-1.amazonaws.com/cert.pem",
+		"https://sns.us-east-1.amazonaws.com/cert.txt",
+	} {
+		bad := m
+		bad.SigningCertURL = u
+		assert.Error(t, v.Verify(context.Background(), bad, time.Now()), u)
+	}
+
+	bad := m
+	bad.SignatureVersion = "3"
+	assert.Error(t, v.Verify(context.Background(), bad, time.Now()))
+}
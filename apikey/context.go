@@ -6,6 +6,7 @@ type contextKey int
 
 const (
 	contextKeyPolicy contextKey = iota
+	contextKeyScope
 )
 
 // PolicyFromContext returns the Policy associated with the given context.
@@ -18,3 +19,12 @@ func PolicyFromContext(ctx context.Context) *GQLPolicy {
 func ContextWithPolicy(ctx context.Context, p *GQLPolicy) context.Context {
 	return context.WithValue(ctx, contextKeyPolicy, p)
 }
+
+func scopeFromContext(ctx context.Context) *serviceScope {
+	s, _ := ctx.Value(contextKeyScope).(*serviceScope)
+	return s
+}
+
+func contextWithScope(ctx context.Context, s *serviceScope) context.Context {
+	return context.WithValue(ctx, contextKeyScope, s)
+}
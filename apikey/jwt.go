@@ -35,3 +35,20 @@ func NewGraphQLClaims(id uuid.UUID, policyHash []byte, expires time.Time) jwt.Cl
 		PolicyHash: policyHash,
 	}
 }
+
+// AudienceSCIM is the JWT audience for SCIM provisioning API keys.
+const AudienceSCIM = "apikey-v1/scim-v2"
+
+// NewSCIMClaims returns a new set of claims for a SCIM provisioning API key.
+func NewSCIMClaims(id uuid.UUID, expires time.Time) jwt.Claims {
+	n := time.Now()
+	return &jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Subject:   id.String(),
+		ExpiresAt: jwt.NewNumericDate(expires),
+		IssuedAt:  jwt.NewNumericDate(n),
+		NotBefore: jwt.NewNumericDate(n.Add(-time.Minute)),
+		Issuer:    Issuer,
+		Audience:  []string{AudienceSCIM},
+	}
+}
@@ -10,7 +10,10 @@ import (
 
 type Middleware struct{}
 
-var _ graphql.OperationParameterMutator = Middleware{}
+var (
+	_ graphql.OperationParameterMutator = Middleware{}
+	_ graphql.FieldInterceptor          = Middleware{}
+)
 
 func (Middleware) ExtensionName() string                          { return "GQLAPIKeyMiddleware" }
 func (Middleware) Validate(schema graphql.ExecutableSchema) error { return nil }
@@ -20,6 +23,10 @@ func (Middleware) MutateOperationParameters(ctx context.Context, rc *graphql.Raw
 	if p == nil {
 		return nil
 	}
+	if p.Version == 2 {
+		// Personal keys are not tied to a query, fields are checked by InterceptField.
+		return nil
+	}
 
 	if rc.Query == "" {
 		// Allow query to be omitted for API key requests,
@@ -46,3 +53,33 @@ func (Middleware) MutateOperationParameters(ctx context.Context, rc *graphql.Raw
 
 	return nil
 }
+
+// InterceptField enforces the allowed root fields and service scope of personal API keys.
+func (Middleware) InterceptField(ctx context.Context, next graphql.Resolver) (any, error) {
+	p := PolicyFromContext(ctx)
+	if p == nil || p.Version != 2 {
+		return next(ctx)
+	}
+
+	fc := graphql.GetFieldContext(ctx)
+	scope := scopeFromContext(ctx)
+	if fc.Object == "Query" || fc.Object == "Mutation" {
+		if !p.allowsField(fc.Object + "." + fc.Field.Name) {
+			return nil, permission.NewAccessDenied("field not allowed for API key: " + fc.Object + "." + fc.Field.Name)
+		}
+
+		if scope != nil && fc.Object == "Mutation" {
+			err := scope.checkMutation(ctx, fc.Field.Name, fc.Field.ArgumentMap(graphql.GetOperationContext(ctx).Variables))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	res, err := next(ctx)
+	if err != nil || scope == nil {
+		return res, err
+	}
+
+	return scope.filterResult(res)
+}
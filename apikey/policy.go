@@ -1,6 +1,11 @@
 package apikey
 
-import "github.com/target/goalert/permission"
+import (
+	"slices"
+	"strings"
+
+	"github.com/target/goalert/permission"
+)
 
 // GQLPolicy is a GraphQL API key policy.
 //
@@ -14,4 +19,27 @@ type GQLPolicy struct {
 	Version int
 	Query   string
 	Role    permission.Role
+
+	// UserID is the owner of a personal (version 2) key, requests are made on their behalf.
+	UserID string `json:",omitempty"`
+
+	// AllowedFields is the list of root fields (e.g., `Query.alerts`) a personal key may use.
+	AllowedFields []string `json:",omitempty"`
+
+	// ServiceLabels limits a personal key to services that have all of the listed labels (as `key=value`).
+	ServiceLabels []string `json:",omitempty"`
+
+	// RateLimit is the maximum number of requests per minute, zero means no limit.
+	RateLimit int `json:",omitempty"`
+}
+
+// allowsField returns true if the root field (e.g., `Query.alerts`) may be used with the policy.
+func (p GQLPolicy) allowsField(name string) bool {
+	_, field, _ := strings.Cut(name, ".")
+	if strings.HasPrefix(field, "__") {
+		// introspection
+		return true
+	}
+
+	return slices.Contains(p.AllowedFields, name)
 }
@@ -50,7 +50,10 @@ FROM
 WHERE
     gql_api_keys.id = $1
     AND gql_api_keys.deleted_at IS NULL
-    AND gql_api_keys.expires_at > now();
+    AND gql_api_keys.expires_at > now()
+    -- personal keys are only valid while their owner exists
+    AND (gql_api_keys.policy ->> 'UserID' IS NULL
+        OR gql_api_keys.created_by = (gql_api_keys.policy ->> 'UserID')::uuid);
 
 -- name: APIKeyAuthCheck :one
 SELECT
@@ -60,7 +63,10 @@ FROM
 WHERE
     gql_api_keys.id = $1
     AND gql_api_keys.deleted_at IS NULL
-    AND gql_api_keys.expires_at > now();
+    AND gql_api_keys.expires_at > now()
+    -- personal keys are only valid while their owner exists
+    AND (gql_api_keys.policy ->> 'UserID' IS NULL
+        OR gql_api_keys.created_by = (gql_api_keys.policy ->> 'UserID')::uuid);
 
 -- name: APIKeyList :many
 -- APIKeyList returns all API keys, along with the last time they were used.
@@ -75,3 +81,108 @@ FROM
 WHERE
     gql_api_keys.deleted_at IS NULL;
 
+-- name: APIKeyListByUser :many
+-- APIKeyListByUser returns all API keys created by the given user, along with the last time they were used.
+SELECT
+    gql_api_keys.*,
+    gql_api_key_usage.used_at AS last_used_at,
+    gql_api_key_usage.user_agent AS last_user_agent,
+    gql_api_key_usage.ip_address AS last_ip_address
+FROM
+    gql_api_keys
+    LEFT JOIN gql_api_key_usage ON gql_api_keys.id = gql_api_key_usage.api_key_id
+WHERE
+    gql_api_keys.deleted_at IS NULL
+    AND gql_api_keys.created_by = $1
+ORDER BY
+    gql_api_keys.name;
+
+-- name: APIKeyDeleteByUser :execrows
+-- APIKeyDeleteByUser deletes the API key with the given id, only if it was created by the given user.
+UPDATE
+    gql_api_keys
+SET
+    deleted_at = now(),
+    deleted_by = @user_id
+WHERE
+    id = @id
+    AND created_by = @user_id
+    AND deleted_at IS NULL;
+
+-- name: APIKeyScopedServices :many
+-- APIKeyScopedServices returns the IDs of all services that have every one of the given labels (as key=value).
+SELECT
+    tgt_service_id::uuid
+FROM
+    labels
+WHERE
+    tgt_service_id IS NOT NULL
+    AND (key || '=' || value) = ANY (@labels::text[])
+GROUP BY
+    tgt_service_id
+HAVING
+    count(*) = cardinality(@labels::text[]);
+
+-- name: APIKeyAlertServices :many
+-- APIKeyAlertServices returns the service IDs of the given alerts.
+SELECT DISTINCT
+    service_id
+FROM
+    alerts
+WHERE
+    id = ANY (@alert_ids::bigint[]);
+
+-- name: SCIMKeyInsert :exec
+INSERT INTO scim_api_keys(id, name, description, created_by, expires_at)
+    VALUES ($1, $2, $3, $4, $5);
+
+-- name: SCIMKeyDelete :exec
+UPDATE
+    scim_api_keys
+SET
+    deleted_at = now(),
+    deleted_by = $2
+WHERE
+    id = $1;
+
+-- name: SCIMKeyAuthCheck :one
+-- SCIMKeyAuthCheck returns true if the SCIM key exists, is not deleted, and is not expired.
+-- The last used time is updated at most once per minute.
+WITH valid AS (
+    SELECT
+        id
+    FROM
+        scim_api_keys
+    WHERE
+        scim_api_keys.id = $1
+        AND scim_api_keys.deleted_at IS NULL
+        AND scim_api_keys.expires_at > now()
+),
+_update AS (
+    UPDATE
+        scim_api_keys
+    SET
+        last_used_at = now()
+    WHERE
+        id IN (
+            SELECT
+                id
+            FROM
+                valid)
+            AND (last_used_at IS NULL
+                OR last_used_at < now() - '1 minute'::interval))
+SELECT
+    TRUE
+FROM
+    valid;
+
+-- name: SCIMKeyList :many
+SELECT
+    *
+FROM
+    scim_api_keys
+WHERE
+    deleted_at IS NULL
+ORDER BY
+    name;
+
@@ -0,0 +1,75 @@
+package apikey
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/google/uuid"
+	"github.com/target/goalert/util/errutil"
+)
+
+// rateLimiter tracks per-key request counts over fixed one-minute windows.
+//
+// Limits are enforced per process, so the effective limit of a key is
+// multiplied by the number of running instances.
+type rateLimiter struct {
+	lru *lru.Cache
+	mx  sync.Mutex
+
+	now func() time.Time
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+type rateLimitErr struct {
+	limit      int
+	retryAfter time.Duration
+}
+
+var _ errutil.RateLimitError = rateLimitErr{}
+
+func (e rateLimitErr) Error() string {
+	return fmt.Sprintf("rate limit exceeded for API key (max %d requests per minute)", e.limit)
+}
+func (rateLimitErr) RateLimited() bool           { return true }
+func (e rateLimitErr) RetryAfter() time.Duration { return e.retryAfter }
+
+func newRateLimiter(max int) *rateLimiter {
+	return &rateLimiter{
+		lru: lru.New(max),
+		now: time.Now,
+	}
+}
+
+// Allow will record a request for the given key, returning an error if the key has
+// exceeded limit requests in the current window. A limit of zero or less always allows the request.
+func (r *rateLimiter) Allow(id uuid.UUID, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	now := r.now()
+	var w *rateWindow
+	if v, ok := r.lru.Get(id); ok {
+		w = v.(*rateWindow)
+	}
+	if w == nil || now.Sub(w.start) >= time.Minute {
+		w = &rateWindow{start: now}
+		r.lru.Add(id, w)
+	}
+
+	if w.count >= limit {
+		return rateLimitErr{limit: limit, retryAfter: w.start.Add(time.Minute).Sub(now)}
+	}
+	w.count++
+
+	return nil
+}
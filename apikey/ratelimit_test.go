@@ -0,0 +1,38 @@
+package apikey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/util/errutil"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := newRateLimiter(10)
+	r.now = func() time.Time { return now }
+
+	id := uuid.New()
+	assert.NoError(t, r.Allow(id, 2))
+	assert.NoError(t, r.Allow(id, 2))
+
+	now = now.Add(15 * time.Second)
+	err := r.Allow(id, 2)
+	assert.True(t, errutil.IsRateLimitError(err), "expected rate limit error")
+	var rl errutil.RateLimitError
+	assert.ErrorAs(t, err, &rl)
+	assert.Equal(t, 45*time.Second, rl.RetryAfter())
+
+	// other keys are unaffected
+	assert.NoError(t, r.Allow(uuid.New(), 2))
+
+	// zero means no limit
+	for range 5 {
+		assert.NoError(t, r.Allow(id, 0))
+	}
+
+	now = now.Add(45 * time.Second)
+	assert.NoError(t, r.Allow(id, 2), "new window")
+}
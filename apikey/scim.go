@@ -0,0 +1,152 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// SCIMKeyInfo contains information about a SCIM provisioning API key.
+type SCIMKeyInfo struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	CreatedBy   *uuid.UUID
+	ExpiresAt   time.Time
+	LastUsedAt  *time.Time
+}
+
+// NewSCIMKeyOpts is used to create a new SCIM provisioning API key.
+type NewSCIMKeyOpts struct {
+	Name    string
+	Desc    string
+	Expires time.Time
+}
+
+// CreateSCIMKey will create a new SCIM provisioning API key returning the ID and token.
+func (s *Store) CreateSCIMKey(ctx context.Context, opt NewSCIMKeyOpts) (uuid.UUID, string, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	err = validate.Many(
+		validate.IDName("Name", opt.Name),
+		validate.Text("Description", opt.Desc, 0, 255),
+	)
+	if time.Until(opt.Expires) <= 0 {
+		err = validate.Many(err, validation.NewFieldError("Expires", "must be in the future"))
+	}
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	id := uuid.New()
+	err = gadb.New(s.db).SCIMKeyInsert(ctx, gadb.SCIMKeyInsertParams{
+		ID:          id,
+		Name:        opt.Name,
+		Description: opt.Desc,
+		CreatedBy:   permission.UserNullUUID(ctx),
+		ExpiresAt:   opt.Expires,
+	})
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	tok, err := s.key.SignJWT(NewSCIMClaims(id, opt.Expires))
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	return id, tok, nil
+}
+
+// FindAllSCIMKeys returns all active SCIM provisioning API keys.
+func (s *Store) FindAllSCIMKeys(ctx context.Context) ([]SCIMKeyInfo, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := gadb.New(s.db).SCIMKeyList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]SCIMKeyInfo, 0, len(keys))
+	for _, k := range keys {
+		info := SCIMKeyInfo{
+			ID:          k.ID,
+			Name:        k.Name,
+			Description: k.Description,
+			CreatedAt:   k.CreatedAt,
+			ExpiresAt:   k.ExpiresAt,
+		}
+		if k.CreatedBy.Valid {
+			info.CreatedBy = &k.CreatedBy.UUID
+		}
+		if k.LastUsedAt.Valid {
+			info.LastUsedAt = &k.LastUsedAt.Time
+		}
+		res = append(res, info)
+	}
+
+	return res, nil
+}
+
+// DeleteSCIMKey will revoke the SCIM provisioning API key with the given ID.
+func (s *Store) DeleteSCIMKey(ctx context.Context, id uuid.UUID) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(s.db).SCIMKeyDelete(ctx, gadb.SCIMKeyDeleteParams{
+		ID:        id,
+		DeletedBy: permission.UserNullUUID(ctx),
+	})
+}
+
+// AuthorizeSCIM will validate a SCIM provisioning API key token, returning a context
+// with admin-level access for user and group provisioning.
+func (s *Store) AuthorizeSCIM(ctx context.Context, tok string) (context.Context, error) {
+	var claims jwt.RegisteredClaims
+	_, err := s.key.VerifyJWT(tok, &claims, Issuer, AudienceSCIM)
+	if err != nil {
+		return ctx, permission.Unauthorized()
+	}
+	id, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		log.Logf(ctx, "apikey: invalid SCIM subject: %v", err)
+		return ctx, permission.Unauthorized()
+	}
+
+	valid, err := gadb.New(s.db).SCIMKeyAuthCheck(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ctx, permission.Unauthorized()
+	}
+	if err != nil {
+		return ctx, err
+	}
+	if !valid {
+		return ctx, permission.Unauthorized()
+	}
+
+	ctx = permission.SourceContext(ctx, &permission.SourceInfo{
+		ID:   id.String(),
+		Type: permission.SourceTypeSCIMAPIKey,
+	})
+	ctx = permission.UserContext(ctx, "", permission.RoleAdmin)
+
+	return ctx, nil
+}
@@ -0,0 +1,170 @@
+package apikey
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/service"
+)
+
+// scopeArg describes where a mutation references the resource(s) it operates on.
+type scopeArg struct {
+	Path    string
+	IsAlert bool
+}
+
+// scopedMutations are the only mutations available to service-scoped keys.
+var scopedMutations = map[string]scopeArg{
+	"createAlert":           {Path: "input.serviceID"},
+	"closeMatchingAlert":    {Path: "input.serviceID"},
+	"updateAlertsByService": {Path: "input.serviceID"},
+	"updateService":         {Path: "input.id"},
+	"updateAlerts":          {Path: "input.alertIDs", IsAlert: true},
+	"escalateAlerts":        {Path: "input", IsAlert: true},
+	"setAlertNoiseReason":   {Path: "input.alertID", IsAlert: true},
+}
+
+// serviceScope restricts a request to a fixed set of services.
+type serviceScope struct {
+	services map[string]struct{}
+
+	alertServices func(ctx context.Context, alertIDs []int64) ([]string, error)
+}
+
+func (s *serviceScope) hasService(id string) bool {
+	_, ok := s.services[strings.ToLower(id)]
+	return ok
+}
+
+// argValues returns all non-null values found at the dot-separated path in args, flattening lists.
+func argValues(args map[string]any, path string) []string {
+	var cur any = args
+	for _, part := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[part]
+	}
+
+	var res []string
+	var collect func(v any)
+	collect = func(v any) {
+		switch v := v.(type) {
+		case nil:
+		case []any:
+			for _, e := range v {
+				collect(e)
+			}
+		case string:
+			res = append(res, v)
+		case json.Number:
+			res = append(res, v.String())
+		case float64:
+			res = append(res, strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			res = append(res, fmt.Sprint(v))
+		}
+	}
+	collect(cur)
+
+	return res
+}
+
+// checkMutation returns an error if the named mutation is not permitted with the given arguments.
+func (s *serviceScope) checkMutation(ctx context.Context, name string, args map[string]any) error {
+	sa, ok := scopedMutations[name]
+	if !ok {
+		return permission.NewAccessDenied("mutation not available for service-scoped API key")
+	}
+
+	ids := argValues(args, sa.Path)
+	if len(ids) == 0 {
+		return permission.NewAccessDenied("mutation does not reference a service in API key scope")
+	}
+
+	if sa.IsAlert {
+		alertIDs := make([]int64, len(ids))
+		for i, id := range ids {
+			var err error
+			alertIDs[i], err = strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				return permission.NewAccessDenied("invalid alert ID")
+			}
+		}
+
+		var err error
+		ids, err = s.alertServices(ctx, alertIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, id := range ids {
+		if !s.hasService(id) {
+			return permission.NewAccessDenied("service not in API key scope")
+		}
+	}
+
+	return nil
+}
+
+// filterResult removes services and alerts outside of the scope from a resolver result.
+//
+// Single objects out of scope result in an error, lists and connections are filtered.
+func (s *serviceScope) filterResult(res any) (any, error) {
+	switch r := res.(type) {
+	case *service.Service:
+		if r != nil && !s.hasService(r.ID) {
+			return (*service.Service)(nil), permission.NewAccessDenied("service not in API key scope")
+		}
+	case *alert.Alert:
+		if r != nil && !s.hasService(r.ServiceID) {
+			return (*alert.Alert)(nil), permission.NewAccessDenied("alert not in API key scope")
+		}
+	case []service.Service:
+		return s.filterServices(r), nil
+	case []alert.Alert:
+		return s.filterAlerts(r), nil
+	case *graphql2.ServiceConnection:
+		if r != nil {
+			conn := *r
+			conn.Nodes = s.filterServices(r.Nodes)
+			return &conn, nil
+		}
+	case *graphql2.AlertConnection:
+		if r != nil {
+			conn := *r
+			conn.Nodes = s.filterAlerts(r.Nodes)
+			return &conn, nil
+		}
+	}
+
+	return res, nil
+}
+
+func (s *serviceScope) filterServices(svcs []service.Service) []service.Service {
+	res := make([]service.Service, 0, len(svcs))
+	for _, svc := range svcs {
+		if s.hasService(svc.ID) {
+			res = append(res, svc)
+		}
+	}
+	return res
+}
+
+func (s *serviceScope) filterAlerts(alerts []alert.Alert) []alert.Alert {
+	res := make([]alert.Alert, 0, len(alerts))
+	for _, a := range alerts {
+		if s.hasService(a.ServiceID) {
+			res = append(res, a)
+		}
+	}
+	return res
+}
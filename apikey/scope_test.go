@@ -0,0 +1,99 @@
+package apikey
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/service"
+)
+
+const (
+	svcA = "00000000-0000-0000-0000-00000000000a"
+	svcB = "00000000-0000-0000-0000-00000000000b"
+)
+
+func testScope() *serviceScope {
+	return &serviceScope{
+		services: map[string]struct{}{svcA: {}},
+		alertServices: func(ctx context.Context, ids []int64) ([]string, error) {
+			var res []string
+			for _, id := range ids {
+				if id == 1 {
+					res = append(res, svcA)
+				} else {
+					res = append(res, svcB)
+				}
+			}
+			return res, nil
+		},
+	}
+}
+
+func TestArgValues(t *testing.T) {
+	args := map[string]any{
+		"input": map[string]any{
+			"serviceID": svcA,
+			"alertIDs":  []any{int64(1), json.Number("2"), float64(3)},
+		},
+	}
+
+	assert.Equal(t, []string{svcA}, argValues(args, "input.serviceID"))
+	assert.Equal(t, []string{"1", "2", "3"}, argValues(args, "input.alertIDs"))
+	assert.Empty(t, argValues(args, "input.missing"))
+	assert.Empty(t, argValues(args, "input.serviceID.nested"))
+}
+
+func TestServiceScope_CheckMutation(t *testing.T) {
+	ctx := context.Background()
+	s := testScope()
+
+	check := func(name string, args map[string]any) error { return s.checkMutation(ctx, name, args) }
+
+	assert.NoError(t, check("createAlert", map[string]any{"input": map[string]any{"serviceID": svcA}}))
+	assert.NoError(t, check("escalateAlerts", map[string]any{"input": []any{int64(1)}}))
+
+	err := check("createAlert", map[string]any{"input": map[string]any{"serviceID": svcB}})
+	assert.True(t, permission.IsPermissionError(err), "service out of scope")
+
+	err = check("updateAlerts", map[string]any{"input": map[string]any{"alertIDs": []any{int64(1), int64(2)}}})
+	assert.True(t, permission.IsPermissionError(err), "alert out of scope")
+
+	err = check("createService", map[string]any{"input": map[string]any{"name": "foo"}})
+	assert.True(t, permission.IsPermissionError(err), "unscoped mutation")
+
+	err = check("createAlert", map[string]any{"input": map[string]any{}})
+	assert.True(t, permission.IsPermissionError(err), "missing service")
+}
+
+func TestServiceScope_FilterResult(t *testing.T) {
+	s := testScope()
+
+	res, err := s.filterResult(&service.Service{ID: svcB})
+	assert.True(t, permission.IsPermissionError(err))
+	assert.Nil(t, res.(*service.Service))
+
+	res, err = s.filterResult(&alert.Alert{ID: 1, ServiceID: svcA})
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.(*alert.Alert).ID)
+
+	res, err = s.filterResult(&graphql2.ServiceConnection{
+		Nodes:    []service.Service{{ID: svcA}, {ID: svcB}},
+		PageInfo: &graphql2.PageInfo{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []service.Service{{ID: svcA}}, res.(*graphql2.ServiceConnection).Nodes)
+
+	res, err = s.filterResult([]alert.Alert{{ID: 1, ServiceID: svcB}})
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	res, err = s.filterResult("unrelated")
+	require.NoError(t, err)
+	assert.Equal(t, "unrelated", res)
+}
@@ -27,6 +27,7 @@ type Store struct {
 
 	polCache      *polCache
 	lastUsedCache *lastUsedCache
+	rateLimiter   *rateLimiter
 }
 
 // NewStore will create a new Store.
@@ -43,6 +44,7 @@ func NewStore(ctx context.Context, db *sql.DB, key keyring.Keyring) (*Store, err
 	})
 
 	s.lastUsedCache = newLastUsedCache(1000, s._updateLastUsed)
+	s.rateLimiter = newRateLimiter(1000)
 
 	return s, nil
 }
@@ -82,6 +84,10 @@ func (s *Store) FindAllAdminGraphQLKeys(ctx context.Context) ([]APIKeyInfo, erro
 			log.Log(ctx, fmt.Errorf("invalid policy for key %s: %w", k.ID, err))
 			continue
 		}
+		if p.Version == 2 {
+			// personal keys are managed by their owner
+			continue
+		}
 		if p.Version != 1 {
 			log.Log(ctx, fmt.Errorf("unknown policy version for key %s: %d", k.ID, p.Version))
 			continue
@@ -217,6 +223,11 @@ func (s *Store) AuthorizeGraphQL(ctx context.Context, tok, ua, ip string) (conte
 		return ctx, permission.Unauthorized()
 	}
 
+	err = s.rateLimiter.Allow(id, info.Policy.RateLimit)
+	if err != nil {
+		return ctx, err
+	}
+
 	err = s.lastUsedCache.RecordUsage(ctx, id, ua, ip)
 	if err != nil {
 		// Recording usage is not critical, so we log the error and continue.
@@ -227,7 +238,18 @@ func (s *Store) AuthorizeGraphQL(ctx context.Context, tok, ua, ip string) (conte
 		ID:   id.String(),
 		Type: permission.SourceTypeGQLAPIKey,
 	})
-	ctx = permission.UserContext(ctx, "", info.Policy.Role)
+	if info.Policy.Version == 2 {
+		ctx = permission.UserContext(ctx, info.Policy.UserID, info.Policy.Role)
+		if len(info.Policy.ServiceLabels) > 0 {
+			scope, err := s.serviceScope(ctx, info.Policy.ServiceLabels)
+			if err != nil {
+				return ctx, err
+			}
+			ctx = contextWithScope(ctx, scope)
+		}
+	} else {
+		ctx = permission.UserContext(ctx, "", info.Policy.Role)
+	}
 
 	ctx = ContextWithPolicy(ctx, &info.Policy)
 	return ctx, nil
@@ -0,0 +1,269 @@
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxRateLimit is the maximum requests per minute that can be configured for a personal API key.
+const MaxRateLimit = 10000
+
+// UserKeyInfo contains information about a personal GraphQL API key.
+type UserKeyInfo struct {
+	ID            uuid.UUID
+	Name          string
+	Description   string
+	ExpiresAt     time.Time
+	LastUsed      *APIKeyUsage
+	CreatedAt     time.Time
+	AllowedFields []string
+	ServiceLabels []string
+	RateLimit     int
+}
+
+// NewUserGQLKeyOpts is used to create a new personal GraphQL API key.
+type NewUserGQLKeyOpts struct {
+	Name    string
+	Desc    string
+	Expires time.Time
+
+	// AllowedFields is the list of root fields the key may use (e.g., `Query.alerts`).
+	AllowedFields []string
+
+	// ServiceLabels, if set, limits the key to services with all of the given labels (as `key=value`).
+	ServiceLabels []string
+
+	// RateLimit is the maximum number of requests per minute, zero means no limit.
+	RateLimit int
+}
+
+// normalizeList returns a sorted copy of the list with duplicates removed.
+func normalizeList(list []string) []string {
+	res := slices.Clone(list)
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+func validateAllowedFields(fields []string) error {
+	err := validate.Len("AllowedFields", fields, 1, 100)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		if !strings.HasPrefix(f, "Query.") && !strings.HasPrefix(f, "Mutation.") {
+			return validation.NewFieldError("AllowedFields", fmt.Sprintf("'%s' is not a root field", f))
+		}
+		if _, ok := slices.BinarySearch(graphql2.SchemaFields(), f); !ok {
+			return validation.NewFieldError("AllowedFields", fmt.Sprintf("unknown field '%s'", f))
+		}
+	}
+
+	return nil
+}
+
+func validateServiceLabels(labels []string) error {
+	err := validate.Len("ServiceLabels", labels, 0, 10)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range labels {
+		key, value, ok := strings.Cut(l, "=")
+		if !ok {
+			return validation.NewFieldError("ServiceLabels", fmt.Sprintf("'%s' must be in the format key=value", l))
+		}
+		err = validate.Many(
+			validate.LabelKey("ServiceLabels", key),
+			validate.LabelValue("ServiceLabels", value),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateUserGraphQLKey will create a new personal GraphQL API key for the current user returning the ID and token.
+//
+// Requests made with the key act as the user (with the user role), limited to the allowed fields and service scope.
+func (s *Store) CreateUserGraphQLKey(ctx context.Context, opt NewUserGQLKeyOpts) (uuid.UUID, string, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	if src := permission.Source(ctx); src != nil && (src.Type == permission.SourceTypeGQLAPIKey || src.Type == permission.SourceTypeOAuthToken) {
+		return uuid.Nil, "", permission.NewAccessDenied("API keys cannot be created using an API key or OAuth token")
+	}
+
+	opt.AllowedFields = normalizeList(opt.AllowedFields)
+	opt.ServiceLabels = normalizeList(opt.ServiceLabels)
+	err = validate.Many(
+		validate.IDName("Name", opt.Name),
+		validate.Text("Description", opt.Desc, 0, 255),
+		validateAllowedFields(opt.AllowedFields),
+		validateServiceLabels(opt.ServiceLabels),
+		validate.Range("RateLimit", opt.RateLimit, 0, MaxRateLimit),
+	)
+	if time.Until(opt.Expires) <= 0 {
+		err = validate.Many(err, validation.NewFieldError("Expires", "must be in the future"))
+	}
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	policyData, err := json.Marshal(GQLPolicy{
+		Version:       2,
+		Role:          permission.RoleUser,
+		UserID:        permission.UserID(ctx),
+		AllowedFields: opt.AllowedFields,
+		ServiceLabels: opt.ServiceLabels,
+		RateLimit:     opt.RateLimit,
+	})
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	id := uuid.New()
+	err = gadb.New(s.db).APIKeyInsert(ctx, gadb.APIKeyInsertParams{
+		ID:          id,
+		Name:        opt.Name,
+		Description: opt.Desc,
+		ExpiresAt:   opt.Expires,
+		Policy:      policyData,
+		CreatedBy:   permission.UserNullUUID(ctx),
+		UpdatedBy:   permission.UserNullUUID(ctx),
+	})
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	hash := sha256.Sum256([]byte(policyData))
+	tok, err := s.key.SignJWT(NewGraphQLClaims(id, hash[:], opt.Expires))
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	return id, tok, nil
+}
+
+// FindAllUserGraphQLKeys returns all personal GraphQL API keys belonging to the current user.
+func (s *Store) FindAllUserGraphQLKeys(ctx context.Context) ([]UserKeyInfo, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := gadb.New(s.db).APIKeyListByUser(ctx, permission.UserNullUUID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]UserKeyInfo, 0, len(keys))
+	for _, k := range keys {
+		var p GQLPolicy
+		err = json.Unmarshal(k.Policy, &p)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("invalid policy for key %s: %w", k.ID, err))
+			continue
+		}
+		if p.Version != 2 {
+			// admin keys created by this user
+			continue
+		}
+
+		var lastUsed *APIKeyUsage
+		if k.LastUsedAt.Valid {
+			var ip string
+			if k.LastIpAddress.Valid {
+				ip = k.LastIpAddress.IPNet.IP.String()
+			}
+			lastUsed = &APIKeyUsage{
+				UserAgent: k.LastUserAgent.String,
+				IP:        ip,
+				Time:      k.LastUsedAt.Time,
+			}
+		}
+
+		res = append(res, UserKeyInfo{
+			ID:            k.ID,
+			Name:          k.Name,
+			Description:   k.Description,
+			ExpiresAt:     k.ExpiresAt,
+			LastUsed:      lastUsed,
+			CreatedAt:     k.CreatedAt,
+			AllowedFields: p.AllowedFields,
+			ServiceLabels: p.ServiceLabels,
+			RateLimit:     p.RateLimit,
+		})
+	}
+
+	return res, nil
+}
+
+// DeleteUserGraphQLKey will delete a personal GraphQL API key belonging to the current user.
+func (s *Store) DeleteUserGraphQLKey(ctx context.Context, id uuid.UUID) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := gadb.New(s.db).APIKeyDeleteByUser(ctx, gadb.APIKeyDeleteByUserParams{
+		ID:     id,
+		UserID: permission.UserNullUUID(ctx),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return validation.NewFieldError("ID", "API key not found")
+	}
+
+	s.polCache.Revoke(ctx, id)
+	return nil
+}
+
+// serviceScope returns the scope for a key limited to services with all of the given labels.
+func (s *Store) serviceScope(ctx context.Context, labels []string) (*serviceScope, error) {
+	ids, err := gadb.New(s.db).APIKeyScopedServices(ctx, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := &serviceScope{
+		services: make(map[string]struct{}, len(ids)),
+		alertServices: func(ctx context.Context, alertIDs []int64) ([]string, error) {
+			svcIDs, err := gadb.New(s.db).APIKeyAlertServices(ctx, alertIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			res := make([]string, 0, len(svcIDs))
+			for _, id := range svcIDs {
+				if id.Valid {
+					res = append(res, id.UUID.String())
+				}
+			}
+			return res, nil
+		},
+	}
+	for _, id := range ids {
+		scope.services[id.String()] = struct{}{}
+	}
+
+	return scope, nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/auth/nonce"
+	"github.com/target/goalert/auth/saml"
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/engine"
@@ -41,12 +42,16 @@ import (
 	"github.com/target/goalert/notification/slack"
 	"github.com/target/goalert/notification/twilio"
 	"github.com/target/goalert/notificationchannel"
+	"github.com/target/goalert/oauth"
 	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/oncall/fairness"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/holiday"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/schedule/swap"
 	"github.com/target/goalert/service"
 	"github.com/target/goalert/smtpsrv"
 	"github.com/target/goalert/timezone"
@@ -54,6 +59,7 @@ import (
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/favorite"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/user/unavailability"
 	"github.com/target/goalert/util/calllimiter"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
@@ -94,6 +100,7 @@ type App struct {
 	Engine              *engine.Engine
 	graphql2            *graphqlapp.App
 	AuthHandler         *auth.Handler
+	SAMLProvider        *saml.Provider
 
 	twilioSMS    *twilio.SMS
 	twilioVoice  *twilio.Voice
@@ -112,12 +119,14 @@ type App struct {
 	ContactMethodStore    *contactmethod.Store
 	NotificationRuleStore *notificationrule.Store
 	FavoriteStore         *favorite.Store
+	UnavailabilityStore   *unavailability.Store
 
 	ServiceStore        *service.Store
 	EscalationStore     *escalation.Store
 	IntegrationKeyStore *integrationkey.Store
 	UIKHandler          *uik.Handler
 	ScheduleRuleStore   *rule.Store
+	HolidayStore        *holiday.Store
 	NotificationStore   *notification.Store
 	ScheduleStore       *schedule.Store
 	RotationStore       *rotation.Store
@@ -125,22 +134,26 @@ type App struct {
 
 	CalSubStore    *calsub.Store
 	OverrideStore  *override.Store
+	SwapStore      *swap.Store
 	LimitStore     *limit.Store
 	HeartbeatStore *heartbeat.Store
 
-	OAuthKeyring    keyring.Keyring
-	SessionKeyring  keyring.Keyring
-	APIKeyring      keyring.Keyring
-	AuthLinkKeyring keyring.Keyring
+	OAuthKeyring       keyring.Keyring
+	SessionKeyring     keyring.Keyring
+	APIKeyring         keyring.Keyring
+	AuthLinkKeyring    keyring.Keyring
+	OAuthServerKeyring keyring.Keyring
 
 	NonceStore    *nonce.Store
 	LabelStore    *label.Store
 	OnCallStore   *oncall.Store
+	FairnessStore *fairness.Store
 	NCStore       *notificationchannel.Store
 	TimeZoneStore *timezone.Store
 	NoticeStore   *notice.Store
 	AuthLinkStore *authlink.Store
 	APIKeyStore   *apikey.Store
+	OAuthStore    *oauth.Store
 	River         *river.Client[pgx.Tx]
 
 	// RiverDBSQL is a river client that uses the old sql.DB driver for use while transitioning to pgx.
@@ -7,7 +7,9 @@ import (
 	"github.com/target/goalert/auth"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/auth/github"
+	"github.com/target/goalert/auth/ldap"
 	"github.com/target/goalert/auth/oidc"
+	"github.com/target/goalert/auth/saml"
 )
 
 func (app *App) initAuth(ctx context.Context) error {
@@ -20,6 +22,7 @@ func (app *App) initAuth(ctx context.Context) error {
 		CalSubStore:    app.CalSubStore,
 		APIKeyring:     app.APIKeyring,
 		APIKeyStore:    app.APIKeyStore,
+		OAuthStore:     app.OAuthStore,
 	})
 	if err != nil {
 		return errors.Wrap(err, "init auth handler")
@@ -50,6 +53,27 @@ func (app *App) initAuth(ctx context.Context) error {
 		return err
 	}
 
+	ldapProvider, err := ldap.NewProvider(ctx)
+	if err != nil {
+		return errors.Wrap(err, "init LDAP auth provider")
+	}
+	if err := app.AuthHandler.AddIdentityProvider("ldap", ldapProvider); err != nil {
+		return err
+	}
+
+	app.SAMLProvider, err = saml.NewProvider(ctx, saml.Config{
+		DB:         app.db,
+		Keyring:    app.OAuthKeyring,
+		NonceStore: app.NonceStore,
+		Keys:       app.cfg.EncryptionKeys,
+	})
+	if err != nil {
+		return errors.Wrap(err, "init SAML auth provider")
+	}
+	if err := app.AuthHandler.AddIdentityProvider("saml", app.SAMLProvider); err != nil {
+		return err
+	}
+
 	basicProvider, err := basic.NewProvider(ctx, app.AuthBasicStore)
 	if err != nil {
 		return errors.Wrap(err, "init basic auth provider")
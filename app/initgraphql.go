@@ -19,16 +19,20 @@ func (app *App) initGraphQL(ctx context.Context) error {
 		AlertMetricsStore:   app.AlertMetricsStore,
 		ServiceStore:        app.ServiceStore,
 		FavoriteStore:       app.FavoriteStore,
+		UnavailStore:        app.UnavailabilityStore,
 		PolicyStore:         app.EscalationStore,
 		ScheduleStore:       app.ScheduleStore,
 		CalSubStore:         app.CalSubStore,
 		RotationStore:       app.RotationStore,
 		OnCallStore:         app.OnCallStore,
+		FairnessStore:       app.FairnessStore,
 		TimeZoneStore:       app.TimeZoneStore,
 		IntKeyStore:         app.IntegrationKeyStore,
 		LabelStore:          app.LabelStore,
 		RuleStore:           app.ScheduleRuleStore,
 		OverrideStore:       app.OverrideStore,
+		SwapStore:           app.SwapStore,
+		HolidayStore:        app.HolidayStore,
 		ConfigStore:         app.ConfigStore,
 		LimitStore:          app.LimitStore,
 		NotificationStore:   app.NotificationStore,
@@ -41,6 +45,7 @@ func (app *App) initGraphQL(ctx context.Context) error {
 		AuthLinkStore:       app.AuthLinkStore,
 		SWO:                 app.cfg.SWO,
 		APIKeyStore:         app.APIKeyStore,
+		OAuthStore:          app.OAuthStore,
 		DestReg:             app.DestRegistry,
 		EventBus:            app.EventBus,
 		EncryptionKeys:      app.cfg.EncryptionKeys,
@@ -4,14 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/amazonsns"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/expflag"
 	"github.com/target/goalert/genericapi"
 	"github.com/target/goalert/grafana"
 	"github.com/target/goalert/mailgun"
+	"github.com/target/goalert/nagios"
 	"github.com/target/goalert/notification/twilio"
 	"github.com/target/goalert/permission"
 	prometheus "github.com/target/goalert/prometheusalertmanager"
+	"github.com/target/goalert/scim"
 	"github.com/target/goalert/site24x7"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
@@ -134,6 +138,12 @@ func (app *App) initHTTP(ctx context.Context) error {
 		UserStore:           app.UserStore,
 	})
 
+	scimH := scim.NewHandler(scim.Config{
+		DB:            app.db,
+		UserStore:     app.UserStore,
+		RotationStore: app.RotationStore,
+	})
+
 	mux.Handle("POST /api/graphql", app.graphql2.Handler())
 
 	mux.HandleFunc("GET /api/v2/config", app.ConfigStore.ServeConfig)
@@ -283,6 +293,21 @@ func (app *App) initHTTP(ctx context.Context) error {
 	mux.HandleFunc("POST /api/v2/identity/providers/oidc", oidcAuth)
 	mux.HandleFunc("GET /api/v2/identity/providers/oidc/callback", oidcAuth)
 
+	ldapAuth := app.AuthHandler.IdentityProviderHandler("ldap")
+	mux.HandleFunc("POST /api/v2/identity/providers/ldap", ldapAuth)
+
+	samlAuth := app.AuthHandler.IdentityProviderHandler("saml")
+	mux.HandleFunc("POST /api/v2/identity/providers/saml", samlAuth)
+	mux.HandleFunc("GET /api/v2/identity/providers/saml/callback", samlAuth)
+	mux.HandleFunc("POST /api/v2/identity/providers/saml/acs", app.SAMLProvider.ServeACS)
+	mux.HandleFunc("GET /api/v2/identity/providers/saml/metadata", app.SAMLProvider.ServeMetadata)
+
+	mux.HandleFunc("GET /api/v2/oauth/authorize", app.OAuthStore.ServeAuthorize)
+	mux.HandleFunc("GET /api/v2/oauth/consent", app.OAuthStore.ServeConsentInfo)
+	mux.HandleFunc("POST /api/v2/oauth/consent", app.OAuthStore.ServeConsent)
+	mux.HandleFunc("POST /api/v2/oauth/token", app.OAuthStore.ServeToken)
+	mux.HandleFunc("POST /api/v2/oauth/revoke", app.OAuthStore.ServeRevoke)
+
 	if expflag.ContextHas(ctx, expflag.UnivKeys) {
 		mux.HandleFunc("POST /api/v2/uik", app.UIKHandler.ServeHTTP)
 	}
@@ -290,11 +315,38 @@ func (app *App) initHTTP(ctx context.Context) error {
 	mux.HandleFunc("POST /api/v2/grafana/incoming", grafana.GrafanaToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
 	mux.HandleFunc("POST /api/v2/site24x7/incoming", site24x7.Site24x7ToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
 	mux.HandleFunc("POST /api/v2/prometheusalertmanager/incoming", prometheus.PrometheusAlertmanagerEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("POST /api/v2/zabbix/incoming", nagios.EventsAPI(app.AlertStore, alert.SourceZabbix))
+	mux.HandleFunc("POST /api/v2/nagios/incoming", nagios.EventsAPI(app.AlertStore, alert.SourceNagios))
+	mux.HandleFunc("POST /api/v2/icinga/incoming", nagios.EventsAPI(app.AlertStore, alert.SourceIcinga))
+	mux.Handle("POST /api/v2/amazonsns/incoming", amazonsns.NewHandler(amazonsns.Config{
+		AlertStore: app.AlertStore,
+		Client:     app.httpClient,
+		Verifier:   amazonsns.NewVerifier(amazonsns.HTTPCertFetcher(app.httpClient)),
+	}))
+
+	mux.HandleFunc("GET /api/v2/scim/ServiceProviderConfig", scimH.ServeServiceProviderConfig)
+	mux.HandleFunc("GET /api/v2/scim/ResourceTypes", scimH.ServeResourceTypes)
+	mux.HandleFunc("GET /api/v2/scim/Schemas", scimH.ServeSchemas)
+	mux.HandleFunc("GET /api/v2/scim/Users", scimH.ServeListUsers)
+	mux.HandleFunc("POST /api/v2/scim/Users", scimH.ServeCreateUser)
+	mux.HandleFunc("GET /api/v2/scim/Users/{id}", scimH.ServeGetUser)
+	mux.HandleFunc("PUT /api/v2/scim/Users/{id}", scimH.ServeReplaceUser)
+	mux.HandleFunc("PATCH /api/v2/scim/Users/{id}", scimH.ServePatchUser)
+	mux.HandleFunc("DELETE /api/v2/scim/Users/{id}", scimH.ServeDeleteUser)
+	mux.HandleFunc("GET /api/v2/scim/Groups", scimH.ServeListGroups)
+	mux.HandleFunc("POST /api/v2/scim/Groups", scimH.ServeCreateGroup)
+	mux.HandleFunc("GET /api/v2/scim/Groups/{id}", scimH.ServeGetGroup)
+	mux.HandleFunc("PUT /api/v2/scim/Groups/{id}", scimH.ServeReplaceGroup)
+	mux.HandleFunc("PATCH /api/v2/scim/Groups/{id}", scimH.ServePatchGroup)
+	mux.HandleFunc("DELETE /api/v2/scim/Groups/{id}", scimH.ServeDeleteGroup)
 
 	mux.HandleFunc("POST /api/v2/generic/incoming", generic.ServeCreateAlert)
+	mux.HandleFunc("POST /api/v2/generic/incoming/batch", generic.ServeCreateAlertBatch)
+	mux.HandleFunc("POST /api/v2/cloudevents/incoming", generic.ServeCloudEvent)
 	mux.HandleFunc("POST /api/v2/heartbeat/{heartbeatID}", generic.ServeHeartbeatCheck)
 	mux.HandleFunc("GET /api/v2/user-avatar/{userID}", generic.ServeUserAvatar)
 	mux.HandleFunc("GET /api/v2/calendar", app.CalSubStore.ServeICalData)
+	mux.HandleFunc("GET /api/v2/reports/oncall-fairness.csv", app.FairnessStore.ServeCSV)
 
 	mux.HandleFunc("POST /api/v2/twilio/message", app.twilioSMS.ServeMessage)
 	mux.HandleFunc("POST /api/v2/twilio/message/status", app.twilioSMS.ServeStatusCallback)
@@ -25,18 +25,23 @@ import (
 	"github.com/target/goalert/notification/nfydest"
 	"github.com/target/goalert/notification/slack"
 	"github.com/target/goalert/notificationchannel"
+	"github.com/target/goalert/oauth"
 	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/oncall/fairness"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/holiday"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/schedule/swap"
 	"github.com/target/goalert/service"
 	"github.com/target/goalert/timezone"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/favorite"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/user/unavailability"
 
 	"github.com/pkg/errors"
 )
@@ -126,6 +131,18 @@ func (app *App) initStores(ctx context.Context) error {
 		return errors.Wrap(err, "init API keyring")
 	}
 
+	if app.OAuthServerKeyring == nil {
+		app.OAuthServerKeyring, err = keyring.NewDB(ctx, app.cfg.LegacyLogger, app.db, &keyring.Config{
+			Name:         "oauth-server",
+			RotationDays: 1,
+			MaxOldKeys:   1,
+			Keys:         app.cfg.EncryptionKeys,
+		})
+	}
+	if err != nil {
+		return errors.Wrap(err, "init oauth server keyring")
+	}
+
 	if app.AuthLinkStore == nil {
 		app.AuthLinkStore, err = authlink.NewStore(ctx, app.db, app.AuthLinkKeyring)
 	}
@@ -232,6 +249,10 @@ func (app *App) initStores(ctx context.Context) error {
 		return errors.Wrap(err, "init schedule rule store")
 	}
 
+	if app.HolidayStore == nil {
+		app.HolidayStore = holiday.NewStore(app.db)
+	}
+
 	if app.NotificationStore == nil {
 		app.NotificationStore, err = notification.NewStore(ctx, app.db)
 	}
@@ -246,6 +267,13 @@ func (app *App) initStores(ctx context.Context) error {
 		return errors.Wrap(err, "init favorite store")
 	}
 
+	if app.UnavailabilityStore == nil {
+		app.UnavailabilityStore, err = unavailability.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init unavailability store")
+	}
+
 	if app.OverrideStore == nil {
 		app.OverrideStore, err = override.NewStore(ctx, app.db)
 	}
@@ -273,12 +301,16 @@ func (app *App) initStores(ctx context.Context) error {
 	}
 
 	if app.OnCallStore == nil {
-		app.OnCallStore, err = oncall.NewStore(ctx, app.db, app.ScheduleRuleStore, app.ScheduleStore)
+		app.OnCallStore, err = oncall.NewStore(ctx, app.db, app.ScheduleRuleStore, app.ScheduleStore, app.HolidayStore, app.UnavailabilityStore)
 	}
 	if err != nil {
 		return errors.Wrap(err, "init on-call store")
 	}
 
+	if app.FairnessStore == nil {
+		app.FairnessStore = fairness.NewStore(app.db)
+	}
+
 	if app.TimeZoneStore == nil {
 		app.TimeZoneStore = timezone.NewStore(ctx, app.db)
 	}
@@ -290,6 +322,10 @@ func (app *App) initStores(ctx context.Context) error {
 		return errors.Wrap(err, "init calendar subscription store")
 	}
 
+	if app.SwapStore == nil {
+		app.SwapStore = swap.NewStore(app.db, app.OnCallStore, app.OverrideStore)
+	}
+
 	if app.NoticeStore == nil {
 		app.NoticeStore, err = notice.NewStore(ctx, app.db)
 	}
@@ -304,7 +340,11 @@ func (app *App) initStores(ctx context.Context) error {
 		return errors.Wrap(err, "init API key store")
 	}
 
-	app.UIKHandler = uik.NewHandler(app.db, app.httpClient, app.IntegrationKeyStore, app.AlertStore, app.EventBus)
+	if app.OAuthStore == nil {
+		app.OAuthStore = oauth.NewStore(app.db, app.OAuthServerKeyring)
+	}
+
+	app.UIKHandler = uik.NewHandler(ctx, app.db, app.httpClient, app.IntegrationKeyStore, app.AlertStore, app.EventBus, app.RiverDBSQL, app.RiverWorkers)
 
 	return nil
 }
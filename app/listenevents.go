@@ -3,6 +3,8 @@ package app
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
@@ -16,4 +18,12 @@ func (app *App) setupListenEvents() {
 		})
 		return nil
 	})
+	app.events.Handle(integrationkey.ConfigUpdateChannel, func(ctx context.Context, payload string) error {
+		id, err := uuid.Parse(payload)
+		if err != nil {
+			return err
+		}
+		app.UIKHandler.InvalidateConfig(id)
+		return nil
+	})
 }
@@ -59,12 +59,14 @@ func (app *App) _Shutdown(ctx context.Context) error {
 	// that would still need to process them.
 	shut(app.smtpsrv, "SMTP receiver server")
 	shut(app.srv, "HTTP server")
+	shut(app.UIKHandler, "UIK handler")
 	shut(app.Engine, "engine")
 	shut(app.events, "event listener")
 	shut(app.SessionKeyring, "session keyring")
 	shut(app.OAuthKeyring, "oauth keyring")
 	shut(app.APIKeyring, "API keyring")
 	shut(app.AuthLinkKeyring, "auth link keyring")
+	shut(app.OAuthServerKeyring, "oauth server keyring")
 	shut(app.NonceStore, "nonce store")
 	shut(app.ConfigStore, "config store")
 
@@ -17,6 +17,7 @@ import (
 	"github.com/target/goalert/auth/authtoken"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/oauth"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/util"
@@ -379,6 +380,10 @@ func (h *Handler) canCreateUser(ctx context.Context, providerID string) bool {
 		return cfg.OIDC.NewUsers
 	case "github":
 		return cfg.GitHub.NewUsers
+	case "ldap":
+		return cfg.LDAP.NewUsers
+	case "saml":
+		return cfg.SAML.NewUsers
 	}
 
 	return false
@@ -556,7 +561,20 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 
 	ctx := req.Context()
 	if req.URL.Path == "/api/graphql" && strings.HasPrefix(tokStr, "ey") {
-		ctx, err = h.cfg.APIKeyStore.AuthorizeGraphQL(ctx, tokStr, req.UserAgent(), req.RemoteAddr)
+		if oauth.IsAccessToken(tokStr) {
+			ctx, err = h.cfg.OAuthStore.AuthorizeAccessToken(ctx, tokStr)
+		} else {
+			ctx, err = h.cfg.APIKeyStore.AuthorizeGraphQL(ctx, tokStr, req.UserAgent(), req.RemoteAddr)
+		}
+		if errutil.HTTPError(req.Context(), w, err) {
+			return true
+		}
+
+		next.ServeHTTP(w, req.WithContext(ctx))
+		return true
+	}
+	if strings.HasPrefix(req.URL.Path, "/api/v2/scim/") && strings.HasPrefix(tokStr, "ey") {
+		ctx, err = h.cfg.APIKeyStore.AuthorizeSCIM(ctx, tokStr)
 		if errutil.HTTPError(req.Context(), w, err) {
 			return true
 		}
@@ -586,7 +604,7 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 	}
 
 	switch req.URL.Path {
-	case "/v1/api/alerts", "/api/v2/generic/incoming":
+	case "/v1/api/alerts", "/api/v2/generic/incoming", "/api/v2/generic/incoming/batch":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGeneric)
 	case "/v1/webhooks/grafana", "/api/v2/grafana/incoming":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGrafana)
@@ -594,6 +612,16 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeSite24x7)
 	case "/api/v2/prometheusalertmanager/incoming":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypePrometheusAlertmanager)
+	case "/api/v2/amazonsns/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeAmazonSNS)
+	case "/api/v2/zabbix/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeZabbix)
+	case "/api/v2/nagios/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeNagios)
+	case "/api/v2/icinga/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeIcinga)
+	case "/api/v2/cloudevents/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeCloudEvents)
 	case "/api/v2/calendar":
 		ctx, err = h.cfg.CalSubStore.Authorize(ctx, *tok)
 	default:
@@ -666,6 +694,12 @@ func (h *Handler) WrapHandler(wrapped http.Handler) http.Handler {
 			wrapped.ServeHTTP(w, req)
 			return
 		}
+		if req.URL.Path == "/api/v2/oauth/token" || req.URL.Path == "/api/v2/oauth/revoke" {
+			// OAuth clients authenticate with their own credentials, which
+			// would otherwise be mistaken for a token.
+			wrapped.ServeHTTP(w, req)
+			return
+		}
 		if h.authWithToken(w, req, wrapped) {
 			return
 		}
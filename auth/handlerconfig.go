@@ -5,6 +5,7 @@ import (
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/oauth"
 	"github.com/target/goalert/user"
 )
 
@@ -16,4 +17,5 @@ type HandlerConfig struct {
 	IntKeyStore    *integrationkey.Store
 	CalSubStore    *calsub.Store
 	APIKeyStore    *apikey.Store
+	OAuthStore     *oauth.Store
 }
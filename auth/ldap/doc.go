@@ -0,0 +1,2 @@
+// Package ldap implements an auth provider that identifies a user by binding to an LDAP directory (e.g. Active Directory) with their username & password.
+package ldap
@@ -0,0 +1,231 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/target/goalert/auth"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+const (
+	defaultFilter    = "(uid=%s)"
+	defaultNameAttr  = "displayName"
+	defaultEmailAttr = "mail"
+	memberOfAttr     = "memberOf"
+
+	timeout = 10 * time.Second
+)
+
+// Info implements the auth.Provider interface.
+func (Provider) Info(ctx context.Context) auth.ProviderInfo {
+	cfg := config.FromContext(ctx)
+	name := "LDAP"
+	if cfg.LDAP.OverrideName != "" {
+		name = cfg.LDAP.OverrideName
+	}
+	return auth.ProviderInfo{
+		Title: name,
+		Fields: []auth.Field{
+			{ID: "username", Label: "Username", Required: true},
+			{ID: "password", Label: "Password", Password: true, Required: true},
+		},
+		Enabled: cfg.LDAP.Enable,
+	}
+}
+
+func dial(ctx context.Context) (*ldap.Conn, error) {
+	cfg := config.FromContext(ctx)
+
+	u, err := url.Parse(cfg.LDAP.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse url: %w", err)
+	}
+
+	tlsCfg := &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: cfg.LDAP.SkipVerify,
+	}
+
+	conn, err := ldap.DialURL(cfg.LDAP.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(tlsCfg),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dial: %w", err)
+	}
+	conn.SetTimeout(timeout)
+
+	if cfg.LDAP.StartTLS && u.Scheme == "ldap" {
+		err = conn.StartTLS(tlsCfg)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("start tls: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+// searchFilter returns the configured search filter with all placeholders replaced by the escaped username.
+func searchFilter(filter, username string) string {
+	if filter == "" {
+		filter = defaultFilter
+	}
+
+	return strings.ReplaceAll(filter, "%s", ldap.EscapeFilter(username))
+}
+
+// subjectID returns the unique identifier for the entry using the provided attribute.
+//
+// Binary values (e.g. Active Directory's objectGUID) are hex-encoded.
+func subjectID(e *ldap.Entry, attr string) string {
+	if attr == "" || strings.EqualFold(attr, "dn") {
+		return strings.ToLower(e.DN)
+	}
+
+	raw := e.GetEqualFoldRawAttributeValue(attr)
+	for _, b := range raw {
+		if b < 32 || b > 126 {
+			return hex.EncodeToString(raw)
+		}
+	}
+
+	return string(raw)
+}
+
+// inGroups returns true if any of the entry's groups are in the allowed list.
+func inGroups(e *ldap.Entry, allowed []string) bool {
+	for _, g := range e.GetEqualFoldAttributeValues(memberOfAttr) {
+		for _, a := range allowed {
+			if strings.EqualFold(strings.TrimSpace(g), strings.TrimSpace(a)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ExtractIdentity implements the auth.IdentityProvider interface, providing identity based
+// on the given username and password fields.
+func (p *Provider) ExtractIdentity(route *auth.RouteInfo, w http.ResponseWriter, req *http.Request) (*auth.Identity, error) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+
+	username := strings.TrimSpace(req.FormValue("username"))
+	password := req.FormValue("password")
+	err := validate.Text("Username", username, 1, 255)
+	if err != nil {
+		return nil, auth.Error("invalid username")
+	}
+	if password == "" {
+		// an empty password would result in an unauthenticated bind, which always succeeds
+		return nil, auth.Error("unknown username/password")
+	}
+	ctx = log.WithField(ctx, "username", username)
+
+	err = p.lim.Lock(ctx, strings.ToLower(username))
+	if errutil.HTTPError(ctx, w, err) {
+		return nil, err
+	}
+	defer p.lim.Unlock(strings.ToLower(username))
+
+	conn, err := dial(ctx)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("ldap: %w", err))
+		return nil, auth.Error("Failed to connect to LDAP server.")
+	}
+	defer conn.Close()
+
+	if cfg.LDAP.BindDN != "" {
+		err = conn.Bind(cfg.LDAP.BindDN, cfg.LDAP.BindPassword)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("ldap: bind search account: %w", err))
+			return nil, auth.Error("Failed to search LDAP directory.")
+		}
+	}
+
+	nameAttr := cfg.LDAP.NameAttribute
+	if nameAttr == "" {
+		nameAttr = defaultNameAttr
+	}
+	emailAttr := cfg.LDAP.EmailAttribute
+	if emailAttr == "" {
+		emailAttr = defaultEmailAttr
+	}
+	attrs := []string{nameAttr, "cn", emailAttr, memberOfAttr}
+	if cfg.LDAP.SubjectAttribute != "" && !strings.EqualFold(cfg.LDAP.SubjectAttribute, "dn") {
+		attrs = append(attrs, cfg.LDAP.SubjectAttribute)
+	}
+
+	res, err := conn.Search(ldap.NewSearchRequest(
+		cfg.LDAP.SearchBase,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, int(timeout/time.Second), false,
+		searchFilter(cfg.LDAP.SearchFilter, username),
+		attrs,
+		nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		log.Log(ctx, fmt.Errorf("ldap: search: %w", err))
+		return nil, auth.Error("Failed to search LDAP directory.")
+	}
+	if res == nil || len(res.Entries) != 1 {
+		if res != nil && len(res.Entries) > 1 {
+			log.Log(ctx, fmt.Errorf("ldap: search returned multiple entries for username"))
+		}
+		auth.Delay(ctx)
+		return nil, auth.Error("unknown username/password")
+	}
+	entry := res.Entries[0]
+	ctx = log.WithField(ctx, "ldap_dn", entry.DN)
+
+	err = conn.Bind(entry.DN, password)
+	if err != nil {
+		log.Debug(ctx, fmt.Errorf("ldap: bind user: %w", err))
+		auth.Delay(ctx)
+		return nil, auth.Error("unknown username/password")
+	}
+
+	if len(cfg.LDAP.AllowedGroups) > 0 && !inGroups(entry, cfg.LDAP.AllowedGroups) {
+		log.Debugf(log.WithFields(ctx, log.Fields{
+			"AllowedGroups": cfg.LDAP.AllowedGroups,
+			"MemberOf":      entry.GetEqualFoldAttributeValues(memberOfAttr),
+		}), "not in any allowed group")
+		return nil, auth.Error("Not a member of an allowed group.")
+	}
+
+	sub := subjectID(entry, cfg.LDAP.SubjectAttribute)
+	if sub == "" {
+		log.Log(ctx, fmt.Errorf("ldap: entry missing subject attribute '%s'", cfg.LDAP.SubjectAttribute))
+		return nil, auth.Error("LDAP user is missing a unique identifier.")
+	}
+
+	name := entry.GetEqualFoldAttributeValue(nameAttr)
+	if name == "" {
+		name = entry.GetEqualFoldAttributeValue("cn")
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, auth.Error("LDAP user has no display name set.")
+	}
+
+	email := entry.GetEqualFoldAttributeValue(emailAttr)
+	return &auth.Identity{
+		SubjectID:     sub,
+		Name:          name,
+		Email:         email,
+		EmailVerified: email != "",
+	}, nil
+}
@@ -0,0 +1,37 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchFilter(t *testing.T) {
+	assert.Equal(t, "(uid=bob)", searchFilter("", "bob"))
+	assert.Equal(t, `(&(objectClass=user)(sAMAccountName=bob\2a))`, searchFilter("(&(objectClass=user)(sAMAccountName=%s))", "bob*"))
+	assert.Equal(t, `(|(uid=a\29)(mail=a\29))`, searchFilter("(|(uid=%s)(mail=%s))", "a)"))
+}
+
+func TestSubjectID(t *testing.T) {
+	e := ldap.NewEntry("CN=Bob,DC=Example,DC=com", map[string][]string{
+		"entryUUID":  {"1f6b4c0e-7d7b-4c8e-9a57-0a0b1f6c3d2e"},
+		"objectGUID": {"\x01\x02\x03\xff"},
+	})
+
+	assert.Equal(t, "cn=bob,dc=example,dc=com", subjectID(e, ""))
+	assert.Equal(t, "cn=bob,dc=example,dc=com", subjectID(e, "DN"))
+	assert.Equal(t, "1f6b4c0e-7d7b-4c8e-9a57-0a0b1f6c3d2e", subjectID(e, "entryuuid"))
+	assert.Equal(t, "010203ff", subjectID(e, "objectGUID"))
+	assert.Equal(t, "", subjectID(e, "missing"))
+}
+
+func TestInGroups(t *testing.T) {
+	e := ldap.NewEntry("cn=bob", map[string][]string{
+		"memberOf": {"CN=OnCall,OU=Groups,DC=example,DC=com"},
+	})
+
+	assert.True(t, inGroups(e, []string{"cn=oncall,ou=groups,dc=example,dc=com"}))
+	assert.False(t, inGroups(e, []string{"cn=admins,ou=groups,dc=example,dc=com"}))
+	assert.False(t, inGroups(e, nil))
+}
@@ -0,0 +1,19 @@
+package ldap
+
+import (
+	"context"
+
+	"github.com/target/goalert/ctxlock"
+)
+
+// Provider implements the auth.IdentityProvider interface.
+type Provider struct {
+	lim *ctxlock.IDLocker[string]
+}
+
+// NewProvider creates a new LDAP Provider.
+func NewProvider(ctx context.Context) (*Provider, error) {
+	return &Provider{
+		lim: ctxlock.NewIDLocker[string](ctxlock.Config{MaxHeld: 1}),
+	}, nil
+}
@@ -0,0 +1,20 @@
+package saml
+
+import (
+	"database/sql"
+
+	"github.com/target/goalert/auth/nonce"
+	"github.com/target/goalert/keyring"
+)
+
+// Config is used to configure the SAML service provider.
+type Config struct {
+	DB *sql.DB
+
+	// Keyring is used to sign the short-lived identity tokens passed from the ACS endpoint to the callback.
+	Keyring    keyring.Keyring
+	NonceStore *nonce.Store
+
+	// Keys are used to encrypt the SP private key at rest.
+	Keys keyring.Keys
+}
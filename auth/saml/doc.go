@@ -0,0 +1,2 @@
+// Package saml implements an auth provider that identifies a user by acting as a SAML 2.0 service provider.
+package saml
@@ -0,0 +1,323 @@
+package saml
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/auth"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+)
+
+var _ auth.IdentityProvider = &Provider{}
+
+const (
+	stateCookieName = "goalert_saml_state"
+
+	tokenIssuer   = "goalert"
+	tokenAudience = "saml-identity-v1"
+)
+
+var b64enc = base64.URLEncoding.WithPadding(base64.NoPadding)
+
+var (
+	defaultNameAttrs  = []string{"displayName", "name", "cn", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name"}
+	defaultEmailAttrs = []string{"email", "mail", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"}
+)
+
+// identityClaims are used to pass a validated identity from the ACS endpoint to the callback.
+type identityClaims struct {
+	jwt.RegisteredClaims
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// Info implements the auth.Provider interface.
+//
+// As SAML requires no user input, only the Title is provided.
+func (p *Provider) Info(ctx context.Context) auth.ProviderInfo {
+	cfg := config.FromContext(ctx)
+	title := "SAML"
+	if cfg.SAML.OverrideName != "" {
+		title = cfg.SAML.OverrideName
+	}
+	return auth.ProviderInfo{
+		Title:   title,
+		Enabled: cfg.SAML.Enable,
+	}
+}
+
+// requestID returns the AuthnRequest ID for the given nonce.
+//
+// Deriving the ID from the nonce (sent as RelayState) allows the ACS endpoint to validate
+// InResponseTo without relying on cookies, which browsers do not send on the IdP's cross-site POST.
+func requestID(nonce [16]byte) string { return "id-" + hex.EncodeToString(nonce[:]) }
+
+func parseRelayState(s string) (nonce [16]byte, ok bool) {
+	data, err := b64enc.DecodeString(s)
+	if err != nil || len(data) != len(nonce) {
+		return nonce, false
+	}
+	copy(nonce[:], data)
+	return nonce, true
+}
+
+// ExtractIdentity implements the auth.IdentityProvider interface handling both the login and callback endpoints.
+func (p *Provider) ExtractIdentity(route *auth.RouteInfo, w http.ResponseWriter, req *http.Request) (*auth.Identity, error) {
+	ctx := req.Context()
+
+	switch route.RelativePath {
+	case "/":
+		sp, err := p.serviceProvider(ctx, true)
+		if err != nil {
+			log.Log(ctx, err)
+			return nil, auth.Error("Failed to load SAML identity provider metadata.")
+		}
+
+		loc := sp.GetSSOBindingLocation(saml.HTTPRedirectBinding)
+		if loc == "" {
+			return nil, auth.Error("SAML identity provider does not support the HTTP-Redirect binding.")
+		}
+
+		nonce := p.cfg.NonceStore.New()
+		authReq, err := sp.MakeAuthenticationRequest(loc, saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "make SAML authn request"))
+			return nil, auth.Error("Failed to create SAML request.")
+		}
+		authReq.ID = requestID(nonce)
+
+		state := b64enc.EncodeToString(nonce[:])
+		u, err := authReq.Redirect(state, sp)
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "sign SAML authn request"))
+			return nil, auth.Error("Failed to create SAML request.")
+		}
+
+		auth.SetCookie(w, req, stateCookieName, state, false)
+		return nil, auth.RedirectURL(u.String())
+	case "/callback":
+		// handled below
+	default:
+		return nil, auth.Error("Invalid callback URL specified in SAML identity provider config.")
+	}
+
+	errorDesc := req.FormValue("error")
+	if errorDesc != "" {
+		return nil, auth.Error(errorDesc)
+	}
+
+	stateCookie, err := req.Cookie(stateCookieName)
+	if err != nil {
+		return nil, auth.Error("Invalid state token.")
+	}
+	auth.ClearCookie(w, req, stateCookieName, false)
+
+	var claims identityClaims
+	_, err = p.cfg.Keyring.VerifyJWT(req.FormValue("token"), &claims, tokenIssuer, tokenAudience)
+	if err != nil {
+		log.Debug(ctx, errors.Wrap(err, "verify SAML identity token"))
+		return nil, auth.Error("Invalid or expired SAML login, please try again.")
+	}
+	if claims.ID != stateCookie.Value {
+		return nil, auth.Error("Invalid state token.")
+	}
+
+	nonce, ok := parseRelayState(claims.ID)
+	if !ok {
+		return nil, auth.Error("Invalid state token.")
+	}
+	valid, err := p.cfg.NonceStore.Consume(ctx, nonce)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "consume SAML nonce"))
+		return nil, auth.Error("Could not validate state token.")
+	}
+	if !valid {
+		return nil, auth.Error("Invalid state token.")
+	}
+
+	return &auth.Identity{
+		SubjectID:     claims.Subject,
+		Name:          claims.Name,
+		Email:         claims.Email,
+		EmailVerified: claims.Email != "",
+	}, nil
+}
+
+// attrValue returns the first value of the first matching attribute, by name or friendly name (case-insensitive).
+func attrValue(a *saml.Assertion, names ...string) string {
+	for _, name := range names {
+		for _, st := range a.AttributeStatements {
+			for _, attr := range st.Attributes {
+				if !strings.EqualFold(attr.Name, name) && !strings.EqualFold(attr.FriendlyName, name) {
+					continue
+				}
+				for _, v := range attr.Values {
+					if strings.TrimSpace(v.Value) != "" {
+						return strings.TrimSpace(v.Value)
+					}
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// identityFromAssertion maps a validated assertion to an identity using the configured attributes.
+func identityFromAssertion(cfg config.Config, a *saml.Assertion) (*auth.Identity, error) {
+	var id auth.Identity
+
+	var nameID *saml.NameID
+	if a.Subject != nil {
+		nameID = a.Subject.NameID
+	}
+
+	if cfg.SAML.SubjectAttribute != "" {
+		id.SubjectID = attrValue(a, cfg.SAML.SubjectAttribute)
+	} else if nameID != nil {
+		if nameID.Format == string(saml.TransientNameIDFormat) {
+			return nil, auth.Error("SAML identity provider returned a transient NameID; a subject attribute must be configured.")
+		}
+		id.SubjectID = nameID.Value
+	}
+	if id.SubjectID == "" {
+		return nil, auth.Error("SAML assertion is missing a unique identifier.")
+	}
+
+	emailAttrs := defaultEmailAttrs
+	if cfg.SAML.EmailAttribute != "" {
+		emailAttrs = []string{cfg.SAML.EmailAttribute}
+	}
+	id.Email = attrValue(a, emailAttrs...)
+	if id.Email == "" && nameID != nil && nameID.Format == string(saml.EmailAddressNameIDFormat) {
+		id.Email = nameID.Value
+	}
+	id.EmailVerified = id.Email != ""
+
+	nameAttrs := defaultNameAttrs
+	if cfg.SAML.NameAttribute != "" {
+		nameAttrs = []string{cfg.SAML.NameAttribute}
+	}
+	id.Name = attrValue(a, nameAttrs...)
+	if id.Name == "" {
+		return nil, auth.Error("SAML user has no display name set.")
+	}
+
+	return &id, nil
+}
+
+// ServeACS handles the assertion consumer service endpoint, receiving the IdP's response via the HTTP-POST binding.
+//
+// On success, the user is redirected to the callback endpoint with a short-lived token containing the validated identity.
+func (p *Provider) ServeACS(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	if !cfg.SAML.Enable {
+		http.NotFound(w, req)
+		return
+	}
+
+	q := make(url.Values)
+	redirect := func() {
+		http.Redirect(w, req, cfg.CallbackURL("/api/v2/identity/providers/saml/callback", q), http.StatusSeeOther)
+	}
+
+	err := req.ParseForm()
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	state := req.PostForm.Get("RelayState")
+	nonce, ok := parseRelayState(state)
+	if !ok {
+		q.Set("error", "Invalid state token.")
+		redirect()
+		return
+	}
+
+	sp, err := p.serviceProvider(ctx, true)
+	if err != nil {
+		log.Log(ctx, err)
+		q.Set("error", "Failed to load SAML identity provider metadata.")
+		redirect()
+		return
+	}
+
+	assertion, err := sp.ParseResponse(req, []string{requestID(nonce)})
+	if err != nil {
+		var invalid *saml.InvalidResponseError
+		if errors.As(err, &invalid) {
+			err = fmt.Errorf("%w: %v", err, invalid.PrivateErr)
+		}
+		log.Log(ctx, errors.Wrap(err, "parse SAML response"))
+		q.Set("error", "Invalid SAML response.")
+		redirect()
+		return
+	}
+
+	id, err := identityFromAssertion(cfg, assertion)
+	if err != nil {
+		q.Set("error", err.Error())
+		redirect()
+		return
+	}
+
+	now := time.Now()
+	tok, err := p.cfg.Keyring.SignJWT(identityClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        state,
+			Subject:   id.SubjectID,
+			Issuer:    tokenIssuer,
+			Audience:  jwt.ClaimStrings{tokenAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now.Add(-time.Minute)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+		Name:  id.Name,
+		Email: id.Email,
+	})
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "sign SAML identity token"))
+		q.Set("error", "Failed to complete SAML login.")
+		redirect()
+		return
+	}
+
+	q.Set("token", tok)
+	redirect()
+}
+
+// ServeMetadata serves the SAML service provider metadata.
+func (p *Provider) ServeMetadata(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	if !cfg.SAML.Enable {
+		http.NotFound(w, req)
+		return
+	}
+
+	sp, err := p.serviceProvider(ctx, false)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	data, err := xml.MarshalIndent(sp.Metadata(), "", "  ")
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = w.Write(data)
+}
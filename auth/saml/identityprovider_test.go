@@ -0,0 +1,100 @@
+package saml
+
+import (
+	"testing"
+
+	"github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+)
+
+func TestRelayState(t *testing.T) {
+	nonce := [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+	parsed, ok := parseRelayState(b64enc.EncodeToString(nonce[:]))
+	assert.True(t, ok)
+	assert.Equal(t, nonce, parsed)
+	assert.Equal(t, "id-0102030405060708090a0b0c0d0e0f10", requestID(nonce))
+
+	_, ok = parseRelayState("invalid")
+	assert.False(t, ok)
+	_, ok = parseRelayState("")
+	assert.False(t, ok)
+}
+
+func TestIdentityFromAssertion(t *testing.T) {
+	newAssertion := func(format, nameID string, attrs map[string]string) *saml.Assertion {
+		var st saml.AttributeStatement
+		for k, v := range attrs {
+			st.Attributes = append(st.Attributes, saml.Attribute{
+				FriendlyName: k,
+				Name:         "urn:test:" + k,
+				Values:       []saml.AttributeValue{{Value: v}},
+			})
+		}
+		return &saml.Assertion{
+			Subject:             &saml.Subject{NameID: &saml.NameID{Format: format, Value: nameID}},
+			AttributeStatements: []saml.AttributeStatement{st},
+		}
+	}
+
+	var cfg config.Config
+	id, err := identityFromAssertion(cfg, newAssertion(string(saml.EmailAddressNameIDFormat), "bob@example.com", map[string]string{
+		"cn": "Bob",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "bob@example.com", id.SubjectID)
+	assert.Equal(t, "bob@example.com", id.Email)
+	assert.Equal(t, "Bob", id.Name)
+
+	// displayName takes priority over cn
+	id, err = identityFromAssertion(cfg, newAssertion(string(saml.PersistentNameIDFormat), "abc123", map[string]string{
+		"cn":          "bob",
+		"displayName": "Bob Smith",
+		"mail":        "bob@example.com",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", id.SubjectID)
+	assert.Equal(t, "bob@example.com", id.Email)
+	assert.Equal(t, "Bob Smith", id.Name)
+
+	// transient NameIDs are not stable
+	_, err = identityFromAssertion(cfg, newAssertion(string(saml.TransientNameIDFormat), "xyz", map[string]string{"cn": "Bob"}))
+	assert.Error(t, err)
+
+	// configured attributes, matched by full name
+	cfg.SAML.SubjectAttribute = "urn:test:employeeID"
+	cfg.SAML.NameAttribute = "fullName"
+	cfg.SAML.EmailAttribute = "workEmail"
+	id, err = identityFromAssertion(cfg, newAssertion(string(saml.TransientNameIDFormat), "xyz", map[string]string{
+		"employeeID": "E100",
+		"fullName":   "Robert Smith",
+		"workEmail":  "rsmith@example.com",
+		"cn":         "bob",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "E100", id.SubjectID)
+	assert.Equal(t, "rsmith@example.com", id.Email)
+	assert.Equal(t, "Robert Smith", id.Name)
+
+	// missing subject attribute
+	_, err = identityFromAssertion(cfg, newAssertion("", "", map[string]string{"fullName": "Robert Smith"}))
+	assert.Error(t, err)
+}
+
+func TestParseMetadata(t *testing.T) {
+	_, err := parseMetadata([]byte(`<EntitiesDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata"></EntitiesDescriptor>`))
+	assert.Error(t, err)
+
+	ent, err := parseMetadata([]byte(`<EntitiesDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata">
+	<EntityDescriptor entityID="sp"><SPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol"></SPSSODescriptor></EntityDescriptor>
+	<EntityDescriptor entityID="idp"><IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol"></IDPSSODescriptor></EntityDescriptor>
+</EntitiesDescriptor>`))
+	require.NoError(t, err)
+	assert.Equal(t, "idp", ent.EntityID)
+
+	ent, err = parseMetadata([]byte(`<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="idp"></EntityDescriptor>`))
+	require.NoError(t, err)
+	assert.Equal(t, "idp", ent.EntityID)
+}
@@ -0,0 +1,252 @@
+package saml
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/pkg/errors"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+)
+
+const (
+	keyID    = "default"
+	keyLabel = "SAML SP PRIVATE KEY"
+
+	metadataTTL = time.Hour
+)
+
+// Provider implements the auth.IdentityProvider interface by acting as a SAML 2.0
+// service provider.
+type Provider struct {
+	cfg Config
+
+	key  *rsa.PrivateKey
+	cert *x509.Certificate
+
+	mx   sync.Mutex
+	meta map[string]*cachedMetadata
+}
+
+type cachedMetadata struct {
+	ent     *saml.EntityDescriptor
+	fetched time.Time
+}
+
+// NewProvider prepares a new Provider with the given config, generating
+// the SP signing key if one does not already exist.
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	if cfg.DB == nil {
+		return nil, errors.New("DB missing")
+	}
+	if cfg.Keyring == nil {
+		return nil, errors.New("Keyring missing")
+	}
+	if cfg.NonceStore == nil {
+		return nil, errors.New("NonceStore missing")
+	}
+
+	p := &Provider{
+		cfg:  cfg,
+		meta: make(map[string]*cachedMetadata),
+	}
+
+	err := p.loadKey(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "load SP key")
+	}
+
+	return p, nil
+}
+
+// loadKey will load the SP key pair from the DB, creating it first if necessary.
+func (p *Provider) loadKey(ctx context.Context) error {
+	db := gadb.New(p.cfg.DB)
+	row, err := db.AuthSAMLKeyFind(ctx, keyID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = p.newKey(ctx)
+		if err != nil {
+			return err
+		}
+		row, err = db.AuthSAMLKeyFind(ctx, keyID)
+	}
+	if err != nil {
+		return err
+	}
+
+	data, _, err := p.cfg.Keys.Decrypt(row.PrivateKey)
+	if err != nil {
+		return errors.Wrap(err, "decrypt private key")
+	}
+	p.key, err = x509.ParsePKCS1PrivateKey(data)
+	if err != nil {
+		return errors.Wrap(err, "parse private key")
+	}
+	p.cert, err = x509.ParseCertificate(row.Certificate)
+	if err != nil {
+		return errors.Wrap(err, "parse certificate")
+	}
+
+	return nil
+}
+
+// newKey generates a new RSA key and self-signed certificate for signing requests.
+//
+// If another instance has already stored a key, the new one is discarded.
+func (p *Provider) newKey(ctx context.Context) error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "GoAlert SAML SP"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(20, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return err
+	}
+
+	enc, err := p.cfg.Keys.Encrypt(keyLabel, x509.MarshalPKCS1PrivateKey(key))
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(p.cfg.DB).AuthSAMLKeyInsert(ctx, gadb.AuthSAMLKeyInsertParams{
+		ID:          keyID,
+		PrivateKey:  enc,
+		Certificate: cert,
+	})
+}
+
+func parseMetadata(data []byte) (*saml.EntityDescriptor, error) {
+	var ent saml.EntityDescriptor
+	err := xml.Unmarshal(data, &ent)
+	if err == nil {
+		return &ent, nil
+	}
+
+	// some IdPs wrap their metadata in an EntitiesDescriptor
+	var ents saml.EntitiesDescriptor
+	if xml.Unmarshal(data, &ents) != nil {
+		return nil, err
+	}
+	for i := range ents.EntityDescriptors {
+		if len(ents.EntityDescriptors[i].IDPSSODescriptors) > 0 {
+			return &ents.EntityDescriptors[i], nil
+		}
+	}
+
+	return nil, errors.New("no IdP entity found in metadata")
+}
+
+func (p *Provider) idpMetadata(ctx context.Context) (*saml.EntityDescriptor, error) {
+	cfg := config.FromContext(ctx)
+	if cfg.SAML.IdPMetadata != "" {
+		return parseMetadata([]byte(cfg.SAML.IdPMetadata))
+	}
+
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	c, ok := p.meta[cfg.SAML.IdPMetadataURL]
+	if ok && time.Since(c.fetched) < metadataTTL {
+		return c.ent, nil
+	}
+
+	ent, err := fetchMetadata(ctx, cfg.SAML.IdPMetadataURL)
+	if err != nil && ok {
+		// keep using the previous metadata if the IdP is temporarily unavailable
+		return c.ent, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.meta[cfg.SAML.IdPMetadataURL] = &cachedMetadata{ent: ent, fetched: time.Now()}
+	return ent, nil
+}
+
+func fetchMetadata(ctx context.Context, metaURL string) (*saml.EntityDescriptor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", metaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch metadata: unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMetadata(data)
+}
+
+func mustParseURL(s string) url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return *u
+}
+
+// serviceProvider returns a saml.ServiceProvider for the current config.
+//
+// If withIdP is false, IdP metadata is not loaded (e.g., for serving SP metadata).
+func (p *Provider) serviceProvider(ctx context.Context, withIdP bool) (*saml.ServiceProvider, error) {
+	cfg := config.FromContext(ctx)
+
+	sp := &saml.ServiceProvider{
+		EntityID:          cfg.SAML.EntityID,
+		Key:               p.key,
+		Certificate:       p.cert,
+		MetadataURL:       mustParseURL(cfg.CallbackURL("/api/v2/identity/providers/saml/metadata")),
+		AcsURL:            mustParseURL(cfg.CallbackURL("/api/v2/identity/providers/saml/acs")),
+		SignatureMethod:   dsig.RSASHA256SignatureMethod,
+		AuthnNameIDFormat: saml.UnspecifiedNameIDFormat,
+	}
+	if !withIdP {
+		return sp, nil
+	}
+
+	var err error
+	sp.IDPMetadata, err = p.idpMetadata(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "load IdP metadata")
+	}
+
+	return sp, nil
+}
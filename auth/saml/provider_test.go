@@ -0,0 +1,126 @@
+package saml
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/xml"
+	"html"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+)
+
+type testSPProvider struct{ ent *saml.EntityDescriptor }
+
+func (p testSPProvider) GetServiceProvider(*http.Request, string) (*saml.EntityDescriptor, error) {
+	return p.ent, nil
+}
+
+type testSession struct{}
+
+func (testSession) GetSession(http.ResponseWriter, *http.Request, *saml.IdpAuthnRequest) *saml.Session {
+	return &saml.Session{
+		ID:             "session",
+		CreateTime:     time.Now(),
+		ExpireTime:     time.Now().Add(time.Hour),
+		Index:          "1",
+		NameID:         "bob@example.com",
+		NameIDFormat:   string(saml.EmailAddressNameIDFormat),
+		UserCommonName: "Bob",
+	}
+}
+
+func newTestKey(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return key, cert
+}
+
+func TestServiceProvider_RoundTrip(t *testing.T) {
+	idpKey, idpCert := newTestKey(t)
+	srv := httptest.NewServer(nil)
+	defer srv.Close()
+	base, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	idp := &saml.IdentityProvider{
+		Key:             idpKey,
+		Certificate:     idpCert,
+		MetadataURL:     *base.ResolveReference(&url.URL{Path: "/metadata"}),
+		SSOURL:          *base.ResolveReference(&url.URL{Path: "/sso"}),
+		SessionProvider: testSession{},
+	}
+	srv.Config.Handler = idp.Handler()
+	idpMeta, err := xml.Marshal(idp.Metadata())
+	require.NoError(t, err)
+
+	var cfg config.Config
+	cfg.General.PublicURL = "http://goalert.example.com"
+	cfg.SAML.Enable = true
+	cfg.SAML.IdPMetadata = string(idpMeta)
+	ctx := cfg.Context(context.Background())
+
+	spKey, spCert := newTestKey(t)
+	p := &Provider{key: spKey, cert: spCert, meta: make(map[string]*cachedMetadata)}
+	sp, err := p.serviceProvider(ctx, true)
+	require.NoError(t, err)
+	idp.ServiceProviderProvider = testSPProvider{ent: sp.Metadata()}
+
+	nonce := [16]byte{1, 2, 3}
+	state := b64enc.EncodeToString(nonce[:])
+	authReq, err := sp.MakeAuthenticationRequest(sp.GetSSOBindingLocation(saml.HTTPRedirectBinding), saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	require.NoError(t, err)
+	authReq.ID = requestID(nonce)
+	u, err := authReq.Redirect(state, sp)
+	require.NoError(t, err)
+	assert.NotEmpty(t, u.Query().Get("Signature"), "request should be signed")
+
+	resp, err := http.Get(u.String())
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+
+	m := regexp.MustCompile(`name="SAMLResponse" value="([^"]+)"`).FindSubmatch(body)
+	require.NotNil(t, m, "IdP response should contain a SAMLResponse form")
+
+	form := url.Values{"SAMLResponse": {html.UnescapeString(string(m[1]))}, "RelayState": {state}}
+	req := httptest.NewRequest("POST", cfg.CallbackURL("/api/v2/identity/providers/saml/acs"), strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	require.NoError(t, req.ParseForm())
+
+	_, err = sp.ParseResponse(req, []string{requestID([16]byte{4, 5, 6})})
+	assert.Error(t, err, "response to a different request should be rejected")
+
+	a, err := sp.ParseResponse(req, []string{requestID(nonce)})
+	require.NoError(t, err)
+
+	id, err := identityFromAssertion(cfg, a)
+	require.NoError(t, err)
+	assert.Equal(t, "bob@example.com", id.SubjectID)
+	assert.Equal(t, "Bob", id.Name)
+}
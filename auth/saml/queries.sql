@@ -0,0 +1,15 @@
+-- name: AuthSAMLKeyInsert :exec
+-- Inserts a new SP key pair, if one does not already exist.
+INSERT INTO auth_saml_keys(id, private_key, certificate)
+    VALUES ($1, $2, $3)
+ON CONFLICT (id)
+    DO NOTHING;
+
+-- name: AuthSAMLKeyFind :one
+SELECT
+    private_key,
+    certificate
+FROM
+    auth_saml_keys
+WHERE
+    id = $1;
@@ -0,0 +1,189 @@
+package calsub
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/oncall"
+)
+
+// A source is a schedule (or schedule tier), rotation, or user included in a subscription.
+type source struct {
+	Type string
+	ID   uuid.UUID
+	Name string
+
+	// Tier is the schedule tier, empty for the primary tier.
+	Tier string
+}
+
+// sources returns the schedules, rotations, and users included in a subscription.
+func (s *Store) sources(ctx context.Context, info gadb.CalSubRenderInfoRow, subCfg SubscriptionConfig) ([]source, error) {
+	q := gadb.New(s.db)
+	if info.ScheduleID.Valid {
+		result := []source{{Type: sourceTypeSchedule, ID: info.ScheduleID.UUID, Name: info.ScheduleName.String}}
+		var ids []uuid.UUID
+		for _, id := range subCfg.AdditionalScheduleIDs {
+			u, err := uuid.Parse(id)
+			if err != nil || u == info.ScheduleID.UUID {
+				continue
+			}
+			ids = append(ids, u)
+		}
+		if len(ids) == 0 {
+			return result, nil
+		}
+
+		// deleted schedules are skipped
+		rows, err := q.CalSubScheduleNames(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("lookup schedules: %w", err)
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i].Name < rows[j].Name })
+		for _, r := range rows {
+			result = append(result, source{Type: sourceTypeSchedule, ID: r.ID, Name: r.Name})
+		}
+
+		return result, nil
+	}
+
+	if !info.EscalationPolicyID.Valid {
+		return nil, nil
+	}
+
+	rows, err := q.CalSubEscalationPolicyTargets(ctx, gadb.CalSubEscalationPolicyTargetsParams{
+		EscalationPolicyID: info.EscalationPolicyID.UUID,
+		// a service subscription only includes those paged immediately
+		FirstStepOnly: info.ServiceID.Valid,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lookup escalation policy targets: %w", err)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].StepNumber != rows[j].StepNumber {
+			return rows[i].StepNumber < rows[j].StepNumber
+		}
+		return rows[i].Name < rows[j].Name
+	})
+
+	result := make([]source, len(rows))
+	for i, r := range rows {
+		switch {
+		case r.ScheduleID.Valid:
+			result[i] = source{Type: sourceTypeSchedule, ID: r.ScheduleID.UUID, Name: r.Name, Tier: r.ScheduleTier.String}
+		case r.RotationID.Valid:
+			result[i] = source{Type: sourceTypeRotation, ID: r.RotationID.UUID, Name: r.Name}
+		default:
+			result[i] = source{Type: sourceTypeUser, ID: r.UserID.UUID, Name: r.Name}
+		}
+	}
+
+	return result, nil
+}
+
+// isOverride returns true if the shift overlaps an override adding the user to the schedule.
+func isOverride(overrides []gadb.CalSubOverridesRow, schedID uuid.UUID, shift oncall.Shift) bool {
+	for _, o := range overrides {
+		if o.TgtScheduleID != schedID || o.AddUserID.UUID.String() != shift.UserID {
+			continue
+		}
+		if o.StartTime.Before(shift.End) && shift.Start.Before(o.EndTime) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// shifts calculates the shifts of all sources between start and end.
+func (s *Store) shifts(ctx context.Context, srcs []source, start, end time.Time) ([]renderShift, error) {
+	cfg := config.FromContext(ctx)
+
+	var schedIDs []uuid.UUID
+	for _, src := range srcs {
+		if src.Type == sourceTypeSchedule && src.Tier == "" {
+			schedIDs = append(schedIDs, src.ID)
+		}
+	}
+	var overrides []gadb.CalSubOverridesRow
+	if len(schedIDs) > 0 {
+		var err error
+		overrides, err = gadb.New(s.db).CalSubOverrides(ctx, gadb.CalSubOverridesParams{
+			ScheduleIds: schedIDs,
+			StartTime:   start,
+			EndTime:     end,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("lookup overrides: %w", err)
+		}
+	}
+
+	var result []renderShift
+	for _, src := range srcs {
+		var shifts []oncall.Shift
+		var err error
+		var url string
+		switch src.Type {
+		case sourceTypeSchedule:
+			if src.Tier == "" {
+				shifts, err = s.oc.HistoryBySchedule(ctx, src.ID.String(), start, end)
+			} else {
+				shifts, err = s.oc.HistoryByScheduleTier(ctx, src.ID.String(), src.Tier, start, end)
+			}
+			url = cfg.CallbackURL("/schedules/" + src.ID.String())
+		case sourceTypeRotation:
+			shifts, err = s.oc.ShiftsByRotation(ctx, src.ID.String(), start, end)
+			url = cfg.CallbackURL("/rotations/" + src.ID.String())
+		case sourceTypeUser:
+			// a user assigned directly to the escalation policy is always on-call
+			shifts = []oncall.Shift{{UserID: src.ID.String(), Start: start, End: end, Truncated: true}}
+			url = cfg.CallbackURL("/users/" + src.ID.String())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("calculate shifts for %s %s: %w", src.Type, src.ID, err)
+		}
+
+		for _, sh := range shifts {
+			result = append(result, renderShift{
+				Shift:      sh,
+				SourceType: src.Type,
+				SourceID:   src.ID,
+				SourceName: src.Name,
+				SourceTier: src.Tier,
+				SourceURL:  url,
+				// overrides only apply to the primary tier
+				Override: src.Type == sourceTypeSchedule && src.Tier == "" && isOverride(overrides, src.ID, sh),
+			})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+
+	return result, nil
+}
+
+// scheduleHolidays returns the holidays of each schedule source.
+func (s *Store) scheduleHolidays(ctx context.Context, srcs []source, start, end time.Time) ([]renderHoliday, error) {
+	var result []renderHoliday
+	seen := make(map[uuid.UUID]bool)
+	for _, src := range srcs {
+		if src.Type != sourceTypeSchedule || seen[src.ID] {
+			// a schedule may be included more than once for different tiers
+			continue
+		}
+		seen[src.ID] = true
+		hols, err := s.holidays(ctx, src.ID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hols {
+			result = append(result, renderHoliday{Holiday: h, ScheduleID: src.ID, ScheduleName: src.Name})
+		}
+	}
+
+	return result, nil
+}
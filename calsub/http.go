@@ -11,8 +11,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/gadb"
-	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule/holiday"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/version"
 )
@@ -28,13 +28,25 @@ type JSONResponseV1 struct {
 	// Type is the embedded type & version for calendar subscription payloads and should be set to PayloadType.
 	Type string
 
+	// ScheduleID, ScheduleName, and ScheduleURL are only set for schedule subscriptions.
 	ScheduleID   uuid.UUID
 	ScheduleName string
 	ScheduleURL  string
 
+	// ServiceName and ServiceURL are only set for service subscriptions.
+	ServiceName string
+	ServiceURL  string
+
+	// EscalationPolicyName and EscalationPolicyURL are set for service and escalation policy subscriptions.
+	EscalationPolicyName string
+	EscalationPolicyURL  string
+
 	Start, End time.Time
 
 	Shifts []JSONShiftV1
+
+	// Holidays are from the holiday calendars used by the schedules' rules.
+	Holidays []JSONHolidayV1
 }
 
 // JSONShiftV1 is the JSON response format for a shift in a calendar subscription.
@@ -46,9 +58,55 @@ type JSONShiftV1 struct {
 	UserURL  string
 
 	Truncated bool
+
+	// SourceType is "Schedule", "Rotation", or "User" (a user assigned directly to the escalation policy).
+	SourceType string
+	SourceID   uuid.UUID
+	SourceName string
+	SourceURL  string
+
+	// SourceTier is the schedule tier the shift is from, empty for the primary tier.
+	SourceTier string
+
+	// Override is true if the user was added to the schedule by an override.
+	Override bool
+}
+
+// JSONHolidayV1 is the JSON response format for a holiday in a calendar subscription.
+type JSONHolidayV1 struct {
+	Name string
+
+	// Start and End are the first and last dates of the holiday, in YYYY-MM-DD format.
+	Start, End string
+
+	ScheduleID   uuid.UUID
+	ScheduleName string
+}
+
+func (s *Store) holidays(ctx context.Context, schedID uuid.UUID, start, end time.Time) ([]holiday.Holiday, error) {
+	rows, err := gadb.New(s.db).CalSubHolidays(ctx, gadb.CalSubHolidaysParams{
+		ScheduleID: schedID,
+		StartDate:  holiday.Date(start),
+		EndDate:    holiday.Date(end),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lookup holidays: %w", err)
+	}
+
+	result := make([]holiday.Holiday, len(rows))
+	for i, r := range rows {
+		result[i] = holiday.Holiday{
+			ID:    r.ID,
+			Name:  r.Name,
+			Start: holiday.Date(r.StartDate),
+			End:   holiday.Date(r.EndDate),
+		}
+	}
+
+	return result, nil
 }
 
-func (s *Store) userNameMap(ctx context.Context, shifts []oncall.Shift) (map[string]string, error) {
+func (s *Store) userNameMap(ctx context.Context, shifts []renderShift) (map[string]string, error) {
 	names := make(map[string]string)
 	var uniqueIDs []uuid.UUID
 	for _, s := range shifts {
@@ -92,13 +150,24 @@ func (s *Store) ServeICalData(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	shifts, err := s.oc.HistoryBySchedule(ctx, info.ScheduleID.String(), info.Now, info.Now.AddDate(1, 0, 0))
+	var subCfg SubscriptionConfig
+	err = json.Unmarshal(info.Config, &subCfg)
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
 
-	var subCfg SubscriptionConfig
-	err = json.Unmarshal(info.Config, &subCfg)
+	start, end := info.Now, info.Now.AddDate(1, 0, 0)
+	srcs, err := s.sources(ctx, info, subCfg)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	shifts, err := s.shifts(ctx, srcs, start, end)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	holidays, err := s.scheduleHolidays(ctx, srcs, start, end)
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
@@ -115,17 +184,34 @@ func (s *Store) ServeICalData(w http.ResponseWriter, req *http.Request) {
 		shifts = filtered
 	}
 
+	var links []string
+	var svcURL, epURL string
+	if info.ServiceID.Valid {
+		svcURL = cfg.CallbackURL("/services/" + info.ServiceID.UUID.String())
+		links = append(links, "Service: "+svcURL)
+	}
+	if info.EscalationPolicyID.Valid {
+		epURL = cfg.CallbackURL("/escalation-policies/" + info.EscalationPolicyID.UUID.String())
+		links = append(links, "Escalation Policy: "+epURL)
+	}
+
 	ct, _, _ := mime.ParseMediaType(req.Header.Get("Accept"))
 	if ct == "application/json" {
 		data := JSONResponseV1{
-			AppName:      cfg.ApplicationName(),
-			AppVersion:   version.GitVersion(),
-			Type:         PayloadType,
-			ScheduleID:   info.ScheduleID,
-			ScheduleName: info.ScheduleName,
-			ScheduleURL:  cfg.CallbackURL("/schedules/" + info.ScheduleID.String()),
-			Start:        info.Now,
-			End:          info.Now.AddDate(1, 0, 0),
+			AppName:              cfg.ApplicationName(),
+			AppVersion:           version.GitVersion(),
+			Type:                 PayloadType,
+			ScheduleID:           info.ScheduleID.UUID,
+			ScheduleName:         info.ScheduleName.String,
+			ServiceName:          info.ServiceName.String,
+			ServiceURL:           svcURL,
+			EscalationPolicyName: info.EscalationPolicyName.String,
+			EscalationPolicyURL:  epURL,
+			Start:                start,
+			End:                  end,
+		}
+		if info.ScheduleID.Valid {
+			data.ScheduleURL = cfg.CallbackURL("/schedules/" + info.ScheduleID.UUID.String())
 		}
 		m, err := s.userNameMap(ctx, shifts)
 		if errutil.HTTPError(ctx, w, err) {
@@ -133,17 +219,33 @@ func (s *Store) ServeICalData(w http.ResponseWriter, req *http.Request) {
 		}
 		for _, s := range shifts {
 			data.Shifts = append(data.Shifts, JSONShiftV1{
-				Start:     s.Start,
-				End:       s.End,
-				Truncated: s.Truncated,
-				UserID:    uuid.MustParse(s.UserID),
-				UserName:  m[s.UserID],
-				UserURL:   cfg.CallbackURL("/users/" + s.UserID),
+				Start:      s.Start,
+				End:        s.End,
+				Truncated:  s.Truncated,
+				UserID:     uuid.MustParse(s.UserID),
+				UserName:   m[s.UserID],
+				UserURL:    cfg.CallbackURL("/users/" + s.UserID),
+				SourceType: s.SourceType,
+				SourceID:   s.SourceID,
+				SourceName: s.SourceName,
+				SourceURL:  s.SourceURL,
+				SourceTier: s.SourceTier,
+				Override:   s.Override,
 			})
 		}
 		if len(data.Shifts) == 0 {
 			data.Shifts = []JSONShiftV1{}
 		}
+		data.Holidays = []JSONHolidayV1{}
+		for _, h := range holidays {
+			data.Holidays = append(data.Holidays, JSONHolidayV1{
+				Name:         h.Name,
+				Start:        h.Start.Format(time.DateOnly),
+				End:          h.End.Format(time.DateOnly),
+				ScheduleID:   h.ScheduleID,
+				ScheduleName: h.ScheduleName,
+			})
+		}
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(data)
 		if errutil.HTTPError(ctx, w, err) {
@@ -154,13 +256,13 @@ func (s *Store) ServeICalData(w http.ResponseWriter, req *http.Request) {
 
 	data := renderData{
 		ApplicationName: cfg.ApplicationName(),
-		ScheduleID:      info.ScheduleID,
-		ScheduleName:    info.ScheduleName,
 		Shifts:          shifts,
+		Holidays:        holidays,
 		ReminderMinutes: subCfg.ReminderMinutes,
 		Version:         version.GitVersion(),
 		GeneratedAt:     info.Now,
 		FullSchedule:    subCfg.FullSchedule,
+		Links:           links,
 	}
 
 	if subCfg.FullSchedule {
@@ -5,6 +5,8 @@ SELECT
     user_id,
     disabled,
     schedule_id,
+    service_id,
+    escalation_policy_id,
     config,
     last_access
 FROM
@@ -22,18 +24,76 @@ WHERE
     id = ANY ($1::uuid[]);
 
 -- name: CalSubRenderInfo :one
+-- Returns the information needed to render a subscription. For service subscriptions, the escalation policy is the one assigned to the service.
 SELECT
     now()::timestamptz AS now,
     sub.schedule_id,
     sched.name AS schedule_name,
+    sub.service_id,
+    svc.name AS service_name,
+    ep.id AS escalation_policy_id,
+    ep.name AS escalation_policy_name,
     sub.config,
     sub.user_id
 FROM
     user_calendar_subscriptions sub
-    JOIN schedules sched ON sched.id = schedule_id
+    LEFT JOIN schedules sched ON sched.id = sub.schedule_id
+    LEFT JOIN services svc ON svc.id = sub.service_id
+    LEFT JOIN escalation_policies ep ON ep.id = coalesce(sub.escalation_policy_id, svc.escalation_policy_id)
 WHERE
     sub.id = $1;
 
+-- name: CalSubScheduleNames :many
+SELECT
+    id,
+    name
+FROM
+    schedules
+WHERE
+    id = ANY (@schedule_ids::uuid[]);
+
+-- name: CalSubEscalationPolicyTargets :many
+-- Returns the schedules (and schedule tiers), rotations, and users assigned to the steps of an escalation policy, optionally only the first step.
+SELECT DISTINCT ON (coalesce(act.schedule_id, act.rotation_id, act.user_id), coalesce(act.schedule_tier, ''))
+    step.step_number,
+    act.schedule_id,
+    act.schedule_tier,
+    act.rotation_id,
+    act.user_id,
+    coalesce(sched.name || coalesce(' (' || act.schedule_tier || ')', ''), rot.name, u.name)::text AS name
+FROM
+    escalation_policy_steps step
+    JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+    LEFT JOIN schedules sched ON sched.id = act.schedule_id
+    LEFT JOIN rotations rot ON rot.id = act.rotation_id
+    LEFT JOIN users u ON u.id = act.user_id
+WHERE
+    step.escalation_policy_id = @escalation_policy_id
+    AND (act.schedule_id NOTNULL
+        OR act.rotation_id NOTNULL
+        OR act.user_id NOTNULL)
+    AND (NOT @first_step_only::bool
+        OR step.step_number = 0)
+ORDER BY
+    coalesce(act.schedule_id, act.rotation_id, act.user_id),
+    coalesce(act.schedule_tier, ''),
+    step.step_number;
+
+-- name: CalSubOverrides :many
+-- Returns overrides that add a user to one of the schedules during the given time range.
+SELECT
+    tgt_schedule_id,
+    add_user_id,
+    start_time,
+    end_time
+FROM
+    user_overrides
+WHERE
+    tgt_schedule_id = ANY (@schedule_ids::uuid[])
+    AND add_user_id NOTNULL
+    AND end_time > @start_time
+    AND start_time < @end_time;
+
 -- name: FindOneCalSubForUpdate :one
 SELECT
     id,
@@ -41,6 +101,8 @@ SELECT
     user_id,
     disabled,
     schedule_id,
+    service_id,
+    escalation_policy_id,
     config,
     last_access
 FROM
@@ -56,6 +118,8 @@ SELECT
     user_id,
     disabled,
     schedule_id,
+    service_id,
+    escalation_policy_id,
     config,
     last_access
 FROM
@@ -69,8 +133,8 @@ WHERE id = ANY ($1::uuid[])
     AND user_id = $2;
 
 -- name: CreateCalSub :one
-INSERT INTO user_calendar_subscriptions(id, NAME, user_id, disabled, schedule_id, config)
-    VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO user_calendar_subscriptions(id, NAME, user_id, disabled, schedule_id, service_id, escalation_policy_id, config)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING
     created_at;
 
@@ -98,3 +162,26 @@ WHERE
     id = $4
     AND user_id = $5;
 
+
+-- name: CalSubHolidays :many
+-- Returns holidays, from calendars used by the schedule's rules, that overlap the given dates.
+SELECT DISTINCT ON (hol.start_date, hol.name)
+    hol.id,
+    hol.name,
+    hol.start_date,
+    hol.end_date
+FROM
+    holidays hol
+WHERE
+    hol.calendar_id IN (
+        SELECT
+            holiday_calendar_id
+        FROM
+            schedule_rules
+        WHERE
+            schedule_id = @schedule_id)
+    AND hol.end_date >= @start_date::date
+    AND hol.start_date <= @end_date::date
+ORDER BY
+    hol.start_date,
+    hol.name;
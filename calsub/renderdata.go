@@ -5,16 +5,50 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/schedule/holiday"
 )
 
+// Source types for shifts in a feed.
+const (
+	sourceTypeSchedule = "Schedule"
+	sourceTypeRotation = "Rotation"
+	sourceTypeUser     = "User"
+)
+
+// A renderShift is a shift along with the schedule, rotation, or user it is from.
+type renderShift struct {
+	oncall.Shift
+
+	SourceType string
+	SourceID   uuid.UUID
+	SourceName string
+	SourceURL  string
+
+	// SourceTier is the schedule tier the shift is from, empty for the primary tier.
+	SourceTier string
+
+	// Override is set if the user was added to the schedule by an override.
+	Override bool
+}
+
+type renderHoliday struct {
+	holiday.Holiday
+
+	ScheduleID   uuid.UUID
+	ScheduleName string
+}
+
 type renderData struct {
 	ApplicationName string
-	ScheduleID      uuid.UUID
-	ScheduleName    string
-	Shifts          []oncall.Shift
+	Shifts          []renderShift
+	Holidays        []renderHoliday
 	ReminderMinutes []int
 	Version         string
 	GeneratedAt     time.Time
 	FullSchedule    bool
 	UserNames       map[string]string
+
+	// Links are added to the description of every shift (e.g., the service and
+	// escalation policy of the subscription).
+	Links []string
 }
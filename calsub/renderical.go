@@ -12,19 +12,21 @@ import (
 )
 
 // RFC can be found at https://tools.ietf.org/html/rfc5545
-var iCalTemplate = template.Must(template.New("ical").Parse(strings.ReplaceAll(`BEGIN:VCALENDAR
-PRODID:-//{{.ApplicationName}}//{{.Version}}//EN
+var iCalTemplate = template.Must(template.New("ical").Funcs(template.FuncMap{"escape": escapeText}).Parse(strings.ReplaceAll(`BEGIN:VCALENDAR
+PRODID:-//{{escape .ApplicationName}}//{{.Version}}//EN
 VERSION:2.0
 CALSCALE:GREGORIAN
 METHOD:PUBLISH
 {{- $mins := .ReminderMinutes }}
 {{- $genTime := .GeneratedAt }}
 {{- $eventUIDs := .EventUIDs}}
+{{- $descriptions := .Descriptions}}
 {{- range $i, $s := .Shifts}}
 BEGIN:VEVENT
 UID:{{index $eventUIDs $i}}
-SUMMARY:{{if $.FullSchedule}}{{index $.UserNames $s.UserID}} {{end}}On-Call ({{$.ApplicationName}}: {{$.ScheduleName}}){{if $s.Truncated}} Begins*
-DESCRIPTION:The end time of this shift is unknown and will continue beyond what is displayed.
+SUMMARY:{{if $.FullSchedule}}{{escape (index $.UserNames $s.UserID)}} {{end}}On-Call ({{escape $.ApplicationName}}: {{escape $s.SourceName}}){{if $s.Override}} (Override){{end}}{{if $s.Truncated}} Begins*{{end}}
+{{- with index $descriptions $i}}
+DESCRIPTION:{{.}}
 {{- end }}
 DTSTAMP:{{$genTime.UTC.Format "20060102T150405Z"}}
 DTSTART:{{.Start.UTC.Format "20060102T150405Z"}}
@@ -38,23 +40,70 @@ END:VALARM
 {{- end}}
 END:VEVENT
 {{- end}}
+{{- $holidayUIDs := .HolidayUIDs}}
+{{- range $i, $h := .Holidays}}
+BEGIN:VEVENT
+UID:{{index $holidayUIDs $i}}
+SUMMARY:{{escape $h.Name}} (Holiday: {{escape $h.ScheduleName}})
+DTSTAMP:{{$genTime.UTC.Format "20060102T150405Z"}}
+DTSTART;VALUE=DATE:{{$h.Start.Format "20060102"}}
+DTEND;VALUE=DATE:{{($h.End.AddDate 0 0 1).Format "20060102"}}
+TRANSP:TRANSPARENT
+END:VEVENT
+{{- end}}
 END:VCALENDAR
 `, "\n", "\r\n")))
 
+// escapeText escapes a value for use in an iCalendar TEXT property.
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(value)
+}
+
+// description returns the escaped event description for the shift.
+func (s renderShift) description(links []string) string {
+	var lines []string
+	if s.Truncated {
+		lines = append(lines, "The end time of this shift is unknown and will continue beyond what is displayed.")
+	}
+	if s.Override {
+		lines = append(lines, "This shift was added by an override.")
+	}
+	if s.SourceURL != "" {
+		lines = append(lines, s.SourceType+": "+s.SourceURL)
+	}
+	lines = append(lines, links...)
+
+	for i, l := range lines {
+		lines[i] = escapeText(l)
+	}
+	return strings.Join(lines, `\n`)
+}
+
 // renderICal will generate an iCal file from the renderData.
 func (r renderData) renderICal() ([]byte, error) {
 	var icalRender struct {
 		renderData
-		EventUIDs []string
+		EventUIDs    []string
+		Descriptions []string
+		HolidayUIDs  []string
 	}
 	icalRender.renderData = r
 	for _, s := range r.Shifts {
 		t := s.End
-		if s.Truncated {
+		switch {
+		case s.SourceType == sourceTypeUser:
+			// the shift always spans the whole feed, so neither end is stable
+			t = time.Time{}
+		case s.Truncated:
 			t = s.Start
 		}
-		sum := sha256.Sum256([]byte(s.UserID + r.ScheduleID.String() + t.Format(time.RFC3339)))
+		sum := sha256.Sum256([]byte(s.UserID + s.SourceID.String() + s.SourceTier + t.Format(time.RFC3339)))
 		icalRender.EventUIDs = append(icalRender.EventUIDs, hex.EncodeToString(sum[:]))
+		icalRender.Descriptions = append(icalRender.Descriptions, s.description(r.Links))
+	}
+	for _, h := range r.Holidays {
+		sum := sha256.Sum256([]byte(h.Name + h.ScheduleID.String() + h.Start.Format(time.DateOnly)))
+		icalRender.HolidayUIDs = append(icalRender.HolidayUIDs, hex.EncodeToString(sum[:]))
 	}
 
 	buf := bytes.NewBuffer(nil)
@@ -9,24 +9,32 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/schedule/holiday"
 )
 
 func TestRenderData_RenderICal(t *testing.T) {
-	shifts := []oncall.Shift{{
-		UserID: "01020304-0506-0708-090a-0b0c0d0e0f10",
-		Start:  time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC),
-		End:    time.Date(2020, 1, 15, 8, 0, 0, 0, time.UTC),
+	schedID := uuid.MustParse("100f0e0d-0c0b-0a09-0807-060504030201")
+	shifts := []renderShift{{
+		Shift: oncall.Shift{
+			UserID: "01020304-0506-0708-090a-0b0c0d0e0f10",
+			Start:  time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC),
+			End:    time.Date(2020, 1, 15, 8, 0, 0, 0, time.UTC),
+		},
+		SourceID:   schedID,
+		SourceName: "Sched",
 	}, {
-		UserID:    "01020304-0506-0708-090a-0b0c0d0e0f10",
-		Start:     time.Date(2020, 2, 1, 8, 0, 0, 0, time.UTC),
-		End:       time.Date(2020, 2, 15, 8, 0, 0, 0, time.UTC),
-		Truncated: true,
+		Shift: oncall.Shift{
+			UserID:    "01020304-0506-0708-090a-0b0c0d0e0f10",
+			Start:     time.Date(2020, 2, 1, 8, 0, 0, 0, time.UTC),
+			End:       time.Date(2020, 2, 15, 8, 0, 0, 0, time.UTC),
+			Truncated: true,
+		},
+		SourceID:   schedID,
+		SourceName: "Sched",
 	}}
 	generatedAt := time.Date(2020, 1, 1, 5, 0, 0, 0, time.UTC)
 	r := renderData{
 		ApplicationName: "GoAlert",
-		ScheduleID:      uuid.MustParse("100f0e0d-0c0b-0a09-0807-060504030201"),
-		ScheduleName:    "Sched",
 		Shifts:          shifts,
 		ReminderMinutes: []int{5, 10},
 		Version:         "dev",
@@ -80,3 +88,100 @@ func TestRenderData_RenderICal(t *testing.T) {
 	}, "\r\n")
 	assert.Equal(t, expected, string(iCal))
 }
+
+func TestRenderData_RenderICal_Holidays(t *testing.T) {
+	r := renderData{
+		ApplicationName: "GoAlert",
+		Holidays: []renderHoliday{{
+			Holiday: holiday.Holiday{
+				Name:  "Winter Break",
+				Start: time.Date(2020, 12, 24, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2020, 12, 26, 0, 0, 0, 0, time.UTC),
+			},
+			ScheduleID:   uuid.MustParse("100f0e0d-0c0b-0a09-0807-060504030201"),
+			ScheduleName: "Sched",
+		}},
+		Version:     "dev",
+		GeneratedAt: time.Date(2020, 1, 1, 5, 0, 0, 0, time.UTC),
+	}
+	iCal, err := r.renderICal()
+	require.NoError(t, err)
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"PRODID:-//GoAlert//dev//EN",
+		"VERSION:2.0",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		"UID:4a457424a721857b3e32c208ce90276615bb0b3179ce05c2cb820ff87c20f739",
+		"SUMMARY:Winter Break (Holiday: Sched)",
+		"DTSTAMP:20200101T050000Z",
+		"DTSTART;VALUE=DATE:20201224",
+		"DTEND;VALUE=DATE:20201227",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	assert.Equal(t, expected, string(iCal))
+}
+
+func TestRenderData_RenderICal_Links(t *testing.T) {
+	r := renderData{
+		ApplicationName: "GoAlert",
+		Shifts: []renderShift{{
+			Shift: oncall.Shift{
+				UserID: "01020304-0506-0708-090a-0b0c0d0e0f10",
+				Start:  time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC),
+				End:    time.Date(2020, 1, 2, 8, 0, 0, 0, time.UTC),
+			},
+			SourceType: sourceTypeRotation,
+			SourceID:   uuid.MustParse("100f0e0d-0c0b-0a09-0807-060504030201"),
+			SourceName: "Primary, Weekly",
+			SourceURL:  "http://example.com/rotations/1",
+			Override:   true,
+		}},
+		Links:       []string{"Service: http://example.com/services/2"},
+		Version:     "dev",
+		GeneratedAt: time.Date(2020, 1, 1, 5, 0, 0, 0, time.UTC),
+	}
+	iCal, err := r.renderICal()
+	require.NoError(t, err)
+
+	assert.Contains(t, string(iCal), "SUMMARY:On-Call (GoAlert: Primary\\, Weekly) (Override)\r\n")
+	assert.Contains(t, string(iCal), `DESCRIPTION:This shift was added by an override.\nRotation: http://example.com/rotations/1\nService: http://example.com/services/2`+"\r\n")
+}
+
+func TestRenderData_RenderICal_Escape(t *testing.T) {
+	r := renderData{
+		ApplicationName: "GoAlert",
+		Shifts: []renderShift{{
+			Shift: oncall.Shift{
+				UserID: "01020304-0506-0708-090a-0b0c0d0e0f10",
+				Start:  time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC),
+				End:    time.Date(2020, 1, 2, 8, 0, 0, 0, time.UTC),
+			},
+			SourceType: sourceTypeSchedule,
+			SourceID:   uuid.MustParse("100f0e0d-0c0b-0a09-0807-060504030201"),
+			SourceName: "Ops; Primary",
+		}},
+		Holidays: []renderHoliday{{
+			Holiday: holiday.Holiday{
+				Name:  "Christmas, Observed",
+				Start: time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC),
+			},
+			ScheduleID:   uuid.MustParse("100f0e0d-0c0b-0a09-0807-060504030201"),
+			ScheduleName: "Ops; Primary",
+		}},
+		FullSchedule: true,
+		UserNames:    map[string]string{"01020304-0506-0708-090a-0b0c0d0e0f10": `Smith\, Joe`},
+		Version:      "dev",
+		GeneratedAt:  time.Date(2020, 1, 1, 5, 0, 0, 0, time.UTC),
+	}
+	iCal, err := r.renderICal()
+	require.NoError(t, err)
+
+	assert.Contains(t, string(iCal), `SUMMARY:Smith\\\, Joe On-Call (GoAlert: Ops\; Primary)`+"\r\n")
+	assert.Contains(t, string(iCal), `SUMMARY:Christmas\, Observed (Holiday: Ops\; Primary)`+"\r\n")
+}
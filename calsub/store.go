@@ -34,6 +34,22 @@ func NewStore(ctx context.Context, db *sql.DB, apiKeyring keyring.Keyring, oc *o
 	}, nil
 }
 
+func nullUUID(id string) uuid.NullUUID {
+	if id == "" {
+		return uuid.NullUUID{}
+	}
+
+	return uuid.NullUUID{UUID: uuid.MustParse(id), Valid: true}
+}
+
+func idString(id uuid.NullUUID) string {
+	if !id.Valid {
+		return ""
+	}
+
+	return id.UUID.String()
+}
+
 // Authorize will return an authorized context associated with the given token. If the token is invalid
 // or otherwise can not be authenticated, an error is returned.
 func (s *Store) Authorize(ctx context.Context, tok authtoken.Token) (context.Context, error) {
@@ -88,12 +104,14 @@ func (s *Store) _FindOne(ctx context.Context, q *gadb.Queries, id string, upd bo
 	}
 
 	cs := Subscription{
-		ID:         sub.ID.String(),
-		Name:       sub.Name,
-		UserID:     sub.UserID.String(),
-		Disabled:   sub.Disabled,
-		ScheduleID: sub.ScheduleID.String(),
-		LastAccess: sub.LastAccess.Time,
+		ID:                 sub.ID.String(),
+		Name:               sub.Name,
+		UserID:             sub.UserID.String(),
+		Disabled:           sub.Disabled,
+		ScheduleID:         idString(sub.ScheduleID),
+		ServiceID:          idString(sub.ServiceID),
+		EscalationPolicyID: idString(sub.EscalationPolicyID),
+		LastAccess:         sub.LastAccess.Time,
 	}
 	err = json.Unmarshal(sub.Config, &cs.Config)
 	if err != nil {
@@ -166,12 +184,14 @@ func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, cs *Subscription) (*Su
 	}
 
 	now, err := gadb.New(s.db).WithTx(tx).CreateCalSub(ctx, gadb.CreateCalSubParams{
-		ID:         uuid.MustParse(n.ID),
-		Name:       n.Name,
-		UserID:     uuid.MustParse(n.UserID),
-		Disabled:   n.Disabled,
-		ScheduleID: uuid.MustParse(n.ScheduleID),
-		Config:     cfgData,
+		ID:                 uuid.MustParse(n.ID),
+		Name:               n.Name,
+		UserID:             uuid.MustParse(n.UserID),
+		Disabled:           n.Disabled,
+		ScheduleID:         nullUUID(n.ScheduleID),
+		ServiceID:          nullUUID(n.ServiceID),
+		EscalationPolicyID: nullUUID(n.EscalationPolicyID),
+		Config:             cfgData,
 	})
 	if err != nil {
 		return nil, err
@@ -210,12 +230,14 @@ func (s *Store) FindAllByUser(ctx context.Context, userID string) ([]Subscriptio
 	cs := make([]Subscription, len(subs))
 	for i, sub := range subs {
 		cs[i] = Subscription{
-			ID:         sub.ID.String(),
-			Name:       sub.Name,
-			UserID:     sub.UserID.String(),
-			Disabled:   sub.Disabled,
-			ScheduleID: sub.ScheduleID.String(),
-			LastAccess: sub.LastAccess.Time,
+			ID:                 sub.ID.String(),
+			Name:               sub.Name,
+			UserID:             sub.UserID.String(),
+			Disabled:           sub.Disabled,
+			ScheduleID:         idString(sub.ScheduleID),
+			ServiceID:          idString(sub.ServiceID),
+			EscalationPolicyID: idString(sub.EscalationPolicyID),
+			LastAccess:         sub.LastAccess.Time,
 		}
 		err = json.Unmarshal(sub.Config, &cs[i].Config)
 		if err != nil {
@@ -4,15 +4,28 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
+// MaxAdditionalSchedules is the maximum number of additional schedules a subscription may include.
+const MaxAdditionalSchedules = 10
+
 // Subscription stores the information from user subscriptions
 type Subscription struct {
-	ID         string
-	Name       string
-	UserID     string
-	ScheduleID string
+	ID     string
+	Name   string
+	UserID string
+
+	// Exactly one of ScheduleID, ServiceID, or EscalationPolicyID is set.
+	//
+	// A service subscription includes the schedules and rotations on the first
+	// step of the service's escalation policy; an escalation policy subscription
+	// includes those on every step.
+	ScheduleID         string
+	ServiceID          string
+	EscalationPolicyID string
+
 	LastUpdate time.Time
 	LastAccess time.Time
 	Disabled   bool
@@ -33,11 +46,33 @@ func (cs Subscription) Normalize() (*Subscription, error) {
 		cs.ID = uuid.New().String()
 	}
 
-	err := validate.Many(
+	var err error
+	var scopes int
+	for _, f := range []struct{ name, id string }{
+		{"ScheduleID", cs.ScheduleID},
+		{"ServiceID", cs.ServiceID},
+		{"EscalationPolicyID", cs.EscalationPolicyID},
+	} {
+		if f.id == "" {
+			continue
+		}
+		scopes++
+		err = validate.Many(err, validate.UUID(f.name, f.id))
+	}
+	if scopes != 1 {
+		return nil, validation.NewFieldError("ScheduleID", "exactly one of schedule, service, or escalation policy is required")
+	}
+	if cs.ScheduleID == "" && len(cs.Config.AdditionalScheduleIDs) > 0 {
+		err = validate.Many(err, validation.NewFieldError("AdditionalScheduleIDs", "only allowed for schedule subscriptions"))
+	}
+
+	err = validate.Many(
+		err,
 		validate.Range("ReminderMinutes", len(cs.Config.ReminderMinutes), 0, 15),
 		validate.IDName("Name", cs.Name),
 		validate.UUID("ID", cs.ID),
 		validate.UUID("UserID", cs.UserID),
+		validate.ManyUUID("AdditionalScheduleIDs", cs.Config.AdditionalScheduleIDs, MaxAdditionalSchedules),
 	)
 	if err != nil {
 		return nil, err
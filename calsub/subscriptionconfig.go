@@ -11,6 +11,10 @@ import (
 type SubscriptionConfig struct {
 	ReminderMinutes []int
 	FullSchedule    bool
+
+	// AdditionalScheduleIDs are included in the feed along with the subscription's
+	// schedule, allowing a single feed to cover a team's schedules.
+	AdditionalScheduleIDs []string `json:",omitempty"`
 }
 
 var (
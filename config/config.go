@@ -43,6 +43,10 @@ type Config struct {
 		HighPriorityLabelValue string `public:"true" info:"Label value indicating high priority alerts."`
 	}
 
+	Schedules struct {
+		CoverageGapDays int `public:"true" info:"Schedules will be checked for periods with nobody on call this many days into the future, notifying their on-call notification channels when a new gap is found (0 means disable)."`
+	}
+
 	Maintenance struct {
 		AlertCleanupDays     int  `public:"true" info:"Closed alerts will be deleted after this many days (0 means disable cleanup)."`
 		AlertAutoCloseDays   int  `public:"true" info:"Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close)."`
@@ -86,6 +90,57 @@ type Config struct {
 		UserInfoNamePath          string `info:"JMESPath expression to find full name in UserInfo. If set, the name claim will be ignored in favor of this. (suggestion: name || cn || join(' ', [firstname, lastname]))"`
 	}
 
+	LDAP struct {
+		Enable bool `public:"true" info:"Enable LDAP (e.g. Active Directory) authentication."`
+
+		NewUsers     bool   `info:"Allow new user creation via LDAP authentication."`
+		OverrideName string `info:"Set the name/label on the login page to something other than LDAP."`
+
+		URL        string `info:"LDAP server URL (e.g. ldaps://ad.example.com or ldap://ldap.example.com:389)."`
+		StartTLS   bool   `info:"Upgrade ldap:// connections using StartTLS."`
+		SkipVerify bool   `info:"Disables certificate validation for TLS/StartTLS (insecure)."`
+
+		BindDN       string `info:"DN (or UPN for Active Directory) of the account used to search for users. If blank, an anonymous bind is used."`
+		BindPassword string `password:"true" info:"Password for the search account."`
+
+		SearchBase   string `info:"Base DN to search for users (e.g. ou=people,dc=example,dc=com)."`
+		SearchFilter string `info:"Filter used to find a user, with %s replaced by the username entered at login. If blank, (uid=%s) will be used. (suggestion for Active Directory: (&(objectClass=user)(sAMAccountName=%s)))"`
+
+		SubjectAttribute string `info:"Attribute that uniquely identifies a user (e.g. objectGUID or entryUUID). If blank, the user's DN will be used."`
+		NameAttribute    string `info:"Attribute containing the user's full name. If blank, displayName (or cn) will be used."`
+		EmailAttribute   string `info:"Attribute containing the user's email address. If blank, mail will be used."`
+
+		AllowedGroups []string `info:"If set, only members of any listed group DN (via the memberOf attribute) may authenticate."`
+	}
+
+	SAML struct {
+		Enable bool `public:"true" info:"Enable SAML 2.0 authentication."`
+
+		NewUsers     bool   `info:"Allow new user creation via SAML authentication."`
+		OverrideName string `info:"Set the name/label on the login page to something other than SAML."`
+
+		IdPMetadataURL string `info:"URL of the identity provider's SAML metadata."`
+		IdPMetadata    string `info:"SAML metadata XML of the identity provider. If set, IdPMetadataURL will be ignored."`
+
+		EntityID string `info:"Service provider entity ID. If blank, the SP metadata URL (<public URL>/api/v2/identity/providers/saml/metadata) will be used."`
+
+		SubjectAttribute string `info:"Assertion attribute that uniquely identifies a user. If blank, the NameID will be used (transient NameIDs are not supported)."`
+		NameAttribute    string `info:"Assertion attribute containing the user's full name. If blank, displayName, name, or cn will be used."`
+		EmailAttribute   string `info:"Assertion attribute containing the user's email address. If blank, email or mail will be used."`
+	}
+
+	SCIM struct {
+		Enable bool `public:"true" info:"Enable the SCIM 2.0 user and group provisioning API (requires a SCIM API key)."`
+
+		LinkProviderID string `info:"If set, provisioned users will also be linked to this auth provider (e.g. 'oidc') using their SCIM userName as the subject ID."`
+
+		DeleteInactiveUsers bool `info:"If set, users marked inactive (active=false) or deleted via SCIM will be deleted, along with their alert and on-call history. This cannot be undone. Otherwise, requests to deactivate a user are rejected, and deleted users are only unlinked from SCIM."`
+	}
+
+	OAuthServer struct {
+		Enable bool `public:"true" info:"Allow third-party applications to access the GraphQL API on behalf of users via OAuth 2.0 (authorization code flow with PKCE)."`
+	}
+
 	Mailgun struct {
 		Enable bool `public:"true"`
 
@@ -468,6 +523,22 @@ func (cfg Config) Validate() error {
 		}
 		return validate.OAuthScope(fname, val, "openid")
 	}
+	validateLDAPURL := func(fname, val string) error {
+		u, err := url.Parse(val)
+		if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+			return validation.NewFieldError(fname, "must be a valid ldap:// or ldaps:// URL")
+		}
+		return nil
+	}
+	validateLDAPFilter := func(fname, val string) error {
+		if !strings.HasPrefix(val, "(") || !strings.HasSuffix(val, ")") {
+			return validation.NewFieldError(fname, "must be enclosed in parentheses")
+		}
+		if !strings.Contains(val, "%s") {
+			return validation.NewFieldError(fname, "must contain %s as a placeholder for the username")
+		}
+		return nil
+	}
 	validateLabels := func(fname string, vals []string) (err error) {
 		for i, v := range vals {
 			err = validate.Many(err, validate.LabelKey(fmt.Sprintf("%s[%d]", fname, i), v))
@@ -494,6 +565,7 @@ func (cfg Config) Validate() error {
 		validate.Range("Maintenance.AlertAutoCloseDays", cfg.Maintenance.AlertAutoCloseDays, 0, 9000),
 		validate.Range("Maintenance.APIKeyExpireDays", cfg.Maintenance.APIKeyExpireDays, 0, 9000),
 		validate.Range("Maintenance.ScheduleCleanupDays", cfg.Maintenance.ScheduleCleanupDays, 0, 9000),
+		validate.Range("Schedules.CoverageGapDays", cfg.Schedules.CoverageGapDays, 0, 90),
 		validateScopes("OIDC.Scopes", cfg.OIDC.Scopes),
 		validatePath("OIDC.UserInfoEmailPath", cfg.OIDC.UserInfoEmailPath),
 		validatePath("OIDC.UserInfoEmailVerifiedPath", cfg.OIDC.UserInfoEmailVerifiedPath),
@@ -547,6 +619,24 @@ func (cfg Config) Validate() error {
 	if cfg.WebPush.SubscriberEmail != "" {
 		err = validate.Many(err, validate.Email("WebPush.SubscriberEmail", cfg.WebPush.SubscriberEmail))
 	}
+	if cfg.LDAP.URL != "" {
+		err = validate.Many(err, validateLDAPURL("LDAP.URL", cfg.LDAP.URL))
+	}
+	if cfg.LDAP.SearchFilter != "" {
+		err = validate.Many(err, validateLDAPFilter("LDAP.SearchFilter", cfg.LDAP.SearchFilter))
+	}
+	if cfg.SAML.IdPMetadataURL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("SAML.IdPMetadataURL", cfg.SAML.IdPMetadataURL))
+	}
+	if cfg.SAML.Enable && cfg.SAML.IdPMetadataURL == "" && cfg.SAML.IdPMetadata == "" {
+		err = validate.Many(err,
+			validation.NewFieldError("SAML.Enable", "requires SAML.IdPMetadataURL or SAML.IdPMetadata to be set"),
+			validation.NewFieldError("SAML.IdPMetadataURL", "required to enable SAML"),
+		)
+	}
+	if cfg.SCIM.LinkProviderID != "" {
+		err = validate.Many(err, validate.SubjectID("SCIM.LinkProviderID", cfg.SCIM.LinkProviderID))
+	}
 	if cfg.Slack.InteractiveMessages && cfg.Slack.SigningSecret == "" {
 		err = validate.Many(err, validation.NewFieldError("Slack.SigningSecret", "required to enable Slack interactive messages"))
 	}
@@ -580,6 +670,10 @@ func (cfg Config) Validate() error {
 			"ClientID", cfg.OIDC.ClientID,
 			"ClientSecret", cfg.OIDC.ClientSecret,
 		),
+		validateEnable("LDAP", cfg.LDAP.Enable,
+			"URL", cfg.LDAP.URL,
+			"SearchBase", cfg.LDAP.SearchBase,
+		),
 		validateEnable("SMTP", cfg.SMTP.Enable,
 			"From", cfg.SMTP.From,
 			"Address", cfg.SMTP.Address,
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/crewjam/saml"
+)
+
+// spProvider fetches the metadata of the service provider on demand.
+type spProvider struct {
+	metadataURL string
+}
+
+func (p spProvider) GetServiceProvider(r *http.Request, serviceProviderID string) (*saml.EntityDescriptor, error) {
+	resp, err := http.Get(p.metadataURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch SP metadata: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var ent saml.EntityDescriptor
+	err = xml.Unmarshal(data, &ent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ent, nil
+}
+
+// userSession always returns a session for the same test user.
+type userSession struct {
+	name, email string
+}
+
+func (u userSession) GetSession(w http.ResponseWriter, r *http.Request, req *saml.IdpAuthnRequest) *saml.Session {
+	return &saml.Session{
+		ID:             fmt.Sprintf("session-%d", time.Now().UnixNano()),
+		CreateTime:     time.Now(),
+		ExpireTime:     time.Now().Add(time.Hour),
+		Index:          "1",
+		NameID:         u.email,
+		NameIDFormat:   string(saml.EmailAddressNameIDFormat),
+		UserName:       u.email,
+		UserEmail:      u.email,
+		UserCommonName: u.name,
+		CustomAttributes: []saml.Attribute{
+			{Name: "email", Values: []saml.AttributeValue{{Type: "xs:string", Value: u.email}}},
+			{Name: "displayName", Values: []saml.AttributeValue{{Type: "xs:string", Value: u.name}}},
+		},
+	}
+}
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:9997", "Server listen address.")
+	spMeta := flag.String("sp-metadata", "http://localhost:3030/api/v2/identity/providers/saml/metadata", "URL of the service provider metadata.")
+	name := flag.String("name", "Jane Doe", "Display name of the test user.")
+	email := flag.String("email", "jane.doe@example.com", "Email address of the test user.")
+	flag.Parse()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "mocksaml"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		log.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	base, err := url.Parse("http://" + *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	idp := &saml.IdentityProvider{
+		Key:                     key,
+		Certificate:             cert,
+		MetadataURL:             *base.ResolveReference(&url.URL{Path: "/metadata"}),
+		SSOURL:                  *base.ResolveReference(&url.URL{Path: "/sso"}),
+		ServiceProviderProvider: spProvider{metadataURL: *spMeta},
+		SessionProvider:         userSession{name: *name, email: *email},
+	}
+
+	log.Printf("SAML IdP metadata: %s", idp.MetadataURL.String())
+	log.Fatal(http.ListenAndServe(*addr, idp.Handler()))
+}
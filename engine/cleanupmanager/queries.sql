@@ -63,6 +63,36 @@ WHERE id = ANY (
         FOR UPDATE
             SKIP LOCKED);
 
+-- name: CleanupMgrDeleteOldScheduleTierShifts :execrows
+-- CleanupMgrDeleteOldScheduleTierShifts will delete old schedule tier shifts from the schedule_tier_on_call_users table that are older than the given number of days before now.
+DELETE FROM schedule_tier_on_call_users
+WHERE id = ANY (
+        SELECT
+            id
+        FROM
+            schedule_tier_on_call_users
+        WHERE
+            end_time <(now() - '1 day'::interval * sqlc.arg(history_threshold_days))
+        LIMIT 100
+        FOR UPDATE
+            SKIP LOCKED);
+
+-- name: CleanupMgrTrimUIKRequests :execrows
+-- CleanupMgrTrimUIKRequests will delete recorded universal key requests beyond the most recent ones of each key.
+DELETE FROM uik_request_log
+WHERE id = ANY (
+        SELECT
+            r.id
+        FROM (
+            SELECT
+                id,
+                row_number() OVER (PARTITION BY key_id ORDER BY received_at DESC, id DESC) AS n
+            FROM
+                uik_request_log) r
+        WHERE
+            r.n > sqlc.arg(max_requests)::bigint
+        LIMIT 100);
+
 -- name: CleanupMgrDeleteOldStepShifts :execrows
 -- CleanupMgrDeleteOldStepShifts will delete old EP step shifts from the ep_step_on_call_users table that are older than the given number of days before now.
 DELETE FROM ep_step_on_call_users
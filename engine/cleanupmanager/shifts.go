@@ -32,6 +32,17 @@ func (db *DB) CleanupShifts(ctx context.Context, j *river.Job[ShiftArgs]) error
 		return err
 	}
 
+	err = db.whileWork(ctx, func(ctx context.Context, tx *sql.Tx) (done bool, err error) {
+		count, err := gadb.New(tx).CleanupMgrDeleteOldScheduleTierShifts(ctx, int64(cfg.Maintenance.ScheduleCleanupDays))
+		if err != nil {
+			return false, fmt.Errorf("delete old tier shifts: %w", err)
+		}
+		return count < 100, nil
+	})
+	if err != nil {
+		return err
+	}
+
 	err = db.whileWork(ctx, func(ctx context.Context, tx *sql.Tx) (done bool, err error) {
 		count, err := gadb.New(tx).CleanupMgrDeleteOldOverrides(ctx, int64(cfg.Maintenance.ScheduleCleanupDays))
 		if err != nil {
@@ -0,0 +1,31 @@
+package cleanupmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/riverqueue/river"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/integrationkey"
+)
+
+type UIKRequestsArgs struct{}
+
+func (UIKRequestsArgs) Kind() string { return "cleanup-manager-uik-requests" }
+
+// CleanupUIKRequests will remove recorded universal key requests beyond the most recent ones of each key.
+func (db *DB) CleanupUIKRequests(ctx context.Context, j *river.Job[UIKRequestsArgs]) error {
+	err := db.whileWork(ctx, func(ctx context.Context, tx *sql.Tx) (done bool, err error) {
+		count, err := gadb.New(tx).CleanupMgrTrimUIKRequests(ctx, integrationkey.MaxRecordedRequests)
+		if err != nil {
+			return false, fmt.Errorf("trim uik requests: %w", err)
+		}
+		return count < 100, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -32,7 +32,7 @@ func (db *DB) Name() string { return "Engine.EscalationManager" }
 // NewDB creates a new DB.
 func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
-		Version: 4,
+		Version: 5,
 		Type:    processinglock.TypeEscalation,
 	})
 	if err != nil {
@@ -51,13 +51,20 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 			with on_call as (
 				select
 					step.id step_id,
-					coalesce(act.user_id, part.user_id, sched.user_id) user_id
+					coalesce(act.user_id, part.user_id, sched.user_id, tier.user_id) user_id
 				from escalation_policy_steps step
 				join escalation_policy_actions act on act.escalation_policy_step_id = step.id
 				left join rotation_state rState on rState.rotation_id = act.rotation_id
 				left join rotation_participants part on part.id = rState.rotation_participant_id
-				left join schedule_on_call_users sched on sched.schedule_id = act.schedule_id and sched.end_time isnull
-				where coalesce(act.user_id, part.user_id, sched.user_id) notnull
+				left join schedule_on_call_users sched on
+					sched.schedule_id = act.schedule_id and
+					act.schedule_tier isnull and
+					sched.end_time isnull
+				left join schedule_tier_on_call_users tier on
+					tier.schedule_id = act.schedule_id and
+					tier.tier = act.schedule_tier and
+					tier.end_time isnull
+				where coalesce(act.user_id, part.user_id, sched.user_id, tier.user_id) notnull
 			), ended as (
 				select
 				ep_step_id step_id,
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/engine/message"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/notification"
+)
+
+const (
+	handoffMaxServices = 5
+	handoffMaxAlerts   = 10
+)
+
+// buildHandoffReport summarizes the alerts of the shift recorded by a handoff report.
+func (p *Engine) buildHandoffReport(ctx context.Context, msg *message.Message) (*notification.ScheduleHandoffReport, error) {
+	id, err := uuid.Parse(msg.HandoffReportID)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse handoff report id")
+	}
+	q := gadb.New(p.b.db)
+	report, err := q.EngineGetHandoffReport(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup handoff report")
+	}
+	cfg := p.cfg.ConfigSource.Config()
+
+	rows, err := q.EngineHandoffUserNames(ctx, append(append([]uuid.UUID{}, report.OutgoingUserIds...), report.IncomingUserIds...))
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup user names")
+	}
+	names := make(map[uuid.UUID]string, len(rows))
+	for _, r := range rows {
+		names[r.ID] = r.Name
+	}
+	users := func(ids []uuid.UUID) []notification.User {
+		var result []notification.User
+		for _, id := range ids {
+			name, ok := names[id]
+			if !ok {
+				// user was deleted
+				continue
+			}
+			result = append(result, notification.User{
+				ID:   id.String(),
+				Name: name,
+				URL:  cfg.CallbackURL("/users/" + id.String()),
+			})
+		}
+		return result
+	}
+
+	counts, err := q.EngineHandoffAlertCounts(ctx, gadb.EngineHandoffAlertCountsParams{
+		ScheduleID: report.ScheduleID,
+		ShiftStart: report.ShiftStart,
+		ShiftEnd:   report.ShiftEnd,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "count alerts")
+	}
+	svcRows, err := q.EngineHandoffTopServices(ctx, gadb.EngineHandoffTopServicesParams{
+		ScheduleID:  report.ScheduleID,
+		ShiftStart:  report.ShiftStart,
+		ShiftEnd:    report.ShiftEnd,
+		MaxServices: handoffMaxServices,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup top services")
+	}
+	alertRows, err := q.EngineHandoffUnresolvedAlerts(ctx, gadb.EngineHandoffUnresolvedAlertsParams{
+		ScheduleID: report.ScheduleID,
+		MaxAlerts:  handoffMaxAlerts,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup unresolved alerts")
+	}
+
+	res := &notification.ScheduleHandoffReport{
+		Base:          msg.Base(),
+		ReportID:      msg.HandoffReportID,
+		ScheduleID:    report.ScheduleID.String(),
+		ScheduleName:  report.ScheduleName,
+		ScheduleURL:   cfg.CallbackURL("/schedules/" + report.ScheduleID.String()),
+		ShiftStart:    report.ShiftStart,
+		ShiftEnd:      report.ShiftEnd,
+		OutgoingUsers: users(report.OutgoingUserIds),
+		IncomingUsers: users(report.IncomingUserIds),
+		OpenCount:     int(counts.OpenCount),
+		AckedCount:    int(counts.AckedCount),
+		ClosedCount:   int(counts.ClosedCount),
+	}
+	for _, r := range svcRows {
+		res.TopServices = append(res.TopServices, notification.HandoffService{
+			ID:         r.ID.String(),
+			Name:       r.Name,
+			AlertCount: int(r.AlertCount),
+		})
+	}
+	for _, r := range alertRows {
+		res.UnresolvedCount = int(r.Total)
+		res.Unresolved = append(res.Unresolved, notification.HandoffAlert{
+			ID:           int(r.ID),
+			Summary:      r.Summary,
+			ServiceName:  r.ServiceName,
+			URL:          cfg.CallbackURL("/alerts/" + strconv.FormatInt(r.ID, 10)),
+			Acknowledged: r.Status == gadb.EnumAlertStatusActive,
+		})
+	}
+
+	return res, nil
+}
@@ -341,6 +341,12 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 		if row.ScheduleID.Valid {
 			msg.ScheduleID = row.ScheduleID.UUID.String()
 		}
+		if row.ShiftSwapRequestID.Valid {
+			msg.SwapID = row.ShiftSwapRequestID.UUID.String()
+		}
+		if row.ScheduleHandoffReportID.Valid {
+			msg.HandoffReportID = row.ScheduleHandoffReportID.UUID.String()
+		}
 		msg.AlertStatus = notification.AlertStateUnknown
 		if row.AlertStatus.Valid {
 			switch row.AlertStatus.EnumAlertStatus {
@@ -18,11 +18,13 @@ type Message struct {
 	AlertLogID int
 	VerifyID   string
 
-	UserID     string
-	ServiceID  string
-	ScheduleID string
-	CreatedAt  time.Time
-	SentAt     time.Time
+	UserID          string
+	ServiceID       string
+	ScheduleID      string
+	SwapID          string
+	HandoffReportID string
+	CreatedAt       time.Time
+	SentAt          time.Time
 
 	StatusAlertIDs []int64
 	AlertStatus    notification.AlertState
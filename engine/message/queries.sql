@@ -14,6 +14,8 @@ SELECT
     msg.sent_at,
     msg.status_alert_ids,
     msg.schedule_id,
+    msg.shift_swap_request_id,
+    msg.schedule_handoff_report_id,
     alerts.status AS alert_status
 FROM
     outgoing_messages msg
@@ -15,6 +15,9 @@ var typePriority = map[gadb.EnumOutgoingMessagesType]int{
 	notification.MessageTypeTest:         2,
 
 	notification.MessageTypeScheduleOnCallUsers: 3,
+	notification.MessageTypeShiftSwap:           3,
+	notification.MessageTypeCoverageGap:         3,
+	notification.MessageTypeHandoffReport:       3,
 
 	// First alert will jump the list with priority 0, so this only
 	// represents additional alerts to the service after the first.
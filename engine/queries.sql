@@ -24,3 +24,137 @@ SELECT
         WHERE
             nc.dest = $1);
 
+
+-- name: EngineGetShiftSwapRequest :one
+-- Get a shift swap request along with the schedule and requester names for rendering a notification.
+SELECT
+    req.id,
+    req.schedule_id,
+    req.requester_shift_start,
+    req.requester_shift_end,
+    req.counterpart_shift_start,
+    req.counterpart_shift_end,
+    req.message,
+    req.status,
+    sched.name AS schedule_name,
+    u.name AS requester_name
+FROM
+    shift_swap_requests req
+    JOIN schedules sched ON sched.id = req.schedule_id
+    JOIN users u ON u.id = req.requester_id
+WHERE
+    req.id = $1;
+
+-- name: EngineGetScheduleCoverageGaps :many
+-- Get the current and upcoming coverage gaps for a schedule for rendering a notification.
+SELECT
+    start_time,
+    end_time
+FROM
+    schedule_coverage_gaps
+WHERE
+    schedule_id = $1
+    AND end_time > now()
+ORDER BY
+    start_time;
+
+-- name: EngineGetHandoffReport :one
+-- Get a schedule handoff report along with the schedule name for rendering a notification.
+SELECT
+    r.id,
+    r.schedule_id,
+    r.shift_start,
+    r.shift_end,
+    r.outgoing_user_ids,
+    r.incoming_user_ids,
+    sched.name AS schedule_name
+FROM
+    schedule_handoff_reports r
+    JOIN schedules sched ON sched.id = r.schedule_id
+WHERE
+    r.id = $1;
+
+-- name: EngineHandoffUserNames :many
+-- Get the names of the outgoing and incoming users of a handoff report.
+SELECT
+    id,
+    name
+FROM
+    users
+WHERE
+    id = ANY (@user_ids::uuid[]);
+
+-- name: EngineHandoffAlertCounts :one
+-- Count alerts, by current status, created during a shift on services escalating to the schedule.
+SELECT
+    count(*) FILTER (WHERE a.status = 'triggered') AS open_count,
+    count(*) FILTER (WHERE a.status = 'active') AS acked_count,
+    count(*) FILTER (WHERE a.status = 'closed') AS closed_count
+FROM
+    alerts a
+WHERE
+    a.service_id IN (
+        SELECT
+            svc.id
+        FROM
+            services svc
+            JOIN escalation_policy_steps step ON step.escalation_policy_id = svc.escalation_policy_id
+            JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+        WHERE
+            act.schedule_id = @schedule_id::uuid)
+    AND a.created_at >= @shift_start
+    AND a.created_at < @shift_end;
+
+-- name: EngineHandoffTopServices :many
+-- Get the services, escalating to the schedule, with the most alerts created during a shift.
+SELECT
+    svc.id,
+    svc.name,
+    count(*) AS alert_count
+FROM
+    alerts a
+    JOIN services svc ON svc.id = a.service_id
+WHERE
+    svc.id IN (
+        SELECT
+            s.id
+        FROM
+            services s
+            JOIN escalation_policy_steps step ON step.escalation_policy_id = s.escalation_policy_id
+            JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+        WHERE
+            act.schedule_id = @schedule_id::uuid)
+    AND a.created_at >= @shift_start
+    AND a.created_at < @shift_end
+GROUP BY
+    svc.id
+ORDER BY
+    alert_count DESC,
+    svc.name
+LIMIT @max_services::int;
+
+-- name: EngineHandoffUnresolvedAlerts :many
+-- Get the oldest unresolved alerts on services escalating to the schedule, along with the total number unresolved.
+SELECT
+    a.id,
+    a.summary,
+    a.status,
+    svc.name AS service_name,
+    count(*) OVER () AS total
+FROM
+    alerts a
+    JOIN services svc ON svc.id = a.service_id
+WHERE
+    svc.id IN (
+        SELECT
+            s.id
+        FROM
+            services s
+            JOIN escalation_policy_steps step ON step.escalation_policy_id = s.escalation_policy_id
+            JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+        WHERE
+            act.schedule_id = @schedule_id::uuid)
+    AND a.status <> 'closed'
+ORDER BY
+    a.id
+LIMIT @max_alerts::int;
@@ -12,6 +12,9 @@ import (
 type advance struct {
 	id          string
 	newPosition int
+
+	// handoff is true if the shift changed, as opposed to only updating the state.
+	handoff bool
 }
 
 type rotState struct {
@@ -81,8 +84,14 @@ func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state
 		state.ShiftStart = end
 	}
 
+	if pos, ok := rot.ParticipantPosition(t); ok {
+		// each shift of a participant rotation belongs to a specific participant
+		state.Position = pos
+	}
+
 	return &advance{
 		id:          rot.ID,
 		newPosition: state.Position,
+		handoff:     true,
 	}, nil
 }
@@ -14,7 +14,25 @@ SELECT
         WHERE
             p.rotation_id = rot.id
         ORDER BY
-            position)::uuid[] AS participants
+            position)::uuid[] AS participants,
+    ARRAY (
+        SELECT
+            p.user_id
+        FROM
+            rotation_participants p
+        WHERE
+            p.rotation_id = rot.id
+        ORDER BY
+            position)::uuid[] AS participant_user_ids,
+    ARRAY (
+        SELECT
+            coalesce(p.shift_length, rot.shift_length)
+        FROM
+            rotation_participants p
+        WHERE
+            p.rotation_id = rot.id
+        ORDER BY
+            position)::int[] AS participant_shift_lengths
     FROM
         rotations rot
     LEFT JOIN rotation_state state ON rot.id = state.rotation_id
@@ -52,6 +70,73 @@ SET
 WHERE
     rotation_id = @rotation_id;
 
+-- name: RotMgrUnavailable :many
+-- Get the unavailability windows of a rotation's participants overlapping the shift.
+SELECT
+    u.id,
+    u.user_id,
+    u.start_time,
+    u.end_time
+FROM
+    user_unavailability u
+WHERE
+    u.user_id IN (
+        SELECT
+            p.user_id
+        FROM
+            rotation_participants p
+        WHERE
+            p.rotation_id = @rotation_id)
+    AND u.start_time < @shift_end
+    AND u.end_time > @shift_start
+ORDER BY
+    u.start_time,
+    u.id;
+
+-- name: RotMgrRecordSkip :exec
+-- Record an override on each schedule using the rotation (primary tier only), replacing a skipped participant with
+-- the one that took their shift. Schedules with a conflicting override, or at their override limit, are left alone.
+INSERT INTO user_overrides(id, tgt_schedule_id, remove_user_id, add_user_id, start_time, end_time, unavailability_id)
+SELECT
+    gen_random_uuid(),
+    sched.schedule_id,
+    @remove_user_id::uuid,
+    @add_user_id::uuid,
+    @start_time::timestamptz,
+    @end_time::timestamptz,
+    @unavailability_id::uuid
+FROM (
+    SELECT DISTINCT
+        r.schedule_id
+    FROM
+        schedule_rules r
+    WHERE
+        r.tgt_rotation_id = @rotation_id::uuid
+        AND r.tier ISNULL) sched
+WHERE
+    NOT EXISTS (
+        SELECT
+        FROM
+            user_overrides o
+        WHERE
+            o.tgt_schedule_id = sched.schedule_id
+            AND (o.add_user_id IN (@remove_user_id::uuid, @add_user_id::uuid)
+                OR o.remove_user_id IN (@remove_user_id::uuid, @add_user_id::uuid))
+            AND (o.start_time, o.end_time) OVERLAPS (@start_time::timestamptz, @end_time::timestamptz))
+    AND (
+        SELECT
+            count(*)
+        FROM
+            user_overrides o
+        WHERE
+            o.tgt_schedule_id = sched.schedule_id
+            AND o.end_time > now()) < coalesce((
+            SELECT
+                nullif(max, -1)
+            FROM config_limits
+            WHERE
+                id = 'user_overrides_per_schedule'), 2147483647);
+
 -- name: RotMgrFindWork :many
 WITH items AS (
     SELECT
@@ -0,0 +1,64 @@
+package rotationmanager
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/user/unavailability"
+)
+
+// skip records a participant that was passed over because they are unavailable for part of the new shift.
+type skip struct {
+	UnavailabilityID uuid.UUID
+	RemoveUserID     uuid.UUID
+	AddUserID        uuid.UUID
+	Start, End       time.Time
+}
+
+// calcSkip will return the position of the first participant, starting at pos, that is available for the entire
+// shift, along with the skips to record for any participants passed over. If no participant is available, pos is
+// returned with no skips.
+func calcSkip(pos int, userIDs []uuid.UUID, unavail []gadb.RotMgrUnavailableRow, shiftStart, shiftEnd time.Time) (int, []skip) {
+	byUser := make(map[uuid.UUID][]gadb.RotMgrUnavailableRow)
+	for _, u := range unavail {
+		if !u.StartTime.Before(shiftEnd) || !u.EndTime.After(shiftStart) {
+			continue
+		}
+		byUser[u.UserID] = append(byUser[u.UserID], u)
+	}
+
+	n := len(userIDs)
+	newPos := unavailability.NextAvailable(pos, n, func(p int) bool { return len(byUser[userIDs[p]]) > 0 })
+	if newPos == pos {
+		return pos, nil
+	}
+
+	var skips []skip
+	seen := make(map[uuid.UUID]bool)
+	for p := pos; p != newPos; p = (p + 1) % n {
+		for _, u := range byUser[userIDs[p]] {
+			if seen[u.ID] {
+				continue
+			}
+			seen[u.ID] = true
+
+			s := skip{
+				UnavailabilityID: u.ID,
+				RemoveUserID:     u.UserID,
+				AddUserID:        userIDs[newPos],
+				Start:            u.StartTime,
+				End:              u.EndTime,
+			}
+			if s.Start.Before(shiftStart) {
+				s.Start = shiftStart
+			}
+			if s.End.After(shiftEnd) {
+				s.End = shiftEnd
+			}
+			skips = append(skips, s)
+		}
+	}
+
+	return newPos, skips
+}
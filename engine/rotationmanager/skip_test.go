@@ -0,0 +1,67 @@
+package rotationmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/gadb"
+)
+
+func TestCalcSkip(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	users := []uuid.UUID{a, b, c}
+	start := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	unavail := func(userID uuid.UUID, s, e time.Time) gadb.RotMgrUnavailableRow {
+		return gadb.RotMgrUnavailableRow{ID: uuid.New(), UserID: userID, StartTime: s, EndTime: e}
+	}
+
+	t.Run("available", func(t *testing.T) {
+		pos, skips := calcSkip(1, users, nil, start, end)
+		assert.Equal(t, 1, pos)
+		assert.Empty(t, skips)
+	})
+
+	t.Run("skip one", func(t *testing.T) {
+		u := unavail(b, start.Add(-48*time.Hour), start.Add(2*time.Hour))
+		pos, skips := calcSkip(1, users, []gadb.RotMgrUnavailableRow{u}, start, end)
+		assert.Equal(t, 2, pos)
+		assert.Equal(t, []skip{{
+			UnavailabilityID: u.ID,
+			RemoveUserID:     b,
+			AddUserID:        c,
+			Start:            start,
+			End:              start.Add(2 * time.Hour),
+		}}, skips)
+	})
+
+	t.Run("wrap around", func(t *testing.T) {
+		pos, skips := calcSkip(1, users, []gadb.RotMgrUnavailableRow{
+			unavail(b, start, end),
+			unavail(c, start.Add(time.Hour), end.Add(time.Hour)),
+		}, start, end)
+		assert.Equal(t, 0, pos)
+		assert.Len(t, skips, 2)
+		assert.Equal(t, a, skips[0].AddUserID)
+		assert.Equal(t, end, skips[1].End)
+	})
+
+	t.Run("outside shift", func(t *testing.T) {
+		pos, skips := calcSkip(1, users, []gadb.RotMgrUnavailableRow{unavail(b, end, end.Add(time.Hour))}, start, end)
+		assert.Equal(t, 1, pos)
+		assert.Empty(t, skips)
+	})
+
+	t.Run("all unavailable", func(t *testing.T) {
+		pos, skips := calcSkip(1, users, []gadb.RotMgrUnavailableRow{
+			unavail(a, start, end),
+			unavail(b, start, end),
+			unavail(c, start, end),
+		}, start, end)
+		assert.Equal(t, 1, pos)
+		assert.Empty(t, skips)
+	})
+}
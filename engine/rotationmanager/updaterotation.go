@@ -57,6 +57,12 @@ func (db *DB) updateRotation(ctx context.Context, j *river.Job[UpdateArgs]) erro
 			Start:       row.Rotation.StartTime.In(loc),
 			ShiftLength: int(row.Rotation.ShiftLength),
 		}
+		for _, h := range row.Rotation.ShiftPattern {
+			r.ShiftPattern = append(r.ShiftPattern, int(h))
+		}
+		for _, h := range row.ParticipantShiftLengths {
+			r.ParticipantShiftLengths = append(r.ParticipantShiftLengths, int(h))
+		}
 
 		// schedule next run
 		_, err = db.riverDBSQL.InsertTx(ctx, tx, UpdateArgs{RotationID: j.Args.RotationID}, &river.InsertOpts{
@@ -78,6 +84,21 @@ func (db *DB) updateRotation(ctx context.Context, j *river.Job[UpdateArgs]) erro
 			if err != nil {
 				return fmt.Errorf("start rotation: %w", err)
 			}
+
+			pos, ok := r.ParticipantPosition(row.Now)
+			if !ok || pos == 0 {
+				return nil
+			}
+
+			// participant rotations start with whoever's shift is current
+			err = g.RotMgrUpdate(ctx, gadb.RotMgrUpdateParams{
+				RotationID:            j.Args.RotationID,
+				Position:              int32(pos),
+				RotationParticipantID: row.Participants[pos],
+			})
+			if err != nil {
+				return fmt.Errorf("update rotation state (start): %w", err)
+			}
 			return nil
 		}
 
@@ -95,6 +116,35 @@ func (db *DB) updateRotation(ctx context.Context, j *river.Job[UpdateArgs]) erro
 			return nil
 		}
 
+		if adv.handoff {
+			// skip any participants that are unavailable for part of the new shift
+			shiftEnd := r.EndTime(row.Now)
+			unavail, err := g.RotMgrUnavailable(ctx, gadb.RotMgrUnavailableParams{
+				RotationID: j.Args.RotationID,
+				ShiftStart: row.Now,
+				ShiftEnd:   shiftEnd,
+			})
+			if err != nil {
+				return fmt.Errorf("load unavailability: %w", err)
+			}
+
+			var skips []skip
+			adv.newPosition, skips = calcSkip(adv.newPosition, row.ParticipantUserIds, unavail, row.Now, shiftEnd)
+			for _, sk := range skips {
+				err = g.RotMgrRecordSkip(ctx, gadb.RotMgrRecordSkipParams{
+					RotationID:       j.Args.RotationID,
+					RemoveUserID:     sk.RemoveUserID,
+					AddUserID:        sk.AddUserID,
+					StartTime:        sk.Start,
+					EndTime:          sk.End,
+					UnavailabilityID: sk.UnavailabilityID,
+				})
+				if err != nil {
+					return fmt.Errorf("record skip: %w", err)
+				}
+			}
+		}
+
 		err = g.RotMgrUpdate(ctx, gadb.RotMgrUpdateParams{
 			RotationID:            j.Args.RotationID,
 			Position:              int32(adv.newPosition),
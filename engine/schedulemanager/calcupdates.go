@@ -3,12 +3,14 @@ package schedulemanager
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/google/uuid"
 	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/holiday"
 	"github.com/target/goalert/util/jsonutil"
 )
 
@@ -20,6 +22,17 @@ type updateInfo struct {
 	CurrentOnCall   mapset.Set[uuid.UUID]
 	Rules           []gadb.SchedMgrRulesRow
 	ActiveOverrides []gadb.SchedMgrOverridesRow
+
+	// Holidays are the current holidays, by calendar ID.
+	Holidays map[uuid.UUID][]holiday.Holiday
+
+	Tiers []gadb.SchedMgrTiersRow
+
+	// TierOnCall are the users currently on call, by tier name.
+	TierOnCall map[string]mapset.Set[uuid.UUID]
+
+	// RotationParticipants are the participants of primary tier rotations, in order, by rotation ID.
+	RotationParticipants map[uuid.UUID][]gadb.SchedMgrTierRotationParticipantsRow
 }
 
 type updateResult struct {
@@ -28,6 +41,13 @@ type updateResult struct {
 	UsersToStop          mapset.Set[uuid.UUID]
 	NewRawScheduleData   json.RawMessage       // no update necessary if nil
 	NotificationChannels mapset.Set[uuid.UUID] // channels to notify, empty if no notifications
+
+	HandoffReport   bool                  // true if a handoff report should be recorded
+	HandoffUsers    bool                  // true if the report should be sent to the outgoing and incoming users
+	HandoffChannels mapset.Set[uuid.UUID] // channels to send the handoff report to
+
+	TierUsersToStart map[string]mapset.Set[uuid.UUID] // by tier name, only set for tiers with changes
+	TierUsersToStop  map[string]mapset.Set[uuid.UUID] // by tier name, only set for tiers with changes
 }
 
 func (info updateInfo) calcLatestOnCall(now time.Time) mapset.Set[uuid.UUID] {
@@ -39,7 +59,10 @@ func (info updateInfo) calcLatestOnCall(now time.Time) mapset.Set[uuid.UUID] {
 	now = now.In(info.TimeZone)
 	newOnCall := mapset.NewThreadUnsafeSet[uuid.UUID]()
 	for _, r := range info.Rules {
-		if ruleRowIsActive(r, now) {
+		if r.Tier.Valid {
+			continue
+		}
+		if ruleRowIsActive(r, now, info.Holidays[r.HolidayCalendarID.UUID]) {
 			newOnCall.Add(r.ResolvedUserID)
 		}
 	}
@@ -62,6 +85,45 @@ func (info updateInfo) calcLatestOnCall(now time.Time) mapset.Set[uuid.UUID] {
 	return newOnCall
 }
 
+// calcTierOnCall returns the users that should be on call for a tier.
+//
+// Overrides and temporary schedules only apply to the primary tier.
+func (info updateInfo) calcTierOnCall(now time.Time, tier gadb.SchedMgrTiersRow) mapset.Set[uuid.UUID] {
+	now = now.In(info.TimeZone)
+	onCall := mapset.NewThreadUnsafeSet[uuid.UUID]()
+	for _, r := range info.Rules {
+		if r.Tier.String != tier.Name {
+			continue
+		}
+		if ruleRowIsActive(r, now, info.Holidays[r.HolidayCalendarID.UUID]) {
+			onCall.Add(r.ResolvedUserID)
+		}
+	}
+	if tier.RotationOffset == 0 {
+		return onCall
+	}
+
+	// follow the primary tier's rotations, offset by the configured number of participants
+	for _, r := range info.Rules {
+		if r.Tier.Valid || !r.TgtRotationID.Valid || !r.RotationPosition.Valid {
+			continue
+		}
+		if !ruleRowIsActive(r, now, info.Holidays[r.HolidayCalendarID.UUID]) {
+			continue
+		}
+		parts := info.RotationParticipants[r.TgtRotationID.UUID]
+		idx := slices.IndexFunc(parts, func(p gadb.SchedMgrTierRotationParticipantsRow) bool {
+			return p.Position == r.RotationPosition.Int32
+		})
+		if idx == -1 {
+			continue
+		}
+		onCall.Add(parts[(idx+int(tier.RotationOffset))%len(parts)].UserID)
+	}
+
+	return onCall
+}
+
 func (info updateInfo) calcUpdates(now time.Time) (*updateResult, error) {
 	if info.CurrentOnCall == nil {
 		info.CurrentOnCall = mapset.NewThreadUnsafeSet[uuid.UUID]()
@@ -73,9 +135,26 @@ func (info updateInfo) calcUpdates(now time.Time) (*updateResult, error) {
 		UsersToStart:         mapset.NewThreadUnsafeSet[uuid.UUID](),
 		UsersToStop:          mapset.NewThreadUnsafeSet[uuid.UUID](),
 		NotificationChannels: mapset.NewThreadUnsafeSet[uuid.UUID](),
+		HandoffChannels:      mapset.NewThreadUnsafeSet[uuid.UUID](),
+		TierUsersToStart:     make(map[string]mapset.Set[uuid.UUID]),
+		TierUsersToStop:      make(map[string]mapset.Set[uuid.UUID]),
 	}
 	now = now.In(info.TimeZone)
 
+	for _, t := range info.Tiers {
+		cur := info.TierOnCall[t.Name]
+		if cur == nil {
+			cur = mapset.NewThreadUnsafeSet[uuid.UUID]()
+		}
+		next := info.calcTierOnCall(now, t)
+		if toStart := next.Difference(cur); toStart.Cardinality() > 0 {
+			result.TierUsersToStart[t.Name] = toStart
+		}
+		if toStop := cur.Difference(next); toStop.Cardinality() > 0 {
+			result.TierUsersToStop[t.Name] = toStop
+		}
+	}
+
 	newOnCall := info.calcLatestOnCall(now)
 	onCallChanged := !newOnCall.Equal(info.CurrentOnCall)
 	if onCallChanged {
@@ -83,6 +162,8 @@ func (info updateInfo) calcUpdates(now time.Time) (*updateResult, error) {
 		result.UsersToStart = newOnCall.Difference(info.CurrentOnCall) // not currently on-call, but should be
 	}
 
+	result.HandoffUsers = onCallChanged && info.ScheduleData.V1.HandoffReport.NotifyUsers
+
 	var dataNeedsUpdate bool
 	newRules := make([]schedule.OnCallNotificationRule, len(info.ScheduleData.V1.OnCallNotificationRules))
 	// we copy the rules to avoid modifying the original slice
@@ -91,6 +172,9 @@ func (info updateInfo) calcUpdates(now time.Time) (*updateResult, error) {
 		if r.Time == nil { // if time is not set, then it is a "when schedule changes" rule
 			if onCallChanged {
 				result.NotificationChannels.Add(r.ChannelID)
+				if r.HandoffReport {
+					result.HandoffChannels.Add(r.ChannelID)
+				}
 			}
 			continue
 		}
@@ -106,6 +190,8 @@ func (info updateInfo) calcUpdates(now time.Time) (*updateResult, error) {
 		newRules[i].NextNotification = newTime
 	}
 
+	result.HandoffReport = result.HandoffUsers || result.HandoffChannels.Cardinality() > 0
+
 	if dataNeedsUpdate {
 		info.ScheduleData.V1.OnCallNotificationRules = newRules
 		jsonData, err := jsonutil.Apply(info.RawScheduleData, info.ScheduleData)
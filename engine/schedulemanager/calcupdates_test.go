@@ -1,6 +1,7 @@
 package schedulemanager
 
 import (
+	"database/sql"
 	"encoding/json"
 	"testing"
 	"time"
@@ -87,3 +88,99 @@ func TestUpdateInfo_calcUpdates_NotifyAtTime(t *testing.T) {
 	require.NoError(t, err)
 	require.JSONEq(t, string(expectedData), string(result.NewRawScheduleData))
 }
+
+func TestUpdateInfo_calcUpdates_HandoffReport(t *testing.T) {
+	channelID1 := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	channelID2 := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+	var sData schedule.Data
+	sData.V1.OnCallNotificationRules = []schedule.OnCallNotificationRule{
+		{ChannelID: channelID1, HandoffReport: true}, {ChannelID: channelID2},
+	}
+	data, err := json.Marshal(sData)
+	require.NoError(t, err)
+	info := updateInfo{
+		ScheduleID:      uuid.New(),
+		TimeZone:        time.UTC,
+		RawScheduleData: data,
+		ScheduleData:    sData,
+		CurrentOnCall:   mapset.NewThreadUnsafeSet(uuid.New()), // no rules, so no longer on-call
+		Rules:           []gadb.SchedMgrRulesRow{},
+		ActiveOverrides: []gadb.SchedMgrOverridesRow{},
+	}
+
+	result, err := info.calcUpdates(time.Now())
+	require.NoError(t, err)
+	require.True(t, result.HandoffReport)
+	require.False(t, result.HandoffUsers)
+	require.Equal(t, []uuid.UUID{channelID1}, result.HandoffChannels.ToSlice())
+
+	info.ScheduleData.V1.OnCallNotificationRules = nil
+	info.ScheduleData.V1.HandoffReport.NotifyUsers = true
+	result, err = info.calcUpdates(time.Now())
+	require.NoError(t, err)
+	require.True(t, result.HandoffReport)
+	require.True(t, result.HandoffUsers)
+	require.Empty(t, result.HandoffChannels.ToSlice())
+
+	info.CurrentOnCall = mapset.NewThreadUnsafeSet[uuid.UUID]() // no change
+	result, err = info.calcUpdates(time.Now())
+	require.NoError(t, err)
+	require.False(t, result.HandoffReport)
+}
+
+func TestUpdateInfo_calcUpdates_Tiers(t *testing.T) {
+	rotID := uuid.New()
+	userA, userB, userC, userD := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	alwaysActive := func(row gadb.SchedMgrRulesRow) gadb.SchedMgrRulesRow {
+		row.Sunday, row.Monday, row.Tuesday, row.Wednesday, row.Thursday, row.Friday, row.Saturday = true, true, true, true, true, true, true
+		return row
+	}
+
+	info := updateInfo{
+		ScheduleID:    uuid.New(),
+		TimeZone:      time.UTC,
+		CurrentOnCall: mapset.NewThreadUnsafeSet[uuid.UUID](),
+		Rules: []gadb.SchedMgrRulesRow{
+			alwaysActive(gadb.SchedMgrRulesRow{
+				TgtRotationID:    uuid.NullUUID{UUID: rotID, Valid: true},
+				ResolvedUserID:   userB,
+				RotationPosition: sql.NullInt32{Int32: 1, Valid: true},
+			}),
+			alwaysActive(gadb.SchedMgrRulesRow{
+				TgtUserID:      uuid.NullUUID{UUID: userD, Valid: true},
+				ResolvedUserID: userD,
+				Tier:           sql.NullString{String: "backup", Valid: true},
+			}),
+		},
+		Tiers: []gadb.SchedMgrTiersRow{
+			{Name: "secondary", RotationOffset: 1},
+			{Name: "backup"},
+		},
+		TierOnCall: map[string]mapset.Set[uuid.UUID]{
+			"secondary": mapset.NewThreadUnsafeSet(userA),
+		},
+		RotationParticipants: map[uuid.UUID][]gadb.SchedMgrTierRotationParticipantsRow{
+			rotID: {
+				{RotationID: rotID, Position: 0, UserID: userA},
+				{RotationID: rotID, Position: 1, UserID: userB},
+				{RotationID: rotID, Position: 2, UserID: userC},
+			},
+		},
+	}
+
+	result, err := info.calcUpdates(time.Now())
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{userB}, result.UsersToStart.ToSlice(), "tier rules should not affect the primary tier")
+	require.ElementsMatch(t, []uuid.UUID{userC}, result.TierUsersToStart["secondary"].ToSlice(), "secondary should follow the rotation offset by one")
+	require.ElementsMatch(t, []uuid.UUID{userA}, result.TierUsersToStop["secondary"].ToSlice())
+	require.ElementsMatch(t, []uuid.UUID{userD}, result.TierUsersToStart["backup"].ToSlice())
+	require.NotContains(t, result.TierUsersToStop, "backup")
+
+	// wraps around to the first participant
+	info.Rules[0].RotationPosition.Int32 = 2
+	info.Rules[0].ResolvedUserID = userC
+	result, err = info.calcUpdates(time.Now())
+	require.NoError(t, err)
+	require.NotContains(t, result.TierUsersToStart, "secondary", "already on call")
+	require.NotContains(t, result.TierUsersToStop, "secondary")
+}
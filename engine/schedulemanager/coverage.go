@@ -0,0 +1,146 @@
+package schedulemanager
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/permission"
+)
+
+type CoverageArgs struct {
+	ScheduleID uuid.UUID
+}
+
+func (CoverageArgs) Kind() string { return "schedule-manager-coverage" }
+
+type CoverageLFWArgs struct{}
+
+func (CoverageLFWArgs) Kind() string { return "schedule-manager-coverage-lfw" }
+
+// lookForCoverageWork will queue a coverage check for every schedule.
+func (db *DB) lookForCoverageWork(ctx context.Context, j *river.Job[CoverageLFWArgs]) error {
+	cfg := config.FromContext(ctx)
+	if cfg.Schedules.CoverageGapDays <= 0 {
+		return nil
+	}
+
+	var ids []uuid.UUID
+	err := db.lock.WithTxShared(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		ids, err = gadb.New(tx).SchedMgrAllIDs(ctx)
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var params []river.InsertManyParams
+	for _, id := range ids {
+		params = append(params, river.InsertManyParams{
+			Args: CoverageArgs{ScheduleID: id},
+			InsertOpts: &river.InsertOpts{
+				Queue:      QueueName,
+				Priority:   PriorityCoverage,
+				UniqueOpts: river.UniqueOpts{ByArgs: true},
+			},
+		})
+	}
+
+	if len(params) == 0 {
+		return nil
+	}
+
+	_, err = river.ClientFromContext[pgx.Tx](ctx).InsertMany(ctx, params)
+	if err != nil {
+		return fmt.Errorf("insert many: %w", err)
+	}
+
+	return nil
+}
+
+// updateCoverage projects a schedule forward and records any periods with
+// nobody on call. If a gap is found that was not previously known, a message
+// is sent to each of the schedule's on-call notification channels.
+func (db *DB) updateCoverage(ctx context.Context, j *river.Job[CoverageArgs]) error {
+	cfg := config.FromContext(ctx)
+	if cfg.Schedules.CoverageGapDays <= 0 {
+		return nil
+	}
+	ctx = permission.SystemContext(ctx, "ScheduleCoverage")
+
+	start := time.Now().Truncate(time.Minute)
+	end := start.AddDate(0, 0, cfg.Schedules.CoverageGapDays)
+
+	// Shift calculation holds its own transaction, so it is done before taking the lock.
+	shifts, err := db.oc.HistoryBySchedule(ctx, j.Args.ScheduleID.String(), start, end)
+	if err != nil {
+		return fmt.Errorf("calculate shifts: %w", err)
+	}
+	gaps := oncall.Gaps(shifts, start, end)
+
+	return db.lock.WithTxShared(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		q := gadb.New(tx)
+
+		rows, err := q.SchedMgrCoverageGaps(ctx, j.Args.ScheduleID)
+		if err != nil {
+			return fmt.Errorf("get previous gaps: %w", err)
+		}
+		prev := make([]oncall.Gap, len(rows))
+		for i, r := range rows {
+			prev[i] = oncall.Gap{Start: r.StartTime, End: r.EndTime}
+		}
+
+		err = q.SchedMgrClearCoverageGaps(ctx, j.Args.ScheduleID)
+		if err != nil {
+			return fmt.Errorf("clear gaps: %w", err)
+		}
+
+		var hasNew bool
+		for _, g := range gaps {
+			err = q.SchedMgrInsertCoverageGap(ctx, gadb.SchedMgrInsertCoverageGapParams{
+				ScheduleID: j.Args.ScheduleID,
+				StartTime:  g.Start,
+				EndTime:    g.End,
+			})
+			if err != nil {
+				return fmt.Errorf("insert gap: %w", err)
+			}
+
+			// A gap that overlaps one already known is the same gap, shortened or
+			// extended, and has already been reported.
+			hasNew = hasNew || !g.Overlaps(prev)
+		}
+		if !hasNew {
+			return nil
+		}
+
+		channels, err := q.SchedMgrCoverageGapChannels(ctx, j.Args.ScheduleID)
+		if err != nil {
+			return fmt.Errorf("get notification channels: %w", err)
+		}
+		for _, id := range channels {
+			err = q.SchedMgrInsertCoverageGapMessage(ctx, gadb.SchedMgrInsertCoverageGapMessageParams{
+				ID:         uuid.New(),
+				ChannelID:  uuid.NullUUID{UUID: id, Valid: true},
+				ScheduleID: uuid.NullUUID{UUID: j.Args.ScheduleID, Valid: true},
+			})
+			if err != nil {
+				return fmt.Errorf("insert message: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
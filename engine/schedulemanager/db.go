@@ -6,12 +6,15 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/oncall"
 )
 
 // DB will manage schedules and schedule rules in Postgres.
 type DB struct {
 	lock *processinglock.Lock
 
+	oc *oncall.Store
+
 	migrateSchedIDs []uuid.UUID
 	migrateMap      map[uuid.UUID]uuid.UUID
 }
@@ -20,14 +23,14 @@ type DB struct {
 func (db *DB) Name() string { return "Engine.ScheduleManager" }
 
 // NewDB will create a new DB instance, preparing all statements.
-func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
+func NewDB(ctx context.Context, db *sql.DB, oc *oncall.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeSchedule,
-		Version: 3,
+		Version: 4,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &DB{lock: lock}, nil
+	return &DB{lock: lock, oc: oc}, nil
 }
@@ -34,7 +34,8 @@ WHERE
 -- name: SchedMgrRules :many
 SELECT
     rule.*,
-    coalesce(rule.tgt_user_id, part.user_id) AS resolved_user_id
+    coalesce(rule.tgt_user_id, part.user_id) AS resolved_user_id,
+    rState.position AS rotation_position
 FROM
     schedule_rules rule
     LEFT JOIN rotation_state rState ON rState.rotation_id = rule.tgt_rotation_id
@@ -108,7 +109,173 @@ WHERE
     AND user_id = $2
     AND end_time ISNULL;
 
+-- name: SchedMgrTiers :many
+SELECT
+    schedule_id,
+    name,
+    rotation_offset
+FROM
+    schedule_tiers;
+
+-- name: SchedMgrTierRotationParticipants :many
+-- Returns the participants, in order, of the primary tier rotations of schedules with offset tiers.
+SELECT
+    rotation_id,
+    position,
+    user_id
+FROM
+    rotation_participants
+WHERE
+    rotation_id IN (
+        SELECT
+            rule.tgt_rotation_id
+        FROM
+            schedule_rules rule
+            JOIN schedule_tiers tier ON tier.schedule_id = rule.schedule_id
+                AND tier.rotation_offset > 0
+        WHERE
+            rule.tier ISNULL)
+ORDER BY
+    rotation_id,
+    position;
+
+-- name: SchedMgrTierOnCall :many
+SELECT
+    schedule_id,
+    tier,
+    user_id
+FROM
+    schedule_tier_on_call_users
+WHERE
+    end_time ISNULL;
+
+-- name: SchedMgrStartTierOnCall :exec
+INSERT INTO schedule_tier_on_call_users(schedule_id, tier, user_id)
+SELECT
+    @schedule_id,
+    @tier,
+    @user_id
+FROM
+    users
+WHERE
+    id = @user_id;
+
+-- name: SchedMgrEndTierOnCall :exec
+UPDATE
+    schedule_tier_on_call_users
+SET
+    end_time = now()
+WHERE
+    schedule_id = @schedule_id
+    AND tier = @tier
+    AND user_id = @user_id
+    AND end_time ISNULL;
+
 -- name: SchedMgrInsertMessage :exec
 INSERT INTO outgoing_messages(id, message_type, channel_id, schedule_id)
     VALUES ($1, 'schedule_on_call_notification', $2, $3);
 
+
+-- name: SchedMgrHolidays :many
+-- Returns holidays, from calendars used by schedule rules, that may be in effect now in any time zone.
+SELECT
+    calendar_id,
+    start_date,
+    end_date
+FROM
+    holidays
+WHERE
+    calendar_id IN (
+        SELECT
+            holiday_calendar_id
+        FROM
+            schedule_rules)
+    AND end_date >= (now() - '1 day'::interval)::date
+    AND start_date <= (now() + '1 day'::interval)::date;
+
+-- name: SchedMgrAllIDs :many
+-- Returns the IDs of all schedules.
+SELECT
+    id
+FROM
+    schedules;
+
+-- name: SchedMgrCoverageGaps :many
+-- Returns the previously detected coverage gaps for a schedule, locking them for update.
+SELECT
+    start_time,
+    end_time
+FROM
+    schedule_coverage_gaps
+WHERE
+    schedule_id = $1
+FOR UPDATE;
+
+-- name: SchedMgrClearCoverageGaps :exec
+DELETE FROM schedule_coverage_gaps
+WHERE schedule_id = $1;
+
+-- name: SchedMgrInsertCoverageGap :exec
+INSERT INTO schedule_coverage_gaps(schedule_id, start_time, end_time)
+    VALUES ($1, $2, $3);
+
+-- name: SchedMgrCoverageGapChannels :many
+-- Returns the notification channels from a schedule's on-call notification rules that can receive coverage gap messages.
+SELECT DISTINCT
+    nc.id
+FROM
+    schedule_data data,
+    jsonb_array_elements(data.data -> 'V1' -> 'OnCallNotificationRules') AS rule
+    JOIN notification_channels nc ON nc.id =(rule ->> 'ChannelID')::uuid
+WHERE
+    data.schedule_id = $1
+    AND nc.dest ->> 'Type' <> 'builtin-slack-usergroup';
+
+-- name: SchedMgrInsertCoverageGapMessage :exec
+INSERT INTO outgoing_messages(id, message_type, channel_id, schedule_id)
+    VALUES ($1, 'schedule_coverage_gap', $2, $3);
+
+-- name: SchedMgrInsertHandoffReport :exec
+-- Records a shift change for a handoff report. The previous shift is considered to have started at the last
+-- recorded handoff or the most recent on-call start, whichever is later, limited to the last 7 days.
+INSERT INTO schedule_handoff_reports(id, schedule_id, shift_start, outgoing_user_ids, incoming_user_ids)
+    VALUES (@id, @schedule_id, greatest(coalesce(greatest((
+                SELECT
+                    max(shift_end)
+                FROM schedule_handoff_reports r
+                WHERE
+                    r.schedule_id = @schedule_id),(
+                SELECT
+                    max(start_time)
+                FROM schedule_on_call_users oc
+                WHERE
+                    oc.schedule_id = @schedule_id
+                    AND oc.end_time ISNULL)), now()), now() - '7 days'::interval), @outgoing_user_ids::uuid[], @incoming_user_ids::uuid[]);
+
+-- name: SchedMgrInsertHandoffMessage :exec
+INSERT INTO outgoing_messages(id, message_type, channel_id, schedule_id, schedule_handoff_report_id)
+    VALUES ($1, 'schedule_handoff_report', $2, $3, $4);
+
+-- name: SchedMgrHandoffNotifyUsers :exec
+-- Queues a handoff report to each contact method the outgoing and incoming users have set to be notified
+-- immediately. Voice calls are skipped as the report is too long to be read out.
+INSERT INTO outgoing_messages(message_type, contact_method_id, user_id, schedule_id, schedule_handoff_report_id)
+SELECT
+    'schedule_handoff_report',
+    cm.id,
+    cm.user_id,
+    @schedule_id,
+    @report_id
+FROM
+    user_contact_methods cm
+WHERE
+    cm.user_id = ANY (@user_ids::uuid[])
+    AND NOT cm.disabled
+    AND cm.dest ->> 'Type' <> 'builtin-twilio-voice'
+    AND EXISTS (
+        SELECT
+        FROM
+            user_notification_rules r
+        WHERE
+            r.contact_method_id = cm.id
+            AND r.delay_minutes = 0);
@@ -0,0 +1,44 @@
+package schedulemanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/target/goalert/engine/processinglock"
+)
+
+const (
+	QueueName        = "schedule-manager"
+	PriorityCoverage = 3
+	PriorityLFW      = 4
+)
+
+var _ processinglock.Setupable = (*DB)(nil) // assert that DB implements processinglock.Setupable
+
+// Setup implements processinglock.Setupable.
+func (db *DB) Setup(ctx context.Context, args processinglock.SetupArgs) error {
+	river.AddWorker(args.Workers, river.WorkFunc(db.updateCoverage))
+	river.AddWorker(args.Workers, river.WorkFunc(db.lookForCoverageWork))
+
+	err := args.River.Queues().Add(QueueName, river.QueueConfig{MaxWorkers: 3})
+	if err != nil {
+		return fmt.Errorf("add queue: %w", err)
+	}
+
+	args.River.PeriodicJobs().AddMany([]*river.PeriodicJob{
+		river.NewPeriodicJob(
+			river.PeriodicInterval(time.Hour),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return CoverageLFWArgs{}, &river.InsertOpts{
+					Queue:    QueueName,
+					Priority: PriorityLFW,
+				}
+			},
+			&river.PeriodicJobOpts{RunOnStart: true},
+		),
+	})
+
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/holiday"
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/log"
@@ -30,7 +31,7 @@ func (db *DB) UpdateAll(ctx context.Context) error {
 	return err
 }
 
-func ruleRowIsActive(row gadb.SchedMgrRulesRow, t time.Time) bool {
+func ruleRowIsActive(row gadb.SchedMgrRulesRow, t time.Time, holidays []holiday.Holiday) bool {
 	var wf timeutil.WeekdayFilter
 	if row.Sunday {
 		wf[0] = 1
@@ -53,11 +54,16 @@ func ruleRowIsActive(row gadb.SchedMgrRulesRow, t time.Time) bool {
 	if row.Saturday {
 		wf[6] = 1
 	}
-	return rule.Rule{
+	isActive := rule.Rule{
 		Start:         row.StartTime,
 		End:           row.EndTime,
 		WeekdayFilter: wf,
 	}.IsActive(t)
+	if !isActive || !row.HolidayMode.Valid {
+		return isActive
+	}
+
+	return holiday.Mode(row.HolidayMode.EnumHolidayMode).Allows(holiday.Contains(holidays, t))
 }
 
 func (db *DB) update(ctx context.Context) error {
@@ -147,6 +153,18 @@ func (db *DB) update(ctx context.Context) error {
 		info.Rules = append(info.Rules, r)
 	}
 
+	holRows, err := q.SchedMgrHolidays(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get holidays")
+	}
+	holidays := make(map[uuid.UUID][]holiday.Holiday)
+	for _, row := range holRows {
+		holidays[row.CalendarID] = append(holidays[row.CalendarID], holiday.Holiday{
+			CalendarID: row.CalendarID,
+			Start:      holiday.Date(row.StartDate),
+			End:        holiday.Date(row.EndDate),
+		})
+	}
 	tzRows, err := q.SchedMgrTimezones(ctx)
 	if err != nil {
 		return fmt.Errorf("get timezones: %w", err)
@@ -167,14 +185,67 @@ func (db *DB) update(ctx context.Context) error {
 		getInfo(row.ScheduleID).CurrentOnCall.Add(row.UserID)
 	}
 
+	tierRows, err := q.SchedMgrTiers(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get tiers")
+	}
+	for _, row := range tierRows {
+		info := getInfo(row.ScheduleID)
+		info.Tiers = append(info.Tiers, row)
+	}
+
+	tierOnCallRows, err := q.SchedMgrTierOnCall(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get tier on call")
+	}
+	for _, row := range tierOnCallRows {
+		info := getInfo(row.ScheduleID)
+		if info.TierOnCall == nil {
+			info.TierOnCall = make(map[string]mapset.Set[uuid.UUID])
+		}
+		if info.TierOnCall[row.Tier] == nil {
+			info.TierOnCall[row.Tier] = mapset.NewThreadUnsafeSet[uuid.UUID]()
+		}
+		info.TierOnCall[row.Tier].Add(row.UserID)
+	}
+
+	partRows, err := q.SchedMgrTierRotationParticipants(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get tier rotation participants")
+	}
+	rotParts := make(map[uuid.UUID][]gadb.SchedMgrTierRotationParticipantsRow)
+	for _, row := range partRows {
+		rotParts[row.RotationID] = append(rotParts[row.RotationID], row)
+	}
+
 updateLoop:
 	for scheduleID, info := range updateData {
+		info.Holidays = holidays
+		info.RotationParticipants = rotParts
 		result, err := info.calcUpdates(now)
 		if err != nil {
 			log.Log(log.WithField(ctx, "ScheduleID", scheduleID), errors.Wrap(err, "calc updates"))
 			continue
 		}
 
+		// The report must be recorded before the on-call changes, as the previous shift start is derived from them.
+		var reportID uuid.UUID
+		if result.HandoffReport {
+			reportID = uuid.New()
+			err = q.SchedMgrInsertHandoffReport(ctx, gadb.SchedMgrInsertHandoffReportParams{
+				ID:              reportID,
+				ScheduleID:      info.ScheduleID,
+				OutgoingUserIds: result.UsersToStop.ToSlice(),
+				IncomingUserIds: result.UsersToStart.ToSlice(),
+			})
+			if isScheduleDeleted(err) {
+				continue
+			}
+			if err != nil {
+				return errors.Wrapf(err, "record handoff report for schedule %s", info.ScheduleID)
+			}
+		}
+
 		for userID := range mapset.Elements(result.UsersToStart) {
 			err = q.SchedMgrStartOnCall(ctx, gadb.SchedMgrStartOnCallParams{
 				ScheduleID: info.ScheduleID,
@@ -202,6 +273,34 @@ updateLoop:
 			}
 		}
 
+		for tier, users := range result.TierUsersToStart {
+			for userID := range mapset.Elements(users) {
+				err = q.SchedMgrStartTierOnCall(ctx, gadb.SchedMgrStartTierOnCallParams{
+					ScheduleID: info.ScheduleID,
+					Tier:       tier,
+					UserID:     userID,
+				})
+				if isScheduleDeleted(err) {
+					continue updateLoop
+				}
+				if err != nil {
+					return errors.Wrapf(err, "record shift start for user %s on schedule %s tier %s", userID, info.ScheduleID, tier)
+				}
+			}
+		}
+		for tier, users := range result.TierUsersToStop {
+			for userID := range mapset.Elements(users) {
+				err = q.SchedMgrEndTierOnCall(ctx, gadb.SchedMgrEndTierOnCallParams{
+					ScheduleID: info.ScheduleID,
+					Tier:       tier,
+					UserID:     userID,
+				})
+				if err != nil {
+					return errors.Wrapf(err, "record shift end for user %s on schedule %s tier %s", userID, info.ScheduleID, tier)
+				}
+			}
+		}
+
 		if result.NewRawScheduleData != nil {
 			err = q.SchedMgrSetData(ctx, gadb.SchedMgrSetDataParams{
 				ScheduleID: info.ScheduleID,
@@ -228,6 +327,31 @@ updateLoop:
 				return errors.Wrapf(err, "insert notification message for channel %s on schedule %s", chanID, info.ScheduleID)
 			}
 		}
+
+		if !result.HandoffReport {
+			continue
+		}
+		for chanID := range mapset.Elements(result.HandoffChannels) {
+			err = q.SchedMgrInsertHandoffMessage(ctx, gadb.SchedMgrInsertHandoffMessageParams{
+				ID:                      uuid.New(),
+				ChannelID:               uuid.NullUUID{UUID: chanID, Valid: true},
+				ScheduleID:              uuid.NullUUID{UUID: info.ScheduleID, Valid: true},
+				ScheduleHandoffReportID: uuid.NullUUID{UUID: reportID, Valid: true},
+			})
+			if err != nil {
+				return errors.Wrapf(err, "insert handoff report message for channel %s on schedule %s", chanID, info.ScheduleID)
+			}
+		}
+		if result.HandoffUsers {
+			err = q.SchedMgrHandoffNotifyUsers(ctx, gadb.SchedMgrHandoffNotifyUsersParams{
+				ScheduleID: uuid.NullUUID{UUID: info.ScheduleID, Valid: true},
+				ReportID:   uuid.NullUUID{UUID: reportID, Valid: true},
+				UserIds:    result.UsersToStop.Union(result.UsersToStart).ToSlice(),
+			})
+			if err != nil {
+				return errors.Wrapf(err, "insert handoff report messages for users on schedule %s", info.ScheduleID)
+			}
+		}
 	}
 
 	return tx.Commit()
@@ -288,7 +412,9 @@ func isScheduleDeleted(err error) bool {
 	switch dbErr.ConstraintName {
 	case "schedule_on_call_users_schedule_id_fkey",
 		"schedule_data_schedule_id_fkey",
-		"outgoing_messages_schedule_id_fkey":
+		"outgoing_messages_schedule_id_fkey",
+		"schedule_handoff_reports_schedule_id_fkey",
+		"schedule_tier_on_call_users_schedule_id_tier_fkey":
 		return true
 	default:
 		return false
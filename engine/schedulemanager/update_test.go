@@ -6,10 +6,35 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/holiday"
 	"github.com/target/goalert/util/timeutil"
 )
 
+func TestRuleRowIsActive_Holidays(t *testing.T) {
+	row := gadb.SchedMgrRulesRow{
+		Sunday: true, Monday: true, Tuesday: true, Wednesday: true, Thursday: true, Friday: true, Saturday: true,
+		HolidayMode: gadb.NullEnumHolidayMode{Valid: true, EnumHolidayMode: gadb.EnumHolidayModeSuppress},
+	}
+	hols := []holiday.Holiday{{
+		Start: time.Date(2021, 12, 25, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2021, 12, 25, 0, 0, 0, 0, time.UTC),
+	}}
+	christmas := time.Date(2021, 12, 25, 12, 0, 0, 0, time.UTC)
+	dayAfter := time.Date(2021, 12, 26, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, ruleRowIsActive(row, christmas, hols))
+	assert.True(t, ruleRowIsActive(row, dayAfter, hols))
+
+	row.HolidayMode.EnumHolidayMode = gadb.EnumHolidayModeSubstitute
+	assert.True(t, ruleRowIsActive(row, christmas, hols))
+	assert.False(t, ruleRowIsActive(row, dayAfter, hols))
+
+	row.HolidayMode.Valid = false
+	assert.True(t, ruleRowIsActive(row, christmas, hols))
+}
+
 func TestNextOnCallNotification(t *testing.T) {
 	now := time.Date(2021, 7, 7, 11, 0, 0, 0, time.UTC)
 
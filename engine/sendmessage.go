@@ -240,6 +240,83 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			ScheduleID:   msg.ScheduleID,
 			Users:        onCallUsers,
 		}
+	case notification.MessageTypeShiftSwap:
+		log.Logf(ctx, "sendMessage: building shift swap payload swapID=%s", msg.SwapID)
+		id, err := uuid.Parse(msg.SwapID)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse shift swap request id")
+		}
+		req, err := gadb.New(p.b.db).EngineGetShiftSwapRequest(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup shift swap request")
+		}
+		if req.Status != gadb.EnumShiftSwapStatusPending {
+			// already answered or cancelled, nothing to ask
+			return &notification.SendResult{
+				ID: msg.ID,
+				Status: notification.Status{
+					Details: "swap request " + string(req.Status) + " before message sent",
+					State:   notification.StateFailedPerm,
+				},
+			}, nil
+		}
+
+		notifMsg = notification.ShiftSwapRequest{
+			Base:                  msg.Base(),
+			RequestID:             msg.SwapID,
+			ScheduleName:          req.ScheduleName,
+			RequesterName:         req.RequesterName,
+			Message:               req.Message,
+			URL:                   p.cfg.ConfigSource.Config().CallbackURL("/schedules/" + req.ScheduleID.String() + "/shifts"),
+			RequesterShiftStart:   req.RequesterShiftStart,
+			RequesterShiftEnd:     req.RequesterShiftEnd,
+			CounterpartShiftStart: req.CounterpartShiftStart.Time,
+			CounterpartShiftEnd:   req.CounterpartShiftEnd.Time,
+		}
+	case notification.MessageTypeCoverageGap:
+		log.Logf(ctx, "sendMessage: building coverage gap payload scheduleID=%s", msg.ScheduleID)
+		id, err := uuid.Parse(msg.ScheduleID)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse schedule id")
+		}
+		rows, err := gadb.New(p.b.db).EngineGetScheduleCoverageGaps(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup schedule coverage gaps")
+		}
+		if len(rows) == 0 {
+			// gaps were filled in the meantime, nothing to report
+			return &notification.SendResult{
+				ID: msg.ID,
+				Status: notification.Status{
+					Details: "coverage gaps resolved before message sent",
+					State:   notification.StateFailedPerm,
+				},
+			}, nil
+		}
+		sched, err := p.cfg.ScheduleStore.FindOne(ctx, msg.ScheduleID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup schedule by id")
+		}
+
+		gaps := make([]notification.CoverageGap, len(rows))
+		for i, r := range rows {
+			gaps[i] = notification.CoverageGap{Start: r.StartTime, End: r.EndTime}
+		}
+
+		notifMsg = notification.ScheduleCoverageGap{
+			Base:         msg.Base(),
+			ScheduleName: sched.Name,
+			ScheduleURL:  p.cfg.ConfigSource.Config().CallbackURL("/schedules/" + msg.ScheduleID + "/shifts"),
+			ScheduleID:   msg.ScheduleID,
+			Gaps:         gaps,
+		}
+	case notification.MessageTypeHandoffReport:
+		log.Logf(ctx, "sendMessage: building handoff report payload reportID=%s", msg.HandoffReportID)
+		report, err := p.buildHandoffReport(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+		notifMsg = *report
 	case notification.MessageTypeSignalMessage:
 		log.Logf(ctx, "sendMessage: building signal payload messageID=%s", msg.ID)
 		id, err := uuid.Parse(msg.ID)
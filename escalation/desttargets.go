@@ -15,6 +15,12 @@ import (
 	"github.com/target/goalert/validation/validate"
 )
 
+// scheduleTierArg returns the tier of a schedule destination, NULL for the primary tier.
+func scheduleTierArg(dest gadb.DestV1) sql.NullString {
+	tier := dest.Arg(schedule.FieldScheduleTier)
+	return sql.NullString{String: tier, Valid: tier != ""}
+}
+
 func (s *Store) AddStepActionTx(ctx context.Context, tx *sql.Tx, stepID uuid.UUID, dest gadb.DestV1) error {
 	err := permission.LimitCheckAny(ctx, permission.User)
 	if err != nil {
@@ -30,6 +36,7 @@ func (s *Store) AddStepActionTx(ctx context.Context, tx *sql.Tx, stepID uuid.UUI
 	}
 
 	var userID, scheduleID, rotationID, channelID uuid.NullUUID
+	var scheduleTier sql.NullString
 	switch dest.Type {
 	case user.DestTypeUser:
 		id, err := validate.ParseUUID("ID", dest.Arg(user.FieldUserID))
@@ -43,6 +50,7 @@ func (s *Store) AddStepActionTx(ctx context.Context, tx *sql.Tx, stepID uuid.UUI
 			return err
 		}
 		scheduleID = uuid.NullUUID{UUID: id, Valid: true}
+		scheduleTier = scheduleTierArg(dest)
 	case rotation.DestTypeRotation:
 		id, err := validate.ParseUUID("ID", dest.Arg(rotation.FieldRotationID))
 		if err != nil {
@@ -63,6 +71,7 @@ func (s *Store) AddStepActionTx(ctx context.Context, tx *sql.Tx, stepID uuid.UUI
 		ScheduleID:             scheduleID,
 		RotationID:             rotationID,
 		ChannelID:              channelID,
+		ScheduleTier:           scheduleTier,
 	})
 }
 
@@ -73,6 +82,7 @@ func (s *Store) DeleteStepActionTx(ctx context.Context, tx *sql.Tx, stepID uuid.
 	}
 
 	var userID, scheduleID, rotationID, channelID uuid.NullUUID
+	var scheduleTier sql.NullString
 	switch dest.Type {
 	case user.DestTypeUser:
 		id, err := validate.ParseUUID("ID", dest.Arg(user.FieldUserID))
@@ -86,6 +96,7 @@ func (s *Store) DeleteStepActionTx(ctx context.Context, tx *sql.Tx, stepID uuid.
 			return err
 		}
 		scheduleID = uuid.NullUUID{UUID: id, Valid: true}
+		scheduleTier = scheduleTierArg(dest)
 	case rotation.DestTypeRotation:
 		id, err := validate.ParseUUID("ID", dest.Arg(rotation.FieldRotationID))
 		if err != nil {
@@ -106,6 +117,7 @@ func (s *Store) DeleteStepActionTx(ctx context.Context, tx *sql.Tx, stepID uuid.
 		ScheduleID:             scheduleID,
 		RotationID:             rotationID,
 		ChannelID:              channelID,
+		ScheduleTier:           scheduleTier,
 	})
 }
 
@@ -133,7 +145,7 @@ func (s *Store) FindAllStepActionsTx(ctx context.Context, tx gadb.DBTX, stepID u
 		case a.UserID.Valid:
 			result = append(result, user.DestFromID(a.UserID.UUID.String()))
 		case a.ScheduleID.Valid:
-			result = append(result, schedule.DestFromID(a.ScheduleID.UUID.String()))
+			result = append(result, schedule.DestFromTier(a.ScheduleID.UUID.String(), a.ScheduleTier.String))
 		case a.RotationID.Valid:
 			result = append(result, rotation.DestFromID(a.RotationID.UUID.String()))
 		case a.Dest.Valid:
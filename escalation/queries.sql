@@ -2,6 +2,7 @@
 SELECT
     a.user_id,
     a.schedule_id,
+    a.schedule_tier,
     a.rotation_id,
     ch.dest
 FROM
@@ -11,14 +12,15 @@ WHERE
     a.escalation_policy_step_id = $1;
 
 -- name: EPStepActionsAddAction :exec
-INSERT INTO escalation_policy_actions(escalation_policy_step_id, user_id, schedule_id, rotation_id, channel_id)
-    VALUES ($1, $2, $3, $4, $5);
+INSERT INTO escalation_policy_actions(escalation_policy_step_id, user_id, schedule_id, rotation_id, channel_id, schedule_tier)
+    VALUES ($1, $2, $3, $4, $5, $6);
 
 -- name: EPStepActionsDeleteAction :exec
 DELETE FROM escalation_policy_actions
 WHERE escalation_policy_step_id = $1
     AND (user_id = $2
-        OR schedule_id = $3
+        OR (schedule_id = $3
+            AND schedule_tier IS NOT DISTINCT FROM $6)
         OR rotation_id = $4
         OR channel_id = $5);
 
@@ -169,13 +169,18 @@ func (ns NullEnumAlertLogSubjectType) Value() (driver.Value, error) {
 type EnumAlertSource string
 
 const (
+	EnumAlertSourceAmazonSNS              EnumAlertSource = "amazonSNS"
+	EnumAlertSourceCloudEvents            EnumAlertSource = "cloudEvents"
 	EnumAlertSourceEmail                  EnumAlertSource = "email"
 	EnumAlertSourceGeneric                EnumAlertSource = "generic"
 	EnumAlertSourceGrafana                EnumAlertSource = "grafana"
+	EnumAlertSourceIcinga                 EnumAlertSource = "icinga"
 	EnumAlertSourceManual                 EnumAlertSource = "manual"
+	EnumAlertSourceNagios                 EnumAlertSource = "nagios"
 	EnumAlertSourcePrometheusAlertmanager EnumAlertSource = "prometheusAlertmanager"
 	EnumAlertSourceSite24x7               EnumAlertSource = "site24x7"
 	EnumAlertSourceUniversal              EnumAlertSource = "universal"
+	EnumAlertSourceZabbix                 EnumAlertSource = "zabbix"
 )
 
 func (e *EnumAlertSource) Scan(src interface{}) error {
@@ -299,15 +304,62 @@ func (ns NullEnumHeartbeatState) Value() (driver.Value, error) {
 	return string(ns.EnumHeartbeatState), nil
 }
 
+type EnumHolidayMode string
+
+const (
+	EnumHolidayModeSubstitute EnumHolidayMode = "substitute"
+	EnumHolidayModeSuppress   EnumHolidayMode = "suppress"
+)
+
+func (e *EnumHolidayMode) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumHolidayMode(s)
+	case string:
+		*e = EnumHolidayMode(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumHolidayMode: %T", src)
+	}
+	return nil
+}
+
+type NullEnumHolidayMode struct {
+	EnumHolidayMode EnumHolidayMode
+	Valid           bool // Valid is true if EnumHolidayMode is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumHolidayMode) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumHolidayMode, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumHolidayMode.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumHolidayMode) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumHolidayMode), nil
+}
+
 type EnumIntegrationKeysType string
 
 const (
+	EnumIntegrationKeysTypeAmazonSNS              EnumIntegrationKeysType = "amazonSNS"
+	EnumIntegrationKeysTypeCloudEvents            EnumIntegrationKeysType = "cloudEvents"
 	EnumIntegrationKeysTypeEmail                  EnumIntegrationKeysType = "email"
 	EnumIntegrationKeysTypeGeneric                EnumIntegrationKeysType = "generic"
 	EnumIntegrationKeysTypeGrafana                EnumIntegrationKeysType = "grafana"
+	EnumIntegrationKeysTypeIcinga                 EnumIntegrationKeysType = "icinga"
+	EnumIntegrationKeysTypeNagios                 EnumIntegrationKeysType = "nagios"
 	EnumIntegrationKeysTypePrometheusAlertmanager EnumIntegrationKeysType = "prometheusAlertmanager"
 	EnumIntegrationKeysTypeSite24x7               EnumIntegrationKeysType = "site24x7"
 	EnumIntegrationKeysTypeUniversal              EnumIntegrationKeysType = "universal"
+	EnumIntegrationKeysTypeZabbix                 EnumIntegrationKeysType = "zabbix"
 )
 
 func (e *EnumIntegrationKeysType) Scan(src interface{}) error {
@@ -498,7 +550,10 @@ const (
 	EnumOutgoingMessagesTypeAlertNotificationBundle    EnumOutgoingMessagesType = "alert_notification_bundle"
 	EnumOutgoingMessagesTypeAlertStatusUpdate          EnumOutgoingMessagesType = "alert_status_update"
 	EnumOutgoingMessagesTypeAlertStatusUpdateBundle    EnumOutgoingMessagesType = "alert_status_update_bundle"
+	EnumOutgoingMessagesTypeScheduleCoverageGap        EnumOutgoingMessagesType = "schedule_coverage_gap"
+	EnumOutgoingMessagesTypeScheduleHandoffReport      EnumOutgoingMessagesType = "schedule_handoff_report"
 	EnumOutgoingMessagesTypeScheduleOnCallNotification EnumOutgoingMessagesType = "schedule_on_call_notification"
+	EnumOutgoingMessagesTypeShiftSwapRequest           EnumOutgoingMessagesType = "shift_swap_request"
 	EnumOutgoingMessagesTypeSignalMessage              EnumOutgoingMessagesType = "signal_message"
 	EnumOutgoingMessagesTypeTestNotification           EnumOutgoingMessagesType = "test_notification"
 	EnumOutgoingMessagesTypeVerificationMessage        EnumOutgoingMessagesType = "verification_message"
@@ -542,10 +597,12 @@ func (ns NullEnumOutgoingMessagesType) Value() (driver.Value, error) {
 type EnumRotationType string
 
 const (
-	EnumRotationTypeDaily   EnumRotationType = "daily"
-	EnumRotationTypeHourly  EnumRotationType = "hourly"
-	EnumRotationTypeMonthly EnumRotationType = "monthly"
-	EnumRotationTypeWeekly  EnumRotationType = "weekly"
+	EnumRotationTypeDaily       EnumRotationType = "daily"
+	EnumRotationTypeHourly      EnumRotationType = "hourly"
+	EnumRotationTypeMonthly     EnumRotationType = "monthly"
+	EnumRotationTypeParticipant EnumRotationType = "participant"
+	EnumRotationTypePattern     EnumRotationType = "pattern"
+	EnumRotationTypeWeekly      EnumRotationType = "weekly"
 )
 
 func (e *EnumRotationType) Scan(src interface{}) error {
@@ -583,6 +640,50 @@ func (ns NullEnumRotationType) Value() (driver.Value, error) {
 	return string(ns.EnumRotationType), nil
 }
 
+type EnumShiftSwapStatus string
+
+const (
+	EnumShiftSwapStatusAccepted  EnumShiftSwapStatus = "accepted"
+	EnumShiftSwapStatusCancelled EnumShiftSwapStatus = "cancelled"
+	EnumShiftSwapStatusDeclined  EnumShiftSwapStatus = "declined"
+	EnumShiftSwapStatusPending   EnumShiftSwapStatus = "pending"
+)
+
+func (e *EnumShiftSwapStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumShiftSwapStatus(s)
+	case string:
+		*e = EnumShiftSwapStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumShiftSwapStatus: %T", src)
+	}
+	return nil
+}
+
+type NullEnumShiftSwapStatus struct {
+	EnumShiftSwapStatus EnumShiftSwapStatus
+	Valid               bool // Valid is true if EnumShiftSwapStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumShiftSwapStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumShiftSwapStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumShiftSwapStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumShiftSwapStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumShiftSwapStatus), nil
+}
+
 type EnumSwitchoverState string
 
 const (
@@ -887,6 +988,13 @@ type AuthNonce struct {
 	ID        uuid.UUID
 }
 
+type AuthSamlKey struct {
+	Certificate []byte
+	CreatedAt   time.Time
+	ID          string
+	PrivateKey  []byte
+}
+
 type AuthSubject struct {
 	CmID       uuid.NullUUID
 	ID         int64
@@ -956,6 +1064,7 @@ type EscalationPolicyAction struct {
 	ID                     uuid.UUID
 	RotationID             uuid.NullUUID
 	ScheduleID             uuid.NullUUID
+	ScheduleTier           sql.NullString
 	UserID                 uuid.NullUUID
 }
 
@@ -1017,6 +1126,21 @@ type HeartbeatMonitor struct {
 	ServiceID         uuid.UUID
 }
 
+type Holiday struct {
+	CalendarID uuid.UUID
+	EndDate    time.Time
+	ID         uuid.UUID
+	Name       string
+	StartDate  time.Time
+}
+
+type HolidayCalendar struct {
+	CreatedAt   time.Time
+	Description string
+	ID          uuid.UUID
+	Name        string
+}
+
 type IntegrationKey struct {
 	ExternalSystemName sql.NullString
 	ID                 uuid.UUID
@@ -1076,32 +1200,67 @@ type NotificationPolicyCycle struct {
 	UserID      uuid.UUID
 }
 
+type OauthAuthCode struct {
+	ClientID      uuid.UUID
+	CodeChallenge string
+	CodeHash      []byte
+	ExpiresAt     time.Time
+	RedirectUri   string
+	Scopes        []string
+	UserID        uuid.UUID
+}
+
+type OauthClient struct {
+	CreatedAt    time.Time
+	CreatedBy    uuid.NullUUID
+	DeletedAt    sql.NullTime
+	Description  string
+	ID           uuid.UUID
+	Name         string
+	RedirectUris []string
+	SecretHash   []byte
+}
+
+type OauthGrant struct {
+	ClientID         uuid.UUID
+	CreatedAt        time.Time
+	ExpiresAt        time.Time
+	ID               uuid.UUID
+	LastUsedAt       sql.NullTime
+	RefreshTokenHash []byte
+	RevokedAt        sql.NullTime
+	Scopes           []string
+	UserID           uuid.UUID
+}
+
 type OutgoingMessage struct {
-	AlertID                sql.NullInt64
-	AlertLogID             sql.NullInt64
-	ChannelID              uuid.NullUUID
-	ContactMethodID        uuid.NullUUID
-	CreatedAt              time.Time
-	CycleID                uuid.NullUUID
-	EscalationPolicyID     uuid.NullUUID
-	FiredAt                sql.NullTime
-	ID                     uuid.UUID
-	LastStatus             EnumOutgoingMessagesStatus
-	LastStatusAt           sql.NullTime
-	MessageType            EnumOutgoingMessagesType
-	NextRetryAt            sql.NullTime
-	ProviderMsgID          ProviderMessageID
-	ProviderSeq            int32
-	RetryCount             int32
-	ScheduleID             uuid.NullUUID
-	SendingDeadline        sql.NullTime
-	SentAt                 sql.NullTime
-	ServiceID              uuid.NullUUID
-	SrcValue               sql.NullString
-	StatusAlertIds         []int64
-	StatusDetails          string
-	UserID                 uuid.NullUUID
-	UserVerificationCodeID uuid.NullUUID
+	AlertID                 sql.NullInt64
+	AlertLogID              sql.NullInt64
+	ChannelID               uuid.NullUUID
+	ContactMethodID         uuid.NullUUID
+	CreatedAt               time.Time
+	CycleID                 uuid.NullUUID
+	EscalationPolicyID      uuid.NullUUID
+	FiredAt                 sql.NullTime
+	ID                      uuid.UUID
+	LastStatus              EnumOutgoingMessagesStatus
+	LastStatusAt            sql.NullTime
+	MessageType             EnumOutgoingMessagesType
+	NextRetryAt             sql.NullTime
+	ProviderMsgID           ProviderMessageID
+	ProviderSeq             int32
+	RetryCount              int32
+	ScheduleHandoffReportID uuid.NullUUID
+	ScheduleID              uuid.NullUUID
+	SendingDeadline         sql.NullTime
+	SentAt                  sql.NullTime
+	ServiceID               uuid.NullUUID
+	ShiftSwapRequestID      uuid.NullUUID
+	SrcValue                sql.NullString
+	StatusAlertIds          []int64
+	StatusDetails           string
+	UserID                  uuid.NullUUID
+	UserVerificationCodeID  uuid.NullUUID
 }
 
 type PendingSignal struct {
@@ -1189,16 +1348,18 @@ type Rotation struct {
 	Name             string
 	ParticipantCount int32
 	ShiftLength      int64
+	ShiftPattern     []int32
 	StartTime        time.Time
 	TimeZone         string
 	Type             EnumRotationType
 }
 
 type RotationParticipant struct {
-	ID         uuid.UUID
-	Position   int32
-	RotationID uuid.UUID
-	UserID     uuid.UUID
+	ID          uuid.UUID
+	Position    int32
+	RotationID  uuid.UUID
+	ShiftLength sql.NullInt32
+	UserID      uuid.UUID
 }
 
 type RotationState struct {
@@ -1218,6 +1379,13 @@ type Schedule struct {
 	TimeZone      string
 }
 
+type ScheduleCoverageGap struct {
+	DetectedAt time.Time
+	EndTime    time.Time
+	ScheduleID uuid.UUID
+	StartTime  time.Time
+}
+
 type ScheduleDatum struct {
 	Data          json.RawMessage
 	ID            int64
@@ -1225,6 +1393,15 @@ type ScheduleDatum struct {
 	ScheduleID    uuid.UUID
 }
 
+type ScheduleHandoffReport struct {
+	ID              uuid.UUID
+	IncomingUserIds []uuid.UUID
+	OutgoingUserIds []uuid.UUID
+	ScheduleID      uuid.UUID
+	ShiftEnd        time.Time
+	ShiftStart      time.Time
+}
+
 type ScheduleOnCallUser struct {
 	EndTime    sql.NullTime
 	ID         int64
@@ -1234,21 +1411,57 @@ type ScheduleOnCallUser struct {
 }
 
 type ScheduleRule struct {
-	CreatedAt     time.Time
-	EndTime       timeutil.Clock
-	Friday        bool
-	ID            uuid.UUID
-	IsActive      bool
-	Monday        bool
-	Saturday      bool
-	ScheduleID    uuid.UUID
-	StartTime     timeutil.Clock
-	Sunday        bool
-	TgtRotationID uuid.NullUUID
-	TgtUserID     uuid.NullUUID
-	Thursday      bool
-	Tuesday       bool
-	Wednesday     bool
+	CreatedAt         time.Time
+	EndTime           timeutil.Clock
+	Friday            bool
+	HolidayCalendarID uuid.NullUUID
+	HolidayMode       NullEnumHolidayMode
+	ID                uuid.UUID
+	IsActive          bool
+	Monday            bool
+	Saturday          bool
+	ScheduleID        uuid.UUID
+	StartTime         timeutil.Clock
+	Sunday            bool
+	TgtRotationID     uuid.NullUUID
+	TgtUserID         uuid.NullUUID
+	Thursday          bool
+	Tier              sql.NullString
+	Tuesday           bool
+	Wednesday         bool
+}
+
+type ScheduleTier struct {
+	CreatedAt      time.Time
+	Name           string
+	RotationOffset int32
+	ScheduleID     uuid.UUID
+}
+
+type ScheduleTierOnCallUser struct {
+	EndTime    sql.NullTime
+	ID         uuid.UUID
+	ScheduleID uuid.UUID
+	StartTime  time.Time
+	Tier       string
+	UserID     uuid.UUID
+}
+
+type ScimApiKey struct {
+	CreatedAt   time.Time
+	CreatedBy   uuid.NullUUID
+	DeletedAt   sql.NullTime
+	DeletedBy   uuid.NullUUID
+	Description string
+	ExpiresAt   time.Time
+	ID          uuid.UUID
+	LastUsedAt  sql.NullTime
+	Name        string
+}
+
+type ScimGroup struct {
+	CreatedAt  time.Time
+	RotationID uuid.UUID
 }
 
 type Service struct {
@@ -1259,6 +1472,21 @@ type Service struct {
 	Name                 string
 }
 
+type ShiftSwapRequest struct {
+	CounterpartID         uuid.UUID
+	CounterpartShiftEnd   sql.NullTime
+	CounterpartShiftStart sql.NullTime
+	CreatedAt             time.Time
+	ID                    uuid.UUID
+	Message               string
+	RequesterID           uuid.UUID
+	RequesterShiftEnd     time.Time
+	RequesterShiftStart   time.Time
+	RespondedAt           sql.NullTime
+	ScheduleID            uuid.UUID
+	Status                EnumShiftSwapStatus
+}
+
 type SwitchoverLog struct {
 	Data      json.RawMessage
 	ID        int64
@@ -1306,6 +1534,30 @@ type UikConfig struct {
 	SecondaryTokenHint sql.NullString
 }
 
+type UikRequestLog struct {
+	Body          []byte
+	BodyTruncated bool
+	Headers       json.RawMessage
+	ID            uuid.UUID
+	KeyID         uuid.UUID
+	Query         string
+	ReceivedAt    time.Time
+	RemoteAddr    string
+	UserAgent     string
+}
+
+type UikWebhookFailure struct {
+	Attempts    int32
+	Body        string
+	ContentType string
+	Error       string
+	FailedAt    time.Time
+	ID          uuid.UUID
+	KeyID       uuid.UUID
+	StatusCode  int32
+	Url         string
+}
+
 type User struct {
 	AlertStatusLogContactMethodID uuid.NullUUID
 	AvatarUrl                     string
@@ -1317,15 +1569,17 @@ type User struct {
 }
 
 type UserCalendarSubscription struct {
-	Config     json.RawMessage
-	CreatedAt  time.Time
-	Disabled   bool
-	ID         uuid.UUID
-	LastAccess sql.NullTime
-	LastUpdate time.Time
-	Name       string
-	ScheduleID uuid.UUID
-	UserID     uuid.UUID
+	Config             json.RawMessage
+	CreatedAt          time.Time
+	Disabled           bool
+	EscalationPolicyID uuid.NullUUID
+	ID                 uuid.UUID
+	LastAccess         sql.NullTime
+	LastUpdate         time.Time
+	Name               string
+	ScheduleID         uuid.NullUUID
+	ServiceID          uuid.NullUUID
+	UserID             uuid.UUID
 }
 
 type UserContactMethod struct {
@@ -1361,12 +1615,13 @@ type UserNotificationRule struct {
 }
 
 type UserOverride struct {
-	AddUserID     uuid.NullUUID
-	EndTime       time.Time
-	ID            uuid.UUID
-	RemoveUserID  uuid.NullUUID
-	StartTime     time.Time
-	TgtScheduleID uuid.UUID
+	AddUserID        uuid.NullUUID
+	EndTime          time.Time
+	ID               uuid.UUID
+	RemoveUserID     uuid.NullUUID
+	StartTime        time.Time
+	TgtScheduleID    uuid.UUID
+	UnavailabilityID uuid.NullUUID
 }
 
 type UserSlackDatum struct {
@@ -1374,6 +1629,15 @@ type UserSlackDatum struct {
 	ID          uuid.UUID
 }
 
+type UserUnavailability struct {
+	CreatedAt time.Time
+	EndTime   time.Time
+	ID        uuid.UUID
+	Reason    string
+	StartTime time.Time
+	UserID    uuid.UUID
+}
+
 type UserVerificationCode struct {
 	Code            int32
 	ContactMethodID uuid.UUID
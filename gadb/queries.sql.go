@@ -18,6 +18,39 @@ import (
 	"github.com/target/goalert/util/timeutil"
 )
 
+const aPIKeyAlertServices = `-- name: APIKeyAlertServices :many
+SELECT DISTINCT
+    service_id
+FROM
+    alerts
+WHERE
+    id = ANY ($1::bigint[])
+`
+
+// APIKeyAlertServices returns the service IDs of the given alerts.
+func (q *Queries) APIKeyAlertServices(ctx context.Context, alertIds []int64) ([]uuid.NullUUID, error) {
+	rows, err := q.db.QueryContext(ctx, aPIKeyAlertServices, pq.Array(alertIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.NullUUID
+	for rows.Next() {
+		var service_id uuid.NullUUID
+		if err := rows.Scan(&service_id); err != nil {
+			return nil, err
+		}
+		items = append(items, service_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const aPIKeyAuthCheck = `-- name: APIKeyAuthCheck :one
 SELECT
     TRUE
@@ -27,6 +60,9 @@ WHERE
     gql_api_keys.id = $1
     AND gql_api_keys.deleted_at IS NULL
     AND gql_api_keys.expires_at > now()
+    -- personal keys are only valid while their owner exists
+    AND (gql_api_keys.policy ->> 'UserID' IS NULL
+        OR gql_api_keys.created_by = (gql_api_keys.policy ->> 'UserID')::uuid)
 `
 
 func (q *Queries) APIKeyAuthCheck(ctx context.Context, id uuid.UUID) (bool, error) {
@@ -45,6 +81,9 @@ WHERE
     gql_api_keys.id = $1
     AND gql_api_keys.deleted_at IS NULL
     AND gql_api_keys.expires_at > now()
+    -- personal keys are only valid while their owner exists
+    AND (gql_api_keys.policy ->> 'UserID' IS NULL
+        OR gql_api_keys.created_by = (gql_api_keys.policy ->> 'UserID')::uuid)
 `
 
 // APIKeyAuth returns the API key policy with the given id, if it exists and is not expired.
@@ -75,6 +114,32 @@ func (q *Queries) APIKeyDelete(ctx context.Context, arg APIKeyDeleteParams) erro
 	return err
 }
 
+const aPIKeyDeleteByUser = `-- name: APIKeyDeleteByUser :execrows
+UPDATE
+    gql_api_keys
+SET
+    deleted_at = now(),
+    deleted_by = $1
+WHERE
+    id = $2
+    AND created_by = $1
+    AND deleted_at IS NULL
+`
+
+type APIKeyDeleteByUserParams struct {
+	UserID uuid.NullUUID
+	ID     uuid.UUID
+}
+
+// APIKeyDeleteByUser deletes the API key with the given id, only if it was created by the given user.
+func (q *Queries) APIKeyDeleteByUser(ctx context.Context, arg APIKeyDeleteByUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, aPIKeyDeleteByUser, arg.UserID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const aPIKeyForUpdate = `-- name: APIKeyForUpdate :one
 SELECT
     name,
@@ -196,6 +261,78 @@ func (q *Queries) APIKeyList(ctx context.Context) ([]APIKeyListRow, error) {
 	return items, nil
 }
 
+const aPIKeyListByUser = `-- name: APIKeyListByUser :many
+SELECT
+    gql_api_keys.created_at, gql_api_keys.created_by, gql_api_keys.deleted_at, gql_api_keys.deleted_by, gql_api_keys.description, gql_api_keys.expires_at, gql_api_keys.id, gql_api_keys.name, gql_api_keys.policy, gql_api_keys.updated_at, gql_api_keys.updated_by,
+    gql_api_key_usage.used_at AS last_used_at,
+    gql_api_key_usage.user_agent AS last_user_agent,
+    gql_api_key_usage.ip_address AS last_ip_address
+FROM
+    gql_api_keys
+    LEFT JOIN gql_api_key_usage ON gql_api_keys.id = gql_api_key_usage.api_key_id
+WHERE
+    gql_api_keys.deleted_at IS NULL
+    AND gql_api_keys.created_by = $1
+ORDER BY
+    gql_api_keys.name
+`
+
+type APIKeyListByUserRow struct {
+	CreatedAt     time.Time
+	CreatedBy     uuid.NullUUID
+	DeletedAt     sql.NullTime
+	DeletedBy     uuid.NullUUID
+	Description   string
+	ExpiresAt     time.Time
+	ID            uuid.UUID
+	Name          string
+	Policy        json.RawMessage
+	UpdatedAt     time.Time
+	UpdatedBy     uuid.NullUUID
+	LastUsedAt    sql.NullTime
+	LastUserAgent sql.NullString
+	LastIpAddress pqtype.Inet
+}
+
+// APIKeyListByUser returns all API keys created by the given user, along with the last time they were used.
+func (q *Queries) APIKeyListByUser(ctx context.Context, createdBy uuid.NullUUID) ([]APIKeyListByUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, aPIKeyListByUser, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []APIKeyListByUserRow
+	for rows.Next() {
+		var i APIKeyListByUserRow
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.Description,
+			&i.ExpiresAt,
+			&i.ID,
+			&i.Name,
+			&i.Policy,
+			&i.UpdatedAt,
+			&i.UpdatedBy,
+			&i.LastUsedAt,
+			&i.LastUserAgent,
+			&i.LastIpAddress,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const aPIKeyRecordUsage = `-- name: APIKeyRecordUsage :exec
 INSERT INTO gql_api_key_usage(api_key_id, user_agent, ip_address)
     VALUES ($1::uuid, $2::text, $3::inet)
@@ -216,6 +353,44 @@ func (q *Queries) APIKeyRecordUsage(ctx context.Context, arg APIKeyRecordUsagePa
 	return err
 }
 
+const aPIKeyScopedServices = `-- name: APIKeyScopedServices :many
+SELECT
+    tgt_service_id::uuid
+FROM
+    labels
+WHERE
+    tgt_service_id IS NOT NULL
+    AND (key || '=' || value) = ANY ($1::text[])
+GROUP BY
+    tgt_service_id
+HAVING
+    count(*) = cardinality($1::text[])
+`
+
+// APIKeyScopedServices returns the IDs of all services that have every one of the given labels (as key=value).
+func (q *Queries) APIKeyScopedServices(ctx context.Context, labels []string) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, aPIKeyScopedServices, pq.Array(labels))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var tgt_service_id uuid.UUID
+		if err := rows.Scan(&tgt_service_id); err != nil {
+			return nil, err
+		}
+		items = append(items, tgt_service_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const aPIKeyUpdate = `-- name: APIKeyUpdate :exec
 UPDATE
     gql_api_keys
@@ -498,6 +673,29 @@ func (q *Queries) Alert_AlertHasEPState(ctx context.Context, alertID int64) (boo
 	return has_ep_state, err
 }
 
+const alert_FindOpenByDedup = `-- name: Alert_FindOpenByDedup :one
+SELECT
+    id
+FROM
+    alerts
+WHERE
+    service_id = $1::uuid
+    AND dedup_key = $2::text
+`
+
+type Alert_FindOpenByDedupParams struct {
+	ServiceID uuid.UUID
+	DedupKey  string
+}
+
+// Returns the ID of the open alert of a service with the given dedup key.
+func (q *Queries) Alert_FindOpenByDedup(ctx context.Context, arg Alert_FindOpenByDedupParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, alert_FindOpenByDedup, arg.ServiceID, arg.DedupKey)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
 const alert_GetAlertFeedback = `-- name: Alert_GetAlertFeedback :many
 SELECT
     alert_id,
@@ -967,6 +1165,47 @@ func (q *Queries) AuthLinkUseReq(ctx context.Context, id uuid.UUID) (AuthLinkUse
 	return i, err
 }
 
+const authSAMLKeyFind = `-- name: AuthSAMLKeyFind :one
+SELECT
+    private_key,
+    certificate
+FROM
+    auth_saml_keys
+WHERE
+    id = $1
+`
+
+type AuthSAMLKeyFindRow struct {
+	PrivateKey  []byte
+	Certificate []byte
+}
+
+func (q *Queries) AuthSAMLKeyFind(ctx context.Context, id string) (AuthSAMLKeyFindRow, error) {
+	row := q.db.QueryRowContext(ctx, authSAMLKeyFind, id)
+	var i AuthSAMLKeyFindRow
+	err := row.Scan(&i.PrivateKey, &i.Certificate)
+	return i, err
+}
+
+const authSAMLKeyInsert = `-- name: AuthSAMLKeyInsert :exec
+INSERT INTO auth_saml_keys(id, private_key, certificate)
+    VALUES ($1, $2, $3)
+ON CONFLICT (id)
+    DO NOTHING
+`
+
+type AuthSAMLKeyInsertParams struct {
+	ID          string
+	PrivateKey  []byte
+	Certificate []byte
+}
+
+// Inserts a new SP key pair, if one does not already exist.
+func (q *Queries) AuthSAMLKeyInsert(ctx context.Context, arg AuthSAMLKeyInsertParams) error {
+	_, err := q.db.ExecContext(ctx, authSAMLKeyInsert, arg.ID, arg.PrivateKey, arg.Certificate)
+	return err
+}
+
 const calSubAuthUser = `-- name: CalSubAuthUser :one
 UPDATE
     user_calendar_subscriptions
@@ -992,66 +1231,130 @@ func (q *Queries) CalSubAuthUser(ctx context.Context, arg CalSubAuthUserParams)
 	return user_id, err
 }
 
-const calSubRenderInfo = `-- name: CalSubRenderInfo :one
-SELECT
-    now()::timestamptz AS now,
-    sub.schedule_id,
-    sched.name AS schedule_name,
-    sub.config,
-    sub.user_id
+const calSubEscalationPolicyTargets = `-- name: CalSubEscalationPolicyTargets :many
+SELECT DISTINCT ON (coalesce(act.schedule_id, act.rotation_id, act.user_id), coalesce(act.schedule_tier, ''))
+    step.step_number,
+    act.schedule_id,
+    act.schedule_tier,
+    act.rotation_id,
+    act.user_id,
+    coalesce(sched.name || coalesce(' (' || act.schedule_tier || ')', ''), rot.name, u.name)::text AS name
 FROM
-    user_calendar_subscriptions sub
-    JOIN schedules sched ON sched.id = schedule_id
+    escalation_policy_steps step
+    JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+    LEFT JOIN schedules sched ON sched.id = act.schedule_id
+    LEFT JOIN rotations rot ON rot.id = act.rotation_id
+    LEFT JOIN users u ON u.id = act.user_id
 WHERE
-    sub.id = $1
+    step.escalation_policy_id = $1
+    AND (act.schedule_id NOTNULL
+        OR act.rotation_id NOTNULL
+        OR act.user_id NOTNULL)
+    AND (NOT $2::bool
+        OR step.step_number = 0)
+ORDER BY
+    coalesce(act.schedule_id, act.rotation_id, act.user_id),
+    coalesce(act.schedule_tier, ''),
+    step.step_number
 `
 
-type CalSubRenderInfoRow struct {
-	Now          time.Time
-	ScheduleID   uuid.UUID
-	ScheduleName string
-	Config       json.RawMessage
-	UserID       uuid.UUID
+type CalSubEscalationPolicyTargetsParams struct {
+	EscalationPolicyID uuid.UUID
+	FirstStepOnly      bool
 }
 
-func (q *Queries) CalSubRenderInfo(ctx context.Context, id uuid.UUID) (CalSubRenderInfoRow, error) {
-	row := q.db.QueryRowContext(ctx, calSubRenderInfo, id)
-	var i CalSubRenderInfoRow
-	err := row.Scan(
-		&i.Now,
-		&i.ScheduleID,
-		&i.ScheduleName,
-		&i.Config,
-		&i.UserID,
-	)
-	return i, err
+type CalSubEscalationPolicyTargetsRow struct {
+	StepNumber   int32
+	ScheduleID   uuid.NullUUID
+	ScheduleTier sql.NullString
+	RotationID   uuid.NullUUID
+	UserID       uuid.NullUUID
+	Name         string
 }
 
-const calSubUserNames = `-- name: CalSubUserNames :many
-SELECT
-    id,
-    name
+// Returns the schedules (and schedule tiers), rotations, and users assigned to the steps of an escalation policy, optionally only the first step.
+func (q *Queries) CalSubEscalationPolicyTargets(ctx context.Context, arg CalSubEscalationPolicyTargetsParams) ([]CalSubEscalationPolicyTargetsRow, error) {
+	rows, err := q.db.QueryContext(ctx, calSubEscalationPolicyTargets, arg.EscalationPolicyID, arg.FirstStepOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CalSubEscalationPolicyTargetsRow
+	for rows.Next() {
+		var i CalSubEscalationPolicyTargetsRow
+		if err := rows.Scan(
+			&i.StepNumber,
+			&i.ScheduleID,
+			&i.ScheduleTier,
+			&i.RotationID,
+			&i.UserID,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const calSubHolidays = `-- name: CalSubHolidays :many
+SELECT DISTINCT ON (hol.start_date, hol.name)
+    hol.id,
+    hol.name,
+    hol.start_date,
+    hol.end_date
 FROM
-    users
+    holidays hol
 WHERE
-    id = ANY ($1::uuid[])
+    hol.calendar_id IN (
+        SELECT
+            holiday_calendar_id
+        FROM
+            schedule_rules
+        WHERE
+            schedule_id = $1)
+    AND hol.end_date >= $2::date
+    AND hol.start_date <= $3::date
+ORDER BY
+    hol.start_date,
+    hol.name
 `
 
-type CalSubUserNamesRow struct {
-	ID   uuid.UUID
-	Name string
+type CalSubHolidaysParams struct {
+	ScheduleID uuid.UUID
+	StartDate  time.Time
+	EndDate    time.Time
 }
 
-func (q *Queries) CalSubUserNames(ctx context.Context, dollar_1 []uuid.UUID) ([]CalSubUserNamesRow, error) {
-	rows, err := q.db.QueryContext(ctx, calSubUserNames, pq.Array(dollar_1))
+type CalSubHolidaysRow struct {
+	ID        uuid.UUID
+	Name      string
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// Returns holidays, from calendars used by the schedule's rules, that overlap the given dates.
+func (q *Queries) CalSubHolidays(ctx context.Context, arg CalSubHolidaysParams) ([]CalSubHolidaysRow, error) {
+	rows, err := q.db.QueryContext(ctx, calSubHolidays, arg.ScheduleID, arg.StartDate, arg.EndDate)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []CalSubUserNamesRow
+	var items []CalSubHolidaysRow
 	for rows.Next() {
-		var i CalSubUserNamesRow
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		var i CalSubHolidaysRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.StartDate,
+			&i.EndDate,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1065,19 +1368,202 @@ func (q *Queries) CalSubUserNames(ctx context.Context, dollar_1 []uuid.UUID) ([]
 	return items, nil
 }
 
-const cleanupAlertLogs = `-- name: CleanupAlertLogs :one
-WITH scope AS (
-    SELECT
-        id
-    FROM
-        alert_logs l
-    WHERE
-        l.id BETWEEN $2 AND $3- 1
-    ORDER BY
-        l.id
-    LIMIT $4
-),
-id_range AS (
+const calSubOverrides = `-- name: CalSubOverrides :many
+SELECT
+    tgt_schedule_id,
+    add_user_id,
+    start_time,
+    end_time
+FROM
+    user_overrides
+WHERE
+    tgt_schedule_id = ANY ($1::uuid[])
+    AND add_user_id NOTNULL
+    AND end_time > $2
+    AND start_time < $3
+`
+
+type CalSubOverridesParams struct {
+	ScheduleIds []uuid.UUID
+	StartTime   time.Time
+	EndTime     time.Time
+}
+
+type CalSubOverridesRow struct {
+	TgtScheduleID uuid.UUID
+	AddUserID     uuid.NullUUID
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
+// Returns overrides that add a user to one of the schedules during the given time range.
+func (q *Queries) CalSubOverrides(ctx context.Context, arg CalSubOverridesParams) ([]CalSubOverridesRow, error) {
+	rows, err := q.db.QueryContext(ctx, calSubOverrides, pq.Array(arg.ScheduleIds), arg.StartTime, arg.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CalSubOverridesRow
+	for rows.Next() {
+		var i CalSubOverridesRow
+		if err := rows.Scan(
+			&i.TgtScheduleID,
+			&i.AddUserID,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const calSubRenderInfo = `-- name: CalSubRenderInfo :one
+SELECT
+    now()::timestamptz AS now,
+    sub.schedule_id,
+    sched.name AS schedule_name,
+    sub.service_id,
+    svc.name AS service_name,
+    ep.id AS escalation_policy_id,
+    ep.name AS escalation_policy_name,
+    sub.config,
+    sub.user_id
+FROM
+    user_calendar_subscriptions sub
+    LEFT JOIN schedules sched ON sched.id = sub.schedule_id
+    LEFT JOIN services svc ON svc.id = sub.service_id
+    LEFT JOIN escalation_policies ep ON ep.id = coalesce(sub.escalation_policy_id, svc.escalation_policy_id)
+WHERE
+    sub.id = $1
+`
+
+type CalSubRenderInfoRow struct {
+	Now                  time.Time
+	ScheduleID           uuid.NullUUID
+	ScheduleName         sql.NullString
+	ServiceID            uuid.NullUUID
+	ServiceName          sql.NullString
+	EscalationPolicyID   uuid.NullUUID
+	EscalationPolicyName sql.NullString
+	Config               json.RawMessage
+	UserID               uuid.UUID
+}
+
+// Returns the information needed to render a subscription. For service subscriptions, the escalation policy is the one assigned to the service.
+func (q *Queries) CalSubRenderInfo(ctx context.Context, id uuid.UUID) (CalSubRenderInfoRow, error) {
+	row := q.db.QueryRowContext(ctx, calSubRenderInfo, id)
+	var i CalSubRenderInfoRow
+	err := row.Scan(
+		&i.Now,
+		&i.ScheduleID,
+		&i.ScheduleName,
+		&i.ServiceID,
+		&i.ServiceName,
+		&i.EscalationPolicyID,
+		&i.EscalationPolicyName,
+		&i.Config,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const calSubScheduleNames = `-- name: CalSubScheduleNames :many
+SELECT
+    id,
+    name
+FROM
+    schedules
+WHERE
+    id = ANY ($1::uuid[])
+`
+
+type CalSubScheduleNamesRow struct {
+	ID   uuid.UUID
+	Name string
+}
+
+func (q *Queries) CalSubScheduleNames(ctx context.Context, scheduleIds []uuid.UUID) ([]CalSubScheduleNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, calSubScheduleNames, pq.Array(scheduleIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CalSubScheduleNamesRow
+	for rows.Next() {
+		var i CalSubScheduleNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const calSubUserNames = `-- name: CalSubUserNames :many
+SELECT
+    id,
+    name
+FROM
+    users
+WHERE
+    id = ANY ($1::uuid[])
+`
+
+type CalSubUserNamesRow struct {
+	ID   uuid.UUID
+	Name string
+}
+
+func (q *Queries) CalSubUserNames(ctx context.Context, dollar_1 []uuid.UUID) ([]CalSubUserNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, calSubUserNames, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CalSubUserNamesRow
+	for rows.Next() {
+		var i CalSubUserNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const cleanupAlertLogs = `-- name: CleanupAlertLogs :one
+WITH scope AS (
+    SELECT
+        id
+    FROM
+        alert_logs l
+    WHERE
+        l.id BETWEEN $2 AND $3- 1
+    ORDER BY
+        l.id
+    LIMIT $4
+),
+id_range AS (
     SELECT
         min(id),
         max(id)
@@ -1229,6 +1715,29 @@ func (q *Queries) CleanupMgrDeleteOldScheduleShifts(ctx context.Context, history
 	return result.RowsAffected()
 }
 
+const cleanupMgrDeleteOldScheduleTierShifts = `-- name: CleanupMgrDeleteOldScheduleTierShifts :execrows
+DELETE FROM schedule_tier_on_call_users
+WHERE id = ANY (
+        SELECT
+            id
+        FROM
+            schedule_tier_on_call_users
+        WHERE
+            end_time <(now() - '1 day'::interval * $1)
+        LIMIT 100
+        FOR UPDATE
+            SKIP LOCKED)
+`
+
+// CleanupMgrDeleteOldScheduleTierShifts will delete old schedule tier shifts from the schedule_tier_on_call_users table that are older than the given number of days before now.
+func (q *Queries) CleanupMgrDeleteOldScheduleTierShifts(ctx context.Context, historyThresholdDays interface{}) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cleanupMgrDeleteOldScheduleTierShifts, historyThresholdDays)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const cleanupMgrDeleteOldSessions = `-- name: CleanupMgrDeleteOldSessions :execrows
 DELETE FROM auth_user_sessions
 WHERE id = ANY (
@@ -1425,6 +1934,31 @@ func (q *Queries) CleanupMgrScheduleNeedsCleanup(ctx context.Context, cleanupInt
 	return items, nil
 }
 
+const cleanupMgrTrimUIKRequests = `-- name: CleanupMgrTrimUIKRequests :execrows
+DELETE FROM uik_request_log
+WHERE id = ANY (
+        SELECT
+            r.id
+        FROM (
+            SELECT
+                id,
+                row_number() OVER (PARTITION BY key_id ORDER BY received_at DESC, id DESC) AS n
+            FROM
+                uik_request_log) r
+        WHERE
+            r.n > $1::bigint
+        LIMIT 100)
+`
+
+// CleanupMgrTrimUIKRequests will delete recorded universal key requests beyond the most recent ones of each key.
+func (q *Queries) CleanupMgrTrimUIKRequests(ctx context.Context, maxRequests int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cleanupMgrTrimUIKRequests, maxRequests)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const cleanupMgrUpdateScheduleData = `-- name: CleanupMgrUpdateScheduleData :exec
 UPDATE
     schedule_data
@@ -1986,19 +2520,21 @@ func (q *Queries) ContactMethodUpdateMetaDest(ctx context.Context, arg ContactMe
 }
 
 const createCalSub = `-- name: CreateCalSub :one
-INSERT INTO user_calendar_subscriptions(id, NAME, user_id, disabled, schedule_id, config)
-    VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO user_calendar_subscriptions(id, NAME, user_id, disabled, schedule_id, service_id, escalation_policy_id, config)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING
     created_at
 `
 
 type CreateCalSubParams struct {
-	ID         uuid.UUID
-	Name       string
-	UserID     uuid.UUID
-	Disabled   bool
-	ScheduleID uuid.UUID
-	Config     json.RawMessage
+	ID                 uuid.UUID
+	Name               string
+	UserID             uuid.UUID
+	Disabled           bool
+	ScheduleID         uuid.NullUUID
+	ServiceID          uuid.NullUUID
+	EscalationPolicyID uuid.NullUUID
+	Config             json.RawMessage
 }
 
 func (q *Queries) CreateCalSub(ctx context.Context, arg CreateCalSubParams) (time.Time, error) {
@@ -2008,6 +2544,8 @@ func (q *Queries) CreateCalSub(ctx context.Context, arg CreateCalSubParams) (tim
 		arg.UserID,
 		arg.Disabled,
 		arg.ScheduleID,
+		arg.ServiceID,
+		arg.EscalationPolicyID,
 		arg.Config,
 	)
 	var created_at time.Time
@@ -2071,8 +2609,8 @@ func (q *Queries) DisableChangeLogTriggers(ctx context.Context) error {
 }
 
 const ePStepActionsAddAction = `-- name: EPStepActionsAddAction :exec
-INSERT INTO escalation_policy_actions(escalation_policy_step_id, user_id, schedule_id, rotation_id, channel_id)
-    VALUES ($1, $2, $3, $4, $5)
+INSERT INTO escalation_policy_actions(escalation_policy_step_id, user_id, schedule_id, rotation_id, channel_id, schedule_tier)
+    VALUES ($1, $2, $3, $4, $5, $6)
 `
 
 type EPStepActionsAddActionParams struct {
@@ -2081,6 +2619,7 @@ type EPStepActionsAddActionParams struct {
 	ScheduleID             uuid.NullUUID
 	RotationID             uuid.NullUUID
 	ChannelID              uuid.NullUUID
+	ScheduleTier           sql.NullString
 }
 
 func (q *Queries) EPStepActionsAddAction(ctx context.Context, arg EPStepActionsAddActionParams) error {
@@ -2090,6 +2629,7 @@ func (q *Queries) EPStepActionsAddAction(ctx context.Context, arg EPStepActionsA
 		arg.ScheduleID,
 		arg.RotationID,
 		arg.ChannelID,
+		arg.ScheduleTier,
 	)
 	return err
 }
@@ -2098,6 +2638,7 @@ const ePStepActionsByStepId = `-- name: EPStepActionsByStepId :many
 SELECT
     a.user_id,
     a.schedule_id,
+    a.schedule_tier,
     a.rotation_id,
     ch.dest
 FROM
@@ -2108,10 +2649,11 @@ WHERE
 `
 
 type EPStepActionsByStepIdRow struct {
-	UserID     uuid.NullUUID
-	ScheduleID uuid.NullUUID
-	RotationID uuid.NullUUID
-	Dest       NullDestV1
+	UserID       uuid.NullUUID
+	ScheduleID   uuid.NullUUID
+	ScheduleTier sql.NullString
+	RotationID   uuid.NullUUID
+	Dest         NullDestV1
 }
 
 func (q *Queries) EPStepActionsByStepId(ctx context.Context, escalationPolicyStepID uuid.UUID) ([]EPStepActionsByStepIdRow, error) {
@@ -2126,6 +2668,7 @@ func (q *Queries) EPStepActionsByStepId(ctx context.Context, escalationPolicySte
 		if err := rows.Scan(
 			&i.UserID,
 			&i.ScheduleID,
+			&i.ScheduleTier,
 			&i.RotationID,
 			&i.Dest,
 		); err != nil {
@@ -2146,7 +2689,8 @@ const ePStepActionsDeleteAction = `-- name: EPStepActionsDeleteAction :exec
 DELETE FROM escalation_policy_actions
 WHERE escalation_policy_step_id = $1
     AND (user_id = $2
-        OR schedule_id = $3
+        OR (schedule_id = $3
+            AND schedule_tier IS NOT DISTINCT FROM $6)
         OR rotation_id = $4
         OR channel_id = $5)
 `
@@ -2157,6 +2701,7 @@ type EPStepActionsDeleteActionParams struct {
 	ScheduleID             uuid.NullUUID
 	RotationID             uuid.NullUUID
 	ChannelID              uuid.NullUUID
+	ScheduleTier           sql.NullString
 }
 
 func (q *Queries) EPStepActionsDeleteAction(ctx context.Context, arg EPStepActionsDeleteActionParams) error {
@@ -2166,6 +2711,7 @@ func (q *Queries) EPStepActionsDeleteAction(ctx context.Context, arg EPStepActio
 		arg.ScheduleID,
 		arg.RotationID,
 		arg.ChannelID,
+		arg.ScheduleTier,
 	)
 	return err
 }
@@ -2181,88 +2727,581 @@ func (q *Queries) EnableChangeLogTriggers(ctx context.Context) error {
 	return err
 }
 
-const engineGetSignalParams = `-- name: EngineGetSignalParams :one
+const engineGetHandoffReport = `-- name: EngineGetHandoffReport :one
 SELECT
-    params
+    r.id,
+    r.schedule_id,
+    r.shift_start,
+    r.shift_end,
+    r.outgoing_user_ids,
+    r.incoming_user_ids,
+    sched.name AS schedule_name
 FROM
-    pending_signals
+    schedule_handoff_reports r
+    JOIN schedules sched ON sched.id = r.schedule_id
 WHERE
-    message_id = $1
+    r.id = $1
 `
 
-// Get a pending signal's rendered params.
-func (q *Queries) EngineGetSignalParams(ctx context.Context, messageID uuid.NullUUID) (json.RawMessage, error) {
-	row := q.db.QueryRowContext(ctx, engineGetSignalParams, messageID)
-	var params json.RawMessage
-	err := row.Scan(&params)
-	return params, err
+type EngineGetHandoffReportRow struct {
+	ID              uuid.UUID
+	ScheduleID      uuid.UUID
+	ShiftStart      time.Time
+	ShiftEnd        time.Time
+	OutgoingUserIds []uuid.UUID
+	IncomingUserIds []uuid.UUID
+	ScheduleName    string
 }
 
-const engineIsKnownDest = `-- name: EngineIsKnownDest :one
-SELECT
-    EXISTS (
-        SELECT
-        FROM
-            user_contact_methods uc
-        WHERE
-            uc.dest = $1
-            AND uc.disabled = FALSE)
-    OR EXISTS (
-        SELECT
-        FROM
-            notification_channels nc
-        WHERE
-            nc.dest = $1)
-`
-
-// Check if a destination is known in user_contact_methods or notification_channels table.
-func (q *Queries) EngineIsKnownDest(ctx context.Context, dest NullDestV1) (sql.NullBool, error) {
-	row := q.db.QueryRowContext(ctx, engineIsKnownDest, dest)
-	var column_1 sql.NullBool
-	err := row.Scan(&column_1)
-	return column_1, err
+// Get a schedule handoff report along with the schedule name for rendering a notification.
+func (q *Queries) EngineGetHandoffReport(ctx context.Context, id uuid.UUID) (EngineGetHandoffReportRow, error) {
+	row := q.db.QueryRowContext(ctx, engineGetHandoffReport, id)
+	var i EngineGetHandoffReportRow
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduleID,
+		&i.ShiftStart,
+		&i.ShiftEnd,
+		pq.Array(&i.OutgoingUserIds),
+		pq.Array(&i.IncomingUserIds),
+		&i.ScheduleName,
+	)
+	return i, err
 }
 
-const findManyCalSubByUser = `-- name: FindManyCalSubByUser :many
+const engineGetScheduleCoverageGaps = `-- name: EngineGetScheduleCoverageGaps :many
 SELECT
-    id,
-    NAME,
-    user_id,
-    disabled,
-    schedule_id,
-    config,
-    last_access
+    start_time,
+    end_time
 FROM
-    user_calendar_subscriptions
+    schedule_coverage_gaps
 WHERE
-    user_id = $1
+    schedule_id = $1
+    AND end_time > now()
+ORDER BY
+    start_time
 `
 
-type FindManyCalSubByUserRow struct {
-	ID         uuid.UUID
-	Name       string
-	UserID     uuid.UUID
-	Disabled   bool
-	ScheduleID uuid.UUID
-	Config     json.RawMessage
-	LastAccess sql.NullTime
+type EngineGetScheduleCoverageGapsRow struct {
+	StartTime time.Time
+	EndTime   time.Time
 }
 
-func (q *Queries) FindManyCalSubByUser(ctx context.Context, userID uuid.UUID) ([]FindManyCalSubByUserRow, error) {
-	rows, err := q.db.QueryContext(ctx, findManyCalSubByUser, userID)
+// Get the current and upcoming coverage gaps for a schedule for rendering a notification.
+func (q *Queries) EngineGetScheduleCoverageGaps(ctx context.Context, scheduleID uuid.UUID) ([]EngineGetScheduleCoverageGapsRow, error) {
+	rows, err := q.db.QueryContext(ctx, engineGetScheduleCoverageGaps, scheduleID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []FindManyCalSubByUserRow
+	var items []EngineGetScheduleCoverageGapsRow
 	for rows.Next() {
-		var i FindManyCalSubByUserRow
-		if err := rows.Scan(
-			&i.ID,
+		var i EngineGetScheduleCoverageGapsRow
+		if err := rows.Scan(&i.StartTime, &i.EndTime); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const engineGetShiftSwapRequest = `-- name: EngineGetShiftSwapRequest :one
+SELECT
+    req.id,
+    req.schedule_id,
+    req.requester_shift_start,
+    req.requester_shift_end,
+    req.counterpart_shift_start,
+    req.counterpart_shift_end,
+    req.message,
+    req.status,
+    sched.name AS schedule_name,
+    u.name AS requester_name
+FROM
+    shift_swap_requests req
+    JOIN schedules sched ON sched.id = req.schedule_id
+    JOIN users u ON u.id = req.requester_id
+WHERE
+    req.id = $1
+`
+
+type EngineGetShiftSwapRequestRow struct {
+	ID                    uuid.UUID
+	ScheduleID            uuid.UUID
+	RequesterShiftStart   time.Time
+	RequesterShiftEnd     time.Time
+	CounterpartShiftStart sql.NullTime
+	CounterpartShiftEnd   sql.NullTime
+	Message               string
+	Status                EnumShiftSwapStatus
+	ScheduleName          string
+	RequesterName         string
+}
+
+// Get a shift swap request along with the schedule and requester names for rendering a notification.
+func (q *Queries) EngineGetShiftSwapRequest(ctx context.Context, id uuid.UUID) (EngineGetShiftSwapRequestRow, error) {
+	row := q.db.QueryRowContext(ctx, engineGetShiftSwapRequest, id)
+	var i EngineGetShiftSwapRequestRow
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduleID,
+		&i.RequesterShiftStart,
+		&i.RequesterShiftEnd,
+		&i.CounterpartShiftStart,
+		&i.CounterpartShiftEnd,
+		&i.Message,
+		&i.Status,
+		&i.ScheduleName,
+		&i.RequesterName,
+	)
+	return i, err
+}
+
+const engineGetSignalParams = `-- name: EngineGetSignalParams :one
+SELECT
+    params
+FROM
+    pending_signals
+WHERE
+    message_id = $1
+`
+
+// Get a pending signal's rendered params.
+func (q *Queries) EngineGetSignalParams(ctx context.Context, messageID uuid.NullUUID) (json.RawMessage, error) {
+	row := q.db.QueryRowContext(ctx, engineGetSignalParams, messageID)
+	var params json.RawMessage
+	err := row.Scan(&params)
+	return params, err
+}
+
+const engineHandoffAlertCounts = `-- name: EngineHandoffAlertCounts :one
+SELECT
+    count(*) FILTER (WHERE a.status = 'triggered') AS open_count,
+    count(*) FILTER (WHERE a.status = 'active') AS acked_count,
+    count(*) FILTER (WHERE a.status = 'closed') AS closed_count
+FROM
+    alerts a
+WHERE
+    a.service_id IN (
+        SELECT
+            svc.id
+        FROM
+            services svc
+            JOIN escalation_policy_steps step ON step.escalation_policy_id = svc.escalation_policy_id
+            JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+        WHERE
+            act.schedule_id = $1::uuid)
+    AND a.created_at >= $2
+    AND a.created_at < $3
+`
+
+type EngineHandoffAlertCountsParams struct {
+	ScheduleID uuid.UUID
+	ShiftStart time.Time
+	ShiftEnd   time.Time
+}
+
+type EngineHandoffAlertCountsRow struct {
+	OpenCount   int64
+	AckedCount  int64
+	ClosedCount int64
+}
+
+// Count alerts, by current status, created during a shift on services escalating to the schedule.
+func (q *Queries) EngineHandoffAlertCounts(ctx context.Context, arg EngineHandoffAlertCountsParams) (EngineHandoffAlertCountsRow, error) {
+	row := q.db.QueryRowContext(ctx, engineHandoffAlertCounts, arg.ScheduleID, arg.ShiftStart, arg.ShiftEnd)
+	var i EngineHandoffAlertCountsRow
+	err := row.Scan(&i.OpenCount, &i.AckedCount, &i.ClosedCount)
+	return i, err
+}
+
+const engineHandoffTopServices = `-- name: EngineHandoffTopServices :many
+SELECT
+    svc.id,
+    svc.name,
+    count(*) AS alert_count
+FROM
+    alerts a
+    JOIN services svc ON svc.id = a.service_id
+WHERE
+    svc.id IN (
+        SELECT
+            s.id
+        FROM
+            services s
+            JOIN escalation_policy_steps step ON step.escalation_policy_id = s.escalation_policy_id
+            JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+        WHERE
+            act.schedule_id = $1::uuid)
+    AND a.created_at >= $2
+    AND a.created_at < $3
+GROUP BY
+    svc.id
+ORDER BY
+    alert_count DESC,
+    svc.name
+LIMIT $4::int
+`
+
+type EngineHandoffTopServicesParams struct {
+	ScheduleID  uuid.UUID
+	ShiftStart  time.Time
+	ShiftEnd    time.Time
+	MaxServices int32
+}
+
+type EngineHandoffTopServicesRow struct {
+	ID         uuid.UUID
+	Name       string
+	AlertCount int64
+}
+
+// Get the services, escalating to the schedule, with the most alerts created during a shift.
+func (q *Queries) EngineHandoffTopServices(ctx context.Context, arg EngineHandoffTopServicesParams) ([]EngineHandoffTopServicesRow, error) {
+	rows, err := q.db.QueryContext(ctx, engineHandoffTopServices,
+		arg.ScheduleID,
+		arg.ShiftStart,
+		arg.ShiftEnd,
+		arg.MaxServices,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EngineHandoffTopServicesRow
+	for rows.Next() {
+		var i EngineHandoffTopServicesRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.AlertCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const engineHandoffUnresolvedAlerts = `-- name: EngineHandoffUnresolvedAlerts :many
+SELECT
+    a.id,
+    a.summary,
+    a.status,
+    svc.name AS service_name,
+    count(*) OVER () AS total
+FROM
+    alerts a
+    JOIN services svc ON svc.id = a.service_id
+WHERE
+    svc.id IN (
+        SELECT
+            s.id
+        FROM
+            services s
+            JOIN escalation_policy_steps step ON step.escalation_policy_id = s.escalation_policy_id
+            JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+        WHERE
+            act.schedule_id = $1::uuid)
+    AND a.status <> 'closed'
+ORDER BY
+    a.id
+LIMIT $2::int
+`
+
+type EngineHandoffUnresolvedAlertsParams struct {
+	ScheduleID uuid.UUID
+	MaxAlerts  int32
+}
+
+type EngineHandoffUnresolvedAlertsRow struct {
+	ID          int64
+	Summary     string
+	Status      EnumAlertStatus
+	ServiceName string
+	Total       int64
+}
+
+// Get the oldest unresolved alerts on services escalating to the schedule, along with the total number unresolved.
+func (q *Queries) EngineHandoffUnresolvedAlerts(ctx context.Context, arg EngineHandoffUnresolvedAlertsParams) ([]EngineHandoffUnresolvedAlertsRow, error) {
+	rows, err := q.db.QueryContext(ctx, engineHandoffUnresolvedAlerts, arg.ScheduleID, arg.MaxAlerts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EngineHandoffUnresolvedAlertsRow
+	for rows.Next() {
+		var i EngineHandoffUnresolvedAlertsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Summary,
+			&i.Status,
+			&i.ServiceName,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const engineHandoffUserNames = `-- name: EngineHandoffUserNames :many
+SELECT
+    id,
+    name
+FROM
+    users
+WHERE
+    id = ANY ($1::uuid[])
+`
+
+type EngineHandoffUserNamesRow struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// Get the names of the outgoing and incoming users of a handoff report.
+func (q *Queries) EngineHandoffUserNames(ctx context.Context, userIds []uuid.UUID) ([]EngineHandoffUserNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, engineHandoffUserNames, pq.Array(userIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EngineHandoffUserNamesRow
+	for rows.Next() {
+		var i EngineHandoffUserNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const engineIsKnownDest = `-- name: EngineIsKnownDest :one
+SELECT
+    EXISTS (
+        SELECT
+        FROM
+            user_contact_methods uc
+        WHERE
+            uc.dest = $1
+            AND uc.disabled = FALSE)
+    OR EXISTS (
+        SELECT
+        FROM
+            notification_channels nc
+        WHERE
+            nc.dest = $1)
+`
+
+// Check if a destination is known in user_contact_methods or notification_channels table.
+func (q *Queries) EngineIsKnownDest(ctx context.Context, dest NullDestV1) (sql.NullBool, error) {
+	row := q.db.QueryRowContext(ctx, engineIsKnownDest, dest)
+	var column_1 sql.NullBool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const fairnessPages = `-- name: FairnessPages :many
+SELECT
+    u.id AS user_id,
+    u.name AS user_name,
+    min(coalesce(om.sent_at, bundle.sent_at))::timestamptz AS sent_at
+FROM
+    outgoing_messages om
+    LEFT JOIN outgoing_messages bundle ON om.last_status = 'bundled'
+        AND bundle.id::text = om.status_details
+    JOIN users u ON u.id = om.user_id
+WHERE
+    om.message_type = 'alert_notification'
+    AND coalesce(om.sent_at, bundle.sent_at) < $1::timestamptz
+    AND (cardinality($2::uuid[]) = 0
+        OR om.user_id IN (
+            SELECT
+                oc.user_id
+            FROM
+                schedule_on_call_users oc
+            WHERE
+                oc.schedule_id = ANY ($2::uuid[])
+                AND tstzrange(oc.start_time, oc.end_time) && tstzrange($3, $1)))
+GROUP BY
+    u.id,
+    om.alert_id
+HAVING
+    min(coalesce(om.sent_at, bundle.sent_at)) >= $3::timestamptz
+ORDER BY
+    min(coalesce(om.sent_at, bundle.sent_at))
+`
+
+type FairnessPagesParams struct {
+	EndTime     time.Time
+	ScheduleIds []uuid.UUID
+	StartTime   interface{}
+}
+
+type FairnessPagesRow struct {
+	UserID   uuid.UUID
+	UserName string
+	SentAt   time.Time
+}
+
+// FairnessPages returns, for each alert a user was notified of, the time the first notification was delivered,
+// if it falls within the given time range. Notifications for the same alert across contact methods, repeats,
+// and bundles count once.
+func (q *Queries) FairnessPages(ctx context.Context, arg FairnessPagesParams) ([]FairnessPagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, fairnessPages, arg.EndTime, pq.Array(arg.ScheduleIds), arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FairnessPagesRow
+	for rows.Next() {
+		var i FairnessPagesRow
+		if err := rows.Scan(&i.UserID, &i.UserName, &i.SentAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const fairnessShifts = `-- name: FairnessShifts :many
+SELECT
+    oc.user_id,
+    u.name AS user_name,
+    sched.time_zone,
+    greatest(oc.start_time, $1::timestamptz)::timestamptz AS start_time,
+    least(coalesce(oc.end_time, now()), $2::timestamptz)::timestamptz AS end_time
+FROM
+    schedule_on_call_users oc
+    JOIN schedules sched ON sched.id = oc.schedule_id
+    JOIN users u ON u.id = oc.user_id
+WHERE
+    tstzrange(oc.start_time, oc.end_time) && tstzrange($1, $2)
+    AND (cardinality($3::uuid[]) = 0
+        OR oc.schedule_id = ANY ($3::uuid[]))
+ORDER BY
+    oc.start_time
+`
+
+type FairnessShiftsParams struct {
+	StartTime   time.Time
+	EndTime     time.Time
+	ScheduleIds []uuid.UUID
+}
+
+type FairnessShiftsRow struct {
+	UserID    uuid.UUID
+	UserName  string
+	TimeZone  string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// FairnessShifts returns schedule shifts overlapping the given time range, clipped to it, along with the schedule time zone.
+func (q *Queries) FairnessShifts(ctx context.Context, arg FairnessShiftsParams) ([]FairnessShiftsRow, error) {
+	rows, err := q.db.QueryContext(ctx, fairnessShifts, arg.StartTime, arg.EndTime, pq.Array(arg.ScheduleIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FairnessShiftsRow
+	for rows.Next() {
+		var i FairnessShiftsRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.UserName,
+			&i.TimeZone,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findManyCalSubByUser = `-- name: FindManyCalSubByUser :many
+SELECT
+    id,
+    NAME,
+    user_id,
+    disabled,
+    schedule_id,
+    service_id,
+    escalation_policy_id,
+    config,
+    last_access
+FROM
+    user_calendar_subscriptions
+WHERE
+    user_id = $1
+`
+
+type FindManyCalSubByUserRow struct {
+	ID                 uuid.UUID
+	Name               string
+	UserID             uuid.UUID
+	Disabled           bool
+	ScheduleID         uuid.NullUUID
+	ServiceID          uuid.NullUUID
+	EscalationPolicyID uuid.NullUUID
+	Config             json.RawMessage
+	LastAccess         sql.NullTime
+}
+
+func (q *Queries) FindManyCalSubByUser(ctx context.Context, userID uuid.UUID) ([]FindManyCalSubByUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, findManyCalSubByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindManyCalSubByUserRow
+	for rows.Next() {
+		var i FindManyCalSubByUserRow
+		if err := rows.Scan(
+			&i.ID,
 			&i.Name,
 			&i.UserID,
 			&i.Disabled,
 			&i.ScheduleID,
+			&i.ServiceID,
+			&i.EscalationPolicyID,
 			&i.Config,
 			&i.LastAccess,
 		); err != nil {
@@ -2286,6 +3325,8 @@ SELECT
     user_id,
     disabled,
     schedule_id,
+    service_id,
+    escalation_policy_id,
     config,
     last_access
 FROM
@@ -2295,13 +3336,15 @@ WHERE
 `
 
 type FindOneCalSubRow struct {
-	ID         uuid.UUID
-	Name       string
-	UserID     uuid.UUID
-	Disabled   bool
-	ScheduleID uuid.UUID
-	Config     json.RawMessage
-	LastAccess sql.NullTime
+	ID                 uuid.UUID
+	Name               string
+	UserID             uuid.UUID
+	Disabled           bool
+	ScheduleID         uuid.NullUUID
+	ServiceID          uuid.NullUUID
+	EscalationPolicyID uuid.NullUUID
+	Config             json.RawMessage
+	LastAccess         sql.NullTime
 }
 
 func (q *Queries) FindOneCalSub(ctx context.Context, id uuid.UUID) (FindOneCalSubRow, error) {
@@ -2313,6 +3356,8 @@ func (q *Queries) FindOneCalSub(ctx context.Context, id uuid.UUID) (FindOneCalSu
 		&i.UserID,
 		&i.Disabled,
 		&i.ScheduleID,
+		&i.ServiceID,
+		&i.EscalationPolicyID,
 		&i.Config,
 		&i.LastAccess,
 	)
@@ -2326,6 +3371,8 @@ SELECT
     user_id,
     disabled,
     schedule_id,
+    service_id,
+    escalation_policy_id,
     config,
     last_access
 FROM
@@ -2336,13 +3383,15 @@ FOR UPDATE
 `
 
 type FindOneCalSubForUpdateRow struct {
-	ID         uuid.UUID
-	Name       string
-	UserID     uuid.UUID
-	Disabled   bool
-	ScheduleID uuid.UUID
-	Config     json.RawMessage
-	LastAccess sql.NullTime
+	ID                 uuid.UUID
+	Name               string
+	UserID             uuid.UUID
+	Disabled           bool
+	ScheduleID         uuid.NullUUID
+	ServiceID          uuid.NullUUID
+	EscalationPolicyID uuid.NullUUID
+	Config             json.RawMessage
+	LastAccess         sql.NullTime
 }
 
 func (q *Queries) FindOneCalSubForUpdate(ctx context.Context, id uuid.UUID) (FindOneCalSubForUpdateRow, error) {
@@ -2354,6 +3403,8 @@ func (q *Queries) FindOneCalSubForUpdate(ctx context.Context, id uuid.UUID) (Fin
 		&i.UserID,
 		&i.Disabled,
 		&i.ScheduleID,
+		&i.ServiceID,
+		&i.EscalationPolicyID,
 		&i.Config,
 		&i.LastAccess,
 	)
@@ -2574,61 +3625,282 @@ func (q *Queries) HBDelete(ctx context.Context, id []uuid.UUID) error {
 	return err
 }
 
-const hBInsert = `-- name: HBInsert :exec
-INSERT INTO heartbeat_monitors(id, name, service_id, heartbeat_interval, additional_details, muted)
-    VALUES ($1, $2, $3, $4, $5, $6)
+const hBInsert = `-- name: HBInsert :exec
+INSERT INTO heartbeat_monitors(id, name, service_id, heartbeat_interval, additional_details, muted)
+    VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type HBInsertParams struct {
+	ID                uuid.UUID
+	Name              string
+	ServiceID         uuid.UUID
+	HeartbeatInterval sqlutil.Interval
+	AdditionalDetails sql.NullString
+	Muted             sql.NullString
+}
+
+// HBInsert will insert a new heartbeat record.
+func (q *Queries) HBInsert(ctx context.Context, arg HBInsertParams) error {
+	_, err := q.db.ExecContext(ctx, hBInsert,
+		arg.ID,
+		arg.Name,
+		arg.ServiceID,
+		arg.HeartbeatInterval,
+		arg.AdditionalDetails,
+		arg.Muted,
+	)
+	return err
+}
+
+const hBManyByID = `-- name: HBManyByID :many
+SELECT
+    additional_details, heartbeat_interval, id, last_heartbeat, last_state, muted, name, service_id
+FROM
+    heartbeat_monitors
+WHERE
+    id = ANY ($1::uuid[])
+`
+
+// HBManyByID returns multiple heartbeat records by their IDs.
+func (q *Queries) HBManyByID(ctx context.Context, ids []uuid.UUID) ([]HeartbeatMonitor, error) {
+	rows, err := q.db.QueryContext(ctx, hBManyByID, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []HeartbeatMonitor
+	for rows.Next() {
+		var i HeartbeatMonitor
+		if err := rows.Scan(
+			&i.AdditionalDetails,
+			&i.HeartbeatInterval,
+			&i.ID,
+			&i.LastHeartbeat,
+			&i.LastState,
+			&i.Muted,
+			&i.Name,
+			&i.ServiceID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const hBRecordHeartbeat = `-- name: HBRecordHeartbeat :exec
+UPDATE
+    heartbeat_monitors
+SET
+    last_heartbeat = now()
+WHERE
+    id = $1
+`
+
+// HBRecordHeartbeat updates the last heartbeat time for a monitor.
+func (q *Queries) HBRecordHeartbeat(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, hBRecordHeartbeat, id)
+	return err
+}
+
+const hBUpdate = `-- name: HBUpdate :exec
+UPDATE
+    heartbeat_monitors
+SET
+    name = $1,
+    heartbeat_interval = $2,
+    additional_details = $3,
+    muted = $4
+WHERE
+    id = $5
+`
+
+type HBUpdateParams struct {
+	Name              string
+	HeartbeatInterval sqlutil.Interval
+	AdditionalDetails sql.NullString
+	Muted             sql.NullString
+	ID                uuid.UUID
+}
+
+// HBUpdate will update a heartbeat record.
+func (q *Queries) HBUpdate(ctx context.Context, arg HBUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, hBUpdate,
+		arg.Name,
+		arg.HeartbeatInterval,
+		arg.AdditionalDetails,
+		arg.Muted,
+		arg.ID,
+	)
+	return err
+}
+
+const holidayCalendarDelete = `-- name: HolidayCalendarDelete :execrows
+DELETE FROM holiday_calendars
+WHERE id = $1
+`
+
+func (q *Queries) HolidayCalendarDelete(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, holidayCalendarDelete, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const holidayCalendarFind = `-- name: HolidayCalendarFind :one
+SELECT
+    created_at, description, id, name
+FROM
+    holiday_calendars
+WHERE
+    id = $1
+`
+
+func (q *Queries) HolidayCalendarFind(ctx context.Context, id uuid.UUID) (HolidayCalendar, error) {
+	row := q.db.QueryRowContext(ctx, holidayCalendarFind, id)
+	var i HolidayCalendar
+	err := row.Scan(
+		&i.CreatedAt,
+		&i.Description,
+		&i.ID,
+		&i.Name,
+	)
+	return i, err
+}
+
+const holidayCalendarFindAll = `-- name: HolidayCalendarFindAll :many
+SELECT
+    created_at, description, id, name
+FROM
+    holiday_calendars
+ORDER BY
+    lower(name)
+`
+
+func (q *Queries) HolidayCalendarFindAll(ctx context.Context) ([]HolidayCalendar, error) {
+	rows, err := q.db.QueryContext(ctx, holidayCalendarFindAll)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []HolidayCalendar
+	for rows.Next() {
+		var i HolidayCalendar
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.Description,
+			&i.ID,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const holidayCalendarInsert = `-- name: HolidayCalendarInsert :exec
+INSERT INTO holiday_calendars(id, name, description)
+    VALUES ($1, $2, $3)
+`
+
+type HolidayCalendarInsertParams struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+}
+
+func (q *Queries) HolidayCalendarInsert(ctx context.Context, arg HolidayCalendarInsertParams) error {
+	_, err := q.db.ExecContext(ctx, holidayCalendarInsert, arg.ID, arg.Name, arg.Description)
+	return err
+}
+
+const holidayCalendarUpdate = `-- name: HolidayCalendarUpdate :execrows
+UPDATE
+    holiday_calendars
+SET
+    name = $2,
+    description = $3
+WHERE
+    id = $1
+`
+
+type HolidayCalendarUpdateParams struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+}
+
+func (q *Queries) HolidayCalendarUpdate(ctx context.Context, arg HolidayCalendarUpdateParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, holidayCalendarUpdate, arg.ID, arg.Name, arg.Description)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const holidayDelete = `-- name: HolidayDelete :execrows
+DELETE FROM holidays
+WHERE id = $1
 `
 
-type HBInsertParams struct {
-	ID                uuid.UUID
-	Name              string
-	ServiceID         uuid.UUID
-	HeartbeatInterval sqlutil.Interval
-	AdditionalDetails sql.NullString
-	Muted             sql.NullString
-}
-
-// HBInsert will insert a new heartbeat record.
-func (q *Queries) HBInsert(ctx context.Context, arg HBInsertParams) error {
-	_, err := q.db.ExecContext(ctx, hBInsert,
-		arg.ID,
-		arg.Name,
-		arg.ServiceID,
-		arg.HeartbeatInterval,
-		arg.AdditionalDetails,
-		arg.Muted,
-	)
-	return err
+func (q *Queries) HolidayDelete(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, holidayDelete, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const hBManyByID = `-- name: HBManyByID :many
+const holidayFindByCalendars = `-- name: HolidayFindByCalendars :many
 SELECT
-    additional_details, heartbeat_interval, id, last_heartbeat, last_state, muted, name, service_id
+    calendar_id, end_date, id, name, start_date
 FROM
-    heartbeat_monitors
+    holidays
 WHERE
-    id = ANY ($1::uuid[])
+    calendar_id = ANY ($1::uuid[])
+    AND end_date >= $2::date
+    AND start_date <= $3::date
+ORDER BY
+    start_date,
+    name
 `
 
-// HBManyByID returns multiple heartbeat records by their IDs.
-func (q *Queries) HBManyByID(ctx context.Context, ids []uuid.UUID) ([]HeartbeatMonitor, error) {
-	rows, err := q.db.QueryContext(ctx, hBManyByID, pq.Array(ids))
+type HolidayFindByCalendarsParams struct {
+	CalendarIds []uuid.UUID
+	StartDate   time.Time
+	EndDate     time.Time
+}
+
+// HolidayFindByCalendars returns holidays from any of the calendars that overlap the given dates.
+func (q *Queries) HolidayFindByCalendars(ctx context.Context, arg HolidayFindByCalendarsParams) ([]Holiday, error) {
+	rows, err := q.db.QueryContext(ctx, holidayFindByCalendars, pq.Array(arg.CalendarIds), arg.StartDate, arg.EndDate)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []HeartbeatMonitor
+	var items []Holiday
 	for rows.Next() {
-		var i HeartbeatMonitor
+		var i Holiday
 		if err := rows.Scan(
-			&i.AdditionalDetails,
-			&i.HeartbeatInterval,
+			&i.CalendarID,
+			&i.EndDate,
 			&i.ID,
-			&i.LastHeartbeat,
-			&i.LastState,
-			&i.Muted,
 			&i.Name,
-			&i.ServiceID,
+			&i.StartDate,
 		); err != nil {
 			return nil, err
 		}
@@ -2643,50 +3915,43 @@ func (q *Queries) HBManyByID(ctx context.Context, ids []uuid.UUID) ([]HeartbeatM
 	return items, nil
 }
 
-const hBRecordHeartbeat = `-- name: HBRecordHeartbeat :exec
-UPDATE
-    heartbeat_monitors
-SET
-    last_heartbeat = now()
-WHERE
-    id = $1
-`
-
-// HBRecordHeartbeat updates the last heartbeat time for a monitor.
-func (q *Queries) HBRecordHeartbeat(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, hBRecordHeartbeat, id)
-	return err
-}
-
-const hBUpdate = `-- name: HBUpdate :exec
-UPDATE
-    heartbeat_monitors
-SET
-    name = $1,
-    heartbeat_interval = $2,
-    additional_details = $3,
-    muted = $4
-WHERE
-    id = $5
+const holidayInsert = `-- name: HolidayInsert :execrows
+INSERT INTO holidays(id, calendar_id, name, start_date, end_date)
+    VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (calendar_id, start_date, name)
+    DO NOTHING
 `
 
-type HBUpdateParams struct {
-	Name              string
-	HeartbeatInterval sqlutil.Interval
-	AdditionalDetails sql.NullString
-	Muted             sql.NullString
-	ID                uuid.UUID
+type HolidayInsertParams struct {
+	ID         uuid.UUID
+	CalendarID uuid.UUID
+	Name       string
+	StartDate  time.Time
+	EndDate    time.Time
 }
 
-// HBUpdate will update a heartbeat record.
-func (q *Queries) HBUpdate(ctx context.Context, arg HBUpdateParams) error {
-	_, err := q.db.ExecContext(ctx, hBUpdate,
-		arg.Name,
-		arg.HeartbeatInterval,
-		arg.AdditionalDetails,
-		arg.Muted,
+// HolidayInsert adds a holiday, ignoring duplicates with the same name and start date.
+func (q *Queries) HolidayInsert(ctx context.Context, arg HolidayInsertParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, holidayInsert,
 		arg.ID,
+		arg.CalendarID,
+		arg.Name,
+		arg.StartDate,
+		arg.EndDate,
 	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const intKeyClearWebhookFailures = `-- name: IntKeyClearWebhookFailures :exec
+DELETE FROM uik_webhook_failures
+WHERE key_id = $1
+`
+
+func (q *Queries) IntKeyClearWebhookFailures(ctx context.Context, keyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, intKeyClearWebhookFailures, keyID)
 	return err
 }
 
@@ -2833,6 +4098,55 @@ func (q *Queries) IntKeyFindOne(ctx context.Context, id uuid.UUID) (IntKeyFindOn
 	return i, err
 }
 
+const intKeyFindRequest = `-- name: IntKeyFindRequest :one
+SELECT
+    id,
+    received_at,
+    body,
+    body_truncated,
+    query,
+    headers,
+    user_agent,
+    remote_addr
+FROM
+    uik_request_log
+WHERE
+    key_id = $1
+    AND id = $2
+`
+
+type IntKeyFindRequestParams struct {
+	KeyID uuid.UUID
+	ID    uuid.UUID
+}
+
+type IntKeyFindRequestRow struct {
+	ID            uuid.UUID
+	ReceivedAt    time.Time
+	Body          []byte
+	BodyTruncated bool
+	Query         string
+	Headers       json.RawMessage
+	UserAgent     string
+	RemoteAddr    string
+}
+
+func (q *Queries) IntKeyFindRequest(ctx context.Context, arg IntKeyFindRequestParams) (IntKeyFindRequestRow, error) {
+	row := q.db.QueryRowContext(ctx, intKeyFindRequest, arg.KeyID, arg.ID)
+	var i IntKeyFindRequestRow
+	err := row.Scan(
+		&i.ID,
+		&i.ReceivedAt,
+		&i.Body,
+		&i.BodyTruncated,
+		&i.Query,
+		&i.Headers,
+		&i.UserAgent,
+		&i.RemoteAddr,
+	)
+	return i, err
+}
+
 const intKeyGetConfig = `-- name: IntKeyGetConfig :one
 SELECT
     config
@@ -2904,6 +4218,17 @@ func (q *Queries) IntKeyInsertSignalMessage(ctx context.Context, arg IntKeyInser
 	return err
 }
 
+const intKeyNotifyConfigUpdate = `-- name: IntKeyNotifyConfigUpdate :exec
+SELECT
+    pg_notify('/goalert/uik-config-update', $1::text)
+`
+
+// Notify all instances that the config of a key changed, once the transaction commits.
+func (q *Queries) IntKeyNotifyConfigUpdate(ctx context.Context, keyID string) error {
+	_, err := q.db.ExecContext(ctx, intKeyNotifyConfigUpdate, keyID)
+	return err
+}
+
 const intKeyPromoteSecondary = `-- name: IntKeyPromoteSecondary :one
 UPDATE
     uik_config
@@ -2925,6 +4250,136 @@ func (q *Queries) IntKeyPromoteSecondary(ctx context.Context, id uuid.UUID) (sql
 	return primary_token_hint, err
 }
 
+const intKeyRecentRequests = `-- name: IntKeyRecentRequests :many
+SELECT
+    id,
+    received_at,
+    body,
+    body_truncated,
+    query,
+    headers,
+    user_agent,
+    remote_addr
+FROM
+    uik_request_log
+WHERE
+    key_id = $1
+ORDER BY
+    received_at DESC,
+    id DESC
+LIMIT $2
+`
+
+type IntKeyRecentRequestsParams struct {
+	KeyID       uuid.UUID
+	MaxRequests int32
+}
+
+type IntKeyRecentRequestsRow struct {
+	ID            uuid.UUID
+	ReceivedAt    time.Time
+	Body          []byte
+	BodyTruncated bool
+	Query         string
+	Headers       json.RawMessage
+	UserAgent     string
+	RemoteAddr    string
+}
+
+func (q *Queries) IntKeyRecentRequests(ctx context.Context, arg IntKeyRecentRequestsParams) ([]IntKeyRecentRequestsRow, error) {
+	rows, err := q.db.QueryContext(ctx, intKeyRecentRequests, arg.KeyID, arg.MaxRequests)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IntKeyRecentRequestsRow
+	for rows.Next() {
+		var i IntKeyRecentRequestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReceivedAt,
+			&i.Body,
+			&i.BodyTruncated,
+			&i.Query,
+			&i.Headers,
+			&i.UserAgent,
+			&i.RemoteAddr,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const intKeyRecordRequest = `-- name: IntKeyRecordRequest :exec
+INSERT INTO uik_request_log(id, key_id, received_at, body, body_truncated, query, headers, user_agent, remote_addr)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+
+type IntKeyRecordRequestParams struct {
+	ID            uuid.UUID
+	KeyID         uuid.UUID
+	ReceivedAt    time.Time
+	Body          []byte
+	BodyTruncated bool
+	Query         string
+	Headers       json.RawMessage
+	UserAgent     string
+	RemoteAddr    string
+}
+
+func (q *Queries) IntKeyRecordRequest(ctx context.Context, arg IntKeyRecordRequestParams) error {
+	_, err := q.db.ExecContext(ctx, intKeyRecordRequest,
+		arg.ID,
+		arg.KeyID,
+		arg.ReceivedAt,
+		arg.Body,
+		arg.BodyTruncated,
+		arg.Query,
+		arg.Headers,
+		arg.UserAgent,
+		arg.RemoteAddr,
+	)
+	return err
+}
+
+const intKeyRecordWebhookFailure = `-- name: IntKeyRecordWebhookFailure :exec
+INSERT INTO uik_webhook_failures(id, key_id, url, content_type, body, attempts, status_code, error)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type IntKeyRecordWebhookFailureParams struct {
+	ID          uuid.UUID
+	KeyID       uuid.UUID
+	Url         string
+	ContentType string
+	Body        string
+	Attempts    int32
+	StatusCode  int32
+	Error       string
+}
+
+func (q *Queries) IntKeyRecordWebhookFailure(ctx context.Context, arg IntKeyRecordWebhookFailureParams) error {
+	_, err := q.db.ExecContext(ctx, intKeyRecordWebhookFailure,
+		arg.ID,
+		arg.KeyID,
+		arg.Url,
+		arg.ContentType,
+		arg.Body,
+		arg.Attempts,
+		arg.StatusCode,
+		arg.Error,
+	)
+	return err
+}
+
 const intKeySetConfig = `-- name: IntKeySetConfig :exec
 INSERT INTO uik_config(id, config)
     VALUES ($1, $2)
@@ -3018,6 +4473,33 @@ func (q *Queries) IntKeyTokenHints(ctx context.Context, id uuid.UUID) (IntKeyTok
 	return i, err
 }
 
+const intKeyTrimWebhookFailures = `-- name: IntKeyTrimWebhookFailures :exec
+DELETE FROM uik_webhook_failures f
+WHERE f.key_id = $1
+    AND f.id NOT IN (
+        SELECT
+            r.id
+        FROM
+            uik_webhook_failures r
+        WHERE
+            r.key_id = $1
+        ORDER BY
+            r.failed_at DESC,
+            r.id DESC
+        LIMIT $2)
+`
+
+type IntKeyTrimWebhookFailuresParams struct {
+	KeyID       uuid.UUID
+	MaxFailures int32
+}
+
+// Delete all but the most recent webhook failures of a key.
+func (q *Queries) IntKeyTrimWebhookFailures(ctx context.Context, arg IntKeyTrimWebhookFailuresParams) error {
+	_, err := q.db.ExecContext(ctx, intKeyTrimWebhookFailures, arg.KeyID, arg.MaxFailures)
+	return err
+}
+
 const intKeyUIKValidateService = `-- name: IntKeyUIKValidateService :one
 SELECT
     k.service_id
@@ -3043,6 +4525,68 @@ func (q *Queries) IntKeyUIKValidateService(ctx context.Context, arg IntKeyUIKVal
 	return service_id, err
 }
 
+const intKeyWebhookFailures = `-- name: IntKeyWebhookFailures :many
+SELECT
+    id,
+    failed_at,
+    url,
+    content_type,
+    body,
+    attempts,
+    status_code,
+    error
+FROM
+    uik_webhook_failures
+WHERE
+    key_id = $1
+ORDER BY
+    failed_at DESC,
+    id DESC
+`
+
+type IntKeyWebhookFailuresRow struct {
+	ID          uuid.UUID
+	FailedAt    time.Time
+	Url         string
+	ContentType string
+	Body        string
+	Attempts    int32
+	StatusCode  int32
+	Error       string
+}
+
+func (q *Queries) IntKeyWebhookFailures(ctx context.Context, keyID uuid.UUID) ([]IntKeyWebhookFailuresRow, error) {
+	rows, err := q.db.QueryContext(ctx, intKeyWebhookFailures, keyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IntKeyWebhookFailuresRow
+	for rows.Next() {
+		var i IntKeyWebhookFailuresRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FailedAt,
+			&i.Url,
+			&i.ContentType,
+			&i.Body,
+			&i.Attempts,
+			&i.StatusCode,
+			&i.Error,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const keyring_GetConfigPayloads = `-- name: Keyring_GetConfigPayloads :many
 SELECT
     id,
@@ -3064,8 +4608,46 @@ func (q *Queries) Keyring_GetConfigPayloads(ctx context.Context) ([]Keyring_GetC
 	defer rows.Close()
 	var items []Keyring_GetConfigPayloadsRow
 	for rows.Next() {
-		var i Keyring_GetConfigPayloadsRow
-		if err := rows.Scan(&i.ID, &i.Data); err != nil {
+		var i Keyring_GetConfigPayloadsRow
+		if err := rows.Scan(&i.ID, &i.Data); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const keyring_GetKeyringSecrets = `-- name: Keyring_GetKeyringSecrets :many
+SELECT
+    id,
+    signing_key,
+    next_key
+FROM
+    keyring
+`
+
+type Keyring_GetKeyringSecretsRow struct {
+	ID         string
+	SigningKey []byte
+	NextKey    []byte
+}
+
+func (q *Queries) Keyring_GetKeyringSecrets(ctx context.Context) ([]Keyring_GetKeyringSecretsRow, error) {
+	rows, err := q.db.QueryContext(ctx, keyring_GetKeyringSecrets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Keyring_GetKeyringSecretsRow
+	for rows.Next() {
+		var i Keyring_GetKeyringSecretsRow
+		if err := rows.Scan(&i.ID, &i.SigningKey, &i.NextKey); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -3079,31 +4661,29 @@ func (q *Queries) Keyring_GetConfigPayloads(ctx context.Context) ([]Keyring_GetC
 	return items, nil
 }
 
-const keyring_GetKeyringSecrets = `-- name: Keyring_GetKeyringSecrets :many
+const keyring_GetSAMLKeys = `-- name: Keyring_GetSAMLKeys :many
 SELECT
     id,
-    signing_key,
-    next_key
+    private_key
 FROM
-    keyring
+    auth_saml_keys
 `
 
-type Keyring_GetKeyringSecretsRow struct {
+type Keyring_GetSAMLKeysRow struct {
 	ID         string
-	SigningKey []byte
-	NextKey    []byte
+	PrivateKey []byte
 }
 
-func (q *Queries) Keyring_GetKeyringSecrets(ctx context.Context) ([]Keyring_GetKeyringSecretsRow, error) {
-	rows, err := q.db.QueryContext(ctx, keyring_GetKeyringSecrets)
+func (q *Queries) Keyring_GetSAMLKeys(ctx context.Context) ([]Keyring_GetSAMLKeysRow, error) {
+	rows, err := q.db.QueryContext(ctx, keyring_GetSAMLKeys)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Keyring_GetKeyringSecretsRow
+	var items []Keyring_GetSAMLKeysRow
 	for rows.Next() {
-		var i Keyring_GetKeyringSecretsRow
-		if err := rows.Scan(&i.ID, &i.SigningKey, &i.NextKey); err != nil {
+		var i Keyring_GetSAMLKeysRow
+		if err := rows.Scan(&i.ID, &i.PrivateKey); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -3137,6 +4717,16 @@ func (q *Queries) Keyring_LockKeyrings(ctx context.Context) error {
 	return err
 }
 
+const keyring_LockSAMLKeys = `-- name: Keyring_LockSAMLKeys :exec
+LOCK TABLE auth_saml_keys IN ACCESS EXCLUSIVE MODE
+`
+
+// Locks the auth_saml_keys table so no new keys can be created.
+func (q *Queries) Keyring_LockSAMLKeys(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, keyring_LockSAMLKeys)
+	return err
+}
+
 const keyring_UpdateConfigPayload = `-- name: Keyring_UpdateConfigPayload :exec
 UPDATE
     config
@@ -3177,6 +4767,25 @@ func (q *Queries) Keyring_UpdateKeyringSecrets(ctx context.Context, arg Keyring_
 	return err
 }
 
+const keyring_UpdateSAMLKey = `-- name: Keyring_UpdateSAMLKey :exec
+UPDATE
+    auth_saml_keys
+SET
+    private_key = $1
+WHERE
+    id = $2
+`
+
+type Keyring_UpdateSAMLKeyParams struct {
+	PrivateKey []byte
+	ID         string
+}
+
+func (q *Queries) Keyring_UpdateSAMLKey(ctx context.Context, arg Keyring_UpdateSAMLKeyParams) error {
+	_, err := q.db.ExecContext(ctx, keyring_UpdateSAMLKey, arg.PrivateKey, arg.ID)
+	return err
+}
+
 const labelDeleteKeyByTarget = `-- name: LabelDeleteKeyByTarget :exec
 DELETE FROM labels
 WHERE key = $1
@@ -3863,6 +5472,8 @@ SELECT
     msg.sent_at,
     msg.status_alert_ids,
     msg.schedule_id,
+    msg.shift_swap_request_id,
+    msg.schedule_handoff_report_id,
     alerts.status AS alert_status
 FROM
     outgoing_messages msg
@@ -3879,21 +5490,23 @@ WHERE
 `
 
 type MessageMgrGetPendingRow struct {
-	ID                     uuid.UUID
-	MessageType            EnumOutgoingMessagesType
-	CmID                   uuid.NullUUID
-	ChanID                 uuid.NullUUID
-	Dest                   NullDestV1
-	AlertID                sql.NullInt64
-	AlertLogID             sql.NullInt64
-	UserVerificationCodeID uuid.NullUUID
-	UserID                 uuid.NullUUID
-	ServiceID              uuid.NullUUID
-	CreatedAt              time.Time
-	SentAt                 sql.NullTime
-	StatusAlertIds         []int64
-	ScheduleID             uuid.NullUUID
-	AlertStatus            NullEnumAlertStatus
+	ID                      uuid.UUID
+	MessageType             EnumOutgoingMessagesType
+	CmID                    uuid.NullUUID
+	ChanID                  uuid.NullUUID
+	Dest                    NullDestV1
+	AlertID                 sql.NullInt64
+	AlertLogID              sql.NullInt64
+	UserVerificationCodeID  uuid.NullUUID
+	UserID                  uuid.NullUUID
+	ServiceID               uuid.NullUUID
+	CreatedAt               time.Time
+	SentAt                  sql.NullTime
+	StatusAlertIds          []int64
+	ScheduleID              uuid.NullUUID
+	ShiftSwapRequestID      uuid.NullUUID
+	ScheduleHandoffReportID uuid.NullUUID
+	AlertStatus             NullEnumAlertStatus
 }
 
 func (q *Queries) MessageMgrGetPending(ctx context.Context, sentAt sql.NullTime) ([]MessageMgrGetPendingRow, error) {
@@ -3920,6 +5533,8 @@ func (q *Queries) MessageMgrGetPending(ctx context.Context, sentAt sql.NullTime)
 			&i.SentAt,
 			pq.Array(&i.StatusAlertIds),
 			&i.ScheduleID,
+			&i.ShiftSwapRequestID,
+			&i.ScheduleHandoffReportID,
 			&i.AlertStatus,
 		); err != nil {
 			return nil, err
@@ -3937,7 +5552,7 @@ func (q *Queries) MessageMgrGetPending(ctx context.Context, sentAt sql.NullTime)
 
 const nfyLastMessageStatus = `-- name: NfyLastMessageStatus :one
 SELECT
-    om.alert_id, om.alert_log_id, om.channel_id, om.contact_method_id, om.created_at, om.cycle_id, om.escalation_policy_id, om.fired_at, om.id, om.last_status, om.last_status_at, om.message_type, om.next_retry_at, om.provider_msg_id, om.provider_seq, om.retry_count, om.schedule_id, om.sending_deadline, om.sent_at, om.service_id, om.src_value, om.status_alert_ids, om.status_details, om.user_id, om.user_verification_code_id,
+    om.alert_id, om.alert_log_id, om.channel_id, om.contact_method_id, om.created_at, om.cycle_id, om.escalation_policy_id, om.fired_at, om.id, om.last_status, om.last_status_at, om.message_type, om.next_retry_at, om.provider_msg_id, om.provider_seq, om.retry_count, om.schedule_handoff_report_id, om.schedule_id, om.sending_deadline, om.sent_at, om.service_id, om.shift_swap_request_id, om.src_value, om.status_alert_ids, om.status_details, om.user_id, om.user_verification_code_id,
     cm.dest AS cm_dest,
     ch.dest AS ch_dest
 FROM
@@ -3982,10 +5597,12 @@ func (q *Queries) NfyLastMessageStatus(ctx context.Context, arg NfyLastMessageSt
 		&i.OutgoingMessage.ProviderMsgID,
 		&i.OutgoingMessage.ProviderSeq,
 		&i.OutgoingMessage.RetryCount,
+		&i.OutgoingMessage.ScheduleHandoffReportID,
 		&i.OutgoingMessage.ScheduleID,
 		&i.OutgoingMessage.SendingDeadline,
 		&i.OutgoingMessage.SentAt,
 		&i.OutgoingMessage.ServiceID,
+		&i.OutgoingMessage.ShiftSwapRequestID,
 		&i.OutgoingMessage.SrcValue,
 		pq.Array(&i.OutgoingMessage.StatusAlertIds),
 		&i.OutgoingMessage.StatusDetails,
@@ -3999,7 +5616,7 @@ func (q *Queries) NfyLastMessageStatus(ctx context.Context, arg NfyLastMessageSt
 
 const nfyManyMessageStatus = `-- name: NfyManyMessageStatus :many
 SELECT
-    om.alert_id, om.alert_log_id, om.channel_id, om.contact_method_id, om.created_at, om.cycle_id, om.escalation_policy_id, om.fired_at, om.id, om.last_status, om.last_status_at, om.message_type, om.next_retry_at, om.provider_msg_id, om.provider_seq, om.retry_count, om.schedule_id, om.sending_deadline, om.sent_at, om.service_id, om.src_value, om.status_alert_ids, om.status_details, om.user_id, om.user_verification_code_id,
+    om.alert_id, om.alert_log_id, om.channel_id, om.contact_method_id, om.created_at, om.cycle_id, om.escalation_policy_id, om.fired_at, om.id, om.last_status, om.last_status_at, om.message_type, om.next_retry_at, om.provider_msg_id, om.provider_seq, om.retry_count, om.schedule_handoff_report_id, om.schedule_id, om.sending_deadline, om.sent_at, om.service_id, om.shift_swap_request_id, om.src_value, om.status_alert_ids, om.status_details, om.user_id, om.user_verification_code_id,
     cm.dest AS cm_dest,
     ch.dest AS ch_dest
 FROM
@@ -4042,10 +5659,12 @@ func (q *Queries) NfyManyMessageStatus(ctx context.Context, dollar_1 []uuid.UUID
 			&i.OutgoingMessage.ProviderMsgID,
 			&i.OutgoingMessage.ProviderSeq,
 			&i.OutgoingMessage.RetryCount,
+			&i.OutgoingMessage.ScheduleHandoffReportID,
 			&i.OutgoingMessage.ScheduleID,
 			&i.OutgoingMessage.SendingDeadline,
 			&i.OutgoingMessage.SentAt,
 			&i.OutgoingMessage.ServiceID,
+			&i.OutgoingMessage.ShiftSwapRequestID,
 			&i.OutgoingMessage.SrcValue,
 			pq.Array(&i.OutgoingMessage.StatusAlertIds),
 			&i.OutgoingMessage.StatusDetails,
@@ -4069,7 +5688,7 @@ func (q *Queries) NfyManyMessageStatus(ctx context.Context, dollar_1 []uuid.UUID
 
 const nfyOriginalMessageStatus = `-- name: NfyOriginalMessageStatus :one
 SELECT
-    om.alert_id, om.alert_log_id, om.channel_id, om.contact_method_id, om.created_at, om.cycle_id, om.escalation_policy_id, om.fired_at, om.id, om.last_status, om.last_status_at, om.message_type, om.next_retry_at, om.provider_msg_id, om.provider_seq, om.retry_count, om.schedule_id, om.sending_deadline, om.sent_at, om.service_id, om.src_value, om.status_alert_ids, om.status_details, om.user_id, om.user_verification_code_id,
+    om.alert_id, om.alert_log_id, om.channel_id, om.contact_method_id, om.created_at, om.cycle_id, om.escalation_policy_id, om.fired_at, om.id, om.last_status, om.last_status_at, om.message_type, om.next_retry_at, om.provider_msg_id, om.provider_seq, om.retry_count, om.schedule_handoff_report_id, om.schedule_id, om.sending_deadline, om.sent_at, om.service_id, om.shift_swap_request_id, om.src_value, om.status_alert_ids, om.status_details, om.user_id, om.user_verification_code_id,
     cm.dest AS cm_dest,
     ch.dest AS ch_dest
 FROM
@@ -4118,10 +5737,12 @@ func (q *Queries) NfyOriginalMessageStatus(ctx context.Context, arg NfyOriginalM
 		&i.OutgoingMessage.ProviderMsgID,
 		&i.OutgoingMessage.ProviderSeq,
 		&i.OutgoingMessage.RetryCount,
+		&i.OutgoingMessage.ScheduleHandoffReportID,
 		&i.OutgoingMessage.ScheduleID,
 		&i.OutgoingMessage.SendingDeadline,
 		&i.OutgoingMessage.SentAt,
 		&i.OutgoingMessage.ServiceID,
+		&i.OutgoingMessage.ShiftSwapRequestID,
 		&i.OutgoingMessage.SrcValue,
 		pq.Array(&i.OutgoingMessage.StatusAlertIds),
 		&i.OutgoingMessage.StatusDetails,
@@ -4257,44 +5878,442 @@ const notifChanLock = `-- name: NotifChanLock :exec
 LOCK notification_channels IN SHARE ROW EXCLUSIVE MODE
 `
 
-func (q *Queries) NotifChanLock(ctx context.Context) error {
-	_, err := q.db.ExecContext(ctx, notifChanLock)
+func (q *Queries) NotifChanLock(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, notifChanLock)
+	return err
+}
+
+const notifChanUpsertDest = `-- name: NotifChanUpsertDest :one
+INSERT INTO notification_channels(id, dest, name)
+    VALUES ($1, $2, $3)
+ON CONFLICT (dest)
+    DO UPDATE SET
+        name = $3
+    RETURNING
+        id
+`
+
+type NotifChanUpsertDestParams struct {
+	ID   uuid.UUID
+	Dest NullDestV1
+	Name string
+}
+
+// NotifChanUpsertDest will insert a new destination if it does not exist, or updating it's name if it does.
+func (q *Queries) NotifChanUpsertDest(ctx context.Context, arg NotifChanUpsertDestParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, notifChanUpsertDest, arg.ID, arg.Dest, arg.Name)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const now = `-- name: Now :one
+SELECT now()::timestamptz
+`
+
+func (q *Queries) Now(ctx context.Context) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, now)
+	var column_1 time.Time
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const oAuthClientDelete = `-- name: OAuthClientDelete :exec
+UPDATE
+    oauth_clients
+SET
+    deleted_at = now()
+WHERE
+    id = $1
+`
+
+func (q *Queries) OAuthClientDelete(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, oAuthClientDelete, id)
+	return err
+}
+
+const oAuthClientFind = `-- name: OAuthClientFind :one
+SELECT
+    created_at, created_by, deleted_at, description, id, name, redirect_uris, secret_hash
+FROM
+    oauth_clients
+WHERE
+    id = $1
+    AND deleted_at IS NULL
+`
+
+func (q *Queries) OAuthClientFind(ctx context.Context, id uuid.UUID) (OauthClient, error) {
+	row := q.db.QueryRowContext(ctx, oAuthClientFind, id)
+	var i OauthClient
+	err := row.Scan(
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.DeletedAt,
+		&i.Description,
+		&i.ID,
+		&i.Name,
+		pq.Array(&i.RedirectUris),
+		&i.SecretHash,
+	)
+	return i, err
+}
+
+const oAuthClientInsert = `-- name: OAuthClientInsert :exec
+INSERT INTO oauth_clients(id, name, description, redirect_uris, secret_hash, created_by)
+    VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type OAuthClientInsertParams struct {
+	ID           uuid.UUID
+	Name         string
+	Description  string
+	RedirectUris []string
+	SecretHash   []byte
+	CreatedBy    uuid.NullUUID
+}
+
+func (q *Queries) OAuthClientInsert(ctx context.Context, arg OAuthClientInsertParams) error {
+	_, err := q.db.ExecContext(ctx, oAuthClientInsert,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		pq.Array(arg.RedirectUris),
+		arg.SecretHash,
+		arg.CreatedBy,
+	)
+	return err
+}
+
+const oAuthClientList = `-- name: OAuthClientList :many
+SELECT
+    created_at, created_by, deleted_at, description, id, name, redirect_uris, secret_hash
+FROM
+    oauth_clients
+WHERE
+    deleted_at IS NULL
+ORDER BY
+    name
+`
+
+func (q *Queries) OAuthClientList(ctx context.Context) ([]OauthClient, error) {
+	rows, err := q.db.QueryContext(ctx, oAuthClientList)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OauthClient
+	for rows.Next() {
+		var i OauthClient
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.DeletedAt,
+			&i.Description,
+			&i.ID,
+			&i.Name,
+			pq.Array(&i.RedirectUris),
+			&i.SecretHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const oAuthCodeConsume = `-- name: OAuthCodeConsume :one
+DELETE FROM oauth_auth_codes
+WHERE code_hash = $1
+RETURNING
+    client_id, code_challenge, code_hash, expires_at, redirect_uri, scopes, user_id
+`
+
+// OAuthCodeConsume deletes and returns an authorization code, ensuring it can only be used once.
+func (q *Queries) OAuthCodeConsume(ctx context.Context, codeHash []byte) (OauthAuthCode, error) {
+	row := q.db.QueryRowContext(ctx, oAuthCodeConsume, codeHash)
+	var i OauthAuthCode
+	err := row.Scan(
+		&i.ClientID,
+		&i.CodeChallenge,
+		&i.CodeHash,
+		&i.ExpiresAt,
+		&i.RedirectUri,
+		pq.Array(&i.Scopes),
+		&i.UserID,
+	)
+	return i, err
+}
+
+const oAuthCodeDeleteExpired = `-- name: OAuthCodeDeleteExpired :exec
+DELETE FROM oauth_auth_codes
+WHERE expires_at < now()
+`
+
+func (q *Queries) OAuthCodeDeleteExpired(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, oAuthCodeDeleteExpired)
+	return err
+}
+
+const oAuthCodeInsert = `-- name: OAuthCodeInsert :exec
+INSERT INTO oauth_auth_codes(code_hash, client_id, user_id, redirect_uri, scopes, code_challenge, expires_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type OAuthCodeInsertParams struct {
+	CodeHash      []byte
+	ClientID      uuid.UUID
+	UserID        uuid.UUID
+	RedirectUri   string
+	Scopes        []string
+	CodeChallenge string
+	ExpiresAt     time.Time
+}
+
+func (q *Queries) OAuthCodeInsert(ctx context.Context, arg OAuthCodeInsertParams) error {
+	_, err := q.db.ExecContext(ctx, oAuthCodeInsert,
+		arg.CodeHash,
+		arg.ClientID,
+		arg.UserID,
+		arg.RedirectUri,
+		pq.Array(arg.Scopes),
+		arg.CodeChallenge,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const oAuthGrantAuth = `-- name: OAuthGrantAuth :one
+WITH valid AS (
+    SELECT
+        g.id
+    FROM
+        oauth_grants g
+        JOIN oauth_clients c ON c.id = g.client_id
+            AND c.deleted_at IS NULL
+    WHERE
+        g.id = $1
+        AND g.user_id = $2
+        AND g.client_id = $3
+        AND g.revoked_at IS NULL
+        AND g.expires_at > now()
+),
+_update AS (
+    UPDATE
+        oauth_grants
+    SET
+        last_used_at = now()
+    WHERE
+        id IN (
+            SELECT
+                id
+            FROM
+                valid)
+            AND (last_used_at IS NULL
+                OR last_used_at < now() - '1 minute'::interval))
+SELECT
+    TRUE
+FROM
+    valid
+`
+
+type OAuthGrantAuthParams struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	ClientID uuid.UUID
+}
+
+// OAuthGrantAuth returns true if the grant is active for the given user and client.
+// The last used time is updated at most once per minute.
+func (q *Queries) OAuthGrantAuth(ctx context.Context, arg OAuthGrantAuthParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, oAuthGrantAuth, arg.ID, arg.UserID, arg.ClientID)
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const oAuthGrantForUpdate = `-- name: OAuthGrantForUpdate :one
+SELECT
+    oauth_grants.client_id, oauth_grants.created_at, oauth_grants.expires_at, oauth_grants.id, oauth_grants.last_used_at, oauth_grants.refresh_token_hash, oauth_grants.revoked_at, oauth_grants.scopes, oauth_grants.user_id
+FROM
+    oauth_grants
+    JOIN oauth_clients c ON c.id = oauth_grants.client_id
+        AND c.deleted_at IS NULL
+WHERE
+    oauth_grants.id = $1
+    AND oauth_grants.revoked_at IS NULL
+    AND oauth_grants.expires_at > now()
+FOR UPDATE
+    OF oauth_grants
+`
+
+// OAuthGrantForUpdate returns an active grant for refreshing.
+func (q *Queries) OAuthGrantForUpdate(ctx context.Context, id uuid.UUID) (OauthGrant, error) {
+	row := q.db.QueryRowContext(ctx, oAuthGrantForUpdate, id)
+	var i OauthGrant
+	err := row.Scan(
+		&i.ClientID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.ID,
+		&i.LastUsedAt,
+		&i.RefreshTokenHash,
+		&i.RevokedAt,
+		pq.Array(&i.Scopes),
+		&i.UserID,
+	)
+	return i, err
+}
+
+const oAuthGrantInsert = `-- name: OAuthGrantInsert :exec
+INSERT INTO oauth_grants(id, client_id, user_id, scopes, refresh_token_hash, expires_at)
+    VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type OAuthGrantInsertParams struct {
+	ID               uuid.UUID
+	ClientID         uuid.UUID
+	UserID           uuid.UUID
+	Scopes           []string
+	RefreshTokenHash []byte
+	ExpiresAt        time.Time
+}
+
+func (q *Queries) OAuthGrantInsert(ctx context.Context, arg OAuthGrantInsertParams) error {
+	_, err := q.db.ExecContext(ctx, oAuthGrantInsert,
+		arg.ID,
+		arg.ClientID,
+		arg.UserID,
+		pq.Array(arg.Scopes),
+		arg.RefreshTokenHash,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const oAuthGrantListByUser = `-- name: OAuthGrantListByUser :many
+SELECT
+    g.id,
+    g.scopes,
+    g.created_at,
+    g.last_used_at,
+    c.id AS client_id,
+    c.name AS client_name
+FROM
+    oauth_grants g
+    JOIN oauth_clients c ON c.id = g.client_id
+        AND c.deleted_at IS NULL
+WHERE
+    g.user_id = $1
+    AND g.revoked_at IS NULL
+    AND g.expires_at > now()
+ORDER BY
+    g.created_at DESC
+`
+
+type OAuthGrantListByUserRow struct {
+	ID         uuid.UUID
+	Scopes     []string
+	CreatedAt  time.Time
+	LastUsedAt sql.NullTime
+	ClientID   uuid.UUID
+	ClientName string
+}
+
+func (q *Queries) OAuthGrantListByUser(ctx context.Context, userID uuid.UUID) ([]OAuthGrantListByUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, oAuthGrantListByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OAuthGrantListByUserRow
+	for rows.Next() {
+		var i OAuthGrantListByUserRow
+		if err := rows.Scan(
+			&i.ID,
+			pq.Array(&i.Scopes),
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.ClientID,
+			&i.ClientName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const oAuthGrantRevoke = `-- name: OAuthGrantRevoke :exec
+UPDATE
+    oauth_grants
+SET
+    revoked_at = now()
+WHERE
+    id = $1
+    AND revoked_at IS NULL
+`
+
+func (q *Queries) OAuthGrantRevoke(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, oAuthGrantRevoke, id)
 	return err
 }
 
-const notifChanUpsertDest = `-- name: NotifChanUpsertDest :one
-INSERT INTO notification_channels(id, dest, name)
-    VALUES ($1, $2, $3)
-ON CONFLICT (dest)
-    DO UPDATE SET
-        name = $3
-    RETURNING
-        id
+const oAuthGrantRevokeByUser = `-- name: OAuthGrantRevokeByUser :execrows
+UPDATE
+    oauth_grants
+SET
+    revoked_at = now()
+WHERE
+    id = $1
+    AND user_id = $2
+    AND revoked_at IS NULL
 `
 
-type NotifChanUpsertDestParams struct {
-	ID   uuid.UUID
-	Dest NullDestV1
-	Name string
+type OAuthGrantRevokeByUserParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
 }
 
-// NotifChanUpsertDest will insert a new destination if it does not exist, or updating it's name if it does.
-func (q *Queries) NotifChanUpsertDest(ctx context.Context, arg NotifChanUpsertDestParams) (uuid.UUID, error) {
-	row := q.db.QueryRowContext(ctx, notifChanUpsertDest, arg.ID, arg.Dest, arg.Name)
-	var id uuid.UUID
-	err := row.Scan(&id)
-	return id, err
+func (q *Queries) OAuthGrantRevokeByUser(ctx context.Context, arg OAuthGrantRevokeByUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, oAuthGrantRevokeByUser, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const now = `-- name: Now :one
-SELECT now()::timestamptz
+const oAuthGrantRotate = `-- name: OAuthGrantRotate :exec
+UPDATE
+    oauth_grants
+SET
+    refresh_token_hash = $2,
+    expires_at = $3,
+    last_used_at = now()
+WHERE
+    id = $1
 `
 
-func (q *Queries) Now(ctx context.Context) (time.Time, error) {
-	row := q.db.QueryRowContext(ctx, now)
-	var column_1 time.Time
-	err := row.Scan(&column_1)
-	return column_1, err
+type OAuthGrantRotateParams struct {
+	ID               uuid.UUID
+	RefreshTokenHash []byte
+	ExpiresAt        time.Time
+}
+
+func (q *Queries) OAuthGrantRotate(ctx context.Context, arg OAuthGrantRotateParams) error {
+	_, err := q.db.ExecContext(ctx, oAuthGrantRotate, arg.ID, arg.RefreshTokenHash, arg.ExpiresAt)
+	return err
 }
 
 const overrideSearch = `-- name: OverrideSearch :many
@@ -4314,7 +6333,8 @@ SELECT
     o.end_time,
     add_user_id,
     remove_user_id,
-    tgt_schedule_id
+    tgt_schedule_id,
+    unavailability_id
 FROM
     user_overrides o
     LEFT JOIN AFTER ON TRUE
@@ -4365,12 +6385,13 @@ type OverrideSearchParams struct {
 }
 
 type OverrideSearchRow struct {
-	ID            uuid.UUID
-	StartTime     time.Time
-	EndTime       time.Time
-	AddUserID     uuid.NullUUID
-	RemoveUserID  uuid.NullUUID
-	TgtScheduleID uuid.UUID
+	ID               uuid.UUID
+	StartTime        time.Time
+	EndTime          time.Time
+	AddUserID        uuid.NullUUID
+	RemoveUserID     uuid.NullUUID
+	TgtScheduleID    uuid.UUID
+	UnavailabilityID uuid.NullUUID
 }
 
 func (q *Queries) OverrideSearch(ctx context.Context, arg OverrideSearchParams) ([]OverrideSearchRow, error) {
@@ -4398,6 +6419,7 @@ func (q *Queries) OverrideSearch(ctx context.Context, arg OverrideSearchParams)
 			&i.AddUserID,
 			&i.RemoveUserID,
 			&i.TgtScheduleID,
+			&i.UnavailabilityID,
 		); err != nil {
 			return nil, err
 		}
@@ -4555,22 +6577,347 @@ _delete AS (
 SELECT DISTINCT
     entity_id
 FROM
-    items
+    items
+`
+
+func (q *Queries) RotMgrFindWork(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, rotMgrFindWork)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var entity_id uuid.UUID
+		if err := rows.Scan(&entity_id); err != nil {
+			return nil, err
+		}
+		items = append(items, entity_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rotMgrRecordSkip = `-- name: RotMgrRecordSkip :exec
+INSERT INTO user_overrides(id, tgt_schedule_id, remove_user_id, add_user_id, start_time, end_time, unavailability_id)
+SELECT
+    gen_random_uuid(),
+    sched.schedule_id,
+    $1::uuid,
+    $2::uuid,
+    $3::timestamptz,
+    $4::timestamptz,
+    $5::uuid
+FROM (
+    SELECT DISTINCT
+        r.schedule_id
+    FROM
+        schedule_rules r
+    WHERE
+        r.tgt_rotation_id = $6::uuid
+        AND r.tier ISNULL) sched
+WHERE
+    NOT EXISTS (
+        SELECT
+        FROM
+            user_overrides o
+        WHERE
+            o.tgt_schedule_id = sched.schedule_id
+            AND (o.add_user_id IN ($1::uuid, $2::uuid)
+                OR o.remove_user_id IN ($1::uuid, $2::uuid))
+            AND (o.start_time, o.end_time) OVERLAPS ($3::timestamptz, $4::timestamptz))
+    AND (
+        SELECT
+            count(*)
+        FROM
+            user_overrides o
+        WHERE
+            o.tgt_schedule_id = sched.schedule_id
+            AND o.end_time > now()) < coalesce((
+            SELECT
+                nullif(max, -1)
+            FROM config_limits
+            WHERE
+                id = 'user_overrides_per_schedule'), 2147483647)
+`
+
+type RotMgrRecordSkipParams struct {
+	RemoveUserID     uuid.UUID
+	AddUserID        uuid.UUID
+	StartTime        time.Time
+	EndTime          time.Time
+	UnavailabilityID uuid.UUID
+	RotationID       uuid.UUID
+}
+
+// Record an override on each schedule using the rotation (primary tier only), replacing a skipped participant with
+// the one that took their shift. Schedules with a conflicting override, or at their override limit, are left alone.
+func (q *Queries) RotMgrRecordSkip(ctx context.Context, arg RotMgrRecordSkipParams) error {
+	_, err := q.db.ExecContext(ctx, rotMgrRecordSkip,
+		arg.RemoveUserID,
+		arg.AddUserID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.UnavailabilityID,
+		arg.RotationID,
+	)
+	return err
+}
+
+const rotMgrRotationData = `-- name: RotMgrRotationData :one
+SELECT
+    now()::timestamptz AS now,
+    rot.description, rot.id, rot.last_processed, rot.name, rot.participant_count, rot.shift_length, rot.shift_pattern, rot.start_time, rot.time_zone, rot.type,
+    coalesce(state.version, 0) AS state_version,
+    coalesce(state.position, 0) AS state_position,
+    state.shift_start AS state_shift_start,
+    ARRAY (
+        SELECT
+            p.id
+        FROM
+            rotation_participants p
+        WHERE
+            p.rotation_id = rot.id
+        ORDER BY
+            position)::uuid[] AS participants,
+    ARRAY (
+        SELECT
+            p.user_id
+        FROM
+            rotation_participants p
+        WHERE
+            p.rotation_id = rot.id
+        ORDER BY
+            position)::uuid[] AS participant_user_ids,
+    ARRAY (
+        SELECT
+            coalesce(p.shift_length, rot.shift_length)
+        FROM
+            rotation_participants p
+        WHERE
+            p.rotation_id = rot.id
+        ORDER BY
+            position)::int[] AS participant_shift_lengths
+    FROM
+        rotations rot
+    LEFT JOIN rotation_state state ON rot.id = state.rotation_id
+WHERE
+    rot.id = $1
+`
+
+type RotMgrRotationDataRow struct {
+	Now                     time.Time
+	Rotation                Rotation
+	StateVersion            int32
+	StatePosition           int32
+	StateShiftStart         sql.NullTime
+	Participants            []uuid.UUID
+	ParticipantUserIds      []uuid.UUID
+	ParticipantShiftLengths []int32
+}
+
+// Get rotation data for a given rotation ID
+func (q *Queries) RotMgrRotationData(ctx context.Context, rotationID uuid.UUID) (RotMgrRotationDataRow, error) {
+	row := q.db.QueryRowContext(ctx, rotMgrRotationData, rotationID)
+	var i RotMgrRotationDataRow
+	err := row.Scan(
+		&i.Now,
+		&i.Rotation.Description,
+		&i.Rotation.ID,
+		&i.Rotation.LastProcessed,
+		&i.Rotation.Name,
+		&i.Rotation.ParticipantCount,
+		&i.Rotation.ShiftLength,
+		pq.Array(&i.Rotation.ShiftPattern),
+		&i.Rotation.StartTime,
+		&i.Rotation.TimeZone,
+		&i.Rotation.Type,
+		&i.StateVersion,
+		&i.StatePosition,
+		&i.StateShiftStart,
+		pq.Array(&i.Participants),
+		pq.Array(&i.ParticipantUserIds),
+		pq.Array(&i.ParticipantShiftLengths),
+	)
+	return i, err
+}
+
+const rotMgrStart = `-- name: RotMgrStart :exec
+INSERT INTO rotation_state(rotation_id, position, shift_start, rotation_participant_id)
+SELECT
+    p.rotation_id,
+    0,
+    now(),
+    id
+FROM
+    rotation_participants p
+WHERE
+    p.rotation_id = $1
+    AND position = 0
+`
+
+// Start a rotation.
+func (q *Queries) RotMgrStart(ctx context.Context, rotationID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, rotMgrStart, rotationID)
+	return err
+}
+
+const rotMgrUnavailable = `-- name: RotMgrUnavailable :many
+SELECT
+    u.id,
+    u.user_id,
+    u.start_time,
+    u.end_time
+FROM
+    user_unavailability u
+WHERE
+    u.user_id IN (
+        SELECT
+            p.user_id
+        FROM
+            rotation_participants p
+        WHERE
+            p.rotation_id = $1)
+    AND u.start_time < $2
+    AND u.end_time > $3
+ORDER BY
+    u.start_time,
+    u.id
+`
+
+type RotMgrUnavailableParams struct {
+	RotationID uuid.UUID
+	ShiftEnd   time.Time
+	ShiftStart time.Time
+}
+
+type RotMgrUnavailableRow struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Get the unavailability windows of a rotation's participants overlapping the shift.
+func (q *Queries) RotMgrUnavailable(ctx context.Context, arg RotMgrUnavailableParams) ([]RotMgrUnavailableRow, error) {
+	rows, err := q.db.QueryContext(ctx, rotMgrUnavailable, arg.RotationID, arg.ShiftEnd, arg.ShiftStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RotMgrUnavailableRow
+	for rows.Next() {
+		var i RotMgrUnavailableRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rotMgrUpdate = `-- name: RotMgrUpdate :exec
+UPDATE
+    rotation_state
+SET
+    position = $1,
+    shift_start = now(),
+    rotation_participant_id = $2,
+    version = 2
+WHERE
+    rotation_id = $3
+`
+
+type RotMgrUpdateParams struct {
+	Position              int32
+	RotationParticipantID uuid.UUID
+	RotationID            uuid.UUID
+}
+
+// Update the rotation state.
+func (q *Queries) RotMgrUpdate(ctx context.Context, arg RotMgrUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, rotMgrUpdate, arg.Position, arg.RotationParticipantID, arg.RotationID)
+	return err
+}
+
+const sCIMGroupExists = `-- name: SCIMGroupExists :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            scim_groups
+        WHERE
+            rotation_id = $1)
+`
+
+// SCIMGroupExists returns true if the rotation was created via SCIM.
+func (q *Queries) SCIMGroupExists(ctx context.Context, rotationID uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, sCIMGroupExists, rotationID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const sCIMGroupInsert = `-- name: SCIMGroupInsert :exec
+INSERT INTO scim_groups(rotation_id)
+    VALUES ($1)
+`
+
+func (q *Queries) SCIMGroupInsert(ctx context.Context, rotationID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, sCIMGroupInsert, rotationID)
+	return err
+}
+
+const sCIMGroupList = `-- name: SCIMGroupList :many
+SELECT
+    r.id,
+    r.name
+FROM
+    scim_groups g
+    JOIN rotations r ON r.id = g.rotation_id
+WHERE
+    $1::text IS NULL
+    OR lower(r.name) = lower($1)
+ORDER BY
+    r.name
 `
 
-func (q *Queries) RotMgrFindWork(ctx context.Context) ([]uuid.UUID, error) {
-	rows, err := q.db.QueryContext(ctx, rotMgrFindWork)
+type SCIMGroupListRow struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// SCIMGroupList returns the rotations created via SCIM, optionally filtered by (case-insensitive) name.
+func (q *Queries) SCIMGroupList(ctx context.Context, name sql.NullString) ([]SCIMGroupListRow, error) {
+	rows, err := q.db.QueryContext(ctx, sCIMGroupList, name)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []uuid.UUID
+	var items []SCIMGroupListRow
 	for rows.Next() {
-		var entity_id uuid.UUID
-		if err := rows.Scan(&entity_id); err != nil {
+		var i SCIMGroupListRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
 			return nil, err
 		}
-		items = append(items, entity_id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -4581,105 +6928,133 @@ func (q *Queries) RotMgrFindWork(ctx context.Context) ([]uuid.UUID, error) {
 	return items, nil
 }
 
-const rotMgrRotationData = `-- name: RotMgrRotationData :one
-SELECT
-    now()::timestamptz AS now,
-    rot.description, rot.id, rot.last_processed, rot.name, rot.participant_count, rot.shift_length, rot.start_time, rot.time_zone, rot.type,
-    coalesce(state.version, 0) AS state_version,
-    coalesce(state.position, 0) AS state_position,
-    state.shift_start AS state_shift_start,
-    ARRAY (
-        SELECT
-            p.id
-        FROM
-            rotation_participants p
-        WHERE
-            p.rotation_id = rot.id
-        ORDER BY
-            position)::uuid[] AS participants
+const sCIMKeyAuthCheck = `-- name: SCIMKeyAuthCheck :one
+WITH valid AS (
+    SELECT
+        id
     FROM
-        rotations rot
-    LEFT JOIN rotation_state state ON rot.id = state.rotation_id
-WHERE
-    rot.id = $1
+        scim_api_keys
+    WHERE
+        scim_api_keys.id = $1
+        AND scim_api_keys.deleted_at IS NULL
+        AND scim_api_keys.expires_at > now()
+),
+_update AS (
+    UPDATE
+        scim_api_keys
+    SET
+        last_used_at = now()
+    WHERE
+        id IN (
+            SELECT
+                id
+            FROM
+                valid)
+            AND (last_used_at IS NULL
+                OR last_used_at < now() - '1 minute'::interval))
+SELECT
+    TRUE
+FROM
+    valid
 `
 
-type RotMgrRotationDataRow struct {
-	Now             time.Time
-	Rotation        Rotation
-	StateVersion    int32
-	StatePosition   int32
-	StateShiftStart sql.NullTime
-	Participants    []uuid.UUID
-}
-
-// Get rotation data for a given rotation ID
-func (q *Queries) RotMgrRotationData(ctx context.Context, rotationID uuid.UUID) (RotMgrRotationDataRow, error) {
-	row := q.db.QueryRowContext(ctx, rotMgrRotationData, rotationID)
-	var i RotMgrRotationDataRow
-	err := row.Scan(
-		&i.Now,
-		&i.Rotation.Description,
-		&i.Rotation.ID,
-		&i.Rotation.LastProcessed,
-		&i.Rotation.Name,
-		&i.Rotation.ParticipantCount,
-		&i.Rotation.ShiftLength,
-		&i.Rotation.StartTime,
-		&i.Rotation.TimeZone,
-		&i.Rotation.Type,
-		&i.StateVersion,
-		&i.StatePosition,
-		&i.StateShiftStart,
-		pq.Array(&i.Participants),
-	)
-	return i, err
+// SCIMKeyAuthCheck returns true if the SCIM key exists, is not deleted, and is not expired.
+// The last used time is updated at most once per minute.
+func (q *Queries) SCIMKeyAuthCheck(ctx context.Context, id uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, sCIMKeyAuthCheck, id)
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
 }
 
-const rotMgrStart = `-- name: RotMgrStart :exec
-INSERT INTO rotation_state(rotation_id, position, shift_start, rotation_participant_id)
-SELECT
-    p.rotation_id,
-    0,
-    now(),
-    id
-FROM
-    rotation_participants p
+const sCIMKeyDelete = `-- name: SCIMKeyDelete :exec
+UPDATE
+    scim_api_keys
+SET
+    deleted_at = now(),
+    deleted_by = $2
 WHERE
-    p.rotation_id = $1
-    AND position = 0
+    id = $1
 `
 
-// Start a rotation.
-func (q *Queries) RotMgrStart(ctx context.Context, rotationID uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, rotMgrStart, rotationID)
+type SCIMKeyDeleteParams struct {
+	ID        uuid.UUID
+	DeletedBy uuid.NullUUID
+}
+
+func (q *Queries) SCIMKeyDelete(ctx context.Context, arg SCIMKeyDeleteParams) error {
+	_, err := q.db.ExecContext(ctx, sCIMKeyDelete, arg.ID, arg.DeletedBy)
 	return err
 }
 
-const rotMgrUpdate = `-- name: RotMgrUpdate :exec
-UPDATE
-    rotation_state
-SET
-    position = $1,
-    shift_start = now(),
-    rotation_participant_id = $2,
-    version = 2
-WHERE
-    rotation_id = $3
+const sCIMKeyInsert = `-- name: SCIMKeyInsert :exec
+INSERT INTO scim_api_keys(id, name, description, created_by, expires_at)
+    VALUES ($1, $2, $3, $4, $5)
 `
 
-type RotMgrUpdateParams struct {
-	Position              int32
-	RotationParticipantID uuid.UUID
-	RotationID            uuid.UUID
+type SCIMKeyInsertParams struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	CreatedBy   uuid.NullUUID
+	ExpiresAt   time.Time
 }
 
-// Update the rotation state.
-func (q *Queries) RotMgrUpdate(ctx context.Context, arg RotMgrUpdateParams) error {
-	_, err := q.db.ExecContext(ctx, rotMgrUpdate, arg.Position, arg.RotationParticipantID, arg.RotationID)
+func (q *Queries) SCIMKeyInsert(ctx context.Context, arg SCIMKeyInsertParams) error {
+	_, err := q.db.ExecContext(ctx, sCIMKeyInsert,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.CreatedBy,
+		arg.ExpiresAt,
+	)
 	return err
 }
 
+const sCIMKeyList = `-- name: SCIMKeyList :many
+SELECT
+    created_at, created_by, deleted_at, deleted_by, description, expires_at, id, last_used_at, name
+FROM
+    scim_api_keys
+WHERE
+    deleted_at IS NULL
+ORDER BY
+    name
+`
+
+func (q *Queries) SCIMKeyList(ctx context.Context) ([]ScimApiKey, error) {
+	rows, err := q.db.QueryContext(ctx, sCIMKeyList)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScimApiKey
+	for rows.Next() {
+		var i ScimApiKey
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.Description,
+			&i.ExpiresAt,
+			&i.ID,
+			&i.LastUsedAt,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const sWOConnLock = `-- name: SWOConnLock :one
 WITH LOCK AS (
     SELECT
@@ -4949,6 +7324,123 @@ func (q *Queries) SchedInsertData(ctx context.Context, scheduleID uuid.UUID) err
 	return err
 }
 
+const schedMgrAllIDs = `-- name: SchedMgrAllIDs :many
+SELECT
+    id
+FROM
+    schedules
+`
+
+// Returns the IDs of all schedules.
+func (q *Queries) SchedMgrAllIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, schedMgrAllIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const schedMgrClearCoverageGaps = `-- name: SchedMgrClearCoverageGaps :exec
+DELETE FROM schedule_coverage_gaps
+WHERE schedule_id = $1
+`
+
+func (q *Queries) SchedMgrClearCoverageGaps(ctx context.Context, scheduleID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, schedMgrClearCoverageGaps, scheduleID)
+	return err
+}
+
+const schedMgrCoverageGapChannels = `-- name: SchedMgrCoverageGapChannels :many
+SELECT DISTINCT
+    nc.id
+FROM
+    schedule_data data,
+    jsonb_array_elements(data.data -> 'V1' -> 'OnCallNotificationRules') AS rule
+    JOIN notification_channels nc ON nc.id =(rule ->> 'ChannelID')::uuid
+WHERE
+    data.schedule_id = $1
+    AND nc.dest ->> 'Type' <> 'builtin-slack-usergroup'
+`
+
+// Returns the notification channels from a schedule's on-call notification rules that can receive coverage gap messages.
+func (q *Queries) SchedMgrCoverageGapChannels(ctx context.Context, scheduleID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, schedMgrCoverageGapChannels, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const schedMgrCoverageGaps = `-- name: SchedMgrCoverageGaps :many
+SELECT
+    start_time,
+    end_time
+FROM
+    schedule_coverage_gaps
+WHERE
+    schedule_id = $1
+FOR UPDATE
+`
+
+type SchedMgrCoverageGapsRow struct {
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Returns the previously detected coverage gaps for a schedule, locking them for update.
+func (q *Queries) SchedMgrCoverageGaps(ctx context.Context, scheduleID uuid.UUID) ([]SchedMgrCoverageGapsRow, error) {
+	rows, err := q.db.QueryContext(ctx, schedMgrCoverageGaps, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SchedMgrCoverageGapsRow
+	for rows.Next() {
+		var i SchedMgrCoverageGapsRow
+		if err := rows.Scan(&i.StartTime, &i.EndTime); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const schedMgrDataForUpdate = `-- name: SchedMgrDataForUpdate :many
 SELECT
     schedule_id,
@@ -5040,6 +7532,29 @@ func (q *Queries) SchedMgrEndOnCall(ctx context.Context, arg SchedMgrEndOnCallPa
 	return err
 }
 
+const schedMgrEndTierOnCall = `-- name: SchedMgrEndTierOnCall :exec
+UPDATE
+    schedule_tier_on_call_users
+SET
+    end_time = now()
+WHERE
+    schedule_id = $1
+    AND tier = $2
+    AND user_id = $3
+    AND end_time ISNULL
+`
+
+type SchedMgrEndTierOnCallParams struct {
+	ScheduleID uuid.UUID
+	Tier       string
+	UserID     uuid.UUID
+}
+
+func (q *Queries) SchedMgrEndTierOnCall(ctx context.Context, arg SchedMgrEndTierOnCallParams) error {
+	_, err := q.db.ExecContext(ctx, schedMgrEndTierOnCall, arg.ScheduleID, arg.Tier, arg.UserID)
+	return err
+}
+
 const schedMgrGetData = `-- name: SchedMgrGetData :one
 SELECT
     data
@@ -5057,6 +7572,178 @@ func (q *Queries) SchedMgrGetData(ctx context.Context, scheduleID uuid.UUID) (js
 	return data, err
 }
 
+const schedMgrHandoffNotifyUsers = `-- name: SchedMgrHandoffNotifyUsers :exec
+INSERT INTO outgoing_messages(message_type, contact_method_id, user_id, schedule_id, schedule_handoff_report_id)
+SELECT
+    'schedule_handoff_report',
+    cm.id,
+    cm.user_id,
+    $1,
+    $2
+FROM
+    user_contact_methods cm
+WHERE
+    cm.user_id = ANY ($3::uuid[])
+    AND NOT cm.disabled
+    AND cm.dest ->> 'Type' <> 'builtin-twilio-voice'
+    AND EXISTS (
+        SELECT
+        FROM
+            user_notification_rules r
+        WHERE
+            r.contact_method_id = cm.id
+            AND r.delay_minutes = 0)
+`
+
+type SchedMgrHandoffNotifyUsersParams struct {
+	ScheduleID uuid.NullUUID
+	ReportID   uuid.NullUUID
+	UserIds    []uuid.UUID
+}
+
+// Queues a handoff report to each contact method the outgoing and incoming users have set to be notified
+// immediately. Voice calls are skipped as the report is too long to be read out.
+func (q *Queries) SchedMgrHandoffNotifyUsers(ctx context.Context, arg SchedMgrHandoffNotifyUsersParams) error {
+	_, err := q.db.ExecContext(ctx, schedMgrHandoffNotifyUsers, arg.ScheduleID, arg.ReportID, pq.Array(arg.UserIds))
+	return err
+}
+
+const schedMgrHolidays = `-- name: SchedMgrHolidays :many
+SELECT
+    calendar_id,
+    start_date,
+    end_date
+FROM
+    holidays
+WHERE
+    calendar_id IN (
+        SELECT
+            holiday_calendar_id
+        FROM
+            schedule_rules)
+    AND end_date >= (now() - '1 day'::interval)::date
+    AND start_date <= (now() + '1 day'::interval)::date
+`
+
+type SchedMgrHolidaysRow struct {
+	CalendarID uuid.UUID
+	StartDate  time.Time
+	EndDate    time.Time
+}
+
+// Returns holidays, from calendars used by schedule rules, that may be in effect now in any time zone.
+func (q *Queries) SchedMgrHolidays(ctx context.Context) ([]SchedMgrHolidaysRow, error) {
+	rows, err := q.db.QueryContext(ctx, schedMgrHolidays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SchedMgrHolidaysRow
+	for rows.Next() {
+		var i SchedMgrHolidaysRow
+		if err := rows.Scan(&i.CalendarID, &i.StartDate, &i.EndDate); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const schedMgrInsertCoverageGap = `-- name: SchedMgrInsertCoverageGap :exec
+INSERT INTO schedule_coverage_gaps(schedule_id, start_time, end_time)
+    VALUES ($1, $2, $3)
+`
+
+type SchedMgrInsertCoverageGapParams struct {
+	ScheduleID uuid.UUID
+	StartTime  time.Time
+	EndTime    time.Time
+}
+
+func (q *Queries) SchedMgrInsertCoverageGap(ctx context.Context, arg SchedMgrInsertCoverageGapParams) error {
+	_, err := q.db.ExecContext(ctx, schedMgrInsertCoverageGap, arg.ScheduleID, arg.StartTime, arg.EndTime)
+	return err
+}
+
+const schedMgrInsertCoverageGapMessage = `-- name: SchedMgrInsertCoverageGapMessage :exec
+INSERT INTO outgoing_messages(id, message_type, channel_id, schedule_id)
+    VALUES ($1, 'schedule_coverage_gap', $2, $3)
+`
+
+type SchedMgrInsertCoverageGapMessageParams struct {
+	ID         uuid.UUID
+	ChannelID  uuid.NullUUID
+	ScheduleID uuid.NullUUID
+}
+
+func (q *Queries) SchedMgrInsertCoverageGapMessage(ctx context.Context, arg SchedMgrInsertCoverageGapMessageParams) error {
+	_, err := q.db.ExecContext(ctx, schedMgrInsertCoverageGapMessage, arg.ID, arg.ChannelID, arg.ScheduleID)
+	return err
+}
+
+const schedMgrInsertHandoffMessage = `-- name: SchedMgrInsertHandoffMessage :exec
+INSERT INTO outgoing_messages(id, message_type, channel_id, schedule_id, schedule_handoff_report_id)
+    VALUES ($1, 'schedule_handoff_report', $2, $3, $4)
+`
+
+type SchedMgrInsertHandoffMessageParams struct {
+	ID                      uuid.UUID
+	ChannelID               uuid.NullUUID
+	ScheduleID              uuid.NullUUID
+	ScheduleHandoffReportID uuid.NullUUID
+}
+
+func (q *Queries) SchedMgrInsertHandoffMessage(ctx context.Context, arg SchedMgrInsertHandoffMessageParams) error {
+	_, err := q.db.ExecContext(ctx, schedMgrInsertHandoffMessage,
+		arg.ID,
+		arg.ChannelID,
+		arg.ScheduleID,
+		arg.ScheduleHandoffReportID,
+	)
+	return err
+}
+
+const schedMgrInsertHandoffReport = `-- name: SchedMgrInsertHandoffReport :exec
+INSERT INTO schedule_handoff_reports(id, schedule_id, shift_start, outgoing_user_ids, incoming_user_ids)
+    VALUES ($1, $2, greatest(coalesce(greatest((
+                SELECT
+                    max(shift_end)
+                FROM schedule_handoff_reports r
+                WHERE
+                    r.schedule_id = $2),(
+                SELECT
+                    max(start_time)
+                FROM schedule_on_call_users oc
+                WHERE
+                    oc.schedule_id = $2
+                    AND oc.end_time ISNULL)), now()), now() - '7 days'::interval), $3::uuid[], $4::uuid[])
+`
+
+type SchedMgrInsertHandoffReportParams struct {
+	ID              uuid.UUID
+	ScheduleID      uuid.UUID
+	OutgoingUserIds []uuid.UUID
+	IncomingUserIds []uuid.UUID
+}
+
+// Records a shift change for a handoff report. The previous shift is considered to have started at the last
+// recorded handoff or the most recent on-call start, whichever is later, limited to the last 7 days.
+func (q *Queries) SchedMgrInsertHandoffReport(ctx context.Context, arg SchedMgrInsertHandoffReportParams) error {
+	_, err := q.db.ExecContext(ctx, schedMgrInsertHandoffReport,
+		arg.ID,
+		arg.ScheduleID,
+		pq.Array(arg.OutgoingUserIds),
+		pq.Array(arg.IncomingUserIds),
+	)
+	return err
+}
+
 const schedMgrInsertMessage = `-- name: SchedMgrInsertMessage :exec
 INSERT INTO outgoing_messages(id, message_type, channel_id, schedule_id)
     VALUES ($1, 'schedule_on_call_notification', $2, $3)
@@ -5190,8 +7877,9 @@ func (q *Queries) SchedMgrOverrides(ctx context.Context) ([]SchedMgrOverridesRow
 
 const schedMgrRules = `-- name: SchedMgrRules :many
 SELECT
-    rule.created_at, rule.end_time, rule.friday, rule.id, rule.is_active, rule.monday, rule.saturday, rule.schedule_id, rule.start_time, rule.sunday, rule.tgt_rotation_id, rule.tgt_user_id, rule.thursday, rule.tuesday, rule.wednesday,
-    coalesce(rule.tgt_user_id, part.user_id) AS resolved_user_id
+    rule.created_at, rule.end_time, rule.friday, rule.holiday_calendar_id, rule.holiday_mode, rule.id, rule.is_active, rule.monday, rule.saturday, rule.schedule_id, rule.start_time, rule.sunday, rule.tgt_rotation_id, rule.tgt_user_id, rule.thursday, rule.tier, rule.tuesday, rule.wednesday,
+    coalesce(rule.tgt_user_id, part.user_id) AS resolved_user_id,
+    rState.position AS rotation_position
 FROM
     schedule_rules rule
     LEFT JOIN rotation_state rState ON rState.rotation_id = rule.tgt_rotation_id
@@ -5202,22 +7890,26 @@ WHERE
 `
 
 type SchedMgrRulesRow struct {
-	CreatedAt      time.Time
-	EndTime        timeutil.Clock
-	Friday         bool
-	ID             uuid.UUID
-	IsActive       bool
-	Monday         bool
-	Saturday       bool
-	ScheduleID     uuid.UUID
-	StartTime      timeutil.Clock
-	Sunday         bool
-	TgtRotationID  uuid.NullUUID
-	TgtUserID      uuid.NullUUID
-	Thursday       bool
-	Tuesday        bool
-	Wednesday      bool
-	ResolvedUserID uuid.UUID
+	CreatedAt         time.Time
+	EndTime           timeutil.Clock
+	Friday            bool
+	HolidayCalendarID uuid.NullUUID
+	HolidayMode       NullEnumHolidayMode
+	ID                uuid.UUID
+	IsActive          bool
+	Monday            bool
+	Saturday          bool
+	ScheduleID        uuid.UUID
+	StartTime         timeutil.Clock
+	Sunday            bool
+	TgtRotationID     uuid.NullUUID
+	TgtUserID         uuid.NullUUID
+	Thursday          bool
+	Tier              sql.NullString
+	Tuesday           bool
+	Wednesday         bool
+	ResolvedUserID    uuid.UUID
+	RotationPosition  sql.NullInt32
 }
 
 func (q *Queries) SchedMgrRules(ctx context.Context) ([]SchedMgrRulesRow, error) {
@@ -5233,6 +7925,8 @@ func (q *Queries) SchedMgrRules(ctx context.Context) ([]SchedMgrRulesRow, error)
 			&i.CreatedAt,
 			&i.EndTime,
 			&i.Friday,
+			&i.HolidayCalendarID,
+			&i.HolidayMode,
 			&i.ID,
 			&i.IsActive,
 			&i.Monday,
@@ -5243,9 +7937,11 @@ func (q *Queries) SchedMgrRules(ctx context.Context) ([]SchedMgrRulesRow, error)
 			&i.TgtRotationID,
 			&i.TgtUserID,
 			&i.Thursday,
+			&i.Tier,
 			&i.Tuesday,
 			&i.Wednesday,
 			&i.ResolvedUserID,
+			&i.RotationPosition,
 		); err != nil {
 			return nil, err
 		}
@@ -5321,6 +8017,159 @@ func (q *Queries) SchedMgrStartOnCall(ctx context.Context, arg SchedMgrStartOnCa
 	return err
 }
 
+const schedMgrStartTierOnCall = `-- name: SchedMgrStartTierOnCall :exec
+INSERT INTO schedule_tier_on_call_users(schedule_id, tier, user_id)
+SELECT
+    $1,
+    $2,
+    $3
+FROM
+    users
+WHERE
+    id = $3
+`
+
+type SchedMgrStartTierOnCallParams struct {
+	ScheduleID uuid.UUID
+	Tier       string
+	UserID     uuid.UUID
+}
+
+func (q *Queries) SchedMgrStartTierOnCall(ctx context.Context, arg SchedMgrStartTierOnCallParams) error {
+	_, err := q.db.ExecContext(ctx, schedMgrStartTierOnCall, arg.ScheduleID, arg.Tier, arg.UserID)
+	return err
+}
+
+const schedMgrTierOnCall = `-- name: SchedMgrTierOnCall :many
+SELECT
+    schedule_id,
+    tier,
+    user_id
+FROM
+    schedule_tier_on_call_users
+WHERE
+    end_time ISNULL
+`
+
+type SchedMgrTierOnCallRow struct {
+	ScheduleID uuid.UUID
+	Tier       string
+	UserID     uuid.UUID
+}
+
+func (q *Queries) SchedMgrTierOnCall(ctx context.Context) ([]SchedMgrTierOnCallRow, error) {
+	rows, err := q.db.QueryContext(ctx, schedMgrTierOnCall)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SchedMgrTierOnCallRow
+	for rows.Next() {
+		var i SchedMgrTierOnCallRow
+		if err := rows.Scan(&i.ScheduleID, &i.Tier, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const schedMgrTierRotationParticipants = `-- name: SchedMgrTierRotationParticipants :many
+SELECT
+    rotation_id,
+    position,
+    user_id
+FROM
+    rotation_participants
+WHERE
+    rotation_id IN (
+        SELECT
+            rule.tgt_rotation_id
+        FROM
+            schedule_rules rule
+            JOIN schedule_tiers tier ON tier.schedule_id = rule.schedule_id
+                AND tier.rotation_offset > 0
+        WHERE
+            rule.tier ISNULL)
+ORDER BY
+    rotation_id,
+    position
+`
+
+type SchedMgrTierRotationParticipantsRow struct {
+	RotationID uuid.UUID
+	Position   int32
+	UserID     uuid.UUID
+}
+
+// Returns the participants, in order, of the primary tier rotations of schedules with offset tiers.
+func (q *Queries) SchedMgrTierRotationParticipants(ctx context.Context) ([]SchedMgrTierRotationParticipantsRow, error) {
+	rows, err := q.db.QueryContext(ctx, schedMgrTierRotationParticipants)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SchedMgrTierRotationParticipantsRow
+	for rows.Next() {
+		var i SchedMgrTierRotationParticipantsRow
+		if err := rows.Scan(&i.RotationID, &i.Position, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const schedMgrTiers = `-- name: SchedMgrTiers :many
+SELECT
+    schedule_id,
+    name,
+    rotation_offset
+FROM
+    schedule_tiers
+`
+
+type SchedMgrTiersRow struct {
+	ScheduleID     uuid.UUID
+	Name           string
+	RotationOffset int32
+}
+
+func (q *Queries) SchedMgrTiers(ctx context.Context) ([]SchedMgrTiersRow, error) {
+	rows, err := q.db.QueryContext(ctx, schedMgrTiers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SchedMgrTiersRow
+	for rows.Next() {
+		var i SchedMgrTiersRow
+		if err := rows.Scan(&i.ScheduleID, &i.Name, &i.RotationOffset); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const schedMgrTimezones = `-- name: SchedMgrTimezones :many
 SELECT
     id,
@@ -5392,9 +8241,70 @@ type SchedUpdateDataParams struct {
 	Data       json.RawMessage
 }
 
-// Updates the schedule data for a given schedule ID.
-func (q *Queries) SchedUpdateData(ctx context.Context, arg SchedUpdateDataParams) error {
-	_, err := q.db.ExecContext(ctx, schedUpdateData, arg.ScheduleID, arg.Data)
+// Updates the schedule data for a given schedule ID.
+func (q *Queries) SchedUpdateData(ctx context.Context, arg SchedUpdateDataParams) error {
+	_, err := q.db.ExecContext(ctx, schedUpdateData, arg.ScheduleID, arg.Data)
+	return err
+}
+
+const scheduleCoverageGaps = `-- name: ScheduleCoverageGaps :many
+SELECT
+    start_time,
+    end_time,
+    detected_at
+FROM
+    schedule_coverage_gaps
+WHERE
+    schedule_id = $1
+    AND end_time > now()
+ORDER BY
+    start_time
+`
+
+type ScheduleCoverageGapsRow struct {
+	StartTime  time.Time
+	EndTime    time.Time
+	DetectedAt time.Time
+}
+
+// Returns the current and upcoming coverage gaps detected for a schedule.
+func (q *Queries) ScheduleCoverageGaps(ctx context.Context, scheduleID uuid.UUID) ([]ScheduleCoverageGapsRow, error) {
+	rows, err := q.db.QueryContext(ctx, scheduleCoverageGaps, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScheduleCoverageGapsRow
+	for rows.Next() {
+		var i ScheduleCoverageGapsRow
+		if err := rows.Scan(&i.StartTime, &i.EndTime, &i.DetectedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const scheduleDeleteTier = `-- name: ScheduleDeleteTier :exec
+DELETE FROM schedule_tiers
+WHERE schedule_id = $1
+    AND name = $2
+`
+
+type ScheduleDeleteTierParams struct {
+	ScheduleID uuid.UUID
+	Name       string
+}
+
+// Deletes a schedule tier, along with its rules and escalation policy actions.
+func (q *Queries) ScheduleDeleteTier(ctx context.Context, arg ScheduleDeleteTierParams) error {
+	_, err := q.db.ExecContext(ctx, scheduleDeleteTier, arg.ScheduleID, arg.Name)
 	return err
 }
 
@@ -5449,6 +8359,92 @@ func (q *Queries) ScheduleFindManyByUser(ctx context.Context, tgtUserID uuid.Nul
 	return items, nil
 }
 
+const scheduleSetTier = `-- name: ScheduleSetTier :exec
+INSERT INTO schedule_tiers(schedule_id, name, rotation_offset)
+    VALUES ($1, $2, $3)
+ON CONFLICT (schedule_id, name)
+    DO UPDATE SET
+        rotation_offset = $3
+`
+
+type ScheduleSetTierParams struct {
+	ScheduleID     uuid.UUID
+	Name           string
+	RotationOffset int32
+}
+
+// Creates or updates a schedule tier.
+func (q *Queries) ScheduleSetTier(ctx context.Context, arg ScheduleSetTierParams) error {
+	_, err := q.db.ExecContext(ctx, scheduleSetTier, arg.ScheduleID, arg.Name, arg.RotationOffset)
+	return err
+}
+
+const scheduleTierExists = `-- name: ScheduleTierExists :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            schedule_tiers
+        WHERE
+            schedule_id = $1
+            AND name = $2)
+`
+
+type ScheduleTierExistsParams struct {
+	ScheduleID uuid.UUID
+	Name       string
+}
+
+func (q *Queries) ScheduleTierExists(ctx context.Context, arg ScheduleTierExistsParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, scheduleTierExists, arg.ScheduleID, arg.Name)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const scheduleTiers = `-- name: ScheduleTiers :many
+SELECT
+    name,
+    rotation_offset
+FROM
+    schedule_tiers
+WHERE
+    schedule_id = $1
+ORDER BY
+    created_at,
+    name
+`
+
+type ScheduleTiersRow struct {
+	Name           string
+	RotationOffset int32
+}
+
+// Returns the tiers of a schedule.
+func (q *Queries) ScheduleTiers(ctx context.Context, scheduleID uuid.UUID) ([]ScheduleTiersRow, error) {
+	rows, err := q.db.QueryContext(ctx, scheduleTiers, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScheduleTiersRow
+	for rows.Next() {
+		var i ScheduleTiersRow
+		if err := rows.Scan(&i.Name, &i.RotationOffset); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const sequenceNames = `-- name: SequenceNames :many
 SELECT sequence_name::text
 FROM information_schema.sequences
@@ -5935,6 +8931,208 @@ func (q *Queries) StatusMgrUpdateSub(ctx context.Context, arg StatusMgrUpdateSub
 	return err
 }
 
+const swapRequestFind = `-- name: SwapRequestFind :one
+SELECT
+    counterpart_id, counterpart_shift_end, counterpart_shift_start, created_at, id, message, requester_id, requester_shift_end, requester_shift_start, responded_at, schedule_id, status
+FROM
+    shift_swap_requests
+WHERE
+    id = $1
+`
+
+func (q *Queries) SwapRequestFind(ctx context.Context, id uuid.UUID) (ShiftSwapRequest, error) {
+	row := q.db.QueryRowContext(ctx, swapRequestFind, id)
+	var i ShiftSwapRequest
+	err := row.Scan(
+		&i.CounterpartID,
+		&i.CounterpartShiftEnd,
+		&i.CounterpartShiftStart,
+		&i.CreatedAt,
+		&i.ID,
+		&i.Message,
+		&i.RequesterID,
+		&i.RequesterShiftEnd,
+		&i.RequesterShiftStart,
+		&i.RespondedAt,
+		&i.ScheduleID,
+		&i.Status,
+	)
+	return i, err
+}
+
+const swapRequestForUpdate = `-- name: SwapRequestForUpdate :one
+SELECT
+    counterpart_id, counterpart_shift_end, counterpart_shift_start, created_at, id, message, requester_id, requester_shift_end, requester_shift_start, responded_at, schedule_id, status
+FROM
+    shift_swap_requests
+WHERE
+    id = $1
+FOR UPDATE
+`
+
+func (q *Queries) SwapRequestForUpdate(ctx context.Context, id uuid.UUID) (ShiftSwapRequest, error) {
+	row := q.db.QueryRowContext(ctx, swapRequestForUpdate, id)
+	var i ShiftSwapRequest
+	err := row.Scan(
+		&i.CounterpartID,
+		&i.CounterpartShiftEnd,
+		&i.CounterpartShiftStart,
+		&i.CreatedAt,
+		&i.ID,
+		&i.Message,
+		&i.RequesterID,
+		&i.RequesterShiftEnd,
+		&i.RequesterShiftStart,
+		&i.RespondedAt,
+		&i.ScheduleID,
+		&i.Status,
+	)
+	return i, err
+}
+
+const swapRequestInsert = `-- name: SwapRequestInsert :exec
+INSERT INTO shift_swap_requests(id, schedule_id, requester_id, counterpart_id, requester_shift_start, requester_shift_end, counterpart_shift_start, counterpart_shift_end, message)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+
+type SwapRequestInsertParams struct {
+	ID                    uuid.UUID
+	ScheduleID            uuid.UUID
+	RequesterID           uuid.UUID
+	CounterpartID         uuid.UUID
+	RequesterShiftStart   time.Time
+	RequesterShiftEnd     time.Time
+	CounterpartShiftStart sql.NullTime
+	CounterpartShiftEnd   sql.NullTime
+	Message               string
+}
+
+func (q *Queries) SwapRequestInsert(ctx context.Context, arg SwapRequestInsertParams) error {
+	_, err := q.db.ExecContext(ctx, swapRequestInsert,
+		arg.ID,
+		arg.ScheduleID,
+		arg.RequesterID,
+		arg.CounterpartID,
+		arg.RequesterShiftStart,
+		arg.RequesterShiftEnd,
+		arg.CounterpartShiftStart,
+		arg.CounterpartShiftEnd,
+		arg.Message,
+	)
+	return err
+}
+
+const swapRequestListByUser = `-- name: SwapRequestListByUser :many
+SELECT
+    counterpart_id, counterpart_shift_end, counterpart_shift_start, created_at, id, message, requester_id, requester_shift_end, requester_shift_start, responded_at, schedule_id, status
+FROM
+    shift_swap_requests
+WHERE (requester_id = $1
+    OR counterpart_id = $1)
+AND (NOT $2::bool
+    OR status = 'pending')
+ORDER BY
+    created_at DESC
+LIMIT 100
+`
+
+type SwapRequestListByUserParams struct {
+	UserID      uuid.UUID
+	PendingOnly bool
+}
+
+// SwapRequestListByUser returns the most recent swap requests sent or received by a user.
+func (q *Queries) SwapRequestListByUser(ctx context.Context, arg SwapRequestListByUserParams) ([]ShiftSwapRequest, error) {
+	rows, err := q.db.QueryContext(ctx, swapRequestListByUser, arg.UserID, arg.PendingOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShiftSwapRequest
+	for rows.Next() {
+		var i ShiftSwapRequest
+		if err := rows.Scan(
+			&i.CounterpartID,
+			&i.CounterpartShiftEnd,
+			&i.CounterpartShiftStart,
+			&i.CreatedAt,
+			&i.ID,
+			&i.Message,
+			&i.RequesterID,
+			&i.RequesterShiftEnd,
+			&i.RequesterShiftStart,
+			&i.RespondedAt,
+			&i.ScheduleID,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const swapRequestNotify = `-- name: SwapRequestNotify :execrows
+INSERT INTO outgoing_messages(message_type, contact_method_id, user_id, shift_swap_request_id)
+SELECT
+    'shift_swap_request',
+    cm.id,
+    cm.user_id,
+    $1
+FROM
+    user_contact_methods cm
+WHERE
+    cm.user_id = $2
+    AND NOT cm.disabled
+    AND EXISTS (
+        SELECT
+        FROM
+            user_notification_rules r
+        WHERE
+            r.contact_method_id = cm.id
+            AND r.delay_minutes = 0)
+`
+
+type SwapRequestNotifyParams struct {
+	RequestID uuid.NullUUID
+	UserID    uuid.UUID
+}
+
+// SwapRequestNotify queues a swap request notification to each contact method the counterpart has set to be notified immediately.
+func (q *Queries) SwapRequestNotify(ctx context.Context, arg SwapRequestNotifyParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, swapRequestNotify, arg.RequestID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const swapRequestSetStatus = `-- name: SwapRequestSetStatus :exec
+UPDATE
+    shift_swap_requests
+SET
+    status = $2,
+    responded_at = now()
+WHERE
+    id = $1
+`
+
+type SwapRequestSetStatusParams struct {
+	ID     uuid.UUID
+	Status EnumShiftSwapStatus
+}
+
+func (q *Queries) SwapRequestSetStatus(ctx context.Context, arg SwapRequestSetStatusParams) error {
+	_, err := q.db.ExecContext(ctx, swapRequestSetStatus, arg.ID, arg.Status)
+	return err
+}
+
 const tableColumns = `-- name: TableColumns :many
 SELECT col.table_name::text,
     col.column_name::text,
@@ -5984,6 +9182,166 @@ func (q *Queries) TableColumns(ctx context.Context) ([]TableColumnsRow, error) {
 	return items, nil
 }
 
+const unavailabilityCount = `-- name: UnavailabilityCount :one
+SELECT
+    count(*)
+FROM
+    user_unavailability
+WHERE
+    user_id = $1
+    AND end_time > now()
+`
+
+// Returns the number of current and upcoming unavailability windows of a user.
+func (q *Queries) UnavailabilityCount(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, unavailabilityCount, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const unavailabilityCreate = `-- name: UnavailabilityCreate :exec
+INSERT INTO user_unavailability(id, user_id, start_time, end_time, reason)
+    VALUES ($1, $2, $3, $4, $5)
+`
+
+type UnavailabilityCreateParams struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	StartTime time.Time
+	EndTime   time.Time
+	Reason    string
+}
+
+func (q *Queries) UnavailabilityCreate(ctx context.Context, arg UnavailabilityCreateParams) error {
+	_, err := q.db.ExecContext(ctx, unavailabilityCreate,
+		arg.ID,
+		arg.UserID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Reason,
+	)
+	return err
+}
+
+const unavailabilityDelete = `-- name: UnavailabilityDelete :exec
+DELETE FROM user_unavailability
+WHERE id = $1
+`
+
+func (q *Queries) UnavailabilityDelete(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, unavailabilityDelete, id)
+	return err
+}
+
+const unavailabilityDeleteOverrides = `-- name: UnavailabilityDeleteOverrides :exec
+DELETE FROM user_overrides
+WHERE unavailability_id = $1
+    AND end_time > now()
+`
+
+// Deletes current and upcoming overrides generated from an unavailability window; past overrides are kept for history.
+func (q *Queries) UnavailabilityDeleteOverrides(ctx context.Context, unavailabilityID uuid.NullUUID) error {
+	_, err := q.db.ExecContext(ctx, unavailabilityDeleteOverrides, unavailabilityID)
+	return err
+}
+
+const unavailabilityFindMany = `-- name: UnavailabilityFindMany :many
+SELECT
+    id,
+    user_id,
+    start_time,
+    end_time,
+    reason
+FROM
+    user_unavailability
+WHERE
+    user_id = ANY ($1::uuid[])
+    AND start_time < $2
+    AND end_time > $3
+ORDER BY
+    start_time,
+    id
+`
+
+type UnavailabilityFindManyParams struct {
+	UserIds   []uuid.UUID
+	EndTime   time.Time
+	StartTime time.Time
+}
+
+type UnavailabilityFindManyRow struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	StartTime time.Time
+	EndTime   time.Time
+	Reason    string
+}
+
+// Returns the unavailability windows of the given users overlapping the time range.
+func (q *Queries) UnavailabilityFindMany(ctx context.Context, arg UnavailabilityFindManyParams) ([]UnavailabilityFindManyRow, error) {
+	rows, err := q.db.QueryContext(ctx, unavailabilityFindMany, pq.Array(arg.UserIds), arg.EndTime, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UnavailabilityFindManyRow
+	for rows.Next() {
+		var i UnavailabilityFindManyRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unavailabilityFindOne = `-- name: UnavailabilityFindOne :one
+SELECT
+    id,
+    user_id,
+    start_time,
+    end_time,
+    reason
+FROM
+    user_unavailability
+WHERE
+    id = $1
+`
+
+type UnavailabilityFindOneRow struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	StartTime time.Time
+	EndTime   time.Time
+	Reason    string
+}
+
+func (q *Queries) UnavailabilityFindOne(ctx context.Context, id uuid.UUID) (UnavailabilityFindOneRow, error) {
+	row := q.db.QueryRowContext(ctx, unavailabilityFindOne, id)
+	var i UnavailabilityFindOneRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Reason,
+	)
+	return i, err
+}
+
 const updateCalSub = `-- name: UpdateCalSub :exec
 UPDATE
     user_calendar_subscriptions
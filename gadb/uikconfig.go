@@ -54,6 +54,12 @@ type UIKConfigV1 struct {
 
 	// DefaultActions are the actions to take if no rules match.
 	DefaultActions []UIKActionV1
+
+	// TemplateID is the ID of the built-in template the config was created from, if any.
+	TemplateID string
+
+	// TemplateVersion is the version of the template the config was created from.
+	TemplateVersion int
 }
 
 // UIKRuleV1 is a set of conditions and actions to take if those conditions are met.
@@ -0,0 +1,152 @@
+package genericapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+const (
+	// maxBatchAlerts is the maximum number of alerts accepted in a single batch request.
+	maxBatchAlerts = 5000
+
+	// batchChunkSize is the number of alerts processed in each transaction.
+	batchChunkSize = 100
+
+	maxBatchBodySize = 16 * 1024 * 1024
+)
+
+// batchAlert is a single alert of a batch request, with the same fields as ServeCreateAlert.
+type batchAlert struct {
+	Summary, Details, Action, Dedup string
+	Meta                            map[string]string
+}
+
+// batchResult is the result of a single alert of a batch request.
+type batchResult struct {
+	AlertID   int
+	ServiceID string
+	IsNew     bool
+	Error     string `json:",omitempty"`
+}
+
+// parseBatchAlerts parses a JSON array or newline-delimited JSON (NDJSON) of alerts.
+func parseBatchAlerts(contentType string, r io.Reader) ([]batchAlert, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	var alerts []batchAlert
+	switch mediaType {
+	case "application/json":
+		err := json.NewDecoder(r).Decode(&alerts)
+		if err != nil {
+			return nil, validation.WrapError(err)
+		}
+	case "application/x-ndjson", "application/jsonl":
+		dec := json.NewDecoder(r)
+		for {
+			var a batchAlert
+			err := dec.Decode(&a)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, validation.NewFieldError(fmt.Sprintf("Alerts[%d]", len(alerts)), err.Error())
+			}
+			alerts = append(alerts, a)
+		}
+	default:
+		return nil, validation.NewFieldError("Content-Type", "must be application/json or application/x-ndjson")
+	}
+
+	return alerts, validate.Range("Alerts", len(alerts), 1, maxBatchAlerts)
+}
+
+// ServeCreateAlertBatch allows creating or closing many alerts in a single request.
+//
+// The body is a JSON array, or newline-delimited JSON, of objects with the same fields
+// as the JSON body of ServeCreateAlert. Alerts are processed in chunks, one transaction
+// per chunk, and the response contains a result for each alert, in order.
+func (h *Handler) ServeCreateAlertBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := permission.LimitCheckAny(ctx, permission.Service)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	serviceID := permission.ServiceID(ctx)
+
+	alerts, err := parseBatchAlerts(r.Header.Get("Content-Type"), http.MaxBytesReader(w, r.Body, maxBatchBodySize))
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	items := make([]alert.BatchItem, len(alerts))
+	for i, a := range alerts {
+		items[i] = alert.BatchItem{
+			Alert: newGenericAlert(serviceID, a.Summary, a.Details, a.Action, a.Dedup),
+			Meta:  a.Meta,
+		}
+	}
+
+	var resp struct {
+		Results []batchResult
+	}
+	resp.Results = make([]batchResult, 0, len(items))
+	for start := 0; start < len(items); start += batchChunkSize {
+		chunk := items[start:min(start+batchChunkSize, len(items))]
+
+		var results []alert.BatchResult
+		err = retry.DoTemporaryError(func(int) error {
+			var err error
+			results, err = h.c.AlertStore.CreateOrUpdateBatch(ctx, chunk)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if err != nil && start == 0 {
+			errutil.HTTPError(ctx, w, errors.Wrap(err, "create alerts"))
+			return
+		}
+		if err != nil {
+			// earlier chunks were already committed, so report the failure for the remaining alerts
+			log.Log(ctx, errors.Wrap(err, "create alerts"))
+			for range items[start:] {
+				resp.Results = append(resp.Results, batchResult{Error: "failed to process alert"})
+			}
+			break
+		}
+
+		for _, res := range results {
+			var br batchResult
+			if res.Err != nil {
+				br.Error = res.Err.Error()
+			}
+			if res.Alert != nil {
+				br.AlertID = res.Alert.ID
+				br.ServiceID = res.Alert.ServiceID
+				br.IsNew = res.IsNew
+			}
+			resp.Results = append(resp.Results, br)
+		}
+	}
+
+	data, err := json.Marshal(&resp)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,37 @@
+package genericapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBatchAlerts(t *testing.T) {
+	alerts, err := parseBatchAlerts("application/json", strings.NewReader(`[
+		{"summary": "disk full", "dedup": "db1", "meta": {"region": "us-east"}},
+		{"dedup": "db2", "action": "close"}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, batchAlert{Summary: "disk full", Dedup: "db1", Meta: map[string]string{"region": "us-east"}}, alerts[0])
+	assert.Equal(t, batchAlert{Dedup: "db2", Action: "close"}, alerts[1])
+
+	alerts, err = parseBatchAlerts("application/x-ndjson; charset=utf-8", strings.NewReader(
+		`{"summary": "disk full", "dedup": "db1"}`+"\n"+
+			`{"dedup": "db2", "action": "close"}`+"\n",
+	))
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "db2", alerts[1].Dedup)
+
+	_, err = parseBatchAlerts("application/x-ndjson", strings.NewReader(`{"summary": "ok"}`+"\n"+`not json`))
+	assert.ErrorContains(t, err, "Alerts[1]")
+
+	_, err = parseBatchAlerts("application/json", strings.NewReader(`[]`))
+	assert.Error(t, err, "empty batches should be rejected")
+
+	_, err = parseBatchAlerts("text/plain", strings.NewReader(`[{"summary": "ok"}]`))
+	assert.Error(t, err, "unknown content types should be rejected")
+}
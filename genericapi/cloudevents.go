@@ -0,0 +1,424 @@
+package genericapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// CloudEvent types accepted by ServeCloudEvent.
+const (
+	CloudEventTypeTrigger = "com.goalert.alert.trigger"
+	CloudEventTypeAck     = "com.goalert.alert.ack"
+	CloudEventTypeClose   = "com.goalert.alert.close"
+)
+
+const (
+	ceContentTypeStructured = "application/cloudevents+json"
+	ceContentTypeBatch      = "application/cloudevents-batch+json"
+
+	// ceExtDedup is the extension attribute that sets the dedup key, if not set in the event data.
+	ceExtDedup = "dedup"
+
+	// maxCloudEventBatch is the maximum number of events accepted in a single batch request.
+	maxCloudEventBatch = 100
+)
+
+// ceContextAttrs are the CloudEvents context attributes; any other attribute is an extension.
+var ceContextAttrs = map[string]bool{
+	"specversion":     true,
+	"id":              true,
+	"source":          true,
+	"type":            true,
+	"subject":         true,
+	"time":            true,
+	"datacontenttype": true,
+	"dataschema":      true,
+	"data":            true,
+	"data_base64":     true,
+}
+
+// cloudEvent is a CloudEvent (v1.0) with the alert data of the event.
+type cloudEvent struct {
+	SpecVersion string
+	ID          string
+	Source      string
+	Type        string
+	Subject     string
+
+	// Extensions are the extension attributes of the event, as strings.
+	Extensions map[string]string
+
+	Data struct {
+		Summary string
+		Details string
+		Dedup   string
+	}
+}
+
+// ceAttrString returns a string representation of an attribute value.
+func ceAttrString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// setData will parse the alert data of an event, data must be JSON.
+func (e *cloudEvent) setData(contentType string, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return validation.NewFieldError("datacontenttype", err.Error())
+		}
+		if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+			return validation.NewFieldError("datacontenttype", "must be JSON")
+		}
+	}
+
+	err := json.Unmarshal(data, &e.Data)
+	if err != nil {
+		return validation.NewFieldError("data", err.Error())
+	}
+
+	return nil
+}
+
+// parseStructuredCloudEvent parses an event in structured mode.
+func parseStructuredCloudEvent(raw map[string]json.RawMessage) (*cloudEvent, error) {
+	var e cloudEvent
+	e.Extensions = make(map[string]string)
+	attrs := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if k == "data" || k == "data_base64" {
+			continue
+		}
+
+		var val any
+		err := json.Unmarshal(v, &val)
+		if err != nil {
+			return nil, validation.NewFieldError(k, err.Error())
+		}
+		attrs[k] = ceAttrString(val)
+		if !ceContextAttrs[k] {
+			e.Extensions[k] = attrs[k]
+		}
+	}
+	e.SpecVersion = attrs["specversion"]
+	e.ID = attrs["id"]
+	e.Source = attrs["source"]
+	e.Type = attrs["type"]
+	e.Subject = attrs["subject"]
+
+	var data []byte
+	if b64, ok := raw["data_base64"]; ok {
+		var s string
+		err := json.Unmarshal(b64, &s)
+		if err != nil {
+			return nil, validation.NewFieldError("data_base64", err.Error())
+		}
+		data, err = base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, validation.NewFieldError("data_base64", err.Error())
+		}
+	} else if d, ok := raw["data"]; ok && string(d) != "null" {
+		data = d
+		var s string
+		if json.Unmarshal(d, &s) == nil {
+			// JSON data encoded as a string
+			data = []byte(s)
+		}
+	}
+
+	return &e, e.setData(attrs["datacontenttype"], data)
+}
+
+// parseBinaryCloudEvent parses an event in binary mode, where attributes are sent as ce- headers.
+func parseBinaryCloudEvent(h http.Header, body []byte) (*cloudEvent, error) {
+	e := cloudEvent{Extensions: make(map[string]string)}
+	for k := range h {
+		name, ok := strings.CutPrefix(strings.ToLower(k), "ce-")
+		if !ok {
+			continue
+		}
+		// header values are percent-encoded (CloudEvents HTTP binding, section 3.1.3.2)
+		val, err := url.PathUnescape(h.Get(k))
+		if err != nil {
+			return nil, validation.NewFieldError(k, err.Error())
+		}
+		switch name {
+		case "specversion":
+			e.SpecVersion = val
+		case "id":
+			e.ID = val
+		case "source":
+			e.Source = val
+		case "type":
+			e.Type = val
+		case "subject":
+			e.Subject = val
+		default:
+			if !ceContextAttrs[name] {
+				e.Extensions[name] = val
+			}
+		}
+	}
+
+	return &e, e.setData(h.Get("Content-Type"), body)
+}
+
+// isCloudEventBatch returns true if the request is in batch mode.
+func isCloudEventBatch(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == ceContentTypeBatch
+}
+
+// parseCloudEvents parses the events of a request in structured, batch, or binary mode.
+func parseCloudEvents(r *http.Request) ([]cloudEvent, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1024*1024))
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case ceContentTypeStructured:
+		var raw map[string]json.RawMessage
+		err = json.Unmarshal(body, &raw)
+		if err != nil {
+			return nil, validation.WrapError(err)
+		}
+		e, err := parseStructuredCloudEvent(raw)
+		if err != nil {
+			return nil, err
+		}
+		return []cloudEvent{*e}, nil
+	case ceContentTypeBatch:
+		var raw []map[string]json.RawMessage
+		err = json.Unmarshal(body, &raw)
+		if err != nil {
+			return nil, validation.WrapError(err)
+		}
+		err = validate.Range("Events", len(raw), 1, maxCloudEventBatch)
+		if err != nil {
+			return nil, err
+		}
+		events := make([]cloudEvent, len(raw))
+		for i, m := range raw {
+			e, err := parseStructuredCloudEvent(m)
+			if err != nil {
+				return nil, validation.AddPrefix(fmt.Sprintf("Events[%d].", i), err)
+			}
+			events[i] = *e
+		}
+		return events, nil
+	}
+
+	e, err := parseBinaryCloudEvent(r.Header, body)
+	if err != nil {
+		return nil, err
+	}
+	return []cloudEvent{*e}, nil
+}
+
+// Alert returns the alert and metadata for the event.
+func (e cloudEvent) Alert(serviceID string) (*alert.Alert, map[string]string, error) {
+	err := validate.Many(
+		validate.OneOf("specversion", e.SpecVersion, "1.0"),
+		validate.Text("id", e.ID, 1, 255),
+		validate.Text("source", e.Source, 1, 1024),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var status alert.Status
+	switch e.Type {
+	case CloudEventTypeTrigger:
+		status = alert.StatusTriggered
+	case CloudEventTypeAck:
+		status = alert.StatusActive
+	case CloudEventTypeClose:
+		status = alert.StatusClosed
+	default:
+		return nil, nil, validation.NewFieldError("type", fmt.Sprintf("must be one of %s, %s, or %s", CloudEventTypeTrigger, CloudEventTypeAck, CloudEventTypeClose))
+	}
+
+	dedup := e.Data.Dedup
+	if dedup == "" {
+		dedup = e.Extensions[ceExtDedup]
+	}
+	if dedup == "" && e.Subject != "" {
+		dedup = e.Source + "/" + e.Subject
+	}
+	if dedup == "" && status != alert.StatusTriggered {
+		return nil, nil, validation.NewFieldError("dedup", "required to acknowledge or close an alert (set data.dedup, the dedup extension, or subject)")
+	}
+
+	summary := e.Data.Summary
+	if summary == "" {
+		summary = e.Subject
+	}
+	if summary == "" && status != alert.StatusTriggered {
+		// only used to match an existing alert by dedup
+		summary = e.Type
+	}
+
+	meta := make(map[string]string, len(e.Extensions))
+	for k, v := range e.Extensions {
+		if k == ceExtDedup {
+			continue
+		}
+		meta[k] = v
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(e.Data.Details, alert.MaxDetailsLength),
+		Source:    alert.SourceCloudEvents,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup(dedup),
+		Status:    status,
+	}, meta, alert.ValidateMetadata(meta)
+}
+
+// cloudEventResult is the result of a single event of a batch request.
+type cloudEventResult struct {
+	// ID is the id attribute of the event.
+	ID        string
+	AlertID   int    `json:",omitempty"`
+	ServiceID string `json:",omitempty"`
+	IsNew     bool
+	Error     string `json:",omitempty"`
+}
+
+// ServeCloudEvent allows creating, acknowledging, or closing alerts with CloudEvents, in
+// structured, batch, or binary HTTP mode.
+//
+// Extension attributes are set as alert metadata, except for `dedup`.
+//
+// In structured and binary mode, the response is empty on success. In batch mode, events
+// are processed in a single transaction and the response contains a result for each
+// event, in order; an event that is invalid or exceeds a limit has its Error set and does
+// not prevent the others from being processed.
+func (h *Handler) ServeCloudEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := permission.LimitCheckAny(ctx, permission.Service)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	serviceID := permission.ServiceID(ctx)
+
+	events, err := parseCloudEvents(r)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	if isCloudEventBatch(r) {
+		h.serveCloudEventBatch(w, r, serviceID, events)
+		return
+	}
+
+	a, meta, err := events[0].Alert(serviceID)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	err = retry.DoTemporaryError(func(int) error {
+		_, _, err := h.c.AlertStore.CreateOrUpdateWithMeta(ctx, a, meta)
+		return err
+	},
+		retry.Log(ctx),
+		retry.Limit(10),
+		retry.FibBackoff(time.Second),
+	)
+	if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert")) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveCloudEventBatch processes the events of a batch request and responds with the result of each.
+func (h *Handler) serveCloudEventBatch(w http.ResponseWriter, r *http.Request, serviceID string, events []cloudEvent) {
+	ctx := r.Context()
+
+	var resp struct {
+		Results []cloudEventResult
+	}
+	resp.Results = make([]cloudEventResult, len(events))
+
+	var items []alert.BatchItem
+	var itemIdx []int
+	for i, e := range events {
+		resp.Results[i].ID = e.ID
+		a, meta, err := e.Alert(serviceID)
+		if err != nil {
+			resp.Results[i].Error = err.Error()
+			continue
+		}
+		items = append(items, alert.BatchItem{Alert: a, Meta: meta})
+		itemIdx = append(itemIdx, i)
+	}
+
+	if len(items) > 0 {
+		var results []alert.BatchResult
+		err := retry.DoTemporaryError(func(int) error {
+			var err error
+			results, err = h.c.AlertStore.CreateOrUpdateBatch(ctx, items)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alerts")) {
+			return
+		}
+
+		for i, res := range results {
+			r := &resp.Results[itemIdx[i]]
+			if res.Err != nil {
+				r.Error = res.Err.Error()
+			}
+			if res.Alert != nil {
+				r.AlertID = res.Alert.ID
+				r.ServiceID = res.Alert.ServiceID
+				r.IsNew = res.IsNew
+			}
+		}
+	}
+
+	data, err := json.Marshal(&resp)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
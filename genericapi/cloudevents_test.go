@@ -0,0 +1,99 @@
+package genericapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestParseCloudEvents(t *testing.T) {
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{
+		"specversion": "1.0",
+		"id": "1",
+		"source": "/monitor",
+		"type": "com.goalert.alert.trigger",
+		"subject": "db1",
+		"region": "us-east",
+		"data": {"summary": "disk full", "details": "90% used"}
+	}`))
+	req.Header.Set("Content-Type", "application/cloudevents+json; charset=utf-8")
+	events, err := parseCloudEvents(req)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	a, meta, err := events[0].Alert("svc")
+	require.NoError(t, err)
+	assert.Equal(t, "disk full", a.Summary)
+	assert.Equal(t, "90% used", a.Details)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, alert.SourceCloudEvents, a.Source)
+	assert.Equal(t, alert.NewUserDedup("/monitor/db1"), a.Dedup)
+	assert.Equal(t, map[string]string{"region": "us-east"}, meta)
+
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{"summary": "disk ok"}`))
+	req.Header = http.Header{
+		"Content-Type":   {"application/json"},
+		"Ce-Specversion": {"1.0"},
+		"Ce-Id":          {"2"},
+		"Ce-Source":      {"/monitor"},
+		"Ce-Type":        {"com.goalert.alert.close"},
+		"Ce-Dedup":       {"foo"},
+	}
+	events, err = parseCloudEvents(req)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	a, meta, err = events[0].Alert("svc")
+	require.NoError(t, err)
+	assert.Equal(t, alert.StatusClosed, a.Status)
+	assert.Equal(t, alert.NewUserDedup("foo"), a.Dedup)
+	assert.Empty(t, meta, "dedup extension should not be set as metadata")
+
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`[
+		{"specversion": "1.0", "id": "3", "source": "/monitor", "type": "com.goalert.alert.ack", "data": {"dedup": "bar"}},
+		{"specversion": "1.0", "id": "4", "source": "/monitor", "type": "com.goalert.alert.ack"}
+	]`))
+	req.Header.Set("Content-Type", "application/cloudevents-batch+json")
+	events, err = parseCloudEvents(req)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	a, _, err = events[0].Alert("svc")
+	require.NoError(t, err)
+	assert.Equal(t, alert.StatusActive, a.Status)
+	assert.Equal(t, alert.NewUserDedup("bar"), a.Dedup)
+
+	_, _, err = events[1].Alert("svc")
+	assert.Error(t, err, "ack without a dedup should be rejected")
+
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{}`))
+	req.Header = http.Header{
+		"Content-Type":   {"application/json"},
+		"Ce-Specversion": {"1.0"},
+		"Ce-Id":          {"5"},
+		"Ce-Source":      {"/monitor"},
+		"Ce-Type":        {"com.goalert.alert.trigger"},
+		"Ce-Subject":     {"disk%20full%20%E2%80%94%20db1"},
+		"Ce-Region":      {"us%2Deast"},
+	}
+	events, err = parseCloudEvents(req)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "disk full — db1", events[0].Subject, "header values should be percent-decoded")
+	assert.Equal(t, map[string]string{"region": "us-east"}, events[0].Extensions)
+
+	req.Header.Set("Ce-Subject", "100%")
+	req.Body = io.NopCloser(strings.NewReader(`{}`))
+	_, err = parseCloudEvents(req)
+	assert.Error(t, err, "invalid percent-encoding should be rejected")
+
+	events[0].Type = "com.example.unknown"
+	_, _, err = events[0].Alert("svc")
+	assert.Error(t, err, "unknown event types should be rejected")
+}
@@ -65,6 +65,23 @@ func (h *Handler) ServeHeartbeatCheck(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// newGenericAlert returns the alert for a generic API request.
+func newGenericAlert(serviceID, summary, details, action, dedup string) *alert.Alert {
+	status := alert.StatusTriggered
+	if action == "close" {
+		status = alert.StatusClosed
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(details, alert.MaxDetailsLength),
+		Source:    alert.SourceGeneric,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup(dedup),
+		Status:    status,
+	}
+}
+
 // ServeCreateAlert allows creating or closing an alert.
 func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -122,22 +139,7 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	status := alert.StatusTriggered
-	if action == "close" {
-		status = alert.StatusClosed
-	}
-
-	summary = validate.SanitizeText(summary, alert.MaxSummaryLength)
-	details = validate.SanitizeText(details, alert.MaxDetailsLength)
-
-	a := &alert.Alert{
-		Summary:   summary,
-		Details:   details,
-		Source:    alert.SourceGeneric,
-		ServiceID: serviceID,
-		Dedup:     alert.NewUserDedup(dedup),
-		Status:    status,
-	}
+	a := newGenericAlert(serviceID, summary, details, action, dedup)
 
 	var resp struct {
 		AlertID   int
@@ -40,12 +40,16 @@ import (
 	"github.com/target/goalert/notification/slack"
 	"github.com/target/goalert/notification/twilio"
 	"github.com/target/goalert/notificationchannel"
+	"github.com/target/goalert/oauth"
 	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/oncall/fairness"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/holiday"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/schedule/swap"
 	"github.com/target/goalert/service"
 	"github.com/target/goalert/swo"
 	"github.com/target/goalert/timezone"
@@ -53,6 +57,7 @@ import (
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/favorite"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/user/unavailability"
 	"github.com/target/goalert/util/calllimiter"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
@@ -73,21 +78,26 @@ type App struct {
 	AlertLogStore     *alertlog.Store
 	ServiceStore      *service.Store
 	FavoriteStore     *favorite.Store
+	UnavailStore      *unavailability.Store
 	PolicyStore       *escalation.Store
 	ScheduleStore     *schedule.Store
 	CalSubStore       *calsub.Store
 	RotationStore     *rotation.Store
 	OnCallStore       *oncall.Store
+	FairnessStore     *fairness.Store
 	IntKeyStore       *integrationkey.Store
 	LabelStore        *label.Store
 	RuleStore         *rule.Store
 	OverrideStore     *override.Store
+	SwapStore         *swap.Store
+	HolidayStore      *holiday.Store
 	ConfigStore       *config.Store
 	LimitStore        *limit.Store
 	SlackStore        *slack.ChannelSender
 	HeartbeatStore    *heartbeat.Store
 	NoticeStore       *notice.Store
 	APIKeyStore       *apikey.Store
+	OAuthStore        *oauth.Store
 
 	AuthLinkStore *authlink.Store
 
@@ -7,9 +7,11 @@ import (
 
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/service"
 )
 
 type UserCalendarSubscription App
@@ -25,10 +27,53 @@ func (a *UserCalendarSubscription) FullSchedule(ctx context.Context, obj *calsub
 	return obj.Config.FullSchedule, nil
 }
 
+func optionalID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+func (a *UserCalendarSubscription) ScheduleID(ctx context.Context, obj *calsub.Subscription) (*string, error) {
+	return optionalID(obj.ScheduleID), nil
+}
+
 func (a *UserCalendarSubscription) Schedule(ctx context.Context, obj *calsub.Subscription) (*schedule.Schedule, error) {
+	if obj.ScheduleID == "" {
+		return nil, nil
+	}
 	return a.ScheduleStore.FindOne(ctx, obj.ScheduleID)
 }
 
+func (a *UserCalendarSubscription) AdditionalScheduleIDs(ctx context.Context, obj *calsub.Subscription) ([]string, error) {
+	if obj.Config.AdditionalScheduleIDs == nil {
+		return []string{}, nil
+	}
+	return obj.Config.AdditionalScheduleIDs, nil
+}
+
+func (a *UserCalendarSubscription) ServiceID(ctx context.Context, obj *calsub.Subscription) (*string, error) {
+	return optionalID(obj.ServiceID), nil
+}
+
+func (a *UserCalendarSubscription) Service(ctx context.Context, obj *calsub.Subscription) (*service.Service, error) {
+	if obj.ServiceID == "" {
+		return nil, nil
+	}
+	return (*App)(a).FindOneService(ctx, obj.ServiceID)
+}
+
+func (a *UserCalendarSubscription) EscalationPolicyID(ctx context.Context, obj *calsub.Subscription) (*string, error) {
+	return optionalID(obj.EscalationPolicyID), nil
+}
+
+func (a *UserCalendarSubscription) EscalationPolicy(ctx context.Context, obj *calsub.Subscription) (*escalation.Policy, error) {
+	if obj.EscalationPolicyID == "" {
+		return nil, nil
+	}
+	return (*App)(a).FindOnePolicy(ctx, obj.EscalationPolicyID)
+}
+
 func (a *UserCalendarSubscription) URL(ctx context.Context, obj *calsub.Subscription) (*string, error) {
 	tok := obj.Token()
 	if tok == "" {
@@ -50,10 +95,19 @@ func (q *Query) UserCalendarSubscription(ctx context.Context, id string) (*calsu
 // todo: return UserCalendarSubscription with generated url once endpoint has been created
 func (m *Mutation) CreateUserCalendarSubscription(ctx context.Context, input graphql2.CreateUserCalendarSubscriptionInput) (cs *calsub.Subscription, err error) {
 	cs = &calsub.Subscription{
-		Name:       input.Name,
-		ScheduleID: input.ScheduleID,
-		UserID:     permission.UserID(ctx),
+		Name:   input.Name,
+		UserID: permission.UserID(ctx),
+	}
+	if input.ScheduleID != nil {
+		cs.ScheduleID = *input.ScheduleID
 	}
+	if input.ServiceID != nil {
+		cs.ServiceID = *input.ServiceID
+	}
+	if input.EscalationPolicyID != nil {
+		cs.EscalationPolicyID = *input.EscalationPolicyID
+	}
+	cs.Config.AdditionalScheduleIDs = input.AdditionalScheduleIDs
 	if input.Disabled != nil {
 		cs.Disabled = *input.Disabled
 	}
@@ -89,6 +143,9 @@ func (m *Mutation) UpdateUserCalendarSubscription(ctx context.Context, input gra
 		if input.FullSchedule != nil {
 			cs.Config.FullSchedule = *input.FullSchedule
 		}
+		if input.AdditionalScheduleIDs != nil {
+			cs.Config.AdditionalScheduleIDs = input.AdditionalScheduleIDs
+		}
 
 		return m.CalSubStore.UpdateTx(ctx, tx, cs)
 	})
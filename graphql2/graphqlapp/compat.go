@@ -3,6 +3,7 @@ package graphqlapp
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/gadb"
@@ -33,10 +34,9 @@ func (a *App) CompatTargetToDest(ctx context.Context, tgt assignment.Target) (ga
 			Args: map[string]string{rotation.FieldRotationID: tgt.TargetID()},
 		}, nil
 	case assignment.TargetTypeSchedule:
-		return gadb.DestV1{
-			Type: schedule.DestTypeSchedule,
-			Args: map[string]string{schedule.FieldScheduleID: tgt.TargetID()},
-		}, nil
+		// schedule tiers are referenced as `scheduleID:tier`
+		schedID, tier, _ := strings.Cut(tgt.TargetID(), ":")
+		return schedule.DestFromTier(schedID, tier), nil
 	case assignment.TargetTypeChanWebhook:
 		return gadb.DestV1{
 			Type: webhook.DestTypeWebhook,
@@ -117,9 +117,13 @@ func CompatDestToTarget(d gadb.DestV1) (assignment.RawTarget, error) {
 			ID:   d.Arg(rotation.FieldRotationID),
 		}, nil
 	case schedule.DestTypeSchedule:
+		id := d.Arg(schedule.FieldScheduleID)
+		if tier := d.Arg(schedule.FieldScheduleTier); tier != "" {
+			id += ":" + tier
+		}
 		return assignment.RawTarget{
 			Type: assignment.TargetTypeSchedule,
-			ID:   d.Arg(schedule.FieldScheduleID),
+			ID:   id,
 		}, nil
 	case slack.DestTypeSlackChannel:
 		return assignment.RawTarget{
@@ -17,6 +17,11 @@ func (a *Query) IntegrationKeyTypes(ctx context.Context) ([]graphql2.Integration
 		{ID: "grafana", Name: "Grafana", Label: "Grafana Webhook URL", Enabled: true},
 		{ID: "site24x7", Name: "Site 24x7", Label: "Site24x7 Webhook URL", Enabled: true},
 		{ID: "prometheusAlertmanager", Label: "Alertmanager Webhook URL", Name: "Prometheus Alertmanager", Enabled: true},
+		{ID: "amazonSNS", Label: "SNS HTTPS Subscription Endpoint", Name: "Amazon CloudWatch (SNS)", Enabled: true},
+		{ID: "zabbix", Label: "Zabbix Webhook URL", Name: "Zabbix", Enabled: true},
+		{ID: "nagios", Label: "Nagios Notification URL", Name: "Nagios", Enabled: true},
+		{ID: "icinga", Label: "Icinga Notification URL", Name: "Icinga", Enabled: true},
+		{ID: "cloudEvents", Label: "CloudEvents Webhook URL", Name: "CloudEvents", Enabled: true},
 	}
 
 	if expflag.ContextHas(ctx, expflag.UnivKeys) {
@@ -0,0 +1,51 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/oncall/fairness"
+)
+
+func (q *Query) OnCallFairnessReport(ctx context.Context, input *graphql2.OnCallFairnessReportInput) ([]graphql2.OnCallFairnessReportRow, error) {
+	var opts fairness.Opts
+	if input != nil {
+		if input.Start != nil {
+			opts.Start = *input.Start
+		}
+		if input.End != nil {
+			opts.End = *input.End
+		}
+		if input.Period != nil {
+			opts.Period = *input.Period
+		}
+		if input.WorkdayStart != nil {
+			opts.WorkdayStart = *input.WorkdayStart
+		}
+		if input.WorkdayEnd != nil {
+			opts.WorkdayEnd = *input.WorkdayEnd
+		}
+		opts.ScheduleIDs = input.ScheduleIDs
+	}
+
+	rows, err := q.FairnessStore.Report(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]graphql2.OnCallFairnessReportRow, len(rows))
+	for i, r := range rows {
+		res[i] = graphql2.OnCallFairnessReportRow{
+			PeriodStart:     r.PeriodStart,
+			PeriodEnd:       r.PeriodEnd,
+			UserID:          r.UserID,
+			UserName:        r.UserName,
+			OnCallHours:     r.OnCall.Hours(),
+			AfterHoursHours: r.AfterHours.Hours(),
+			WeekendHours:    r.Weekend.Hours(),
+			Pages:           r.Pages,
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,128 @@
+package graphqlapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/schedule/holiday"
+	"github.com/target/goalert/validation"
+)
+
+type HolidayCalendar App
+
+func (a *App) HolidayCalendar() graphql2.HolidayCalendarResolver { return (*HolidayCalendar)(a) }
+
+func gqlHoliday(h holiday.Holiday) graphql2.Holiday {
+	return graphql2.Holiday{
+		ID:         h.ID.String(),
+		CalendarID: h.CalendarID.String(),
+		Name:       h.Name,
+		Start:      h.Start,
+		End:        h.End,
+	}
+}
+
+func (a *HolidayCalendar) Holidays(ctx context.Context, obj *holiday.Calendar, start, end *time.Time) ([]graphql2.Holiday, error) {
+	s := holiday.Date(time.Now())
+	if start != nil {
+		s = *start
+	}
+	e := s.AddDate(1, 0, 0)
+	if end != nil {
+		e = *end
+	}
+	if e.Before(s) {
+		return nil, validation.NewFieldError("end", "must not be before start")
+	}
+
+	hols, err := a.HolidayStore.FindHolidays(ctx, obj.ID, s, e)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]graphql2.Holiday, len(hols))
+	for i, h := range hols {
+		res[i] = gqlHoliday(h)
+	}
+
+	return res, nil
+}
+
+func (q *Query) HolidayCalendars(ctx context.Context) ([]holiday.Calendar, error) {
+	return q.HolidayStore.FindAllCalendars(ctx)
+}
+
+func (q *Query) HolidayCalendar(ctx context.Context, id string) (*holiday.Calendar, error) {
+	return q.HolidayStore.FindOneCalendar(ctx, id)
+}
+
+func (a *Mutation) CreateHolidayCalendar(ctx context.Context, input graphql2.CreateHolidayCalendarInput) (*holiday.Calendar, error) {
+	cal := holiday.Calendar{Name: input.Name}
+	if input.Description != nil {
+		cal.Description = *input.Description
+	}
+
+	return a.HolidayStore.CreateCalendar(ctx, cal)
+}
+
+func (a *Mutation) UpdateHolidayCalendar(ctx context.Context, input graphql2.UpdateHolidayCalendarInput) (bool, error) {
+	cal, err := a.HolidayStore.FindOneCalendar(ctx, input.ID)
+	if err != nil {
+		return false, err
+	}
+	if input.Name != nil {
+		cal.Name = *input.Name
+	}
+	if input.Description != nil {
+		cal.Description = *input.Description
+	}
+
+	err = a.HolidayStore.UpdateCalendar(ctx, *cal)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (a *Mutation) DeleteHolidayCalendar(ctx context.Context, id string) (bool, error) {
+	err := a.HolidayStore.DeleteCalendar(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (a *Mutation) AddHoliday(ctx context.Context, input graphql2.AddHolidayInput) (*graphql2.Holiday, error) {
+	h := holiday.Holiday{
+		Name:  input.Name,
+		Start: input.Start,
+		End:   input.Start,
+	}
+	if input.End != nil {
+		h.End = *input.End
+	}
+
+	res, err := a.HolidayStore.AddHoliday(ctx, input.CalendarID, h)
+	if err != nil {
+		return nil, err
+	}
+
+	gql := gqlHoliday(*res)
+	return &gql, nil
+}
+
+func (a *Mutation) DeleteHoliday(ctx context.Context, id string) (bool, error) {
+	err := a.HolidayStore.DeleteHoliday(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (a *Mutation) ImportHolidays(ctx context.Context, input graphql2.ImportHolidaysInput) (int, error) {
+	return a.HolidayStore.ImportICal(ctx, input.CalendarID, []byte(input.ICal))
+}
@@ -7,9 +7,11 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/event"
 	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/integrationkey/uiktemplate"
 	"github.com/target/goalert/search"
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
@@ -173,8 +175,18 @@ func (m *Mutation) UpdateKeyConfig(ctx context.Context, input graphql2.UpdateKey
 			cfg.DefaultActions = input.DefaultActions
 		}
 
+		// The config no longer matches the template it was created from, so stop
+		// offering upgrades; applying one would discard the changes made here.
+		cfg.TemplateID = ""
+		cfg.TemplateVersion = 0
+
 		err = m.IntKeyStore.SetConfig(ctx, tx, id, cfg)
-		return err
+		if err != nil {
+			return err
+		}
+
+		event.SendTx(ctx, m.EventBus, tx, integrationkey.ConfigUpdate{KeyID: id})
+		return nil
 	})
 	if err != nil {
 		return false, err
@@ -197,8 +209,23 @@ func (m *Mutation) CreateIntegrationKey(ctx context.Context, input graphql2.Crea
 		if input.ExternalSystemName != nil {
 			key.ExternalSystemName = *input.ExternalSystemName
 		}
+		if input.TemplateID != nil && key.Type != integrationkey.TypeUniversal {
+			return validation.NewFieldError("TemplateID", "templates are only supported for universal keys")
+		}
 		key, err = m.IntKeyStore.Create(ctx, tx, key)
-		return err
+		if err != nil {
+			return err
+		}
+		if input.TemplateID == nil {
+			return nil
+		}
+
+		tmpl, err := uiktemplate.Find(*input.TemplateID)
+		if err != nil {
+			return err
+		}
+		cfg := tmpl.Config()
+		return m.IntKeyStore.SetConfig(ctx, tx, uuid.MustParse(key.ID), &cfg)
 	})
 	return key, err
 }
@@ -229,6 +256,16 @@ func (key *IntegrationKey) Href(ctx context.Context, raw *integrationkey.Integra
 		return cfg.CallbackURL("/api/v2/site24x7/incoming", q), nil
 	case integrationkey.TypePrometheusAlertmanager:
 		return cfg.CallbackURL("/api/v2/prometheusalertmanager/incoming", q), nil
+	case integrationkey.TypeAmazonSNS:
+		return cfg.CallbackURL("/api/v2/amazonsns/incoming", q), nil
+	case integrationkey.TypeZabbix:
+		return cfg.CallbackURL("/api/v2/zabbix/incoming", q), nil
+	case integrationkey.TypeNagios:
+		return cfg.CallbackURL("/api/v2/nagios/incoming", q), nil
+	case integrationkey.TypeIcinga:
+		return cfg.CallbackURL("/api/v2/icinga/incoming", q), nil
+	case integrationkey.TypeCloudEvents:
+		return cfg.CallbackURL("/api/v2/cloudevents/incoming", q), nil
 	case integrationkey.TypeEmail:
 		if !cfg.EmailIngressEnabled() {
 			return "", nil
@@ -110,6 +110,12 @@ func msgTypeFriendlyName(msgType gadb.EnumOutgoingMessagesType) string {
 		return "On-Call Notification"
 	case gadb.EnumOutgoingMessagesTypeSignalMessage:
 		return "Signal Message"
+	case gadb.EnumOutgoingMessagesTypeShiftSwapRequest:
+		return "Shift Swap Request"
+	case gadb.EnumOutgoingMessagesTypeScheduleCoverageGap:
+		return "Coverage Gap Notification"
+	case gadb.EnumOutgoingMessagesTypeScheduleHandoffReport:
+		return "Handoff Report"
 	case gadb.EnumOutgoingMessagesTypeAlertStatusUpdateBundle:
 		return "Status Bundle" // deprecated
 	case gadb.EnumOutgoingMessagesTypeTestNotification:
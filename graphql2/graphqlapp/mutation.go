@@ -88,6 +88,19 @@ func (a *Mutation) SetScheduleOnCallNotificationRules(ctx context.Context, input
 	return err == nil, err
 }
 
+func (a *Mutation) SetScheduleHandoffReport(ctx context.Context, input graphql2.SetScheduleHandoffReportInput) (bool, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	err = a.ScheduleStore.SetHandoffReportConfig(ctx, nil, schedID, schedule.HandoffReportConfig{
+		NotifyUsers: input.NotifyUsers,
+	})
+
+	return err == nil, err
+}
+
 func (a *Mutation) SetTemporarySchedule(ctx context.Context, input graphql2.SetTemporaryScheduleInput) (bool, error) {
 	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
 	if err != nil {
@@ -0,0 +1,106 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/oauth"
+	"github.com/target/goalert/user"
+)
+
+type OAuthClient App
+
+func (a *App) OAuthClient() graphql2.OAuthClientResolver { return (*OAuthClient)(a) }
+
+func (a *OAuthClient) CreatedBy(ctx context.Context, obj *graphql2.OAuthClient) (*user.User, error) {
+	if obj.CreatedBy == nil {
+		return nil, nil
+	}
+
+	return (*App)(a).FindOneUser(ctx, obj.CreatedBy.ID)
+}
+
+func (q *Query) OauthClients(ctx context.Context) ([]graphql2.OAuthClient, error) {
+	clients, err := q.OAuthStore.FindAllClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]graphql2.OAuthClient, len(clients))
+	for i, c := range clients {
+		res[i] = graphql2.OAuthClient{
+			ID:           c.ID.String(),
+			Name:         c.Name,
+			Description:  c.Description,
+			RedirectURIs: c.RedirectURIs,
+			Confidential: c.Confidential,
+			CreatedAt:    c.CreatedAt,
+		}
+
+		if c.CreatedBy != nil {
+			res[i].CreatedBy = &user.User{ID: c.CreatedBy.String()}
+		}
+	}
+
+	return res, nil
+}
+
+func (q *Query) OauthGrants(ctx context.Context) ([]graphql2.OAuthGrant, error) {
+	grants, err := q.OAuthStore.FindAllUserGrants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]graphql2.OAuthGrant, len(grants))
+	for i, g := range grants {
+		res[i] = graphql2.OAuthGrant{
+			ID:         g.ID.String(),
+			ClientID:   g.ClientID.String(),
+			ClientName: g.ClientName,
+			Scopes:     g.Scopes,
+			CreatedAt:  g.CreatedAt,
+			LastUsedAt: g.LastUsedAt,
+		}
+	}
+
+	return res, nil
+}
+
+func (a *Mutation) CreateOAuthClient(ctx context.Context, input graphql2.CreateOAuthClientInput) (*graphql2.CreatedOAuthClient, error) {
+	id, secret, err := a.OAuthStore.CreateClient(ctx, oauth.NewClientOpts{
+		Name:         input.Name,
+		Desc:         input.Description,
+		RedirectURIs: input.RedirectURIs,
+		Confidential: input.Confidential,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &graphql2.CreatedOAuthClient{ID: id.String()}
+	if secret != "" {
+		res.Secret = &secret
+	}
+
+	return res, nil
+}
+
+func (a *Mutation) DeleteOAuthClient(ctx context.Context, input string) (bool, error) {
+	id, err := parseUUID("ID", input)
+	if err != nil {
+		return false, err
+	}
+
+	err = a.OAuthStore.DeleteClient(ctx, id)
+	return err == nil, err
+}
+
+func (a *Mutation) RevokeOAuthGrant(ctx context.Context, input string) (bool, error) {
+	id, err := parseUUID("ID", input)
+	if err != nil {
+		return false, err
+	}
+
+	err = a.OAuthStore.RevokeUserGrant(ctx, id)
+	return err == nil, err
+}
@@ -46,6 +46,9 @@ func (m *Mutation) CreateRotation(ctx context.Context, input graphql2.CreateRota
 		if input.ShiftLength != nil {
 			rot.ShiftLength = *input.ShiftLength
 		}
+		if rot.Type == rotation.TypePattern {
+			rot.ShiftPattern = input.ShiftPattern
+		}
 
 		result, err = m.RotationStore.CreateRotationTx(ctx, tx, rot)
 		if err != nil {
@@ -65,6 +68,17 @@ func (m *Mutation) CreateRotation(ctx context.Context, input graphql2.CreateRota
 				return err
 			}
 		}
+
+		if input.ParticipantShiftLengths != nil && rot.Type == rotation.TypeParticipant {
+			err = rotation.ValidateParticipantShiftLengths("ParticipantShiftLengths", input.ParticipantShiftLengths)
+			if err != nil {
+				return err
+			}
+			err = m.RotationStore.SetParticipantShiftLengthsTx(ctx, tx, result.ID, input.ParticipantShiftLengths)
+			if err != nil {
+				return err
+			}
+		}
 		return err
 	})
 
@@ -79,6 +93,42 @@ func (r *Rotation) IsFavorite(ctx context.Context, rot *rotation.Rotation) (bool
 	return rot.IsUserFavorite(), nil
 }
 
+// withParticipantShiftLengths returns a copy of rot with the shift length of each participant set, for participant rotations.
+func (r *Rotation) withParticipantShiftLengths(ctx context.Context, rot *rotation.Rotation) (*rotation.Rotation, error) {
+	if rot.Type != rotation.TypeParticipant {
+		return rot, nil
+	}
+
+	parts, err := r.RotationStore.FindAllParticipants(ctx, rot.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	cpy := *rot
+	cpy.ParticipantShiftLengths = make([]int, len(parts))
+	for i, p := range parts {
+		cpy.ParticipantShiftLengths[i] = p.ShiftLength
+		if p.ShiftLength == 0 {
+			cpy.ParticipantShiftLengths[i] = rot.ShiftLength
+		}
+	}
+
+	return &cpy, nil
+}
+
+func (r *Rotation) ParticipantShiftLengths(ctx context.Context, rot *rotation.Rotation) ([]int, error) {
+	if rot.Type != rotation.TypeParticipant {
+		return []int{}, nil
+	}
+
+	rot, err := r.withParticipantShiftLengths(ctx, rot)
+	if err != nil {
+		return nil, err
+	}
+
+	return rot.ParticipantShiftLengths, nil
+}
+
 func (r *Rotation) NextHandoffTimes(ctx context.Context, rot *rotation.Rotation, num *int) ([]time.Time, error) {
 	var n int
 	if num != nil {
@@ -109,6 +159,11 @@ func (r *Rotation) NextHandoffTimes(ctx context.Context, rot *rotation.Rotation,
 		return nil, err
 	}
 
+	rot, err = r.withParticipantShiftLengths(ctx, rot)
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]time.Time, n)
 	t := s.ShiftStart
 	for i := range result {
@@ -236,78 +291,6 @@ func (q *Query) Rotations(ctx context.Context, opts *graphql2.RotationSearchOpti
 	return conn, err
 }
 
-func (m *Mutation) updateRotationParticipants(ctx context.Context, tx *sql.Tx, rotationID string, userIDs []string, updateActive bool) (err error) {
-	// Get current participants
-	currentParticipants, err := m.RotationStore.FindAllParticipantsTx(ctx, tx, rotationID)
-	if err != nil {
-		return err
-	}
-
-	var participantIDsToRemove []string
-
-	for i, c := range currentParticipants {
-		if i >= len(userIDs) {
-			participantIDsToRemove = append(participantIDsToRemove, c.ID)
-			continue
-		}
-
-		if c.Target.TargetID() == userIDs[i] {
-			// nothing to update
-			continue
-		}
-
-		// Update
-		err = m.RotationStore.UpdateParticipantUserIDTx(ctx, tx, c.ID, userIDs[i])
-		if err != nil {
-			return err
-		}
-	}
-
-	if len(userIDs) > len(currentParticipants) {
-		// Add users
-		err = m.RotationStore.AddRotationUsersTx(ctx, tx, rotationID, userIDs[len(currentParticipants):])
-		if err != nil {
-			return err
-		}
-	}
-
-	if len(participantIDsToRemove) == 0 {
-		return nil
-	}
-
-	if len(userIDs) == 0 {
-		// Delete rotation state if all users are going to be deleted as per new input
-		err = m.RotationStore.DeleteStateTx(ctx, tx, rotationID)
-		if err != nil {
-			return err
-		}
-	} else if updateActive {
-		// get current active participant
-		s, err := m.RotationStore.StateTx(ctx, tx, rotationID)
-		if errors.Is(err, rotation.ErrNoState) {
-			return nil
-		}
-		if err != nil {
-			return err
-		}
-
-		// if currently active user is going to be deleted
-		// then set to first user before we actually delete any users
-		if s.Position >= len(userIDs) {
-			err = m.RotationStore.SetActiveIndexTx(ctx, tx, rotationID, 0)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	err = m.RotationStore.DeleteRotationParticipantsTx(ctx, tx, participantIDsToRemove)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func (m *Mutation) UpdateRotation(ctx context.Context, input graphql2.UpdateRotationInput) (res bool, err error) {
 	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		result, err := m.RotationStore.FindRotationForUpdateTx(ctx, tx, input.ID)
@@ -334,6 +317,13 @@ func (m *Mutation) UpdateRotation(ctx context.Context, input graphql2.UpdateRota
 			update = true
 			result.Type = *input.Type
 		}
+		if input.ShiftPattern != nil {
+			update = true
+			result.ShiftPattern = input.ShiftPattern
+		}
+		if result.Type != rotation.TypePattern {
+			result.ShiftPattern = nil
+		}
 		if input.ShiftLength != nil {
 			update = true
 			result.ShiftLength = *input.ShiftLength
@@ -356,7 +346,18 @@ func (m *Mutation) UpdateRotation(ctx context.Context, input graphql2.UpdateRota
 		}
 
 		if input.UserIDs != nil {
-			err = m.updateRotationParticipants(ctx, tx, input.ID, input.UserIDs, input.ActiveUserIndex == nil)
+			err = m.RotationStore.SetParticipantUsersTx(ctx, tx, input.ID, input.UserIDs, input.ActiveUserIndex == nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.ParticipantShiftLengths != nil && result.Type == rotation.TypeParticipant {
+			err = rotation.ValidateParticipantShiftLengths("ParticipantShiftLengths", input.ParticipantShiftLengths)
+			if err != nil {
+				return err
+			}
+			err = m.RotationStore.SetParticipantShiftLengthsTx(ctx, tx, input.ID, input.ParticipantShiftLengths)
 			if err != nil {
 				return err
 			}
@@ -392,8 +393,14 @@ func (a *Query) CalcRotationHandoffTimes(ctx context.Context, input *graphql2.Ca
 		return nil, validation.NewFieldError("timeZone", err.Error())
 	}
 
-	if input.ShiftLength != nil && input.ShiftLengthHours != nil {
-		return nil, validation.NewFieldError("shiftLength", "only one of (shiftLength, shiftLengthHours) is allowed")
+	var n int
+	for _, set := range []bool{input.ShiftLength != nil, input.ShiftLengthHours != nil, input.ShiftPattern != nil, input.ParticipantShiftLengths != nil} {
+		if set {
+			n++
+		}
+	}
+	if n > 1 {
+		return nil, validation.NewFieldError("shiftLength", "only one of (shiftLength, shiftLengthHours, shiftPattern, participantShiftLengths) is allowed")
 	}
 
 	rot := rotation.Rotation{
@@ -412,6 +419,23 @@ func (a *Query) CalcRotationHandoffTimes(ctx context.Context, input *graphql2.Ca
 		}
 		rot.Type = rotation.TypeHourly
 		rot.ShiftLength = *input.ShiftLengthHours
+	case input.ShiftPattern != nil:
+		err = rotation.ValidateShiftPattern("shiftPattern", input.ShiftPattern)
+		if err != nil {
+			return nil, err
+		}
+		rot.Type = rotation.TypePattern
+		rot.ShiftPattern = input.ShiftPattern
+	case input.ParticipantShiftLengths != nil:
+		err = validate.Many(
+			validate.Range("participantShiftLengths", len(input.ParticipantShiftLengths), 1, 9000),
+			rotation.ValidateParticipantShiftLengths("participantShiftLengths", input.ParticipantShiftLengths),
+		)
+		if err != nil {
+			return nil, err
+		}
+		rot.Type = rotation.TypeParticipant
+		rot.ParticipantShiftLengths = input.ParticipantShiftLengths
 	default:
 		return nil, validation.NewFieldError("shiftLength", "must be specified")
 	}
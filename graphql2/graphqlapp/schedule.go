@@ -134,8 +134,24 @@ func (s *Schedule) OnCallNotificationRules(ctx context.Context, raw *schedule.Sc
 	return s.ScheduleStore.OnCallNotificationRules(ctx, nil, id)
 }
 
+func (s *Schedule) CoverageGaps(ctx context.Context, raw *schedule.Schedule) ([]schedule.CoverageGap, error) {
+	id, err := parseUUID("ScheduleID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ScheduleStore.CoverageGaps(ctx, id)
+}
+
+func (s *Schedule) HandoffReport(ctx context.Context, raw *schedule.Schedule) (*schedule.HandoffReportConfig, error) {
+	id, err := parseUUID("ScheduleID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ScheduleStore.HandoffReportConfig(ctx, nil, id)
+}
+
 func (s *Schedule) Target(ctx context.Context, raw *schedule.Schedule, input assignment.RawTarget) (*graphql2.ScheduleTarget, error) {
-	rules, err := s.RuleStore.FindByTargetTx(ctx, nil, raw.ID, input)
+	rules, err := s.RuleStore.FindByTargetTx(ctx, nil, raw.ID, "", input)
 	if err != nil {
 		return nil, err
 	}
@@ -153,23 +169,34 @@ func (s *Schedule) Targets(ctx context.Context, raw *schedule.Schedule) ([]graph
 		return nil, err
 	}
 
+	return scheduleTargets(raw.ID, "", rules), nil
+}
+
+// scheduleTargets groups the rules of a schedule tier by target.
+func scheduleTargets(scheduleID, tier string, rules []rule.Rule) []graphql2.ScheduleTarget {
 	m := make(map[assignment.RawTarget][]rule.Rule)
 	for _, r := range rules {
 		tgt := assignment.RawTarget{ID: r.Target.TargetID(), Type: r.Target.TargetType()}
 		m[tgt] = append(m[tgt], r)
 	}
 
+	var tierName *string
+	if tier != "" {
+		tierName = &tier
+	}
+
 	result := make([]graphql2.ScheduleTarget, 0, len(m))
 	for tgt, rules := range m {
 		t := tgt // need to make a copy so we can take a pointer
 		result = append(result, graphql2.ScheduleTarget{
 			Target:     &t,
-			ScheduleID: raw.ID,
+			ScheduleID: scheduleID,
 			Rules:      rules,
+			Tier:       tierName,
 		})
 	}
 
-	return result, nil
+	return result
 }
 
 func (s *Schedule) AssignedTo(ctx context.Context, raw *schedule.Schedule) ([]assignment.RawTarget, error) {
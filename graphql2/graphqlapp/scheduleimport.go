@@ -0,0 +1,128 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/schedule/importer"
+	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/validation"
+)
+
+func importUserID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+func (m *Mutation) ImportSchedule(ctx context.Context, input graphql2.ImportScheduleInput) (*graphql2.ScheduleImportResult, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	sched, err := m.ScheduleStore.FindOne(ctx, input.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	imp, err := importer.Parse(input.Format, []byte(input.Data), sched.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	users, err := m.UserStore.FindManyByEmail(ctx, imp.Emails())
+	if err != nil {
+		return nil, err
+	}
+	unmatched := imp.MapUsers(users)
+	tmps, err := imp.TemporarySchedules(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &graphql2.ScheduleImportResult{
+		Shifts:          make([]graphql2.ScheduleImportShift, len(imp.Shifts)),
+		Rotations:       make([]graphql2.ScheduleImportRotation, len(imp.Rotations)),
+		UnmatchedEmails: unmatched,
+	}
+	for i, s := range imp.Shifts {
+		res.Shifts[i] = graphql2.ScheduleImportShift{
+			Email:  s.Email,
+			UserID: importUserID(s.UserID),
+			Start:  s.Start,
+			End:    s.End,
+		}
+	}
+	for i, r := range imp.Rotations {
+		res.Rotations[i] = graphql2.ScheduleImportRotation{
+			Name:         r.Name,
+			Description:  r.Description,
+			Type:         r.Type,
+			ShiftLength:  r.ShiftLength,
+			Start:        r.Start,
+			TimeZone:     r.Start.Location().String(),
+			Participants: make([]graphql2.ScheduleImportParticipant, len(r.Participants)),
+		}
+		for j, p := range r.Participants {
+			res.Rotations[i].Participants[j] = graphql2.ScheduleImportParticipant{
+				Email:  p.Email,
+				UserID: importUserID(p.UserID),
+			}
+		}
+	}
+
+	if input.DryRun != nil && *input.DryRun {
+		return res, nil
+	}
+	if len(unmatched) > 0 {
+		return nil, validation.NewFieldError("Data", "no unique user found for: "+strings.Join(unmatched, ", "))
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		for i, r := range imp.Rotations {
+			userIDs := make([]string, len(r.Participants))
+			for j, p := range r.Participants {
+				userIDs[j] = p.UserID
+			}
+			desc := r.Description
+			rot, err := m.CreateRotation(ctx, graphql2.CreateRotationInput{
+				Name:         r.Name,
+				Description:  &desc,
+				TimeZone:     r.Start.Location().String(),
+				Start:        r.Start,
+				Type:         r.Type,
+				ShiftLength:  &r.ShiftLength,
+				ShiftPattern: r.ShiftPattern,
+				UserIDs:      userIDs,
+			})
+			if err != nil {
+				return validation.AddPrefix(fmt.Sprintf("Rotations[%d].", i), err)
+			}
+
+			_, err = m.RuleStore.CreateRuleTx(ctx, tx, rule.NewAlwaysActive(sched.ID, assignment.RotationTarget(rot.ID)))
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, tmp := range tmps {
+			err := m.ScheduleStore.SetTemporarySchedule(ctx, tx, schedID, tmp)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Applied = true
+
+	return res, nil
+}
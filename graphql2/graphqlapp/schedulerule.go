@@ -7,9 +7,12 @@ import (
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/holiday"
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/validation"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
@@ -29,24 +32,62 @@ func (r *ScheduleRule) WeekdayFilter(ctx context.Context, raw *rule.Rule) ([]boo
 	return f[:], nil
 }
 
+func (r *ScheduleRule) HolidayCalendarID(ctx context.Context, raw *rule.Rule) (*string, error) {
+	if raw.HolidayCalendarID == "" {
+		return nil, nil
+	}
+
+	return &raw.HolidayCalendarID, nil
+}
+
+func (r *ScheduleRule) HolidayCalendar(ctx context.Context, raw *rule.Rule) (*holiday.Calendar, error) {
+	if raw.HolidayCalendarID == "" {
+		return nil, nil
+	}
+
+	return r.HolidayStore.FindOneCalendar(ctx, raw.HolidayCalendarID)
+}
+
+func (r *ScheduleRule) HolidayMode(ctx context.Context, raw *rule.Rule) (*graphql2.HolidayMode, error) {
+	if raw.HolidayMode == "" {
+		return nil, nil
+	}
+
+	mode := graphql2.HolidayMode(raw.HolidayMode)
+	return &mode, nil
+}
+
 func (m *Mutation) UpdateScheduleTarget(ctx context.Context, input graphql2.ScheduleTargetInput) (bool, error) {
 	var schedID string
 	if input.ScheduleID != nil {
 		schedID = *input.ScheduleID
 	}
+	var tier string
+	if input.Tier != nil && *input.Tier != schedule.PrimaryTier {
+		tier = *input.Tier
+	}
 	if input.Target.Type == assignment.TargetTypeUser && input.Target.ID == "__current_user" {
 		input.Target.ID = permission.UserID(ctx)
 	}
 	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
-		_, err := m.ScheduleStore.FindOneForUpdate(ctx, tx, schedID) // lock schedule
+		sched, err := m.ScheduleStore.FindOneForUpdate(ctx, tx, schedID) // lock schedule
 		if errors.Is(err, sql.ErrNoRows) {
 			return validation.NewFieldError("scheduleID", "schedule not found")
 		}
 		if err != nil {
 			return errors.Wrap(err, "lock schedule")
 		}
+		if tier != "" {
+			ok, err := m.ScheduleStore.TierExists(ctx, tx, uuid.MustParse(sched.ID), tier)
+			if err != nil {
+				return errors.Wrap(err, "lookup tier")
+			}
+			if !ok {
+				return validation.NewFieldError("tier", "tier not found")
+			}
+		}
 
-		rules, err := m.RuleStore.FindByTargetTx(ctx, tx, schedID, input.Target)
+		rules, err := m.RuleStore.FindByTargetTx(ctx, tx, schedID, tier, input.Target)
 		if err != nil {
 			return errors.Wrap(err, "fetch existing rules")
 		}
@@ -57,6 +98,7 @@ func (m *Mutation) UpdateScheduleTarget(ctx context.Context, input graphql2.Sche
 
 		for ruleIndex, inputRule := range input.Rules {
 			r := rule.NewAlwaysActive(schedID, input.Target)
+			r.Tier = tier
 			if inputRule.Start != nil {
 				r.Start = *inputRule.Start
 			}
@@ -66,6 +108,12 @@ func (m *Mutation) UpdateScheduleTarget(ctx context.Context, input graphql2.Sche
 			if inputRule.WeekdayFilter != nil {
 				r.WeekdayFilter = *inputRule.WeekdayFilter
 			}
+			if inputRule.HolidayCalendarID != nil {
+				r.HolidayCalendarID = *inputRule.HolidayCalendarID
+			}
+			if inputRule.HolidayMode != nil {
+				r.HolidayMode = holiday.Mode(*inputRule.HolidayMode)
+			}
 			if ruleIndex < len(rules) {
 				r.ID = rules[ruleIndex].ID
 				err = errors.Wrap(m.RuleStore.UpdateTx(ctx, tx, r), "update rule")
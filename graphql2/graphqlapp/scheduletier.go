@@ -0,0 +1,74 @@
+package graphqlapp
+
+import (
+	context "context"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/user"
+)
+
+type ScheduleTier App
+
+func (a *App) ScheduleTier() graphql2.ScheduleTierResolver { return (*ScheduleTier)(a) }
+
+func (s *Schedule) Tiers(ctx context.Context, raw *schedule.Schedule) ([]schedule.Tier, error) {
+	id, err := parseUUID("ScheduleID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ScheduleStore.Tiers(ctx, nil, id)
+}
+
+func (t *ScheduleTier) Targets(ctx context.Context, raw *schedule.Tier) ([]graphql2.ScheduleTarget, error) {
+	rules, err := t.RuleStore.FindAllByTierTx(ctx, nil, raw.ScheduleID, raw.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduleTargets(raw.ScheduleID, raw.Name, rules), nil
+}
+
+func (t *ScheduleTier) OnCallUsers(ctx context.Context, raw *schedule.Tier) ([]user.User, error) {
+	onCall, err := t.OnCallStore.OnCallUsersByScheduleTier(ctx, raw.ScheduleID, raw.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]user.User, 0, len(onCall))
+	for _, oc := range onCall {
+		u, err := (*App)(t).FindOneUser(ctx, oc.ID)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *u)
+	}
+
+	return users, nil
+}
+
+func (m *Mutation) SetScheduleTier(ctx context.Context, input graphql2.SetScheduleTierInput) (bool, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	t := schedule.Tier{Name: input.Name}
+	if input.RotationOffset != nil {
+		t.RotationOffset = *input.RotationOffset
+	}
+
+	err = m.ScheduleStore.SetTier(ctx, nil, schedID, t)
+	return err == nil, err
+}
+
+func (m *Mutation) DeleteScheduleTier(ctx context.Context, input graphql2.DeleteScheduleTierInput) (bool, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	err = m.ScheduleStore.DeleteTier(ctx, nil, schedID, input.Name)
+	return err == nil, err
+}
@@ -0,0 +1,72 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/user"
+)
+
+type SCIMAPIKey App
+
+func (a *App) SCIMAPIKey() graphql2.SCIMAPIKeyResolver { return (*SCIMAPIKey)(a) }
+
+func (a *SCIMAPIKey) CreatedBy(ctx context.Context, obj *graphql2.SCIMAPIKey) (*user.User, error) {
+	if obj.CreatedBy == nil {
+		return nil, nil
+	}
+
+	return (*App)(a).FindOneUser(ctx, obj.CreatedBy.ID)
+}
+
+func (q *Query) ScimAPIKeys(ctx context.Context) ([]graphql2.SCIMAPIKey, error) {
+	keys, err := q.APIKeyStore.FindAllSCIMKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]graphql2.SCIMAPIKey, len(keys))
+	for i, k := range keys {
+		res[i] = graphql2.SCIMAPIKey{
+			ID:          k.ID.String(),
+			Name:        k.Name,
+			Description: k.Description,
+			CreatedAt:   k.CreatedAt,
+			ExpiresAt:   k.ExpiresAt,
+			LastUsedAt:  k.LastUsedAt,
+		}
+
+		if k.CreatedBy != nil {
+			res[i].CreatedBy = &user.User{ID: k.CreatedBy.String()}
+		}
+	}
+
+	return res, nil
+}
+
+func (a *Mutation) DeleteSCIMAPIKey(ctx context.Context, input string) (bool, error) {
+	id, err := parseUUID("ID", input)
+	if err != nil {
+		return false, err
+	}
+
+	err = a.APIKeyStore.DeleteSCIMKey(ctx, id)
+	return err == nil, err
+}
+
+func (a *Mutation) CreateSCIMAPIKey(ctx context.Context, input graphql2.CreateSCIMAPIKeyInput) (*graphql2.CreatedSCIMAPIKey, error) {
+	id, tok, err := a.APIKeyStore.CreateSCIMKey(ctx, apikey.NewSCIMKeyOpts{
+		Name:    input.Name,
+		Desc:    input.Description,
+		Expires: input.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &graphql2.CreatedSCIMAPIKey{
+		ID:    id.String(),
+		Token: tok,
+	}, nil
+}
@@ -0,0 +1,130 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/swap"
+	"github.com/target/goalert/user"
+)
+
+type ShiftSwapRequest App
+
+func (a *App) ShiftSwapRequest() graphql2.ShiftSwapRequestResolver { return (*ShiftSwapRequest)(a) }
+
+func (a *ShiftSwapRequest) Schedule(ctx context.Context, obj *graphql2.ShiftSwapRequest) (*schedule.Schedule, error) {
+	return (*App)(a).FindOneSchedule(ctx, obj.ScheduleID)
+}
+
+func (a *ShiftSwapRequest) Requester(ctx context.Context, obj *graphql2.ShiftSwapRequest) (*user.User, error) {
+	return (*App)(a).FindOneUser(ctx, obj.Requester.ID)
+}
+
+func (a *ShiftSwapRequest) Counterpart(ctx context.Context, obj *graphql2.ShiftSwapRequest) (*user.User, error) {
+	return (*App)(a).FindOneUser(ctx, obj.Counterpart.ID)
+}
+
+func gqlShiftSwapRequest(req swap.Request) graphql2.ShiftSwapRequest {
+	res := graphql2.ShiftSwapRequest{
+		ID:          req.ID.String(),
+		ScheduleID:  req.ScheduleID.String(),
+		Requester:   &user.User{ID: req.RequesterID.String()},
+		Counterpart: &user.User{ID: req.CounterpartID.String()},
+		RequesterShift: &graphql2.ShiftSwapShift{
+			Start: req.RequesterShift.Start,
+			End:   req.RequesterShift.End,
+		},
+		Message:     req.Message,
+		Status:      graphql2.ShiftSwapStatus(req.Status),
+		CreatedAt:   req.CreatedAt,
+		RespondedAt: req.RespondedAt,
+	}
+	if req.CounterpartShift != nil {
+		res.CounterpartShift = &graphql2.ShiftSwapShift{
+			Start: req.CounterpartShift.Start,
+			End:   req.CounterpartShift.End,
+		}
+	}
+
+	return res
+}
+
+func (q *Query) ShiftSwapRequests(ctx context.Context, pendingOnly *bool) ([]graphql2.ShiftSwapRequest, error) {
+	reqs, err := q.SwapStore.FindAllForUser(ctx, pendingOnly != nil && *pendingOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]graphql2.ShiftSwapRequest, len(reqs))
+	for i, r := range reqs {
+		res[i] = gqlShiftSwapRequest(r)
+	}
+
+	return res, nil
+}
+
+func (q *Query) ShiftSwapRequest(ctx context.Context, id string) (*graphql2.ShiftSwapRequest, error) {
+	req, err := q.SwapStore.FindOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := gqlShiftSwapRequest(*req)
+	return &res, nil
+}
+
+func (a *Mutation) CreateShiftSwapRequest(ctx context.Context, input graphql2.CreateShiftSwapRequestInput) (*graphql2.ShiftSwapRequest, error) {
+	opts := swap.CreateOpts{
+		ScheduleID:    input.ScheduleID,
+		CounterpartID: input.CounterpartID,
+		RequesterShift: swap.Shift{
+			Start: input.RequesterShift.Start,
+			End:   input.RequesterShift.End,
+		},
+	}
+	if input.CounterpartShift != nil {
+		opts.CounterpartShift = &swap.Shift{
+			Start: input.CounterpartShift.Start,
+			End:   input.CounterpartShift.End,
+		}
+	}
+	if input.Message != nil {
+		opts.Message = *input.Message
+	}
+
+	req, err := a.SwapStore.Create(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := gqlShiftSwapRequest(*req)
+	return &res, nil
+}
+
+func (a *Mutation) AcceptShiftSwapRequest(ctx context.Context, id string) (bool, error) {
+	err := a.SwapStore.Accept(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (a *Mutation) DeclineShiftSwapRequest(ctx context.Context, id string) (bool, error) {
+	err := a.SwapStore.Decline(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (a *Mutation) CancelShiftSwapRequest(ctx context.Context, id string) (bool, error) {
+	err := a.SwapStore.Cancel(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,153 @@
+package graphqlapp
+
+import (
+	context "context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/integrationkey/uik"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+func (key *IntegrationKey) RecentRequests(ctx context.Context, raw *integrationkey.IntegrationKey) ([]graphql2.KeyRequest, error) {
+	id, err := validate.ParseUUID("IntegrationKey.ID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs, err := key.IntKeyStore.RecentRequests(ctx, key.DB, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]graphql2.KeyRequest, len(reqs))
+	for i, r := range reqs {
+		hdr := make(map[string]string, len(r.Header))
+		for k, v := range r.Header {
+			hdr[k] = strings.Join(v, ", ")
+		}
+		result[i] = graphql2.KeyRequest{
+			ID:            r.ID.String(),
+			ReceivedAt:    r.ReceivedAt,
+			Body:          string(r.Body),
+			BodyTruncated: r.BodyTruncated,
+			Query:         r.Query.Encode(),
+			Headers:       hdr,
+			UserAgent:     r.UserAgent,
+			RemoteAddr:    r.RemoteAddr,
+		}
+	}
+
+	return result, nil
+}
+
+func (q *Query) KeyDryRun(ctx context.Context, input graphql2.KeyDryRunInput) (*graphql2.KeyDryRunResult, error) {
+	keyID, err := validate.ParseUUID("KeyID", input.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if (input.RequestID == nil) == (input.Request == nil) {
+		return nil, validation.NewFieldError("RequestID", "exactly one of requestID or request must be set")
+	}
+
+	var req integrationkey.UIKRequest
+	if input.RequestID != nil {
+		reqID, err := validate.ParseUUID("RequestID", *input.RequestID)
+		if err != nil {
+			return nil, err
+		}
+		rec, err := q.IntKeyStore.FindRequest(ctx, q.DB, keyID, reqID)
+		if err != nil {
+			return nil, err
+		}
+		if rec.BodyTruncated {
+			return nil, validation.NewFieldError("RequestID", "request body was too large to be recorded in full")
+		}
+		req = rec.UIKRequest
+	} else {
+		in := input.Request
+		req.Body = []byte(in.Body)
+		if in.Query != nil {
+			req.Query, err = url.ParseQuery(*in.Query)
+			if err != nil {
+				return nil, validation.NewFieldError("Request.Query", err.Error())
+			}
+		}
+		req.Header = make(http.Header, len(in.Headers))
+		for k, v := range in.Headers {
+			req.Header.Set(k, v)
+		}
+		if in.UserAgent != nil {
+			req.UserAgent = *in.UserAgent
+		}
+		if in.RemoteAddr != nil {
+			req.RemoteAddr = *in.RemoteAddr
+		}
+	}
+
+	cfg, err := q.IntKeyStore.Config(ctx, q.DB, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := uik.DryRun(*cfg, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &graphql2.KeyDryRunResult{
+		DefaultActions: res.DefaultActions,
+		Actions:        res.Actions,
+	}
+	for _, idx := range res.MatchedRules {
+		result.MatchedRules = append(result.MatchedRules, cfg.Rules[idx])
+	}
+
+	return result, nil
+}
+
+func (key *IntegrationKey) WebhookFailures(ctx context.Context, raw *integrationkey.IntegrationKey) ([]graphql2.KeyWebhookFailure, error) {
+	id, err := validate.ParseUUID("IntegrationKey.ID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	failures, err := key.IntKeyStore.WebhookFailures(ctx, key.DB, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]graphql2.KeyWebhookFailure, len(failures))
+	for i, f := range failures {
+		result[i] = graphql2.KeyWebhookFailure{
+			ID:          f.ID.String(),
+			FailedAt:    f.FailedAt,
+			URL:         f.URL,
+			ContentType: f.ContentType,
+			Body:        f.Body,
+			Attempts:    f.Attempts,
+			StatusCode:  f.StatusCode,
+			Error:       f.Error,
+		}
+	}
+
+	return result, nil
+}
+
+func (m *Mutation) ClearKeyWebhookFailures(ctx context.Context, id string) (bool, error) {
+	keyID, err := validate.ParseUUID("ID", id)
+	if err != nil {
+		return false, err
+	}
+
+	err = m.IntKeyStore.ClearWebhookFailures(ctx, m.DB, keyID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
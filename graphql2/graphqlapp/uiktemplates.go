@@ -0,0 +1,54 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+
+	"github.com/target/goalert/event"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/integrationkey/uiktemplate"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+func (q *Query) IntegrationKeyTemplates(ctx context.Context) ([]uiktemplate.Template, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	return uiktemplate.All(), nil
+}
+
+func (k *KeyConfig) TemplateUpgradeAvailable(ctx context.Context, cfg *gadb.UIKConfigV1) (bool, error) {
+	return uiktemplate.UpgradeAvailable(*cfg), nil
+}
+
+func (m *Mutation) ApplyKeyTemplate(ctx context.Context, input graphql2.ApplyKeyTemplateInput) (bool, error) {
+	id, err := validate.ParseUUID("KeyID", input.KeyID)
+	if err != nil {
+		return false, err
+	}
+	tmpl, err := uiktemplate.Find(input.TemplateID)
+	if err != nil {
+		return false, err
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		cfg := tmpl.Config()
+		err := m.IntKeyStore.SetConfig(ctx, tx, id, &cfg)
+		if err != nil {
+			return err
+		}
+
+		event.SendTx(ctx, m.EventBus, tx, integrationkey.ConfigUpdate{KeyID: id})
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
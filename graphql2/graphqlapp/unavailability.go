@@ -0,0 +1,50 @@
+package graphqlapp
+
+import (
+	context "context"
+	"time"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/user/unavailability"
+)
+
+// unavailabilityHorizon is how far ahead User.unavailability looks for upcoming windows.
+const unavailabilityHorizon = 5 * 365 * 24 * time.Hour
+
+func (a *User) Unavailability(ctx context.Context, raw *user.User) ([]unavailability.Window, error) {
+	now := time.Now()
+	windows, err := a.UnavailStore.FindMany(ctx, []string{raw.ID}, now, now.Add(unavailabilityHorizon))
+	if err != nil {
+		return nil, err
+	}
+	if permission.Admin(ctx) || permission.UserID(ctx) == raw.ID {
+		return windows, nil
+	}
+
+	// the reason may be personal, so only the user and admins can see it
+	for i := range windows {
+		windows[i].Reason = ""
+	}
+
+	return windows, nil
+}
+
+func (m *Mutation) CreateUserUnavailability(ctx context.Context, input graphql2.CreateUserUnavailabilityInput) (*unavailability.Window, error) {
+	w := unavailability.Window{
+		UserID: input.UserID,
+		Start:  input.Start,
+		End:    input.End,
+	}
+	if input.Reason != nil {
+		w.Reason = *input.Reason
+	}
+
+	return m.UnavailStore.Create(ctx, w)
+}
+
+func (m *Mutation) DeleteUserUnavailability(ctx context.Context, id string) (bool, error) {
+	err := m.UnavailStore.Delete(ctx, id)
+	return err == nil, err
+}
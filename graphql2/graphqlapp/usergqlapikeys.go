@@ -0,0 +1,75 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/graphql2"
+)
+
+func (q *Query) UserGQLAPIKeys(ctx context.Context) ([]graphql2.UserGQLAPIKey, error) {
+	keys, err := q.APIKeyStore.FindAllUserGraphQLKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]graphql2.UserGQLAPIKey, len(keys))
+	for i, k := range keys {
+		res[i] = graphql2.UserGQLAPIKey{
+			ID:            k.ID.String(),
+			Name:          k.Name,
+			Description:   k.Description,
+			CreatedAt:     k.CreatedAt,
+			ExpiresAt:     k.ExpiresAt,
+			AllowedFields: k.AllowedFields,
+			ServiceLabels: k.ServiceLabels,
+			RateLimit:     k.RateLimit,
+		}
+		if res[i].ServiceLabels == nil {
+			res[i].ServiceLabels = []string{}
+		}
+
+		if k.LastUsed != nil {
+			res[i].LastUsed = &graphql2.GQLAPIKeyUsage{
+				Time: k.LastUsed.Time,
+				Ua:   k.LastUsed.UserAgent,
+				IP:   k.LastUsed.IP,
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (a *Mutation) DeleteUserGQLAPIKey(ctx context.Context, input string) (bool, error) {
+	id, err := parseUUID("ID", input)
+	if err != nil {
+		return false, err
+	}
+
+	err = a.APIKeyStore.DeleteUserGraphQLKey(ctx, id)
+	return err == nil, err
+}
+
+func (a *Mutation) CreateUserGQLAPIKey(ctx context.Context, input graphql2.CreateUserGQLAPIKeyInput) (*graphql2.CreatedGQLAPIKey, error) {
+	opts := apikey.NewUserGQLKeyOpts{
+		Name:          input.Name,
+		Desc:          input.Description,
+		Expires:       input.ExpiresAt,
+		AllowedFields: input.AllowedFields,
+		ServiceLabels: input.ServiceLabels,
+	}
+	if input.RateLimit != nil {
+		opts.RateLimit = *input.RateLimit
+	}
+
+	id, tok, err := a.APIKeyStore.CreateUserGraphQLKey(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &graphql2.CreatedGQLAPIKey{
+		ID:    id.String(),
+		Token: tok,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package graphql2
+
+import (
+	"io"
+	"time"
+
+	graphql "github.com/99designs/gqlgen/graphql"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/validation"
+)
+
+// MarshalISODate will marshal a date, ignoring the time and location of t.
+func MarshalISODate(t time.Time) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		if t.IsZero() {
+			_, _ = io.WriteString(w, "null")
+			return
+		}
+		_, _ = io.WriteString(w, `"`+t.Format(time.DateOnly)+`"`)
+	})
+}
+
+// UnmarshalISODate will unmarshal a date as midnight UTC.
+func UnmarshalISODate(v interface{}) (time.Time, error) {
+	str, ok := v.(string)
+	if !ok {
+		return time.Time{}, errors.New("dates must be strings")
+	}
+
+	t, err := time.Parse(time.DateOnly, str)
+	return t, validation.WrapError(err)
+}
@@ -37,6 +37,7 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "Services.RequiredLabels", Type: ConfigTypeStringList, Description: "List of label names to require new services to define.", Value: strings.Join(cfg.Services.RequiredLabels, "\n")},
 		{ID: "Alerts.HighPriorityLabelKey", Type: ConfigTypeString, Description: "Label key used to mark high priority alerts.", Value: cfg.Alerts.HighPriorityLabelKey},
 		{ID: "Alerts.HighPriorityLabelValue", Type: ConfigTypeString, Description: "Label value indicating high priority alerts.", Value: cfg.Alerts.HighPriorityLabelValue},
+		{ID: "Schedules.CoverageGapDays", Type: ConfigTypeInteger, Description: "Schedules will be checked for periods with nobody on call this many days into the future, notifying their on-call notification channels when a new gap is found (0 means disable).", Value: fmt.Sprintf("%d", cfg.Schedules.CoverageGapDays)},
 		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
 		{ID: "Maintenance.AlertAutoCloseDays", Type: ConfigTypeInteger, Description: "Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertAutoCloseDays)},
 		{ID: "Maintenance.AutoCloseAckedAlerts", Type: ConfigTypeBoolean, Description: "If set, alerts that are acknowledged will also be automatically closed after the configured number of days of inactivity.", Value: fmt.Sprintf("%t", cfg.Maintenance.AutoCloseAckedAlerts)},
@@ -61,6 +62,33 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "OIDC.UserInfoEmailPath", Type: ConfigTypeString, Description: "JMESPath expression to find email address in UserInfo. If set, the email claim will be ignored in favor of this. (suggestion: email).", Value: cfg.OIDC.UserInfoEmailPath},
 		{ID: "OIDC.UserInfoEmailVerifiedPath", Type: ConfigTypeString, Description: "JMESPath expression to find email verification state in UserInfo. If set, the email_verified claim will be ignored in favor of this. (suggestion: email_verified).", Value: cfg.OIDC.UserInfoEmailVerifiedPath},
 		{ID: "OIDC.UserInfoNamePath", Type: ConfigTypeString, Description: "JMESPath expression to find full name in UserInfo. If set, the name claim will be ignored in favor of this. (suggestion: name || cn || join(' ', [firstname, lastname]))", Value: cfg.OIDC.UserInfoNamePath},
+		{ID: "LDAP.Enable", Type: ConfigTypeBoolean, Description: "Enable LDAP (e.g. Active Directory) authentication.", Value: fmt.Sprintf("%t", cfg.LDAP.Enable)},
+		{ID: "LDAP.NewUsers", Type: ConfigTypeBoolean, Description: "Allow new user creation via LDAP authentication.", Value: fmt.Sprintf("%t", cfg.LDAP.NewUsers)},
+		{ID: "LDAP.OverrideName", Type: ConfigTypeString, Description: "Set the name/label on the login page to something other than LDAP.", Value: cfg.LDAP.OverrideName},
+		{ID: "LDAP.URL", Type: ConfigTypeString, Description: "LDAP server URL (e.g. ldaps://ad.example.com or ldap://ldap.example.com:389).", Value: cfg.LDAP.URL},
+		{ID: "LDAP.StartTLS", Type: ConfigTypeBoolean, Description: "Upgrade ldap:// connections using StartTLS.", Value: fmt.Sprintf("%t", cfg.LDAP.StartTLS)},
+		{ID: "LDAP.SkipVerify", Type: ConfigTypeBoolean, Description: "Disables certificate validation for TLS/StartTLS (insecure).", Value: fmt.Sprintf("%t", cfg.LDAP.SkipVerify)},
+		{ID: "LDAP.BindDN", Type: ConfigTypeString, Description: "DN (or UPN for Active Directory) of the account used to search for users. If blank, an anonymous bind is used.", Value: cfg.LDAP.BindDN},
+		{ID: "LDAP.BindPassword", Type: ConfigTypeString, Description: "Password for the search account.", Value: cfg.LDAP.BindPassword, Password: true},
+		{ID: "LDAP.SearchBase", Type: ConfigTypeString, Description: "Base DN to search for users (e.g. ou=people,dc=example,dc=com).", Value: cfg.LDAP.SearchBase},
+		{ID: "LDAP.SearchFilter", Type: ConfigTypeString, Description: "Filter used to find a user, with %s replaced by the username entered at login. If blank, (uid=%s) will be used. (suggestion for Active Directory: (&(objectClass=user)(sAMAccountName=%s)))", Value: cfg.LDAP.SearchFilter},
+		{ID: "LDAP.SubjectAttribute", Type: ConfigTypeString, Description: "Attribute that uniquely identifies a user (e.g. objectGUID or entryUUID). If blank, the user's DN will be used.", Value: cfg.LDAP.SubjectAttribute},
+		{ID: "LDAP.NameAttribute", Type: ConfigTypeString, Description: "Attribute containing the user's full name. If blank, displayName (or cn) will be used.", Value: cfg.LDAP.NameAttribute},
+		{ID: "LDAP.EmailAttribute", Type: ConfigTypeString, Description: "Attribute containing the user's email address. If blank, mail will be used.", Value: cfg.LDAP.EmailAttribute},
+		{ID: "LDAP.AllowedGroups", Type: ConfigTypeStringList, Description: "If set, only members of any listed group DN (via the memberOf attribute) may authenticate.", Value: strings.Join(cfg.LDAP.AllowedGroups, "\n")},
+		{ID: "SAML.Enable", Type: ConfigTypeBoolean, Description: "Enable SAML 2.0 authentication.", Value: fmt.Sprintf("%t", cfg.SAML.Enable)},
+		{ID: "SAML.NewUsers", Type: ConfigTypeBoolean, Description: "Allow new user creation via SAML authentication.", Value: fmt.Sprintf("%t", cfg.SAML.NewUsers)},
+		{ID: "SAML.OverrideName", Type: ConfigTypeString, Description: "Set the name/label on the login page to something other than SAML.", Value: cfg.SAML.OverrideName},
+		{ID: "SAML.IdPMetadataURL", Type: ConfigTypeString, Description: "URL of the identity provider's SAML metadata.", Value: cfg.SAML.IdPMetadataURL},
+		{ID: "SAML.IdPMetadata", Type: ConfigTypeString, Description: "SAML metadata XML of the identity provider. If set, IdPMetadataURL will be ignored.", Value: cfg.SAML.IdPMetadata},
+		{ID: "SAML.EntityID", Type: ConfigTypeString, Description: "Service provider entity ID. If blank, the SP metadata URL (<public URL>/api/v2/identity/providers/saml/metadata) will be used.", Value: cfg.SAML.EntityID},
+		{ID: "SAML.SubjectAttribute", Type: ConfigTypeString, Description: "Assertion attribute that uniquely identifies a user. If blank, the NameID will be used (transient NameIDs are not supported).", Value: cfg.SAML.SubjectAttribute},
+		{ID: "SAML.NameAttribute", Type: ConfigTypeString, Description: "Assertion attribute containing the user's full name. If blank, displayName, name, or cn will be used.", Value: cfg.SAML.NameAttribute},
+		{ID: "SAML.EmailAttribute", Type: ConfigTypeString, Description: "Assertion attribute containing the user's email address. If blank, email or mail will be used.", Value: cfg.SAML.EmailAttribute},
+		{ID: "SCIM.Enable", Type: ConfigTypeBoolean, Description: "Enable the SCIM 2.0 user and group provisioning API (requires a SCIM API key).", Value: fmt.Sprintf("%t", cfg.SCIM.Enable)},
+		{ID: "SCIM.LinkProviderID", Type: ConfigTypeString, Description: "If set, provisioned users will also be linked to this auth provider (e.g. 'oidc') using their SCIM userName as the subject ID.", Value: cfg.SCIM.LinkProviderID},
+		{ID: "SCIM.DeleteInactiveUsers", Type: ConfigTypeBoolean, Description: "If set, users marked inactive (active=false) or deleted via SCIM will be deleted, along with their alert and on-call history. This cannot be undone. Otherwise, requests to deactivate a user are rejected, and deleted users are only unlinked from SCIM.", Value: fmt.Sprintf("%t", cfg.SCIM.DeleteInactiveUsers)},
+		{ID: "OAuthServer.Enable", Type: ConfigTypeBoolean, Description: "Allow third-party applications to access the GraphQL API on behalf of users via OAuth 2.0 (authorization code flow with PKCE).", Value: fmt.Sprintf("%t", cfg.OAuthServer.Enable)},
 		{ID: "Mailgun.Enable", Type: ConfigTypeBoolean, Description: "", Value: fmt.Sprintf("%t", cfg.Mailgun.Enable)},
 		{ID: "Mailgun.APIKey", Type: ConfigTypeString, Description: "", Value: cfg.Mailgun.APIKey, Password: true},
 		{ID: "Mailgun.EmailDomain", Type: ConfigTypeString, Description: "The TO address for all incoming alerts.", Value: cfg.Mailgun.EmailDomain},
@@ -116,6 +144,7 @@ func MapPublicConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "Services.RequiredLabels", Type: ConfigTypeStringList, Description: "List of label names to require new services to define.", Value: strings.Join(cfg.Services.RequiredLabels, "\n")},
 		{ID: "Alerts.HighPriorityLabelKey", Type: ConfigTypeString, Description: "Label key used to mark high priority alerts.", Value: cfg.Alerts.HighPriorityLabelKey},
 		{ID: "Alerts.HighPriorityLabelValue", Type: ConfigTypeString, Description: "Label value indicating high priority alerts.", Value: cfg.Alerts.HighPriorityLabelValue},
+		{ID: "Schedules.CoverageGapDays", Type: ConfigTypeInteger, Description: "Schedules will be checked for periods with nobody on call this many days into the future, notifying their on-call notification channels when a new gap is found (0 means disable).", Value: fmt.Sprintf("%d", cfg.Schedules.CoverageGapDays)},
 		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
 		{ID: "Maintenance.AlertAutoCloseDays", Type: ConfigTypeInteger, Description: "Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertAutoCloseDays)},
 		{ID: "Maintenance.AutoCloseAckedAlerts", Type: ConfigTypeBoolean, Description: "If set, alerts that are acknowledged will also be automatically closed after the configured number of days of inactivity.", Value: fmt.Sprintf("%t", cfg.Maintenance.AutoCloseAckedAlerts)},
@@ -124,6 +153,10 @@ func MapPublicConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "Auth.DisableBasic", Type: ConfigTypeBoolean, Description: "Disallow username/password login.", Value: fmt.Sprintf("%t", cfg.Auth.DisableBasic)},
 		{ID: "GitHub.Enable", Type: ConfigTypeBoolean, Description: "Enable GitHub authentication.", Value: fmt.Sprintf("%t", cfg.GitHub.Enable)},
 		{ID: "OIDC.Enable", Type: ConfigTypeBoolean, Description: "Enable OpenID Connect authentication.", Value: fmt.Sprintf("%t", cfg.OIDC.Enable)},
+		{ID: "LDAP.Enable", Type: ConfigTypeBoolean, Description: "Enable LDAP (e.g. Active Directory) authentication.", Value: fmt.Sprintf("%t", cfg.LDAP.Enable)},
+		{ID: "SAML.Enable", Type: ConfigTypeBoolean, Description: "Enable SAML 2.0 authentication.", Value: fmt.Sprintf("%t", cfg.SAML.Enable)},
+		{ID: "SCIM.Enable", Type: ConfigTypeBoolean, Description: "Enable the SCIM 2.0 user and group provisioning API (requires a SCIM API key).", Value: fmt.Sprintf("%t", cfg.SCIM.Enable)},
+		{ID: "OAuthServer.Enable", Type: ConfigTypeBoolean, Description: "Allow third-party applications to access the GraphQL API on behalf of users via OAuth 2.0 (authorization code flow with PKCE).", Value: fmt.Sprintf("%t", cfg.OAuthServer.Enable)},
 		{ID: "Mailgun.Enable", Type: ConfigTypeBoolean, Description: "", Value: fmt.Sprintf("%t", cfg.Mailgun.Enable)},
 		{ID: "Slack.Enable", Type: ConfigTypeBoolean, Description: "", Value: fmt.Sprintf("%t", cfg.Slack.Enable)},
 		{ID: "Twilio.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of Voice and SMS messages through the Twilio notification provider.", Value: fmt.Sprintf("%t", cfg.Twilio.Enable)},
@@ -212,6 +245,12 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.Alerts.HighPriorityLabelKey = v.Value
 		case "Alerts.HighPriorityLabelValue":
 			cfg.Alerts.HighPriorityLabelValue = v.Value
+		case "Schedules.CoverageGapDays":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Schedules.CoverageGapDays = val
 		case "Maintenance.AlertCleanupDays":
 			val, err := parseInt(v.ID, v.Value)
 			if err != nil {
@@ -300,6 +339,96 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.OIDC.UserInfoEmailVerifiedPath = v.Value
 		case "OIDC.UserInfoNamePath":
 			cfg.OIDC.UserInfoNamePath = v.Value
+		case "LDAP.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.LDAP.Enable = val
+		case "LDAP.NewUsers":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.LDAP.NewUsers = val
+		case "LDAP.OverrideName":
+			cfg.LDAP.OverrideName = v.Value
+		case "LDAP.URL":
+			cfg.LDAP.URL = v.Value
+		case "LDAP.StartTLS":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.LDAP.StartTLS = val
+		case "LDAP.SkipVerify":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.LDAP.SkipVerify = val
+		case "LDAP.BindDN":
+			cfg.LDAP.BindDN = v.Value
+		case "LDAP.BindPassword":
+			cfg.LDAP.BindPassword = v.Value
+		case "LDAP.SearchBase":
+			cfg.LDAP.SearchBase = v.Value
+		case "LDAP.SearchFilter":
+			cfg.LDAP.SearchFilter = v.Value
+		case "LDAP.SubjectAttribute":
+			cfg.LDAP.SubjectAttribute = v.Value
+		case "LDAP.NameAttribute":
+			cfg.LDAP.NameAttribute = v.Value
+		case "LDAP.EmailAttribute":
+			cfg.LDAP.EmailAttribute = v.Value
+		case "LDAP.AllowedGroups":
+			cfg.LDAP.AllowedGroups = parseStringList(v.Value)
+		case "SAML.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SAML.Enable = val
+		case "SAML.NewUsers":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SAML.NewUsers = val
+		case "SAML.OverrideName":
+			cfg.SAML.OverrideName = v.Value
+		case "SAML.IdPMetadataURL":
+			cfg.SAML.IdPMetadataURL = v.Value
+		case "SAML.IdPMetadata":
+			cfg.SAML.IdPMetadata = v.Value
+		case "SAML.EntityID":
+			cfg.SAML.EntityID = v.Value
+		case "SAML.SubjectAttribute":
+			cfg.SAML.SubjectAttribute = v.Value
+		case "SAML.NameAttribute":
+			cfg.SAML.NameAttribute = v.Value
+		case "SAML.EmailAttribute":
+			cfg.SAML.EmailAttribute = v.Value
+		case "SCIM.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SCIM.Enable = val
+		case "SCIM.LinkProviderID":
+			cfg.SCIM.LinkProviderID = v.Value
+		case "SCIM.DeleteInactiveUsers":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SCIM.DeleteInactiveUsers = val
+		case "OAuthServer.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.OAuthServer.Enable = val
 		case "Mailgun.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -22,6 +22,7 @@ import (
 	"github.com/target/goalert/notification/slack"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/importer"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/service"
@@ -33,6 +34,14 @@ type InlineDisplayInfo interface {
 	IsInlineDisplayInfo()
 }
 
+type AddHolidayInput struct {
+	CalendarID string    `json:"calendarID"`
+	Name       string    `json:"name"`
+	Start      time.Time `json:"start"`
+	// The last day of the holiday, defaults to the start date.
+	End *time.Time `json:"end,omitempty"`
+}
+
 type AlertConnection struct {
 	Nodes    []alert.Alert `json:"nodes"`
 	PageInfo *PageInfo     `json:"pageInfo"`
@@ -102,6 +111,11 @@ type AlertsByStatus struct {
 	Closed  int `json:"closed"`
 }
 
+type ApplyKeyTemplateInput struct {
+	KeyID      string `json:"keyID"`
+	TemplateID string `json:"templateID"`
+}
+
 type AuthSubjectConnection struct {
 	Nodes    []user.AuthSubject `json:"nodes"`
 	PageInfo *PageInfo          `json:"pageInfo"`
@@ -114,7 +128,11 @@ type CalcRotationHandoffTimesInput struct {
 	// Only accurate for hourly-type rotations. Use shiftLength instead.
 	ShiftLengthHours *int                  `json:"shiftLengthHours,omitempty"`
 	ShiftLength      *timeutil.ISODuration `json:"shiftLength,omitempty"`
-	Count            int                   `json:"count"`
+	// Calculate handoffs for a pattern rotation with the given shift lengths, in hours.
+	ShiftPattern []int `json:"shiftPattern,omitempty"`
+	// Calculate handoffs for a participant rotation with the given shift length, in hours, of each participant.
+	ParticipantShiftLengths []int `json:"participantShiftLengths,omitempty"`
+	Count                   int   `json:"count"`
 }
 
 type Clause struct {
@@ -239,12 +257,27 @@ type CreateHeartbeatMonitorInput struct {
 	Muted *string `json:"muted,omitempty"`
 }
 
+type CreateHolidayCalendarInput struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
 type CreateIntegrationKeyInput struct {
 	ServiceID *string            `json:"serviceID,omitempty"`
 	Type      IntegrationKeyType `json:"type"`
 	Name      string             `json:"name"`
 	// Name of the external system this key is managed by.
 	ExternalSystemName *string `json:"externalSystemName,omitempty"`
+	// templateID is the ID of a template to configure a new universal key with.
+	TemplateID *string `json:"templateID,omitempty"`
+}
+
+type CreateOAuthClientInput struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	RedirectURIs []string `json:"redirectURIs"`
+	// Confidential clients (e.g., server-side applications) are issued a secret.
+	Confidential bool `json:"confidential"`
 }
 
 type CreateRotationInput struct {
@@ -255,7 +288,17 @@ type CreateRotationInput struct {
 	Favorite    *bool         `json:"favorite,omitempty"`
 	Type        rotation.Type `json:"type"`
 	ShiftLength *int          `json:"shiftLength,omitempty"`
-	UserIDs     []string      `json:"userIDs,omitempty"`
+	// The repeating sequence of shift lengths, in hours, for pattern rotations. Ignored for other types.
+	ShiftPattern []int    `json:"shiftPattern,omitempty"`
+	UserIDs      []string `json:"userIDs,omitempty"`
+	// The shift length, in hours, of each user in `userIDs`, for participant rotations. Ignored for other types.
+	ParticipantShiftLengths []int `json:"participantShiftLengths,omitempty"`
+}
+
+type CreateSCIMAPIKeyInput struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ExpiresAt   time.Time `json:"expiresAt"`
 }
 
 type CreateScheduleInput struct {
@@ -278,12 +321,29 @@ type CreateServiceInput struct {
 	NewHeartbeatMonitors []CreateHeartbeatMonitorInput `json:"newHeartbeatMonitors,omitempty"`
 }
 
+type CreateShiftSwapRequestInput struct {
+	ScheduleID    string `json:"scheduleID"`
+	CounterpartID string `json:"counterpartID"`
+	// The current user's shift that the counterpart would take over.
+	RequesterShift *ShiftSwapShiftInput `json:"requesterShift"`
+	// The counterpart's shift to take in exchange. If omitted, the counterpart is only asked to cover the requester's shift.
+	CounterpartShift *ShiftSwapShiftInput `json:"counterpartShift,omitempty"`
+	Message          *string              `json:"message,omitempty"`
+}
+
 type CreateUserCalendarSubscriptionInput struct {
 	Name            string `json:"name"`
 	ReminderMinutes []int  `json:"reminderMinutes,omitempty"`
-	ScheduleID      string `json:"scheduleID"`
-	Disabled        *bool  `json:"disabled,omitempty"`
-	FullSchedule    *bool  `json:"fullSchedule,omitempty"`
+	// Exactly one of scheduleID, serviceID, or escalationPolicyID must be provided.
+	ScheduleID *string `json:"scheduleID,omitempty"`
+	// Subscribes to the schedules and rotations on the first step of the service's escalation policy.
+	ServiceID *string `json:"serviceID,omitempty"`
+	// Subscribes to the schedules and rotations on every step of the escalation policy.
+	EscalationPolicyID *string `json:"escalationPolicyID,omitempty"`
+	// Additional schedules to include in a schedule subscription.
+	AdditionalScheduleIDs []string `json:"additionalScheduleIDs,omitempty"`
+	Disabled              *bool    `json:"disabled,omitempty"`
+	FullSchedule          *bool    `json:"fullSchedule,omitempty"`
 }
 
 type CreateUserContactMethodInput struct {
@@ -300,6 +360,18 @@ type CreateUserContactMethodInput struct {
 	EnableStatusUpdates *bool `json:"enableStatusUpdates,omitempty"`
 }
 
+type CreateUserGQLAPIKeyInput struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	// Root fields the key may use, e.g. `Query.alerts` or `Mutation.updateAlerts`.
+	AllowedFields []string `json:"allowedFields"`
+	// If set, the key is limited to services having all of the given labels (as `key=value`).
+	ServiceLabels []string `json:"serviceLabels,omitempty"`
+	// Maximum number of requests per minute, 0 or null means no limit.
+	RateLimit *int `json:"rateLimit,omitempty"`
+}
+
 type CreateUserInput struct {
 	Username string    `json:"username"`
 	Password string    `json:"password"`
@@ -323,11 +395,29 @@ type CreateUserOverrideInput struct {
 	RemoveUserID *string   `json:"removeUserID,omitempty"`
 }
 
+type CreateUserUnavailabilityInput struct {
+	UserID string    `json:"userID"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Reason *string   `json:"reason,omitempty"`
+}
+
 type CreatedGQLAPIKey struct {
 	ID    string `json:"id"`
 	Token string `json:"token"`
 }
 
+type CreatedOAuthClient struct {
+	ID string `json:"id"`
+	// The client secret, only set for confidential clients.
+	Secret *string `json:"secret,omitempty"`
+}
+
+type CreatedSCIMAPIKey struct {
+	ID    string `json:"id"`
+	Token string `json:"token"`
+}
+
 type DebugCarrierInfoInput struct {
 	Number string `json:"number"`
 }
@@ -376,6 +466,11 @@ type DebugSendSMSInput struct {
 	Body string `json:"body"`
 }
 
+type DeleteScheduleTierInput struct {
+	ScheduleID string `json:"scheduleID"`
+	Name       string `json:"name"`
+}
+
 type DestinationDisplayInfoError struct {
 	// error message to display when the display info cannot be retrieved
 	Error string `json:"error"`
@@ -485,6 +580,30 @@ type GQLAPIKeyUsage struct {
 	IP   string    `json:"ip"`
 }
 
+type Holiday struct {
+	ID         string `json:"id"`
+	CalendarID string `json:"calendarID"`
+	Name       string `json:"name"`
+	// The first day of the holiday.
+	Start time.Time `json:"start"`
+	// The last day of the holiday.
+	End time.Time `json:"end"`
+}
+
+type ImportHolidaysInput struct {
+	CalendarID string `json:"calendarID"`
+	// The contents of an iCalendar (.ics) file.
+	ICal string `json:"iCal"`
+}
+
+type ImportScheduleInput struct {
+	ScheduleID string          `json:"scheduleID"`
+	Format     importer.Format `json:"format"`
+	// The contents of the file being imported.
+	Data   string `json:"data"`
+	DryRun *bool  `json:"dryRun,omitempty"`
+}
+
 type IntegrationKeyConnection struct {
 	Nodes    []integrationkey.IntegrationKey `json:"nodes"`
 	PageInfo *PageInfo                       `json:"pageInfo"`
@@ -507,6 +626,59 @@ type IntegrationKeyTypeInfo struct {
 	Enabled bool `json:"enabled"`
 }
 
+type KeyDryRunInput struct {
+	KeyID string `json:"keyID"`
+	// requestID is the ID of a recorded request to replay. Exactly one of requestID or request must be set.
+	RequestID *string `json:"requestID,omitempty"`
+	// request is a request to evaluate. Exactly one of requestID or request must be set.
+	Request *KeyRequestInput `json:"request,omitempty"`
+}
+
+type KeyDryRunResult struct {
+	// matchedRules are the rules that matched the request, in order.
+	MatchedRules []gadb.UIKRuleV1 `json:"matchedRules"`
+	// defaultActions is true if no rules matched, and the default actions were used.
+	DefaultActions bool `json:"defaultActions"`
+	// actions are the actions that would have been performed, with all params evaluated.
+	Actions []gadb.UIKActionV1 `json:"actions"`
+}
+
+type KeyRequest struct {
+	ID         string    `json:"id"`
+	ReceivedAt time.Time `json:"receivedAt"`
+	Body       string    `json:"body"`
+	// bodyTruncated is true if the body was too large to be recorded in full.
+	BodyTruncated bool `json:"bodyTruncated"`
+	// query is the URL-encoded query string of the request, with any credentials removed.
+	Query string `json:"query"`
+	// headers are the request headers, with any credentials removed. Repeated headers are joined with a comma.
+	Headers    map[string]string `json:"headers"`
+	UserAgent  string            `json:"userAgent"`
+	RemoteAddr string            `json:"remoteAddr"`
+}
+
+type KeyRequestInput struct {
+	Body string `json:"body"`
+	// query is a URL-encoded query string.
+	Query      *string           `json:"query,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	UserAgent  *string           `json:"userAgent,omitempty"`
+	RemoteAddr *string           `json:"remoteAddr,omitempty"`
+}
+
+// KeyWebhookFailure is a webhook action that failed after all retries were exhausted.
+type KeyWebhookFailure struct {
+	ID          string    `json:"id"`
+	FailedAt    time.Time `json:"failedAt"`
+	URL         string    `json:"url"`
+	ContentType string    `json:"contentType"`
+	Body        string    `json:"body"`
+	Attempts    int       `json:"attempts"`
+	// statusCode is the HTTP status of the last attempt, or zero if no response was received.
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+}
+
 type LabelConnection struct {
 	Nodes    []label.Label `json:"nodes"`
 	PageInfo *PageInfo     `json:"pageInfo"`
@@ -571,6 +743,55 @@ type NotificationState struct {
 	FormattedSrcValue string              `json:"formattedSrcValue"`
 }
 
+type OAuthClient struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	RedirectURIs []string   `json:"redirectURIs"`
+	Confidential bool       `json:"confidential"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	CreatedBy    *user.User `json:"createdBy,omitempty"`
+}
+
+type OAuthGrant struct {
+	ID         string     `json:"id"`
+	ClientID   string     `json:"clientID"`
+	ClientName string     `json:"clientName"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+type OnCallFairnessReportInput struct {
+	// Start of the report, defaults to 4 weeks before end.
+	Start *time.Time `json:"start,omitempty"`
+	// End of the report, defaults to the current time.
+	End *time.Time `json:"end,omitempty"`
+	// Length of each period, defaults to one week.
+	Period *timeutil.ISODuration `json:"period,omitempty"`
+	// Limits the report to the given schedules and users on call for them.
+	ScheduleIDs []string `json:"scheduleIDs,omitempty"`
+	// Start of working hours, in the time zone of each schedule. Defaults to 09:00.
+	WorkdayStart *timeutil.Clock `json:"workdayStart,omitempty"`
+	// End of working hours, in the time zone of each schedule. Defaults to 17:00.
+	WorkdayEnd *timeutil.Clock `json:"workdayEnd,omitempty"`
+}
+
+type OnCallFairnessReportRow struct {
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+	UserID      string    `json:"userID"`
+	UserName    string    `json:"userName"`
+	// Total hours on call for any of the included schedules.
+	OnCallHours float64 `json:"onCallHours"`
+	// Hours on call on weekdays outside of working hours.
+	AfterHoursHours float64 `json:"afterHoursHours"`
+	// Hours on call on Saturday or Sunday.
+	WeekendHours float64 `json:"weekendHours"`
+	// Number of alerts the user was notified of.
+	Pages int `json:"pages"`
+}
+
 type OnCallOverview struct {
 	ServiceCount       int                       `json:"serviceCount"`
 	ServiceAssignments []OnCallServiceAssignment `json:"serviceAssignments"`
@@ -618,6 +839,16 @@ type RotationSearchOptions struct {
 	FavoritesFirst *bool `json:"favoritesFirst,omitempty"`
 }
 
+type SCIMAPIKey struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CreatedBy   *user.User `json:"createdBy,omitempty"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+}
+
 type SWOConnection struct {
 	Name    string `json:"name"`
 	Version string `json:"version"`
@@ -650,12 +881,48 @@ type ScheduleConnection struct {
 	PageInfo *PageInfo           `json:"pageInfo"`
 }
 
+type ScheduleImportParticipant struct {
+	Email string `json:"email"`
+	// The matched user, if any.
+	UserID *string `json:"userID,omitempty"`
+}
+
+type ScheduleImportResult struct {
+	// True if the import was applied to the schedule.
+	Applied   bool                     `json:"applied"`
+	Shifts    []ScheduleImportShift    `json:"shifts"`
+	Rotations []ScheduleImportRotation `json:"rotations"`
+	// Email addresses that did not match exactly one user. An address shared by more than one user is included, since it is ambiguous.
+	UnmatchedEmails []string `json:"unmatchedEmails"`
+}
+
+type ScheduleImportRotation struct {
+	Name         string                      `json:"name"`
+	Description  string                      `json:"description"`
+	Type         rotation.Type               `json:"type"`
+	ShiftLength  int                         `json:"shiftLength"`
+	Start        time.Time                   `json:"start"`
+	TimeZone     string                      `json:"timeZone"`
+	Participants []ScheduleImportParticipant `json:"participants"`
+}
+
+type ScheduleImportShift struct {
+	Email string `json:"email"`
+	// The matched user, if any.
+	UserID *string   `json:"userID,omitempty"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+}
+
 type ScheduleRuleInput struct {
 	ID    *string         `json:"id,omitempty"`
 	Start *timeutil.Clock `json:"start,omitempty"`
 	End   *timeutil.Clock `json:"end,omitempty"`
 	// Weekday filter is a 7-item array that indicates if the rule is active on each weekday, starting with Sunday.
 	WeekdayFilter *timeutil.WeekdayFilter `json:"weekdayFilter,omitempty"`
+	// If set, the rule is affected by the holidays of the calendar according to holidayMode.
+	HolidayCalendarID *string      `json:"holidayCalendarID,omitempty"`
+	HolidayMode       *HolidayMode `json:"holidayMode,omitempty"`
 }
 
 type ScheduleSearchOptions struct {
@@ -673,6 +940,8 @@ type ScheduleTarget struct {
 	ScheduleID string                `json:"scheduleID"`
 	Target     *assignment.RawTarget `json:"target"`
 	Rules      []rule.Rule           `json:"rules"`
+	// The name of the schedule tier the rules belong to, null for the primary tier.
+	Tier *string `json:"tier,omitempty"`
 }
 
 type ScheduleTargetInput struct {
@@ -680,6 +949,8 @@ type ScheduleTargetInput struct {
 	Target      *assignment.RawTarget `json:"target,omitempty"`
 	NewRotation *CreateRotationInput  `json:"newRotation,omitempty"`
 	Rules       []ScheduleRuleInput   `json:"rules"`
+	// The name of the schedule tier the rules belong to, defaults to the primary tier.
+	Tier *string `json:"tier,omitempty"`
 }
 
 type SendContactMethodVerificationInput struct {
@@ -726,11 +997,23 @@ type SetLabelInput struct {
 	Value string `json:"value"`
 }
 
+type SetScheduleHandoffReportInput struct {
+	ScheduleID  string `json:"scheduleID"`
+	NotifyUsers bool   `json:"notifyUsers"`
+}
+
 type SetScheduleOnCallNotificationRulesInput struct {
 	ScheduleID string                        `json:"scheduleID"`
 	Rules      []OnCallNotificationRuleInput `json:"rules"`
 }
 
+type SetScheduleTierInput struct {
+	ScheduleID string `json:"scheduleID"`
+	Name       string `json:"name"`
+	// If set, the tier follows the rotations of the primary tier, this many participants ahead of the current one.
+	RotationOffset *int `json:"rotationOffset,omitempty"`
+}
+
 type SetTemporaryScheduleInput struct {
 	ScheduleID string                `json:"scheduleID"`
 	ClearStart *time.Time            `json:"clearStart,omitempty"`
@@ -740,6 +1023,30 @@ type SetTemporaryScheduleInput struct {
 	Shifts     []schedule.FixedShift `json:"shifts"`
 }
 
+type ShiftSwapRequest struct {
+	ID               string             `json:"id"`
+	ScheduleID       string             `json:"scheduleID"`
+	Schedule         *schedule.Schedule `json:"schedule,omitempty"`
+	Requester        *user.User         `json:"requester,omitempty"`
+	Counterpart      *user.User         `json:"counterpart,omitempty"`
+	RequesterShift   *ShiftSwapShift    `json:"requesterShift"`
+	CounterpartShift *ShiftSwapShift    `json:"counterpartShift,omitempty"`
+	Message          string             `json:"message"`
+	Status           ShiftSwapStatus    `json:"status"`
+	CreatedAt        time.Time          `json:"createdAt"`
+	RespondedAt      *time.Time         `json:"respondedAt,omitempty"`
+}
+
+type ShiftSwapShift struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+type ShiftSwapShiftInput struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
 type SlackChannelConnection struct {
 	Nodes    []slack.Channel `json:"nodes"`
 	PageInfo *PageInfo       `json:"pageInfo"`
@@ -866,6 +1173,12 @@ type UpdateHeartbeatMonitorInput struct {
 	Muted *string `json:"muted,omitempty"`
 }
 
+type UpdateHolidayCalendarInput struct {
+	ID          string  `json:"id"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
 type UpdateKeyConfigInput struct {
 	KeyID string           `json:"keyID"`
 	Rules []gadb.UIKRuleV1 `json:"rules,omitempty"`
@@ -889,7 +1202,11 @@ type UpdateRotationInput struct {
 	Start       *time.Time     `json:"start,omitempty"`
 	Type        *rotation.Type `json:"type,omitempty"`
 	ShiftLength *int           `json:"shiftLength,omitempty"`
-	UserIDs     []string       `json:"userIDs,omitempty"`
+	// The repeating sequence of shift lengths, in hours, for pattern rotations. Ignored (and cleared) for other types.
+	ShiftPattern []int    `json:"shiftPattern,omitempty"`
+	UserIDs      []string `json:"userIDs,omitempty"`
+	// The shift length, in hours, of each user in `userIDs` (or the current participants), for participant rotations.
+	ParticipantShiftLengths []int `json:"participantShiftLengths,omitempty"`
 	// The index of the user in `userIDs` to set as the active user. If not provided, the existing active user index will be used.
 	ActiveUserIndex *int `json:"activeUserIndex,omitempty"`
 }
@@ -910,11 +1227,12 @@ type UpdateServiceInput struct {
 }
 
 type UpdateUserCalendarSubscriptionInput struct {
-	ID              string  `json:"id"`
-	Name            *string `json:"name,omitempty"`
-	ReminderMinutes []int   `json:"reminderMinutes,omitempty"`
-	Disabled        *bool   `json:"disabled,omitempty"`
-	FullSchedule    *bool   `json:"fullSchedule,omitempty"`
+	ID                    string   `json:"id"`
+	Name                  *string  `json:"name,omitempty"`
+	ReminderMinutes       []int    `json:"reminderMinutes,omitempty"`
+	AdditionalScheduleIDs []string `json:"additionalScheduleIDs,omitempty"`
+	Disabled              *bool    `json:"disabled,omitempty"`
+	FullSchedule          *bool    `json:"fullSchedule,omitempty"`
 }
 
 type UpdateUserContactMethodInput struct {
@@ -948,6 +1266,18 @@ type UserConnection struct {
 	PageInfo *PageInfo   `json:"pageInfo"`
 }
 
+type UserGQLAPIKey struct {
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	LastUsed      *GQLAPIKeyUsage `json:"lastUsed,omitempty"`
+	ExpiresAt     time.Time       `json:"expiresAt"`
+	AllowedFields []string        `json:"allowedFields"`
+	ServiceLabels []string        `json:"serviceLabels"`
+	RateLimit     int             `json:"rateLimit"`
+}
+
 type UserOverrideConnection struct {
 	Nodes    []override.UserOverride `json:"nodes"`
 	PageInfo *PageInfo               `json:"pageInfo"`
@@ -1310,6 +1640,64 @@ func (e ErrorCode) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// HolidayMode determines how a schedule rule is affected by holidays.
+type HolidayMode string
+
+const (
+	// The rule is inactive on holidays.
+	HolidayModeSuppress HolidayMode = "suppress"
+	// The rule is only active on holidays, providing substitute coverage.
+	HolidayModeSubstitute HolidayMode = "substitute"
+)
+
+var AllHolidayMode = []HolidayMode{
+	HolidayModeSuppress,
+	HolidayModeSubstitute,
+}
+
+func (e HolidayMode) IsValid() bool {
+	switch e {
+	case HolidayModeSuppress, HolidayModeSubstitute:
+		return true
+	}
+	return false
+}
+
+func (e HolidayMode) String() string {
+	return string(e)
+}
+
+func (e *HolidayMode) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HolidayMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HolidayMode", str)
+	}
+	return nil
+}
+
+func (e HolidayMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *HolidayMode) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e HolidayMode) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type IntegrationKeyType string
 
 const (
@@ -1319,6 +1707,11 @@ const (
 	IntegrationKeyTypePrometheusAlertmanager IntegrationKeyType = "prometheusAlertmanager"
 	IntegrationKeyTypeEmail                  IntegrationKeyType = "email"
 	IntegrationKeyTypeUniversal              IntegrationKeyType = "universal"
+	IntegrationKeyTypeAmazonSns              IntegrationKeyType = "amazonSNS"
+	IntegrationKeyTypeZabbix                 IntegrationKeyType = "zabbix"
+	IntegrationKeyTypeNagios                 IntegrationKeyType = "nagios"
+	IntegrationKeyTypeIcinga                 IntegrationKeyType = "icinga"
+	IntegrationKeyTypeCloudEvents            IntegrationKeyType = "cloudEvents"
 )
 
 var AllIntegrationKeyType = []IntegrationKeyType{
@@ -1328,11 +1721,16 @@ var AllIntegrationKeyType = []IntegrationKeyType{
 	IntegrationKeyTypePrometheusAlertmanager,
 	IntegrationKeyTypeEmail,
 	IntegrationKeyTypeUniversal,
+	IntegrationKeyTypeAmazonSns,
+	IntegrationKeyTypeZabbix,
+	IntegrationKeyTypeNagios,
+	IntegrationKeyTypeIcinga,
+	IntegrationKeyTypeCloudEvents,
 }
 
 func (e IntegrationKeyType) IsValid() bool {
 	switch e {
-	case IntegrationKeyTypeGeneric, IntegrationKeyTypeGrafana, IntegrationKeyTypeSite24x7, IntegrationKeyTypePrometheusAlertmanager, IntegrationKeyTypeEmail, IntegrationKeyTypeUniversal:
+	case IntegrationKeyTypeGeneric, IntegrationKeyTypeGrafana, IntegrationKeyTypeSite24x7, IntegrationKeyTypePrometheusAlertmanager, IntegrationKeyTypeEmail, IntegrationKeyTypeUniversal, IntegrationKeyTypeAmazonSns, IntegrationKeyTypeZabbix, IntegrationKeyTypeNagios, IntegrationKeyTypeIcinga, IntegrationKeyTypeCloudEvents:
 		return true
 	}
 	return false
@@ -1550,6 +1948,65 @@ func (e SWOState) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type ShiftSwapStatus string
+
+const (
+	ShiftSwapStatusPending   ShiftSwapStatus = "pending"
+	ShiftSwapStatusAccepted  ShiftSwapStatus = "accepted"
+	ShiftSwapStatusDeclined  ShiftSwapStatus = "declined"
+	ShiftSwapStatusCancelled ShiftSwapStatus = "cancelled"
+)
+
+var AllShiftSwapStatus = []ShiftSwapStatus{
+	ShiftSwapStatusPending,
+	ShiftSwapStatusAccepted,
+	ShiftSwapStatusDeclined,
+	ShiftSwapStatusCancelled,
+}
+
+func (e ShiftSwapStatus) IsValid() bool {
+	switch e {
+	case ShiftSwapStatusPending, ShiftSwapStatusAccepted, ShiftSwapStatusDeclined, ShiftSwapStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (e ShiftSwapStatus) String() string {
+	return string(e)
+}
+
+func (e *ShiftSwapStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ShiftSwapStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ShiftSwapStatus", str)
+	}
+	return nil
+}
+
+func (e ShiftSwapStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ShiftSwapStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ShiftSwapStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type StatusUpdateState string
 
 const (
@@ -139,3 +139,90 @@ WHERE
 INSERT INTO pending_signals(dest_id, service_id, params)
     VALUES ($1, $2, $3);
 
+
+-- name: IntKeyRecordRequest :exec
+INSERT INTO uik_request_log(id, key_id, received_at, body, body_truncated, query, headers, user_agent, remote_addr)
+    VALUES (@id, @key_id, @received_at, @body, @body_truncated, @query, @headers, @user_agent, @remote_addr);
+
+
+-- name: IntKeyRecentRequests :many
+SELECT
+    id,
+    received_at,
+    body,
+    body_truncated,
+    query,
+    headers,
+    user_agent,
+    remote_addr
+FROM
+    uik_request_log
+WHERE
+    key_id = @key_id
+ORDER BY
+    received_at DESC,
+    id DESC
+LIMIT @max_requests;
+
+-- name: IntKeyFindRequest :one
+SELECT
+    id,
+    received_at,
+    body,
+    body_truncated,
+    query,
+    headers,
+    user_agent,
+    remote_addr
+FROM
+    uik_request_log
+WHERE
+    key_id = @key_id
+    AND id = @id;
+
+-- name: IntKeyNotifyConfigUpdate :exec
+-- Notify all instances that the config of a key changed, once the transaction commits.
+SELECT
+    pg_notify('/goalert/uik-config-update', @key_id::text);
+
+-- name: IntKeyRecordWebhookFailure :exec
+INSERT INTO uik_webhook_failures(id, key_id, url, content_type, body, attempts, status_code, error)
+    VALUES (@id, @key_id, @url, @content_type, @body, @attempts, @status_code, @error);
+
+-- name: IntKeyTrimWebhookFailures :exec
+-- Delete all but the most recent webhook failures of a key.
+DELETE FROM uik_webhook_failures f
+WHERE f.key_id = @key_id
+    AND f.id NOT IN (
+        SELECT
+            r.id
+        FROM
+            uik_webhook_failures r
+        WHERE
+            r.key_id = @key_id
+        ORDER BY
+            r.failed_at DESC,
+            r.id DESC
+        LIMIT @max_failures);
+
+-- name: IntKeyWebhookFailures :many
+SELECT
+    id,
+    failed_at,
+    url,
+    content_type,
+    body,
+    attempts,
+    status_code,
+    error
+FROM
+    uik_webhook_failures
+WHERE
+    key_id = @key_id
+ORDER BY
+    failed_at DESC,
+    id DESC;
+
+-- name: IntKeyClearWebhookFailures :exec
+DELETE FROM uik_webhook_failures
+WHERE key_id = @key_id;
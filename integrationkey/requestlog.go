@@ -0,0 +1,182 @@
+package integrationkey
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+)
+
+const (
+	// MaxRecordedRequests is the number of recent requests kept for each universal key.
+	//
+	// Older requests are removed periodically by the cleanup manager.
+	MaxRecordedRequests = 25
+
+	// MaxRecordedBodySize is the maximum number of bytes of a request body that will be recorded.
+	MaxRecordedBodySize = 64 * 1024
+)
+
+// redactedQuery and redactedHeaders may carry credentials, and are never recorded.
+var (
+	redactedQuery   = []string{"token", "integrationKey", "integration_key", "key"}
+	redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+)
+
+// UIKRequest is the data of a request made to a universal integration key.
+type UIKRequest struct {
+	Body       []byte
+	Query      url.Values
+	Header     http.Header
+	UserAgent  string
+	RemoteAddr string
+}
+
+// RecordedRequest is a UIKRequest that was recorded for later inspection and replay.
+type RecordedRequest struct {
+	UIKRequest
+	ID            uuid.UUID
+	ReceivedAt    time.Time
+	BodyTruncated bool
+}
+
+// NewUIKRequest will return the UIKRequest for an HTTP request with the provided body.
+func NewUIKRequest(req *http.Request, body []byte) UIKRequest {
+	return UIKRequest{
+		Body:       body,
+		Query:      req.URL.Query(),
+		Header:     req.Header,
+		UserAgent:  req.UserAgent(),
+		RemoteAddr: req.RemoteAddr,
+	}
+}
+
+// RecordRequest will record a request to a universal key, received at the given time.
+// Credentials are removed before recording.
+func (s *Store) RecordRequest(ctx context.Context, db gadb.DBTX, keyID uuid.UUID, receivedAt time.Time, r UIKRequest) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Service)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	for k, v := range r.Query {
+		query[k] = v
+	}
+	for _, k := range redactedQuery {
+		query.Del(k)
+	}
+	hdr := r.Header.Clone()
+	if hdr == nil {
+		hdr = http.Header{}
+	}
+	for _, k := range redactedHeaders {
+		hdr.Del(k)
+	}
+	hdrData, err := json.Marshal(hdr)
+	if err != nil {
+		return fmt.Errorf("marshal headers: %w", err)
+	}
+
+	body := r.Body
+	truncated := len(body) > MaxRecordedBodySize
+	if truncated {
+		body = body[:MaxRecordedBodySize]
+	}
+
+	err = gadb.New(db).IntKeyRecordRequest(ctx, gadb.IntKeyRecordRequestParams{
+		ID:            uuid.New(),
+		KeyID:         keyID,
+		ReceivedAt:    receivedAt,
+		Body:          body,
+		BodyTruncated: truncated,
+		Query:         query.Encode(),
+		Headers:       hdrData,
+		UserAgent:     r.UserAgent,
+		RemoteAddr:    r.RemoteAddr,
+	})
+	if err != nil {
+		return fmt.Errorf("record request: %w", err)
+	}
+
+	return nil
+}
+
+func recordedRequest(id uuid.UUID, receivedAt time.Time, body []byte, truncated bool, query string, headers json.RawMessage, ua, addr string) (*RecordedRequest, error) {
+	q, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("parse query: %w", err)
+	}
+	var hdr http.Header
+	err = json.Unmarshal(headers, &hdr)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal headers: %w", err)
+	}
+
+	return &RecordedRequest{
+		ID:            id,
+		ReceivedAt:    receivedAt,
+		BodyTruncated: truncated,
+		UIKRequest: UIKRequest{
+			Body:       body,
+			Query:      q,
+			Header:     hdr,
+			UserAgent:  ua,
+			RemoteAddr: addr,
+		},
+	}, nil
+}
+
+// RecentRequests returns the most recent requests made to a universal key, newest first.
+func (s *Store) RecentRequests(ctx context.Context, db gadb.DBTX, keyID uuid.UUID) ([]RecordedRequest, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(db).IntKeyRecentRequests(ctx, gadb.IntKeyRecentRequestsParams{
+		KeyID:       keyID,
+		MaxRequests: MaxRecordedRequests,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]RecordedRequest, 0, len(rows))
+	for _, r := range rows {
+		rec, err := recordedRequest(r.ID, r.ReceivedAt, r.Body, r.BodyTruncated, r.Query, r.Headers, r.UserAgent, r.RemoteAddr)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *rec)
+	}
+
+	return result, nil
+}
+
+// FindRequest returns a single recorded request of a universal key.
+func (s *Store) FindRequest(ctx context.Context, db gadb.DBTX, keyID, id uuid.UUID) (*RecordedRequest, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := gadb.New(db).IntKeyFindRequest(ctx, gadb.IntKeyFindRequestParams{KeyID: keyID, ID: id})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("RequestID", "not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return recordedRequest(r.ID, r.ReceivedAt, r.Body, r.BodyTruncated, r.Query, r.Headers, r.UserAgent, r.RemoteAddr)
+}
@@ -16,6 +16,11 @@ const (
 	TypeGeneric                Type = "generic"
 	TypeEmail                  Type = "email"
 	TypeUniversal              Type = "universal"
+	TypeAmazonSNS              Type = "amazonSNS"
+	TypeZabbix                 Type = "zabbix"
+	TypeNagios                 Type = "nagios"
+	TypeIcinga                 Type = "icinga"
+	TypeCloudEvents            Type = "cloudEvents"
 )
 
 func (s Type) Value() (driver.Value, error) {
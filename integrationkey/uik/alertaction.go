@@ -0,0 +1,147 @@
+package uik
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+)
+
+// parseAlertMeta will parse the metadata param of an alert action, if set.
+//
+// Non-string values are stored as JSON.
+func parseAlertMeta(s string) (map[string]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var raw map[string]any
+	err := json.Unmarshal([]byte(s), &raw)
+	if err != nil {
+		return nil, validation.NewFieldError("Meta", "must be a JSON object: "+err.Error())
+	}
+
+	meta := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if str, ok := v.(string); ok {
+			meta[k] = str
+			continue
+		}
+
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		meta[k] = string(data)
+	}
+
+	return meta, alert.ValidateMetadata(meta)
+}
+
+// alertActionType returns the normalized action of an alert action.
+func alertActionType(act gadb.UIKActionV1) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(act.Param(alert.ParamAction))) {
+	case "":
+		if act.Param(alert.ParamClose) == "true" {
+			return alert.ActionClose, nil
+		}
+		return alert.ActionTrigger, nil
+	case alert.ActionTrigger, "create":
+		return alert.ActionTrigger, nil
+	case alert.ActionAck, "acknowledge":
+		return alert.ActionAck, nil
+	case alert.ActionEscalate:
+		return alert.ActionEscalate, nil
+	case alert.ActionClose, "resolve":
+		return alert.ActionClose, nil
+	}
+
+	return "", validation.NewFieldError("Action", fmt.Sprintf("unknown alert action '%s' (expected trigger, ack, escalate, or close)", act.Param(alert.ParamAction)))
+}
+
+// handleAlertAction will create, or update an existing, alert for the service.
+func (h *Handler) handleAlertAction(ctx context.Context, act gadb.UIKActionV1) error {
+	action, err := alertActionType(act)
+	if err != nil {
+		return err
+	}
+	meta, err := parseAlertMeta(act.Param(alert.ParamMeta))
+	if err != nil {
+		return err
+	}
+
+	a := &alert.Alert{
+		ServiceID: permission.ServiceID(ctx),
+		Summary:   act.Param(alert.ParamSummary),
+		Details:   act.Param(alert.ParamDetails),
+		Source:    alert.SourceUniversal,
+		Status:    alert.StatusTriggered,
+		Dedup:     alert.NewUserDedup(act.Param(alert.ParamDedup)),
+	}
+
+	if action == alert.ActionTrigger {
+		n, isNew, err := h.alertStore.CreateOrUpdateWithMeta(ctx, a, meta)
+		if err != nil {
+			return err
+		}
+		if n != nil && !isNew && meta != nil {
+			return h.alertStore.SetMetadataTx(ctx, h.db, n.ID, meta)
+		}
+		return nil
+	}
+
+	if a.Dedup == nil {
+		// match by summary and details, the same as alert creation
+		a, err = a.Normalize()
+		if err != nil {
+			return err
+		}
+	}
+	id, err := h.alertStore.FindOpenByDedup(ctx, a.ServiceID, a.DedupKey())
+	if err != nil {
+		return err
+	}
+	if id == 0 {
+		// no open alert, nothing to do
+		return nil
+	}
+
+	switch action {
+	case alert.ActionAck:
+		err = h.alertStore.UpdateStatus(ctx, id, alert.StatusActive)
+		if alert.IsAlreadyAcknowledged(err) {
+			err = nil
+		}
+	case alert.ActionEscalate:
+		// EscalateAsOf is limited to system and user contexts. The alert was found
+		// within a.ServiceID above, so escalate on behalf of the key only if the
+		// request is authorized for that service.
+		err = permission.LimitCheckAny(ctx, permission.MatchService(a.ServiceID))
+		if err != nil {
+			return err
+		}
+		err = h.alertStore.EscalateAsOf(permission.SystemContext(ctx, "UIK"), id, time.Time{})
+	case alert.ActionClose:
+		err = h.alertStore.UpdateStatus(ctx, id, alert.StatusClosed)
+		if alert.IsAlreadyClosed(err) {
+			err = nil
+		}
+		// closed alerts can't be updated, so metadata is ignored
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if meta != nil {
+		return h.alertStore.SetMetadataTx(ctx, h.db, id, meta)
+	}
+
+	return nil
+}
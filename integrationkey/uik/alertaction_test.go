@@ -0,0 +1,49 @@
+package uik
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/gadb"
+)
+
+func TestAlertActionType(t *testing.T) {
+	check := func(exp string, params map[string]string) {
+		t.Helper()
+		act := gadb.UIKActionV1{Dest: gadb.DestV1{Type: alert.DestTypeAlert}, Params: params}
+		action, err := alertActionType(act)
+		require.NoError(t, err)
+		assert.Equal(t, exp, action)
+	}
+
+	check(alert.ActionTrigger, nil)
+	check(alert.ActionClose, map[string]string{"close": "true"})
+	check(alert.ActionAck, map[string]string{"action": "ack"})
+	check(alert.ActionAck, map[string]string{"action": " Acknowledge "})
+	check(alert.ActionEscalate, map[string]string{"action": "escalate"})
+	check(alert.ActionClose, map[string]string{"action": "resolve"})
+	check(alert.ActionTrigger, map[string]string{"action": "trigger", "close": "true"})
+
+	_, err := alertActionType(gadb.UIKActionV1{Params: map[string]string{"action": "snooze"}})
+	assert.Error(t, err)
+}
+
+func TestParseAlertMeta(t *testing.T) {
+	meta, err := parseAlertMeta("")
+	require.NoError(t, err)
+	assert.Nil(t, meta)
+
+	meta, err = parseAlertMeta(`{"team":"db","count":3,"tags":["a","b"],"ok":true}`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"team":  "db",
+		"count": "3",
+		"tags":  `["a","b"]`,
+		"ok":    "true",
+	}, meta)
+
+	_, err = parseAlertMeta(`["not", "an", "object"]`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package uik
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"strings"
+
+	"github.com/target/goalert/validation"
+)
+
+// parseBody will parse a request body according to its content type.
+//
+// JSON (or a missing content type, or any body that is valid JSON) is decoded as-is, form data becomes a map
+// of the first value of each field, and XML becomes a map of the root element
+// (see xmlValue). Any other content type is provided as a string.
+func parseBody(contentType string, data []byte) (any, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		// Many senders (e.g., `curl -d`) post JSON with the wrong content type, and
+		// JSON bodies were always accepted regardless of content type.
+		var body any
+		if json.Unmarshal(trimmed, &body) == nil {
+			return body, nil
+		}
+	}
+
+	switch {
+	case mediaType == "", mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		var body any
+		err := json.Unmarshal(data, &body)
+		if err != nil {
+			return nil, validation.WrapError(err)
+		}
+		return body, nil
+	case mediaType == "application/x-www-form-urlencoded":
+		vals, err := url.ParseQuery(string(data))
+		if err != nil {
+			return nil, validation.WrapError(err)
+		}
+		body := make(map[string]any, len(vals))
+		for k := range vals {
+			body[k] = vals.Get(k)
+		}
+		return body, nil
+	case mediaType == "application/xml", mediaType == "text/xml", strings.HasSuffix(mediaType, "+xml"):
+		body, err := parseXML(data)
+		if err != nil {
+			return nil, validation.WrapError(err)
+		}
+		return body, nil
+	}
+
+	return string(data), nil
+}
+
+// parseXML will decode an XML document into a map containing the root element.
+func parseXML(data []byte) (map[string]any, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("xml: missing root element")
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		val, err := xmlValue(dec, start)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{start.Name.Local: val}, nil
+	}
+}
+
+// xmlValue will decode the element started by start.
+//
+// An element with only text becomes a string. Otherwise it becomes a map with
+// attributes prefixed by `@`, text (if any) under `#text`, and child elements
+// by name; repeated child elements become a list.
+func xmlValue(dec *xml.Decoder, start xml.StartElement) (any, error) {
+	m := make(map[string]any)
+	for _, a := range start.Attr {
+		m["@"+a.Name.Local] = a.Value
+	}
+
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("xml: element %s: %w", start.Name.Local, err)
+		}
+
+		switch t := tok.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement:
+			val, err := xmlValue(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			name := t.Name.Local
+			switch existing := m[name].(type) {
+			case nil:
+				m[name] = val
+			case []any:
+				m[name] = append(existing, val)
+			default:
+				m[name] = []any{existing, val}
+			}
+		case xml.EndElement:
+			s := strings.TrimSpace(text.String())
+			if len(m) == 0 {
+				return s, nil
+			}
+			if s != "" {
+				m["#text"] = s
+			}
+			return m, nil
+		}
+	}
+}
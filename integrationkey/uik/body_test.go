@@ -0,0 +1,45 @@
+package uik
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBody(t *testing.T) {
+	check := func(desc, contentType, data string, exp any) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			t.Helper()
+			body, err := parseBody(contentType, []byte(data))
+			require.NoError(t, err)
+			assert.Equal(t, exp, body)
+		})
+	}
+
+	check("json", "application/json; charset=utf-8", `{"a":1}`, map[string]any{"a": 1.0})
+	check("json no type", "", `["a"]`, []any{"a"})
+	check("json wrong type", "application/x-www-form-urlencoded", `{"a":"b"}`, map[string]any{"a": "b"})
+	check("form", "application/x-www-form-urlencoded", `a=1&b=2&b=3`, map[string]any{"a": "1", "b": "2"})
+	check("text", "text/plain", `hello`, "hello")
+	check("xml", "application/xml", `<?xml version="1.0"?>
+<alert severity="high">
+	<host>db1</host>
+	<tag>a</tag>
+	<tag>b</tag>
+	<msg lang="en">disk full</msg>
+</alert>`, map[string]any{
+		"alert": map[string]any{
+			"@severity": "high",
+			"host":      "db1",
+			"tag":       []any{"a", "b"},
+			"msg":       map[string]any{"@lang": "en", "#text": "disk full"},
+		},
+	})
+
+	_, err := parseBody("application/json", []byte(`not json`))
+	assert.Error(t, err, "invalid json")
+	_, err = parseBody("text/xml", []byte(`<a><b></a>`))
+	assert.Error(t, err, "invalid xml")
+}
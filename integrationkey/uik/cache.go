@@ -0,0 +1,80 @@
+package uik
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/google/uuid"
+)
+
+const (
+	// configCacheSize is the maximum number of compiled configs kept in memory.
+	configCacheSize = 1000
+
+	// configCacheMaxAge limits how long a compiled config is used, in case an
+	// invalidation is missed (e.g., while the NOTIFY listener reconnects).
+	configCacheMaxAge = 5 * time.Minute
+)
+
+type cachedConfig struct {
+	cfg      *CompiledConfig
+	loadedAt time.Time
+}
+
+// configCache is an LRU cache of compiled configs by key ID.
+type configCache struct {
+	mx  sync.Mutex
+	lru *lru.Cache
+
+	// gen is incremented on every invalidation, so that a config loaded
+	// before an invalidation is not added to the cache after it.
+	gen uint64
+
+	load func(context.Context, uuid.UUID) (*CompiledConfig, error)
+}
+
+func newConfigCache(load func(context.Context, uuid.UUID) (*CompiledConfig, error)) *configCache {
+	return &configCache{
+		lru:  lru.New(configCacheSize),
+		load: load,
+	}
+}
+
+// Get will return the compiled config for the given key, loading and compiling it if necessary.
+func (c *configCache) Get(ctx context.Context, keyID uuid.UUID) (*CompiledConfig, error) {
+	c.mx.Lock()
+	v, ok := c.lru.Get(keyID)
+	if ok && time.Since(v.(cachedConfig).loadedAt) < configCacheMaxAge {
+		c.mx.Unlock()
+		metricConfigCacheTotal.WithLabelValues("hit").Inc()
+		return v.(cachedConfig).cfg, nil
+	}
+	gen := c.gen
+	c.mx.Unlock()
+	metricConfigCacheTotal.WithLabelValues("miss").Inc()
+
+	loadedAt := time.Now()
+	cfg, err := c.load(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if c.gen == gen {
+		c.lru.Add(keyID, cachedConfig{cfg: cfg, loadedAt: loadedAt})
+	}
+
+	return cfg, nil
+}
+
+// Invalidate will remove the given key from the cache.
+func (c *configCache) Invalidate(keyID uuid.UUID) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.gen++
+	c.lru.Remove(keyID)
+}
@@ -0,0 +1,47 @@
+package uik
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigCache(t *testing.T) {
+	ctx := context.Background()
+	keyID := uuid.New()
+
+	var loads int
+	c := newConfigCache(func(ctx context.Context, id uuid.UUID) (*CompiledConfig, error) {
+		require.Equal(t, keyID, id)
+		loads++
+		return &CompiledConfig{}, nil
+	})
+
+	first, err := c.Get(ctx, keyID)
+	require.NoError(t, err)
+	second, err := c.Get(ctx, keyID)
+	require.NoError(t, err)
+	require.Same(t, first, second, "should return cached config")
+	require.Equal(t, 1, loads)
+
+	c.Invalidate(keyID)
+	third, err := c.Get(ctx, keyID)
+	require.NoError(t, err)
+	require.NotSame(t, first, third, "should reload after invalidation")
+	require.Equal(t, 2, loads)
+
+	// invalidated while loading, so the result must not be cached
+	c.Invalidate(keyID)
+	c.load = func(ctx context.Context, id uuid.UUID) (*CompiledConfig, error) {
+		loads++
+		c.Invalidate(id)
+		return &CompiledConfig{}, nil
+	}
+	_, err = c.Get(ctx, keyID)
+	require.NoError(t, err)
+	_, err = c.Get(ctx, keyID)
+	require.NoError(t, err)
+	require.Equal(t, 4, loads)
+}
@@ -56,32 +56,59 @@ func NewCompiledConfig(cfg gadb.UIKConfigV1) (*CompiledConfig, error) {
 	return res, nil
 }
 
+// RunResult is the result of running a CompiledConfig.
+type RunResult struct {
+	// MatchedRules are the indexes of the rules that matched, in order.
+	MatchedRules []int
+
+	// DefaultActions is true if no rules matched, and the default actions were used.
+	DefaultActions bool
+
+	Actions []gadb.UIKActionV1
+}
+
 // Run will execute the compiled config against the provided VM and environment.
 func (c *CompiledConfig) Run(vm *vm.VM, env any) (actions []gadb.UIKActionV1, err error) {
-	var anyMatched bool
+	res, err := c.Trace(vm, env)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Actions, nil
+}
+
+// Trace is like Run, but also reports which rules matched.
+func (c *CompiledConfig) Trace(vm *vm.VM, env any) (*RunResult, error) {
+	var res RunResult
 	for i, p := range c.CompiledRules {
 		ruleActions, matched, err := p.Run(vm, env)
 		if err != nil {
 			return nil, &RuleError{
 				Index: i,
+				Name:  p.Name,
 				Err:   fmt.Errorf("run rules: %w", err),
 			}
 		}
-		actions = append(actions, ruleActions...)
-		anyMatched = anyMatched || matched
-		if matched && !p.ContinueAfterMatch {
+		if !matched {
+			continue
+		}
+		res.MatchedRules = append(res.MatchedRules, i)
+		res.Actions = append(res.Actions, ruleActions...)
+		if !p.ContinueAfterMatch {
 			break
 		}
 	}
 
-	if anyMatched {
-		return actions, nil
+	if len(res.MatchedRules) > 0 {
+		return &res, nil
 	}
 
 	act, err := runActions(vm, c.DefaultActions, env)
 	if err != nil {
 		return nil, fmt.Errorf("run default actions: %w", err)
 	}
+	res.DefaultActions = true
+	res.Actions = act
 
-	return act, nil
+	return &res, nil
 }
@@ -83,3 +83,33 @@ func TestCompiledConfig_Run(t *testing.T) {
 		},
 		[]string{"value2", "value3"})
 }
+
+func TestCompiledConfig_Trace(t *testing.T) {
+	cfg := gadb.UIKConfigV1{
+		Rules: []gadb.UIKRuleV1{
+			{Name: "rule1", ConditionExpr: "shouldRun1", ContinueAfterMatch: true},
+			{Name: "rule2", ConditionExpr: "shouldRun2"},
+			{Name: "rule3", ConditionExpr: "true"},
+		},
+		DefaultActions: []gadb.UIKActionV1{
+			{Params: map[string]string{"key": `"valueDefault"`}},
+		},
+	}
+	cmp, err := NewCompiledConfig(cfg)
+	require.NoError(t, err, "should compile a valid config")
+	var vm vm.VM
+
+	res, err := cmp.Trace(&vm, map[string]any{"shouldRun1": true, "shouldRun2": true})
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1}, res.MatchedRules, "should stop after rule2")
+	require.False(t, res.DefaultActions)
+
+	cfg.Rules = cfg.Rules[:2]
+	cmp, err = NewCompiledConfig(cfg)
+	require.NoError(t, err, "should compile a valid config")
+	res, err = cmp.Trace(&vm, map[string]any{"shouldRun1": false, "shouldRun2": false})
+	require.NoError(t, err)
+	require.Empty(t, res.MatchedRules)
+	require.True(t, res.DefaultActions)
+	require.Len(t, res.Actions, 1)
+}
@@ -7,10 +7,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
+	"time"
 
 	"github.com/expr-lang/expr/vm"
 	"github.com/google/uuid"
+	"github.com/riverqueue/river"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/event"
 	"github.com/target/goalert/expflag"
@@ -27,6 +28,13 @@ type Handler struct {
 	db         TxAble
 	evt        *event.Bus
 	hc         *http.Client
+	river      *river.Client[*sql.Tx]
+
+	cache *configCache
+
+	reqLog         chan requestLogEntry
+	reqLogShutdown chan struct{}
+	reqLogDone     chan struct{}
 }
 
 type TxAble interface {
@@ -34,38 +42,83 @@ type TxAble interface {
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
 }
 
-func NewHandler(db TxAble, hc *http.Client, intStore *integrationkey.Store, aStore *alert.Store, evt *event.Bus) *Handler {
-	return &Handler{intStore: intStore, hc: hc, db: db, alertStore: aStore, evt: evt}
+// NewHandler creates a new Handler. Webhook actions are queued using rv, and delivered by
+// workers registered with workers.
+//
+// Requests are recorded in the background until Shutdown is called.
+func NewHandler(ctx context.Context, db TxAble, hc *http.Client, intStore *integrationkey.Store, aStore *alert.Store, evt *event.Bus, rv *river.Client[*sql.Tx], workers *river.Workers) *Handler {
+	h := &Handler{
+		intStore:   intStore,
+		hc:         hc,
+		db:         db,
+		alertStore: aStore,
+		evt:        evt,
+		river:      rv,
+
+		reqLog:         make(chan requestLogEntry, requestLogQueueSize),
+		reqLogShutdown: make(chan struct{}),
+		reqLogDone:     make(chan struct{}),
+	}
+	go h.requestLogLoop(newRequestLogContext(ctx))
+	river.AddWorker(workers, &webhookWorker{h: h})
+	h.cache = newConfigCache(h.loadConfig)
+
+	event.OnEachBatchTx(evt, func(ctx context.Context, tx *sql.Tx, data []integrationkey.ConfigUpdate) error {
+		q := gadb.New(tx)
+		for _, u := range data {
+			h.cache.Invalidate(u.KeyID)
+
+			// other instances (and this one, once committed) will invalidate on notification
+			err := q.IntKeyNotifyConfigUpdate(ctx, u.KeyID.String())
+			if err != nil {
+				return fmt.Errorf("notify config update: %w", err)
+			}
+		}
+		return nil
+	})
+
+	return h
+}
+
+// InvalidateConfig will remove the compiled config of the given key from the cache.
+func (h *Handler) InvalidateConfig(keyID uuid.UUID) { h.cache.Invalidate(keyID) }
+
+func (h *Handler) loadConfig(ctx context.Context, keyID uuid.UUID) (*CompiledConfig, error) {
+	cfg, err := h.intStore.Config(ctx, h.db, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	compiled, err := NewCompiledConfig(*cfg)
+	if err != nil {
+		return nil, err
+	}
+	metricConfigCompileSeconds.Observe(time.Since(start).Seconds())
+
+	return compiled, nil
 }
 
 func (h *Handler) handleAction(ctx context.Context, act gadb.UIKActionV1) (inserted bool, err error) {
 	var didInsertSignals bool
 	switch act.Dest.Type {
 	case "builtin-webhook":
-		req, err := http.NewRequest("POST", act.Dest.Arg("webhook_url"), strings.NewReader(act.Param("body")))
+		keyID, err := uuid.Parse(permission.Source(ctx).ID)
 		if err != nil {
 			return false, err
 		}
-		req.Header.Set("Content-Type", act.Param("content-type"))
-
-		_, err = h.hc.Do(req.WithContext(ctx))
+		err = h.queueWebhook(ctx, WebhookArgs{
+			KeyID:       keyID,
+			URL:         act.Dest.Arg("webhook_url"),
+			ContentType: act.Param("content-type"),
+			Body:        act.Param("body"),
+		})
 		if err != nil {
 			return false, err
 		}
 
-	case "builtin-alert":
-		status := alert.StatusTriggered
-		if act.Param("close") == "true" {
-			status = alert.StatusClosed
-		}
-
-		_, _, err := h.alertStore.CreateOrUpdate(ctx, &alert.Alert{
-			ServiceID: permission.ServiceID(ctx),
-			Summary:   act.Param("summary"),
-			Details:   act.Param("details"),
-			Source:    alert.SourceUniversal,
-			Status:    status,
-		})
+	case alert.DestTypeAlert:
+		err := h.handleAlertAction(ctx, act)
 		if err != nil {
 			return false, err
 		}
@@ -120,40 +173,21 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
-	var body any
-	err = json.Unmarshal(data, &body)
-	if errutil.HTTPError(ctx, w, validation.WrapError(err)) {
-		return
-	}
+	r := integrationkey.NewUIKRequest(req, data)
 
-	cfg, err := h.intStore.Config(ctx, h.db, keyID)
+	// recorded before evaluation, so failing requests can be inspected too
+	h.logRequest(keyID, r)
+
+	env, err := RequestEnv(r)
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
 
-	// TODO: cache
-	compiled, err := NewCompiledConfig(*cfg)
+	compiled, err := h.cache.Get(ctx, keyID)
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
 
-	q := req.URL.Query()
-	query := make(map[string]string)
-	for key := range q {
-		query[key] = q.Get(key)
-	}
-	querya := map[string][]string(q)
-	env := map[string]any{
-		"sprintf": fmt.Sprintf,
-		"req": map[string]any{
-			"body":   body,
-			"query":  query,
-			"querya": querya,
-			"ua":     req.UserAgent(),
-			"ip":     req.RemoteAddr,
-		},
-	}
-
 	var vm vm.VM
 	actions, err := compiled.Run(&vm, env)
 	if errutil.HTTPError(ctx, w, validation.WrapError(err)) {
@@ -0,0 +1,79 @@
+package uik
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+func hmacSum(alg, key, msg string) ([]byte, error) {
+	var fn func() hash.Hash
+	switch strings.ToLower(alg) {
+	case "sha1":
+		fn = sha1.New
+	case "sha256":
+		fn = sha256.New
+	case "sha512":
+		fn = sha512.New
+	default:
+		return nil, fmt.Errorf("unsupported hmac algorithm '%s' (supported: sha1, sha256, sha512)", alg)
+	}
+
+	h := hmac.New(fn, []byte(key))
+	h.Write([]byte(msg))
+	return h.Sum(nil), nil
+}
+
+// hmacHex returns the hex-encoded HMAC of msg, for use in expressions.
+func hmacHex(alg, key, msg string) (string, error) {
+	sum, err := hmacSum(alg, key, msg)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(sum), nil
+}
+
+// hmacBase64 returns the base64-encoded HMAC of msg, for use in expressions.
+func hmacBase64(alg, key, msg string) (string, error) {
+	sum, err := hmacSum(alg, key, msg)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(sum), nil
+}
+
+// verifyHMAC returns true if sig is the HMAC of msg, hex or base64 encoded,
+// for use in expressions. A prefix ending in `=` (e.g., `sha256=`) is ignored.
+func verifyHMAC(alg, key, msg, sig string) (bool, error) {
+	sum, err := hmacSum(alg, key, msg)
+	if err != nil {
+		return false, err
+	}
+
+	// base64 only uses `=` as trailing padding, so anything after the first `=` means it's a prefix
+	if i := strings.Index(sig, "="); i > 0 && strings.Trim(sig[i:], "=") != "" {
+		sig = sig[i+1:]
+	}
+	if dec, err := hex.DecodeString(sig); err == nil && hmac.Equal(dec, sum) {
+		return true, nil
+	}
+	if dec, err := base64.StdEncoding.DecodeString(sig); err == nil && hmac.Equal(dec, sum) {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// secureCompare returns true if a and b are equal, in constant time, for use in expressions.
+func secureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
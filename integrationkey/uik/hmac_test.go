@@ -0,0 +1,35 @@
+package uik
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMAC(t *testing.T) {
+	// RFC 4231 test case 2
+	const exp = "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	sum, err := hmacHex("sha256", "Jefe", "what do ya want for nothing?")
+	require.NoError(t, err)
+	assert.Equal(t, exp, sum)
+
+	b64, err := hmacBase64("SHA256", "Jefe", "what do ya want for nothing?")
+	require.NoError(t, err)
+
+	for _, sig := range []string{exp, "sha256=" + exp, b64, "v1=" + b64} {
+		ok, err := verifyHMAC("sha256", "Jefe", "what do ya want for nothing?", sig)
+		require.NoError(t, err)
+		assert.True(t, ok, sig)
+	}
+
+	ok, err := verifyHMAC("sha256", "wrong", "what do ya want for nothing?", exp)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = hmacHex("md5", "key", "msg")
+	assert.Error(t, err)
+
+	assert.True(t, secureCompare("abc", "abc"))
+	assert.False(t, secureCompare("abc", "abd"))
+}
@@ -0,0 +1,36 @@
+package uik
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricConfigCacheTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "goalert",
+		Subsystem: "uik",
+		Name:      "config_cache_total",
+		Help:      "Total number of compiled config lookups, by cache result.",
+	}, []string{"result"})
+	metricConfigCompileSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "goalert",
+		Subsystem: "uik",
+		Name:      "config_compile_seconds",
+		Help:      "Time taken to compile a universal key config.",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 12),
+	})
+)
+
+var metricRequestLogDroppedTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "goalert",
+	Subsystem: "uik",
+	Name:      "request_log_dropped_total",
+	Help:      "Total number of requests not recorded because the request log queue was full.",
+})
+
+var metricWebhookTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "goalert",
+	Subsystem: "uik",
+	Name:      "webhook_total",
+	Help:      "Total number of webhook action attempts, by result (success, retry, or failed).",
+}, []string{"result"})
@@ -0,0 +1,77 @@
+package uik
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/expr-lang/expr/vm"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/validation"
+)
+
+// RequestEnv returns the expression environment for a request.
+func RequestEnv(r integrationkey.UIKRequest) (map[string]any, error) {
+	body, err := parseBody(r.Header.Get("Content-Type"), r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	query := make(map[string]string)
+	for key := range r.Query {
+		query[key] = r.Query.Get(key)
+	}
+	querya := map[string][]string(r.Query)
+	if querya == nil {
+		querya = make(map[string][]string)
+	}
+
+	// header names are lower-cased, so they can be used without knowing the sender's casing
+	headers := make(map[string]string, len(r.Header))
+	headersa := make(map[string][]string, len(r.Header))
+	for key, vals := range r.Header {
+		name := strings.ToLower(key)
+		headers[name] = r.Header.Get(key)
+		headersa[name] = vals
+	}
+
+	return map[string]any{
+		"sprintf":       fmt.Sprintf,
+		"hmacHex":       hmacHex,
+		"hmacBase64":    hmacBase64,
+		"verifyHMAC":    verifyHMAC,
+		"secureCompare": secureCompare,
+		"req": map[string]any{
+			"body":     body,
+			"rawBody":  string(r.Body),
+			"query":    query,
+			"querya":   querya,
+			"headers":  headers,
+			"headersa": headersa,
+			"ua":       r.UserAgent,
+			"ip":       r.RemoteAddr,
+		},
+	}, nil
+}
+
+// DryRun will evaluate a request against a config, without performing any of
+// the resulting actions.
+func DryRun(cfg gadb.UIKConfigV1, r integrationkey.UIKRequest) (*RunResult, error) {
+	env, err := RequestEnv(r)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled, err := NewCompiledConfig(cfg)
+	if err != nil {
+		return nil, validation.WrapError(err)
+	}
+
+	var vm vm.VM
+	res, err := compiled.Trace(&vm, env)
+	if err != nil {
+		return nil, validation.WrapError(err)
+	}
+
+	return res, nil
+}
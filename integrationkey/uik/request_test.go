@@ -0,0 +1,62 @@
+package uik
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/integrationkey"
+)
+
+func TestDryRun(t *testing.T) {
+	cfg := gadb.UIKConfigV1{
+		Rules: []gadb.UIKRuleV1{
+			{
+				Name:          "critical",
+				ConditionExpr: `req.body.severity == "critical"`,
+				Actions: []gadb.UIKActionV1{
+					{Params: map[string]string{"summary": `sprintf("%s on %s", req.body.msg, req.query.host)`}},
+				},
+			},
+		},
+	}
+
+	res, err := DryRun(cfg, integrationkey.UIKRequest{
+		Body:  []byte(`{"severity":"critical","msg":"disk full"}`),
+		Query: url.Values{"host": {"db1"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{0}, res.MatchedRules)
+	require.Len(t, res.Actions, 1)
+	require.Equal(t, "disk full on db1", res.Actions[0].Params["summary"])
+
+	_, err = DryRun(cfg, integrationkey.UIKRequest{Body: []byte(`not json`)})
+	require.Error(t, err, "should reject invalid JSON bodies")
+
+	sig, err := hmacHex("sha256", "secret", "status=down")
+	require.NoError(t, err)
+	cfg = gadb.UIKConfigV1{
+		Rules: []gadb.UIKRuleV1{
+			{
+				Name:          "signed",
+				ConditionExpr: `verifyHMAC("sha256", "secret", req.rawBody, req.headers["x-signature"]) && req.body.status == "down"`,
+				Actions: []gadb.UIKActionV1{
+					{Params: map[string]string{"summary": `req.headers["x-event"]`}},
+				},
+			},
+		},
+	}
+	res, err = DryRun(cfg, integrationkey.UIKRequest{
+		Body: []byte("status=down"),
+		Header: http.Header{
+			"Content-Type": {"application/x-www-form-urlencoded"},
+			"X-Signature":  {"sha256=" + sig},
+			"X-Event":      {"outage"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{0}, res.MatchedRules)
+	require.Equal(t, "outage", res.Actions[0].Params["summary"])
+}
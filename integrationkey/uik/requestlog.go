@@ -0,0 +1,125 @@
+package uik
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+const (
+	// requestLogQueueSize is the number of requests that can be waiting to be recorded
+	// before new ones are dropped.
+	requestLogQueueSize = 1000
+
+	// requestLogBatchSize is the maximum number of requests recorded in a single transaction.
+	requestLogBatchSize = 100
+)
+
+type requestLogEntry struct {
+	keyID      uuid.UUID
+	receivedAt time.Time
+	req        integrationkey.UIKRequest
+}
+
+// logRequest queues a request to be recorded in the background, so recording doesn't
+// delay the response. If the queue is full, the request is not recorded.
+func (h *Handler) logRequest(keyID uuid.UUID, r integrationkey.UIKRequest) {
+	select {
+	case h.reqLog <- requestLogEntry{keyID: keyID, receivedAt: time.Now(), req: r}:
+	default:
+		metricRequestLogDroppedTotal.Inc()
+	}
+}
+
+// requestLogLoop records queued requests in batches, until Shutdown is called.
+func (h *Handler) requestLogLoop(ctx context.Context) {
+	defer close(h.reqLogDone)
+
+	batch := make([]requestLogEntry, 0, requestLogBatchSize)
+	flush := func() {
+		err := h.recordRequests(ctx, batch)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("record uik requests: %w", err))
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case e := <-h.reqLog:
+			batch = append(batch, e)
+		case <-h.reqLogShutdown:
+			// record anything still queued
+			for {
+				select {
+				case e := <-h.reqLog:
+					batch = append(batch, e)
+					if len(batch) == requestLogBatchSize {
+						flush()
+					}
+				default:
+					if len(batch) > 0 {
+						flush()
+					}
+					return
+				}
+			}
+		}
+
+		// collect anything else that's already waiting
+	fill:
+		for len(batch) < requestLogBatchSize {
+			select {
+			case e := <-h.reqLog:
+				batch = append(batch, e)
+			default:
+				break fill
+			}
+		}
+		flush()
+	}
+}
+
+// recordRequests records a batch of requests in a single transaction.
+func (h *Handler) recordRequests(ctx context.Context, batch []requestLogEntry) error {
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "uik: record requests", tx)
+
+	for _, e := range batch {
+		err = h.intStore.RecordRequest(ctx, tx, e.keyID, e.receivedAt, e.req)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Shutdown will record any queued requests, and stop recording new ones.
+func (h *Handler) Shutdown(ctx context.Context) error {
+	if h == nil {
+		return nil
+	}
+
+	close(h.reqLogShutdown)
+	select {
+	case <-h.reqLogDone:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newRequestLogContext returns the context used to record requests in the background.
+func newRequestLogContext(ctx context.Context) context.Context {
+	return permission.SystemContext(context.WithoutCancel(ctx), "UIKRequestLog")
+}
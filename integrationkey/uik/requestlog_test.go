@@ -0,0 +1,33 @@
+package uik
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/integrationkey"
+)
+
+func TestHandler_LogRequest(t *testing.T) {
+	h := &Handler{
+		reqLog:         make(chan requestLogEntry, 1),
+		reqLogShutdown: make(chan struct{}),
+		reqLogDone:     make(chan struct{}),
+	}
+
+	keyID := uuid.New()
+	h.logRequest(keyID, integrationkey.UIKRequest{Body: []byte("first")})
+	h.logRequest(keyID, integrationkey.UIKRequest{Body: []byte("second")}) // dropped, queue is full
+
+	require.Len(t, h.reqLog, 1)
+	e := <-h.reqLog
+	assert.Equal(t, keyID, e.keyID)
+	assert.Equal(t, "first", string(e.req.Body))
+	assert.False(t, e.receivedAt.IsZero())
+
+	// nothing queued, so nothing to record
+	go h.requestLogLoop(context.Background())
+	require.NoError(t, h.Shutdown(context.Background()))
+}
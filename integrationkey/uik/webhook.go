@@ -0,0 +1,139 @@
+package uik
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+)
+
+const (
+	// webhookMaxAttempts is the number of times a webhook action will be attempted before it is recorded as failed.
+	webhookMaxAttempts = 8
+
+	// webhookTimeout is the maximum duration of a single webhook attempt.
+	webhookTimeout = 30 * time.Second
+
+	webhookMinBackoff = 10 * time.Second
+	webhookMaxBackoff = time.Hour
+)
+
+// WebhookArgs are the arguments of a job that delivers a webhook action of a universal key.
+type WebhookArgs struct {
+	KeyID       uuid.UUID
+	URL         string
+	ContentType string
+	Body        string
+}
+
+func (WebhookArgs) Kind() string { return "uik-webhook" }
+
+type webhookWorker struct {
+	river.WorkerDefaults[WebhookArgs]
+	h *Handler
+}
+
+// webhookBackoff returns the delay before the next attempt, doubling after each
+// failed attempt up to webhookMaxBackoff.
+func webhookBackoff(attempt int) time.Duration {
+	d := webhookMinBackoff
+	for i := 1; i < attempt && d < webhookMaxBackoff; i++ {
+		d *= 2
+	}
+
+	return min(d, webhookMaxBackoff)
+}
+
+// isPermanentStatus returns true if retrying a request that resulted in the
+// status code is not expected to succeed.
+func isPermanentStatus(code int) bool {
+	if code == http.StatusRequestTimeout || code == http.StatusTooManyRequests {
+		return false
+	}
+
+	return code >= 400 && code < 500
+}
+
+func (w *webhookWorker) NextRetry(j *river.Job[WebhookArgs]) time.Time {
+	return time.Now().Add(webhookBackoff(j.Attempt))
+}
+
+func (w *webhookWorker) Timeout(*river.Job[WebhookArgs]) time.Duration { return webhookTimeout }
+
+// send will make the webhook request, returning the status code of the response, if any.
+func (w *webhookWorker) send(ctx context.Context, args WebhookArgs) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", args.URL, strings.NewReader(args.Body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", args.ContentType)
+
+	resp, err := w.h.hc.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1024*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Work will attempt to deliver the webhook. Once all attempts are exhausted, or
+// the response indicates a retry would not succeed, the failure is recorded
+// with the key.
+func (w *webhookWorker) Work(ctx context.Context, j *river.Job[WebhookArgs]) error {
+	status, err := w.send(ctx, j.Args)
+	if err == nil {
+		metricWebhookTotal.WithLabelValues("success").Inc()
+		return nil
+	}
+
+	permanent := isPermanentStatus(status)
+	if !permanent && j.Attempt < j.MaxAttempts {
+		metricWebhookTotal.WithLabelValues("retry").Inc()
+		return err
+	}
+
+	metricWebhookTotal.WithLabelValues("failed").Inc()
+	recErr := w.h.intStore.RecordWebhookFailure(permission.SystemContext(ctx, "UIKWebhook"), w.h.db, j.Args.KeyID, integrationkey.WebhookFailure{
+		URL:         j.Args.URL,
+		ContentType: j.Args.ContentType,
+		Body:        j.Args.Body,
+		Attempts:    j.Attempt,
+		StatusCode:  status,
+		Error:       err.Error(),
+	})
+	if recErr != nil {
+		return fmt.Errorf("record webhook failure: %w (webhook error: %w)", recErr, err)
+	}
+
+	if permanent {
+		return river.JobCancel(err)
+	}
+
+	return err
+}
+
+// queueWebhook will durably queue a webhook action for delivery.
+func (h *Handler) queueWebhook(ctx context.Context, args WebhookArgs) error {
+	_, err := h.river.Insert(ctx, args, &river.InsertOpts{
+		Queue:       river.QueueDefault,
+		MaxAttempts: webhookMaxAttempts,
+	})
+	if err != nil {
+		return fmt.Errorf("queue webhook: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package uik
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/integrationkey"
+)
+
+// execRecorder is a TxAble that records exec calls; anything else is unsupported.
+type execRecorder struct {
+	TxAble
+	queries []string
+	args    [][]any
+}
+
+func (e *execRecorder) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	e.queries = append(e.queries, query)
+	e.args = append(e.args, args)
+	return nil, nil
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, webhookBackoff(1))
+	assert.Equal(t, 20*time.Second, webhookBackoff(2))
+	assert.Equal(t, 80*time.Second, webhookBackoff(4))
+	assert.Equal(t, time.Hour, webhookBackoff(20))
+}
+
+func TestIsPermanentStatus(t *testing.T) {
+	assert.False(t, isPermanentStatus(0))
+	assert.False(t, isPermanentStatus(http.StatusRequestTimeout))
+	assert.False(t, isPermanentStatus(http.StatusTooManyRequests))
+	assert.False(t, isPermanentStatus(http.StatusBadGateway))
+	assert.True(t, isPermanentStatus(http.StatusBadRequest))
+	assert.True(t, isPermanentStatus(http.StatusNotFound))
+}
+
+func TestWebhookWorker(t *testing.T) {
+	status := http.StatusOK
+	var gotBody, gotType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		gotBody = string(data)
+		gotType = r.Header.Get("Content-Type")
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	w := &webhookWorker{h: &Handler{hc: srv.Client()}}
+	job := &river.Job[WebhookArgs]{
+		JobRow: &rivertype.JobRow{Attempt: 1, MaxAttempts: webhookMaxAttempts},
+		Args:   WebhookArgs{URL: srv.URL, ContentType: "application/json", Body: `{"foo":"bar"}`},
+	}
+
+	err := w.Work(context.Background(), job)
+	require.NoError(t, err)
+	assert.Equal(t, `{"foo":"bar"}`, gotBody)
+	assert.Equal(t, "application/json", gotType)
+
+	// retried until the last attempt
+	status = http.StatusServiceUnavailable
+	err = w.Work(context.Background(), job)
+	assert.ErrorContains(t, err, "503")
+}
+
+func TestWebhookWorker_RecordFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	db := &execRecorder{}
+	w := &webhookWorker{h: &Handler{hc: srv.Client(), db: db, intStore: &integrationkey.Store{}}}
+
+	// multi-byte characters straddling the size limit
+	body := strings.Repeat("a", integrationkey.MaxWebhookFailureBodySize-1) + strings.Repeat("日", 10)
+	keyID := uuid.New()
+	job := &river.Job[WebhookArgs]{
+		JobRow: &rivertype.JobRow{Attempt: webhookMaxAttempts, MaxAttempts: webhookMaxAttempts},
+		Args:   WebhookArgs{KeyID: keyID, URL: srv.URL, ContentType: "text/plain", Body: body},
+	}
+
+	err := w.Work(context.Background(), job)
+	assert.ErrorContains(t, err, "503")
+
+	require.Len(t, db.queries, 2, "should record and trim failures")
+	assert.Contains(t, db.queries[0], "INSERT INTO uik_webhook_failures")
+	args := db.args[0]
+	assert.Equal(t, keyID, args[1])
+	assert.Equal(t, srv.URL, args[2])
+	assert.Equal(t, "text/plain", args[3])
+	stored := args[4].(string)
+	assert.True(t, utf8.ValidString(stored), "stored body should be valid UTF-8")
+	assert.Equal(t, strings.Repeat("a", integrationkey.MaxWebhookFailureBodySize-1), stored)
+	assert.EqualValues(t, webhookMaxAttempts, args[5])
+	assert.EqualValues(t, http.StatusServiceUnavailable, args[6])
+	assert.Contains(t, db.queries[1], "DELETE FROM uik_webhook_failures")
+
+	// permanent failures are recorded on any attempt, and the job is cancelled
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	db.queries, db.args = nil, nil
+	job.Attempt = 1
+	err = w.Work(context.Background(), job)
+	var cancelErr *river.JobCancelError
+	assert.ErrorAs(t, err, &cancelErr)
+	assert.Len(t, db.queries, 2)
+}
@@ -0,0 +1,63 @@
+package uiktemplate
+
+import (
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/gadb"
+)
+
+func init() {
+	register(Template{
+		ID:          "cloudwatch-sns",
+		Name:        "Amazon CloudWatch (SNS)",
+		Description: "CloudWatch alarm notifications delivered by an SNS HTTPS subscription. The subscription confirmation creates an alert containing the confirmation link.",
+		Version:     1,
+		Samples: []Sample{
+			{
+				Name:        "Subscription Confirmation",
+				ContentType: "text/plain; charset=UTF-8",
+				Body:        `{"Type":"SubscriptionConfirmation","MessageId":"165545c9-2a5c-472c-8df2-7ff2be2b3b1b","TopicArn":"arn:aws:sns:us-east-1:123456789012:alarms","Message":"You have chosen to subscribe to the topic arn:aws:sns:us-east-1:123456789012:alarms.","SubscribeURL":"https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&TopicArn=arn:aws:sns:us-east-1:123456789012:alarms&Token=2336412f37"}`,
+			},
+			{
+				Name:        "Alarm",
+				ContentType: "text/plain; charset=UTF-8",
+				Body:        `{"Type":"Notification","MessageId":"22b80b92-fdea-4c2c-8f9d-bdfb0c7bf324","TopicArn":"arn:aws:sns:us-east-1:123456789012:alarms","Subject":"ALARM: \"HighLatency\" in US East (N. Virginia)","Message":"{\"AlarmName\":\"HighLatency\",\"AlarmDescription\":\"p99 latency above 2s\",\"NewStateValue\":\"ALARM\",\"NewStateReason\":\"Threshold Crossed: 1 datapoint [2.4] was greater than the threshold (2.0).\",\"Region\":\"US East (N. Virginia)\",\"AlarmArn\":\"arn:aws:cloudwatch:us-east-1:123456789012:alarm:HighLatency\"}"}`,
+			},
+			{
+				Name:        "OK",
+				ContentType: "text/plain; charset=UTF-8",
+				Body:        `{"Type":"Notification","MessageId":"0e3b9ae5-3f5b-4e62-9a0a-1f0f6a44a6d6","TopicArn":"arn:aws:sns:us-east-1:123456789012:alarms","Subject":"OK: \"HighLatency\" in US East (N. Virginia)","Message":"{\"AlarmName\":\"HighLatency\",\"AlarmDescription\":\"p99 latency above 2s\",\"NewStateValue\":\"OK\",\"NewStateReason\":\"Threshold Crossed: 1 datapoint [1.1] was not greater than the threshold (2.0).\",\"Region\":\"US East (N. Virginia)\",\"AlarmArn\":\"arn:aws:cloudwatch:us-east-1:123456789012:alarm:HighLatency\"}"}`,
+			},
+		},
+		config: func() gadb.UIKConfigV1 {
+			const dedup = `"cloudwatch-" + fromJSON(req.body.Message).AlarmArn`
+			return gadb.UIKConfigV1{
+				Rules: []gadb.UIKRuleV1{
+					{
+						Name:          "Subscription Confirmation",
+						Description:   "Create an alert with the link to confirm the SNS subscription.",
+						ConditionExpr: `req.body.Type == "SubscriptionConfirmation"`,
+						Actions: []gadb.UIKActionV1{alertAction(alert.ActionTrigger, `"sns-subscribe-" + req.body.TopicArn`,
+							`"Confirm SNS subscription for " + req.body.TopicArn`,
+							`"Visit the following link to confirm the subscription:\n\n" + req.body.SubscribeURL`,
+						)},
+					},
+					{
+						Name:          "OK",
+						Description:   "Close the alert when the alarm returns to OK.",
+						ConditionExpr: `req.body.Type == "Notification" && fromJSON(req.body.Message).NewStateValue == "OK"`,
+						Actions:       []gadb.UIKActionV1{alertAction(alert.ActionClose, dedup, "", "")},
+					},
+					{
+						Name:          "Alarm",
+						Description:   "Create an alert when the alarm enters the ALARM state.",
+						ConditionExpr: `req.body.Type == "Notification" && fromJSON(req.body.Message).NewStateValue == "ALARM"`,
+						Actions: []gadb.UIKActionV1{alertAction(alert.ActionTrigger, dedup,
+							`req.body.Subject ?? fromJSON(req.body.Message).AlarmName`,
+							`let m = fromJSON(req.body.Message); (m.AlarmDescription ?? "") + "\n\n" + m.NewStateReason + "\n\nRegion: " + m.Region`,
+						)},
+					},
+				},
+			}
+		},
+	})
+}
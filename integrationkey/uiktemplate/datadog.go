@@ -0,0 +1,50 @@
+package uiktemplate
+
+import (
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/gadb"
+)
+
+func init() {
+	register(Template{
+		ID:   "datadog",
+		Name: "Datadog",
+		Description: "Datadog monitor webhooks. Configure the webhook payload to include at least: " +
+			`{"title": "$EVENT_TITLE", "body": "$EVENT_MSG", "alert_transition": "$ALERT_TRANSITION", "aggreg_key": "$AGGREG_KEY", "hostname": "$HOSTNAME", "link": "$LINK"}`,
+		Version: 1,
+		Samples: []Sample{
+			{
+				Name:        "Triggered",
+				ContentType: "application/json",
+				Body:        `{"title":"[Triggered] High CPU on web-1","body":"CPU usage is above 90%","alert_transition":"Triggered","aggreg_key":"4c1f0e","hostname":"web-1","link":"https://app.datadoghq.com/event/event?id=1"}`,
+			},
+			{
+				Name:        "Recovered",
+				ContentType: "application/json",
+				Body:        `{"title":"[Recovered] High CPU on web-1","body":"CPU usage is back to normal","alert_transition":"Recovered","aggreg_key":"4c1f0e","hostname":"web-1","link":"https://app.datadoghq.com/event/event?id=2"}`,
+			},
+		},
+		config: func() gadb.UIKConfigV1 {
+			const dedup = `"datadog-" + (req.body.aggreg_key ?? req.body.title)`
+			return gadb.UIKConfigV1{
+				Rules: []gadb.UIKRuleV1{
+					{
+						Name:          "Recovered",
+						Description:   "Close the alert when the monitor recovers.",
+						ConditionExpr: `req.body.alert_transition == "Recovered"`,
+						Actions:       []gadb.UIKActionV1{alertAction(alert.ActionClose, dedup, "", "")},
+					},
+					{
+						Name:          "Triggered",
+						Description:   "Create an alert when the monitor triggers.",
+						ConditionExpr: `req.body.alert_transition in ["Triggered", "Re-Triggered", "No Data"]`,
+						Actions: []gadb.UIKActionV1{alertAction(alert.ActionTrigger, dedup,
+							`req.body.title`,
+							`(req.body.body ?? "") + "\n\nHost: " + (req.body.hostname ?? "") + "\n" + (req.body.link ?? "")`,
+						)},
+					},
+				},
+			}
+		},
+	})
+}
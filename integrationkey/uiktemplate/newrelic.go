@@ -0,0 +1,60 @@
+package uiktemplate
+
+import (
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/gadb"
+)
+
+func init() {
+	register(Template{
+		ID:          "newrelic",
+		Name:        "New Relic",
+		Description: "New Relic workflow webhooks, using the default issue payload.",
+		Version:     1,
+		Samples: []Sample{
+			{
+				Name:        "Activated",
+				ContentType: "application/json",
+				Body:        `{"id":"b5c4a3d2-0000-4000-8000-000000000001","issueUrl":"https://one.newrelic.com/alerts-ai/issues/1","title":"Error rate above 5% on checkout","priority":"CRITICAL","state":"ACTIVATED","trigger":"STATE_CHANGE"}`,
+			},
+			{
+				Name:        "Acknowledged",
+				ContentType: "application/json",
+				Body:        `{"id":"b5c4a3d2-0000-4000-8000-000000000001","issueUrl":"https://one.newrelic.com/alerts-ai/issues/1","title":"Error rate above 5% on checkout","priority":"CRITICAL","state":"ACKNOWLEDGED","trigger":"STATE_CHANGE"}`,
+			},
+			{
+				Name:        "Closed",
+				ContentType: "application/json",
+				Body:        `{"id":"b5c4a3d2-0000-4000-8000-000000000001","issueUrl":"https://one.newrelic.com/alerts-ai/issues/1","title":"Error rate above 5% on checkout","priority":"CRITICAL","state":"CLOSED","trigger":"STATE_CHANGE"}`,
+			},
+		},
+		config: func() gadb.UIKConfigV1 {
+			const dedup = `"newrelic-" + req.body.id`
+			return gadb.UIKConfigV1{
+				Rules: []gadb.UIKRuleV1{
+					{
+						Name:          "Closed",
+						Description:   "Close the alert when the issue is closed.",
+						ConditionExpr: `req.body.state == "CLOSED"`,
+						Actions:       []gadb.UIKActionV1{alertAction(alert.ActionClose, dedup, "", "")},
+					},
+					{
+						Name:          "Acknowledged",
+						Description:   "Acknowledge the alert when the issue is acknowledged.",
+						ConditionExpr: `req.body.state == "ACKNOWLEDGED"`,
+						Actions:       []gadb.UIKActionV1{alertAction(alert.ActionAck, dedup, "", "")},
+					},
+					{
+						Name:          "Activated",
+						Description:   "Create an alert when an issue is created or activated.",
+						ConditionExpr: `req.body.state in ["CREATED", "ACTIVATED"]`,
+						Actions: []gadb.UIKActionV1{alertAction(alert.ActionTrigger, dedup,
+							`req.body.title`,
+							`"Priority: " + (req.body.priority ?? "") + "\n" + (req.body.issueUrl ?? "")`,
+						)},
+					},
+				},
+			}
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package uiktemplate
+
+import (
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/gadb"
+)
+
+func init() {
+	register(Template{
+		ID:          "sentry",
+		Name:        "Sentry",
+		Description: "Sentry internal integration webhooks, for issue alerts and issue resolution.",
+		Version:     1,
+		Samples: []Sample{
+			{
+				Name:        "Issue Alert",
+				ContentType: "application/json",
+				Body:        `{"action":"triggered","data":{"event":{"issue_id":"4012","title":"TypeError: Cannot read properties of undefined","culprit":"checkout/cart.js","level":"error","web_url":"https://sentry.io/organizations/acme/issues/4012/events/1/"},"triggered_rule":"Page on new errors"}}`,
+			},
+			{
+				Name:        "Issue Created",
+				ContentType: "application/json",
+				Body:        `{"action":"created","data":{"issue":{"id":"4012","title":"TypeError: Cannot read properties of undefined","culprit":"checkout/cart.js","level":"error","web_url":"https://sentry.io/organizations/acme/issues/4012/"}}}`,
+			},
+			{
+				Name:        "Issue Resolved",
+				ContentType: "application/json",
+				Body:        `{"action":"resolved","data":{"issue":{"id":"4012","title":"TypeError: Cannot read properties of undefined","culprit":"checkout/cart.js","level":"error","web_url":"https://sentry.io/organizations/acme/issues/4012/"}}}`,
+			},
+		},
+		config: func() gadb.UIKConfigV1 {
+			const issueDedup = `"sentry-" + string(req.body.data.issue.id)`
+			return gadb.UIKConfigV1{
+				Rules: []gadb.UIKRuleV1{
+					{
+						Name:          "Issue Alert",
+						Description:   "Create an alert when an issue alert rule is triggered.",
+						ConditionExpr: `req.body.action == "triggered" && req.body.data?.event != nil`,
+						Actions: []gadb.UIKActionV1{alertAction(alert.ActionTrigger, `"sentry-" + string(req.body.data.event.issue_id)`,
+							`req.body.data.event.title`,
+							`"Rule: " + (req.body.data.triggered_rule ?? "") + "\nCulprit: " + (req.body.data.event.culprit ?? "") + "\n" + (req.body.data.event.web_url ?? "")`,
+						)},
+					},
+					{
+						Name:          "Issue Created",
+						Description:   "Create an alert when a new issue is created.",
+						ConditionExpr: `req.body.action == "created" && req.body.data?.issue != nil`,
+						Actions: []gadb.UIKActionV1{alertAction(alert.ActionTrigger, issueDedup,
+							`req.body.data.issue.title`,
+							`"Culprit: " + (req.body.data.issue.culprit ?? "") + "\n" + (req.body.data.issue.web_url ?? "")`,
+						)},
+					},
+					{
+						Name:          "Issue Resolved",
+						Description:   "Close the alert when the issue is resolved.",
+						ConditionExpr: `req.body.action == "resolved" && req.body.data?.issue != nil`,
+						Actions:       []gadb.UIKActionV1{alertAction(alert.ActionClose, issueDedup, "", "")},
+					},
+					{
+						Name:          "Issue Archived",
+						Description:   "Acknowledge the alert when the issue is archived or ignored.",
+						ConditionExpr: `req.body.action in ["ignored", "archived"] && req.body.data?.issue != nil`,
+						Actions:       []gadb.UIKActionV1{alertAction(alert.ActionAck, issueDedup, "", "")},
+					},
+				},
+			}
+		},
+	})
+}
@@ -0,0 +1,97 @@
+package uiktemplate
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/validation"
+)
+
+// A Template is a built-in universal key configuration for a common monitoring tool.
+type Template struct {
+	ID          string
+	Name        string
+	Description string
+
+	// Version is incremented each time the rules of the template change, so keys
+	// created from an older version can be upgraded.
+	Version int
+
+	// Samples are example requests the template is expected to handle.
+	Samples []Sample
+
+	config func() gadb.UIKConfigV1
+}
+
+// A Sample is an example request sent by a monitoring tool.
+type Sample struct {
+	Name        string
+	ContentType string
+	Body        string
+}
+
+var catalog []Template
+
+func register(t Template) { catalog = append(catalog, t) }
+
+// All returns all templates, sorted by name.
+func All() []Template {
+	result := slices.Clone(catalog)
+	slices.SortFunc(result, func(a, b Template) int { return strings.Compare(a.Name, b.Name) })
+	return result
+}
+
+// Find returns the template with the given ID.
+func Find(id string) (*Template, error) {
+	for _, t := range catalog {
+		if t.ID == id {
+			return &t, nil
+		}
+	}
+
+	return nil, validation.NewFieldError("TemplateID", "unknown template")
+}
+
+// Config returns a new copy of the template's configuration, recording the
+// template ID and version it was created from.
+func (t Template) Config() gadb.UIKConfigV1 {
+	cfg := t.config()
+	cfg.TemplateID = t.ID
+	cfg.TemplateVersion = t.Version
+	return cfg
+}
+
+// UpgradeAvailable returns true if cfg was created from an older version of a template.
+func UpgradeAvailable(cfg gadb.UIKConfigV1) bool {
+	if cfg.TemplateID == "" {
+		return false
+	}
+	t, err := Find(cfg.TemplateID)
+	if err != nil {
+		return false
+	}
+
+	return cfg.TemplateVersion < t.Version
+}
+
+// alertAction returns an alert action. The dedup, summary, and details params are expressions.
+func alertAction(action, dedup, summary, details string) gadb.UIKActionV1 {
+	params := map[string]string{
+		alert.ParamAction: strconv.Quote(action),
+		alert.ParamDedup:  dedup,
+	}
+	if summary != "" {
+		params[alert.ParamSummary] = summary
+	}
+	if details != "" {
+		params[alert.ParamDetails] = details
+	}
+
+	return gadb.UIKActionV1{
+		Dest:   gadb.DestV1{Type: alert.DestTypeAlert},
+		Params: params,
+	}
+}
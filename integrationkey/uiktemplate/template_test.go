@@ -0,0 +1,117 @@
+package uiktemplate
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/integrationkey/uik"
+	"github.com/target/goalert/validation/validate"
+)
+
+type expected struct {
+	Action  string
+	Dedup   string
+	Summary string
+}
+
+// results are the expected alert actions for each sample, by template ID and sample name.
+var results = map[string]map[string]*expected{
+	"datadog": {
+		"Triggered": {alert.ActionTrigger, "datadog-4c1f0e", "[Triggered] High CPU on web-1"},
+		"Recovered": {alert.ActionClose, "datadog-4c1f0e", ""},
+	},
+	"newrelic": {
+		"Activated":    {alert.ActionTrigger, "newrelic-b5c4a3d2-0000-4000-8000-000000000001", "Error rate above 5% on checkout"},
+		"Acknowledged": {alert.ActionAck, "newrelic-b5c4a3d2-0000-4000-8000-000000000001", ""},
+		"Closed":       {alert.ActionClose, "newrelic-b5c4a3d2-0000-4000-8000-000000000001", ""},
+	},
+	"zabbix": {
+		"Problem":      {alert.ActionTrigger, "zabbix-1042", "Problem: Zabbix agent is not available"},
+		"Acknowledged": {alert.ActionAck, "zabbix-1042", ""},
+		"Resolved":     {alert.ActionClose, "zabbix-1042", ""},
+	},
+	"cloudwatch-sns": {
+		"Subscription Confirmation": {alert.ActionTrigger, "sns-subscribe-arn:aws:sns:us-east-1:123456789012:alarms", "Confirm SNS subscription for arn:aws:sns:us-east-1:123456789012:alarms"},
+		"Alarm":                     {alert.ActionTrigger, "cloudwatch-arn:aws:cloudwatch:us-east-1:123456789012:alarm:HighLatency", `ALARM: "HighLatency" in US East (N. Virginia)`},
+		"OK":                        {alert.ActionClose, "cloudwatch-arn:aws:cloudwatch:us-east-1:123456789012:alarm:HighLatency", ""},
+	},
+	"sentry": {
+		"Issue Alert":    {alert.ActionTrigger, "sentry-4012", "TypeError: Cannot read properties of undefined"},
+		"Issue Created":  {alert.ActionTrigger, "sentry-4012", "TypeError: Cannot read properties of undefined"},
+		"Issue Resolved": {alert.ActionClose, "sentry-4012", ""},
+	},
+	"uptime-kuma": {
+		"Down": {alert.ActionTrigger, "uptime-kuma-7", "Status Page is down"},
+		"Up":   {alert.ActionClose, "uptime-kuma-7", ""},
+		"Test": nil,
+	},
+}
+
+func TestTemplates(t *testing.T) {
+	ids := make(map[string]bool)
+	for _, tmpl := range All() {
+		t.Run(tmpl.ID, func(t *testing.T) {
+			require.False(t, ids[tmpl.ID], "duplicate template ID")
+			ids[tmpl.ID] = true
+			assert.Positive(t, tmpl.Version)
+			require.NotEmpty(t, tmpl.Samples, "templates must include sample payloads")
+
+			cfg := tmpl.Config()
+			assert.Equal(t, tmpl.ID, cfg.TemplateID)
+			assert.Equal(t, tmpl.Version, cfg.TemplateVersion)
+			for _, r := range cfg.Rules {
+				require.NoError(t, validate.Name("Name", r.Name))
+				require.NoError(t, validate.Text("Description", r.Description, 0, 255))
+			}
+
+			exp, ok := results[tmpl.ID]
+			require.True(t, ok, "missing expected results")
+			require.Len(t, exp, len(tmpl.Samples), "all samples must have expected results")
+
+			for _, s := range tmpl.Samples {
+				t.Run(s.Name, func(t *testing.T) {
+					e, ok := exp[s.Name]
+					require.True(t, ok, "missing expected result")
+
+					res, err := uik.DryRun(tmpl.Config(), integrationkey.UIKRequest{
+						Body:   []byte(s.Body),
+						Header: http.Header{"Content-Type": {s.ContentType}},
+					})
+					require.NoError(t, err)
+					if e == nil {
+						assert.Empty(t, res.Actions)
+						return
+					}
+
+					require.Len(t, res.Actions, 1)
+					act := res.Actions[0]
+					assert.Equal(t, e.Action, act.Param(alert.ParamAction))
+					assert.Equal(t, e.Dedup, act.Param(alert.ParamDedup))
+					assert.Equal(t, e.Summary, act.Param(alert.ParamSummary))
+					assert.NotContains(t, act.Param(alert.ParamDetails), "<nil>")
+				})
+			}
+		})
+	}
+}
+
+func TestUpgradeAvailable(t *testing.T) {
+	tmpl, err := Find("datadog")
+	require.NoError(t, err)
+
+	cfg := tmpl.Config()
+	assert.False(t, UpgradeAvailable(cfg))
+
+	cfg.TemplateVersion--
+	assert.True(t, UpgradeAvailable(cfg))
+
+	cfg.TemplateID = ""
+	assert.False(t, UpgradeAvailable(cfg))
+
+	_, err = Find("does-not-exist")
+	assert.Error(t, err)
+}
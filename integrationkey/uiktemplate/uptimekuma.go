@@ -0,0 +1,54 @@
+package uiktemplate
+
+import (
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/gadb"
+)
+
+func init() {
+	register(Template{
+		ID:          "uptime-kuma",
+		Name:        "Uptime Kuma",
+		Description: "Uptime Kuma webhook notifications, using the default JSON body. Test notifications are ignored.",
+		Version:     1,
+		Samples: []Sample{
+			{
+				Name:        "Down",
+				ContentType: "application/json",
+				Body:        `{"heartbeat":{"monitorID":7,"status":0,"msg":"connect ECONNREFUSED 10.0.0.5:443","time":"2026-10-18 12:00:00"},"monitor":{"id":7,"name":"Status Page","url":"https://status.example.com"},"msg":"[Status Page] [🔴 Down] connect ECONNREFUSED 10.0.0.5:443"}`,
+			},
+			{
+				Name:        "Up",
+				ContentType: "application/json",
+				Body:        `{"heartbeat":{"monitorID":7,"status":1,"msg":"200 - OK","time":"2026-10-18 12:05:00"},"monitor":{"id":7,"name":"Status Page","url":"https://status.example.com"},"msg":"[Status Page] [✅ Up] 200 - OK"}`,
+			},
+			{
+				Name:        "Test",
+				ContentType: "application/json",
+				Body:        `{"heartbeat":null,"monitor":null,"msg":"Uptime Kuma Webhook Testing"}`,
+			},
+		},
+		config: func() gadb.UIKConfigV1 {
+			const dedup = `"uptime-kuma-" + string(req.body.monitor.id)`
+			return gadb.UIKConfigV1{
+				Rules: []gadb.UIKRuleV1{
+					{
+						Name:          "Down",
+						Description:   "Create an alert when a monitor goes down.",
+						ConditionExpr: `req.body.heartbeat?.status == 0 && req.body.monitor != nil`,
+						Actions: []gadb.UIKActionV1{alertAction(alert.ActionTrigger, dedup,
+							`req.body.monitor.name + " is down"`,
+							`req.body.heartbeat.msg + "\n\n" + (req.body.monitor.url ?? "")`,
+						)},
+					},
+					{
+						Name:          "Up",
+						Description:   "Close the alert when the monitor is back up.",
+						ConditionExpr: `req.body.heartbeat?.status == 1 && req.body.monitor != nil`,
+						Actions:       []gadb.UIKActionV1{alertAction(alert.ActionClose, dedup, "", "")},
+					},
+				},
+			}
+		},
+	})
+}
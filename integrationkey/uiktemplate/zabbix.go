@@ -0,0 +1,61 @@
+package uiktemplate
+
+import (
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/gadb"
+)
+
+func init() {
+	register(Template{
+		ID:   "zabbix",
+		Name: "Zabbix",
+		Description: "Zabbix webhook media type. Configure the media type to send a JSON body with at least: " +
+			`{"event_id": "{EVENT.ID}", "event_value": "{EVENT.VALUE}", "event_update_status": "{EVENT.UPDATE.STATUS}", "event_ack": "{EVENT.ACK.STATUS}", "subject": "{ALERT.SUBJECT}", "message": "{ALERT.MESSAGE}", "host": "{HOST.NAME}"}`,
+		Version: 1,
+		Samples: []Sample{
+			{
+				Name:        "Problem",
+				ContentType: "application/json",
+				Body:        `{"event_id":"1042","event_value":"1","event_update_status":"0","event_ack":"No","subject":"Problem: Zabbix agent is not available","message":"Host db-2 is unreachable for 3 minutes","host":"db-2"}`,
+			},
+			{
+				Name:        "Acknowledged",
+				ContentType: "application/json",
+				Body:        `{"event_id":"1042","event_value":"1","event_update_status":"1","event_ack":"Yes","subject":"Updated problem: Zabbix agent is not available","message":"Acknowledged by admin","host":"db-2"}`,
+			},
+			{
+				Name:        "Resolved",
+				ContentType: "application/json",
+				Body:        `{"event_id":"1042","event_value":"0","event_update_status":"0","event_ack":"No","subject":"Resolved: Zabbix agent is not available","message":"Host db-2 is reachable","host":"db-2"}`,
+			},
+		},
+		config: func() gadb.UIKConfigV1 {
+			const dedup = `"zabbix-" + string(req.body.event_id)`
+			return gadb.UIKConfigV1{
+				Rules: []gadb.UIKRuleV1{
+					{
+						Name:          "Resolved",
+						Description:   "Close the alert when the problem is resolved.",
+						ConditionExpr: `string(req.body.event_value) == "0"`,
+						Actions:       []gadb.UIKActionV1{alertAction(alert.ActionClose, dedup, "", "")},
+					},
+					{
+						Name:          "Acknowledged",
+						Description:   "Acknowledge the alert when the problem is acknowledged in Zabbix.",
+						ConditionExpr: `string(req.body.event_update_status) == "1" && req.body.event_ack == "Yes"`,
+						Actions:       []gadb.UIKActionV1{alertAction(alert.ActionAck, dedup, "", "")},
+					},
+					{
+						Name:          "Problem",
+						Description:   "Create an alert for a new problem.",
+						ConditionExpr: `string(req.body.event_value) == "1" && string(req.body.event_update_status ?? "0") == "0"`,
+						Actions: []gadb.UIKActionV1{alertAction(alert.ActionTrigger, dedup,
+							`req.body.subject`,
+							`(req.body.message ?? "") + "\n\nHost: " + (req.body.host ?? "")`,
+						)},
+					},
+				},
+			}
+		},
+	})
+}
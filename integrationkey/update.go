@@ -0,0 +1,11 @@
+package integrationkey
+
+import "github.com/google/uuid"
+
+// ConfigUpdate is an event triggered when the config of a universal key is updated.
+type ConfigUpdate struct {
+	KeyID uuid.UUID
+}
+
+// ConfigUpdateChannel is the Postgres NOTIFY channel used to announce config updates to all instances.
+const ConfigUpdateChannel = "/goalert/uik-config-update"
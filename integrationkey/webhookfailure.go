@@ -0,0 +1,126 @@
+package integrationkey
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+)
+
+const (
+	// MaxWebhookFailures is the number of recent webhook failures kept for each universal key.
+	MaxWebhookFailures = 50
+
+	// MaxWebhookFailureBodySize is the maximum number of bytes of a webhook body that will be recorded.
+	MaxWebhookFailureBodySize = 16 * 1024
+)
+
+// WebhookFailure is a webhook action of a universal key that could not be delivered
+// after all retries were exhausted.
+type WebhookFailure struct {
+	ID          uuid.UUID
+	FailedAt    time.Time
+	URL         string
+	ContentType string
+	Body        string
+	Attempts    int
+
+	// StatusCode is the HTTP status of the last attempt, or zero if no response was received.
+	StatusCode int
+	Error      string
+}
+
+// truncateBody returns body limited to maxBytes, without splitting a multi-byte
+// character, and with any invalid UTF-8 (or NUL characters) removed so it can be stored as text.
+func truncateBody(body string, maxBytes int) string {
+	if len(body) > maxBytes {
+		n := maxBytes
+		for n > 0 && !utf8.RuneStart(body[n]) {
+			n--
+		}
+		body = body[:n]
+	}
+
+	// Postgres text can't contain NUL characters either
+	return strings.ReplaceAll(strings.ToValidUTF8(body, ""), "\x00", "")
+}
+
+// RecordWebhookFailure will record an undeliverable webhook of a universal key, discarding
+// the oldest ones beyond MaxWebhookFailures.
+func (s *Store) RecordWebhookFailure(ctx context.Context, db gadb.DBTX, keyID uuid.UUID, f WebhookFailure) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	f.Body = truncateBody(f.Body, MaxWebhookFailureBodySize)
+
+	q := gadb.New(db)
+	err = q.IntKeyRecordWebhookFailure(ctx, gadb.IntKeyRecordWebhookFailureParams{
+		ID:          uuid.New(),
+		KeyID:       keyID,
+		Url:         f.URL,
+		ContentType: f.ContentType,
+		Body:        f.Body,
+		Attempts:    int32(f.Attempts),
+		StatusCode:  int32(f.StatusCode),
+		Error:       f.Error,
+	})
+	if err != nil {
+		return fmt.Errorf("record webhook failure: %w", err)
+	}
+
+	err = q.IntKeyTrimWebhookFailures(ctx, gadb.IntKeyTrimWebhookFailuresParams{
+		KeyID:       keyID,
+		MaxFailures: MaxWebhookFailures,
+	})
+	if err != nil {
+		return fmt.Errorf("trim webhook failures: %w", err)
+	}
+
+	return nil
+}
+
+// WebhookFailures returns the recent undeliverable webhooks of a universal key, newest first.
+func (s *Store) WebhookFailures(ctx context.Context, db gadb.DBTX, keyID uuid.UUID) ([]WebhookFailure, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(db).IntKeyWebhookFailures(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]WebhookFailure, len(rows))
+	for i, r := range rows {
+		result[i] = WebhookFailure{
+			ID:          r.ID,
+			FailedAt:    r.FailedAt,
+			URL:         r.Url,
+			ContentType: r.ContentType,
+			Body:        r.Body,
+			Attempts:    int(r.Attempts),
+			StatusCode:  int(r.StatusCode),
+			Error:       r.Error,
+		}
+	}
+
+	return result, nil
+}
+
+// ClearWebhookFailures will remove all recorded webhook failures of a universal key.
+func (s *Store) ClearWebhookFailures(ctx context.Context, db gadb.DBTX, keyID uuid.UUID) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(db).IntKeyClearWebhookFailures(ctx, keyID)
+}
@@ -0,0 +1,25 @@
+package integrationkey
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateBody(t *testing.T) {
+	assert.Equal(t, "hello", truncateBody("hello", 10))
+	assert.Equal(t, "hel", truncateBody("hello", 3))
+
+	// "é" is 2 bytes, so cutting at 5 bytes would split the third one
+	assert.Equal(t, "éé", truncateBody("ééé", 5))
+	assert.Equal(t, "ééé", truncateBody("ééé", 6))
+
+	body := strings.Repeat("a", MaxWebhookFailureBodySize-1) + "日本"
+	res := truncateBody(body, MaxWebhookFailureBodySize)
+	assert.True(t, utf8.ValidString(res))
+	assert.Equal(t, strings.Repeat("a", MaxWebhookFailureBodySize-1), res)
+
+	assert.Equal(t, "ab", truncateBody("a\xffb\x00", 10), "invalid UTF-8 and NUL should be removed")
+}
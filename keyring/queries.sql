@@ -38,3 +38,22 @@ SET
 WHERE
     id = @id;
 
+
+-- name: Keyring_LockSAMLKeys :exec
+-- Locks the auth_saml_keys table so no new keys can be created.
+LOCK TABLE auth_saml_keys IN ACCESS EXCLUSIVE MODE;
+
+-- name: Keyring_GetSAMLKeys :many
+SELECT
+    id,
+    private_key
+FROM
+    auth_saml_keys;
+
+-- name: Keyring_UpdateSAMLKey :exec
+UPDATE
+    auth_saml_keys
+SET
+    private_key = @private_key
+WHERE
+    id = @id;
@@ -94,6 +94,34 @@ func ReEncryptAll(ctx context.Context, db *sql.DB, keys Keys) error {
 		}
 	}
 
+	err = gdb.Keyring_LockSAMLKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("lock saml keys: %w", err)
+	}
+
+	samlKeys, err := gdb.Keyring_GetSAMLKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("get saml keys: %w", err)
+	}
+
+	for _, key := range samlKeys {
+		dec, label, err := keys.Decrypt(key.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("decrypt saml key '%s': %w", key.ID, err)
+		}
+		enc, err := keys.Encrypt(label, dec)
+		if err != nil {
+			return fmt.Errorf("encrypt saml key '%s': %w", key.ID, err)
+		}
+		err = gdb.Keyring_UpdateSAMLKey(ctx, gadb.Keyring_UpdateSAMLKeyParams{
+			ID:         key.ID,
+			PrivateKey: enc,
+		})
+		if err != nil {
+			return fmt.Errorf("update saml key '%s': %w", key.ID, err)
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return fmt.Errorf("commit transaction: %w", err)
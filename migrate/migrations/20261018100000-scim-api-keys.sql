@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE scim_api_keys(
+    id uuid PRIMARY KEY,
+    name text NOT NULL,
+    description text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    created_by uuid REFERENCES users(id) ON DELETE SET NULL,
+    expires_at timestamp with time zone NOT NULL,
+    last_used_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    deleted_by uuid REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX scim_api_keys_name_key ON scim_api_keys(name)
+WHERE
+    deleted_at IS NULL;
+
+-- +migrate Down
+DROP TABLE scim_api_keys;
+
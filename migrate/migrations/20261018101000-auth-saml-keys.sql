@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE auth_saml_keys(
+    id text PRIMARY KEY,
+    private_key bytea NOT NULL,
+    certificate bytea NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+DROP TABLE auth_saml_keys;
@@ -0,0 +1,46 @@
+-- +migrate Up
+CREATE TABLE oauth_clients(
+    id uuid PRIMARY KEY,
+    name text NOT NULL,
+    description text NOT NULL,
+    redirect_uris text[] NOT NULL,
+    secret_hash bytea,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    created_by uuid REFERENCES users(id) ON DELETE SET NULL,
+    deleted_at timestamp with time zone
+);
+
+CREATE UNIQUE INDEX oauth_clients_name_key ON oauth_clients(name)
+WHERE
+    deleted_at IS NULL;
+
+CREATE TABLE oauth_auth_codes(
+    code_hash bytea PRIMARY KEY,
+    client_id uuid NOT NULL REFERENCES oauth_clients(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    redirect_uri text NOT NULL,
+    scopes text[] NOT NULL,
+    code_challenge text NOT NULL,
+    expires_at timestamp with time zone NOT NULL
+);
+
+CREATE TABLE oauth_grants(
+    id uuid PRIMARY KEY,
+    client_id uuid NOT NULL REFERENCES oauth_clients(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    scopes text[] NOT NULL,
+    refresh_token_hash bytea NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_used_at timestamp with time zone,
+    expires_at timestamp with time zone NOT NULL,
+    revoked_at timestamp with time zone
+);
+
+CREATE INDEX idx_oauth_grants_user_id ON oauth_grants(user_id);
+
+-- +migrate Down
+DROP TABLE oauth_grants;
+
+DROP TABLE oauth_auth_codes;
+
+DROP TABLE oauth_clients;
@@ -0,0 +1,46 @@
+-- +migrate Up
+CREATE TYPE enum_shift_swap_status AS ENUM(
+    'pending',
+    'accepted',
+    'declined',
+    'cancelled'
+);
+
+CREATE TABLE shift_swap_requests(
+    id uuid PRIMARY KEY,
+    schedule_id uuid NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    requester_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    counterpart_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    requester_shift_start timestamp with time zone NOT NULL,
+    requester_shift_end timestamp with time zone NOT NULL,
+    counterpart_shift_start timestamp with time zone,
+    counterpart_shift_end timestamp with time zone,
+    message text NOT NULL DEFAULT '',
+    status enum_shift_swap_status NOT NULL DEFAULT 'pending',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    responded_at timestamp with time zone,
+    CONSTRAINT shift_swap_distinct_users CHECK (requester_id <> counterpart_id),
+    CONSTRAINT shift_swap_requester_shift CHECK (requester_shift_start < requester_shift_end),
+    CONSTRAINT shift_swap_counterpart_shift CHECK ((counterpart_shift_start IS NULL AND counterpart_shift_end IS NULL) OR counterpart_shift_start < counterpart_shift_end)
+);
+
+CREATE INDEX idx_shift_swap_requests_requester ON shift_swap_requests(requester_id);
+
+CREATE INDEX idx_shift_swap_requests_counterpart ON shift_swap_requests(counterpart_id);
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN shift_swap_request_id uuid REFERENCES shift_swap_requests(id) ON DELETE CASCADE,
+    ADD CONSTRAINT om_shift_swap_request_id CHECK (message_type <> 'shift_swap_request' OR shift_swap_request_id IS NOT NULL);
+
+CREATE INDEX idx_om_shift_swap_request_id ON outgoing_messages(shift_swap_request_id);
+
+-- +migrate Down
+DROP INDEX idx_om_shift_swap_request_id;
+
+ALTER TABLE outgoing_messages
+    DROP CONSTRAINT om_shift_swap_request_id,
+    DROP COLUMN shift_swap_request_id;
+
+DROP TABLE shift_swap_requests;
+
+DROP TYPE enum_shift_swap_status;
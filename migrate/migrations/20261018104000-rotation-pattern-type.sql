@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_rotation_type
+    ADD VALUE IF NOT EXISTS 'pattern';
+
+-- +migrate Down
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE rotations
+    ADD COLUMN shift_pattern integer[] NOT NULL DEFAULT '{}',
+    ADD CONSTRAINT rotations_shift_pattern_check CHECK (type <> 'pattern' OR cardinality(shift_pattern) > 0);
+
+-- +migrate Down
+ALTER TABLE rotations
+    DROP CONSTRAINT rotations_shift_pattern_check,
+    DROP COLUMN shift_pattern;
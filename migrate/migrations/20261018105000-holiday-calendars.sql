@@ -0,0 +1,44 @@
+-- +migrate Up
+CREATE TYPE enum_holiday_mode AS ENUM(
+    'suppress',
+    'substitute'
+);
+
+CREATE TABLE holiday_calendars(
+    id uuid PRIMARY KEY,
+    name text NOT NULL UNIQUE,
+    description text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE holidays(
+    id uuid PRIMARY KEY,
+    calendar_id uuid NOT NULL REFERENCES holiday_calendars(id) ON DELETE CASCADE,
+    name text NOT NULL,
+    start_date date NOT NULL,
+    end_date date NOT NULL,
+    CONSTRAINT holidays_date_range CHECK (start_date <= end_date)
+);
+
+CREATE UNIQUE INDEX idx_holidays_calendar_date_name ON holidays(calendar_id, start_date, name);
+
+ALTER TABLE schedule_rules
+    ADD COLUMN holiday_calendar_id uuid REFERENCES holiday_calendars(id),
+    ADD COLUMN holiday_mode enum_holiday_mode,
+    ADD CONSTRAINT schedule_rules_holiday_mode CHECK ((holiday_calendar_id IS NULL) = (holiday_mode IS NULL));
+
+CREATE INDEX idx_schedule_rules_holiday_calendar ON schedule_rules(holiday_calendar_id);
+
+-- +migrate Down
+DROP INDEX idx_schedule_rules_holiday_calendar;
+
+ALTER TABLE schedule_rules
+    DROP CONSTRAINT schedule_rules_holiday_mode,
+    DROP COLUMN holiday_mode,
+    DROP COLUMN holiday_calendar_id;
+
+DROP TABLE holidays;
+
+DROP TABLE holiday_calendars;
+
+DROP TYPE enum_holiday_mode;
@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE schedule_coverage_gaps(
+    schedule_id uuid NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    start_time timestamp with time zone NOT NULL,
+    end_time timestamp with time zone NOT NULL,
+    detected_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (schedule_id, start_time),
+    CONSTRAINT schedule_coverage_gaps_check CHECK (start_time < end_time)
+);
+
+-- +migrate Down
+DROP TABLE schedule_coverage_gaps;
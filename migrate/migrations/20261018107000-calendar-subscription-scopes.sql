@@ -0,0 +1,16 @@
+-- +migrate Up
+ALTER TABLE user_calendar_subscriptions
+    ALTER COLUMN schedule_id DROP NOT NULL,
+    ADD COLUMN service_id uuid REFERENCES services(id) ON DELETE CASCADE,
+    ADD COLUMN escalation_policy_id uuid REFERENCES escalation_policies(id) ON DELETE CASCADE,
+    ADD CONSTRAINT user_calendar_subscriptions_scope_check CHECK (num_nonnulls(schedule_id, service_id, escalation_policy_id) = 1);
+
+-- +migrate Down
+DELETE FROM user_calendar_subscriptions
+WHERE schedule_id IS NULL;
+
+ALTER TABLE user_calendar_subscriptions
+    DROP CONSTRAINT user_calendar_subscriptions_scope_check,
+    DROP COLUMN service_id,
+    DROP COLUMN escalation_policy_id,
+    ALTER COLUMN schedule_id SET NOT NULL;
@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE schedule_handoff_reports(
+    id uuid PRIMARY KEY,
+    schedule_id uuid NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    shift_start timestamp with time zone NOT NULL,
+    shift_end timestamp with time zone NOT NULL DEFAULT now(),
+    outgoing_user_ids uuid[] NOT NULL DEFAULT '{}',
+    incoming_user_ids uuid[] NOT NULL DEFAULT '{}',
+    CONSTRAINT schedule_handoff_reports_check CHECK (shift_start <= shift_end)
+);
+
+CREATE INDEX idx_schedule_handoff_reports_schedule ON schedule_handoff_reports(schedule_id, shift_end);
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN schedule_handoff_report_id uuid REFERENCES schedule_handoff_reports(id) ON DELETE CASCADE,
+    ADD CONSTRAINT om_schedule_handoff_report_id CHECK (message_type <> 'schedule_handoff_report' OR schedule_handoff_report_id IS NOT NULL);
+
+CREATE INDEX idx_om_schedule_handoff_report_id ON outgoing_messages(schedule_handoff_report_id);
+
+-- +migrate Down
+DROP INDEX idx_om_schedule_handoff_report_id;
+
+ALTER TABLE outgoing_messages
+    DROP CONSTRAINT om_schedule_handoff_report_id,
+    DROP COLUMN schedule_handoff_report_id;
+
+DROP TABLE schedule_handoff_reports;
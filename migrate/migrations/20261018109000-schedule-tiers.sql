@@ -0,0 +1,56 @@
+-- +migrate Up
+CREATE TABLE schedule_tiers(
+    schedule_id uuid NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    name text NOT NULL,
+    rotation_offset integer NOT NULL DEFAULT 0,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (schedule_id, name),
+    CONSTRAINT schedule_tiers_name CHECK (name ~ '^[a-z][a-z0-9-]{0,31}$' AND name <> 'primary'),
+    CONSTRAINT schedule_tiers_rotation_offset CHECK (rotation_offset >= 0)
+);
+
+ALTER TABLE schedule_rules
+    ADD COLUMN tier text,
+    ADD CONSTRAINT schedule_rules_tier_fkey FOREIGN KEY (schedule_id, tier) REFERENCES schedule_tiers(schedule_id, name) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE escalation_policy_actions
+    ADD COLUMN schedule_tier text,
+    ADD CONSTRAINT escalation_policy_actions_schedule_tier_fkey FOREIGN KEY (schedule_id, schedule_tier) REFERENCES schedule_tiers(schedule_id, name) ON DELETE CASCADE ON UPDATE CASCADE,
+    DROP CONSTRAINT epa_no_duplicate_schedules;
+
+CREATE UNIQUE INDEX epa_no_duplicate_schedules ON escalation_policy_actions(escalation_policy_step_id, schedule_id, coalesce(schedule_tier, ''));
+
+CREATE TABLE schedule_tier_on_call_users(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    schedule_id uuid NOT NULL,
+    tier text NOT NULL,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_time timestamp with time zone NOT NULL DEFAULT now(),
+    end_time timestamp with time zone,
+    FOREIGN KEY (schedule_id, tier) REFERENCES schedule_tiers(schedule_id, name) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT schedule_tier_on_call_users_check CHECK (end_time IS NULL OR end_time > start_time)
+);
+
+CREATE UNIQUE INDEX idx_schedule_tier_on_call_once ON schedule_tier_on_call_users(schedule_id, tier, user_id)
+WHERE
+    end_time IS NULL;
+
+-- +migrate Down
+DROP TABLE schedule_tier_on_call_users;
+
+DELETE FROM escalation_policy_actions
+WHERE schedule_tier NOTNULL;
+
+DROP INDEX epa_no_duplicate_schedules;
+
+ALTER TABLE escalation_policy_actions
+    DROP COLUMN schedule_tier,
+    ADD CONSTRAINT epa_no_duplicate_schedules UNIQUE (escalation_policy_step_id, schedule_id);
+
+DELETE FROM schedule_rules
+WHERE tier NOTNULL;
+
+ALTER TABLE schedule_rules
+    DROP COLUMN tier;
+
+DROP TABLE schedule_tiers;
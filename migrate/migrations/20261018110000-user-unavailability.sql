@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE user_unavailability(
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_time timestamp with time zone NOT NULL,
+    end_time timestamp with time zone NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT user_unavailability_check CHECK (start_time < end_time)
+);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability(user_id, end_time);
+
+ALTER TABLE user_overrides
+    ADD COLUMN unavailability_id uuid REFERENCES user_unavailability(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_user_overrides_unavailability ON user_overrides(unavailability_id);
+
+-- +migrate Down
+DROP INDEX idx_user_overrides_unavailability;
+
+ALTER TABLE user_overrides
+    DROP COLUMN unavailability_id;
+
+DROP TABLE user_unavailability;
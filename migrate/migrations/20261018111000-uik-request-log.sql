@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE uik_request_log(
+    id uuid PRIMARY KEY,
+    key_id uuid NOT NULL REFERENCES integration_keys(id) ON DELETE CASCADE,
+    received_at timestamp with time zone NOT NULL DEFAULT now(),
+    body bytea NOT NULL,
+    body_truncated boolean NOT NULL DEFAULT FALSE,
+    query text NOT NULL DEFAULT '',
+    headers jsonb NOT NULL DEFAULT '{}',
+    user_agent text NOT NULL DEFAULT '',
+    remote_addr text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_uik_request_log_key ON uik_request_log(key_id, received_at);
+
+-- +migrate Down
+DROP TABLE uik_request_log;
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE uik_webhook_failures(
+    id uuid PRIMARY KEY,
+    key_id uuid NOT NULL REFERENCES integration_keys(id) ON DELETE CASCADE,
+    failed_at timestamp with time zone NOT NULL DEFAULT now(),
+    url text NOT NULL,
+    content_type text NOT NULL DEFAULT '',
+    body text NOT NULL DEFAULT '',
+    attempts integer NOT NULL,
+    status_code integer NOT NULL DEFAULT 0,
+    error text NOT NULL
+);
+
+CREATE INDEX idx_uik_webhook_failures_key ON uik_webhook_failures(key_id, failed_at);
+
+-- +migrate Down
+DROP TABLE uik_webhook_failures;
@@ -0,0 +1,8 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_integration_keys_type
+  ADD VALUE IF NOT EXISTS 'amazonSNS';
+
+ALTER TYPE enum_alert_source
+  ADD VALUE IF NOT EXISTS 'amazonSNS';
+
+-- +migrate Down
@@ -0,0 +1,20 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_integration_keys_type
+  ADD VALUE IF NOT EXISTS 'zabbix';
+
+ALTER TYPE enum_integration_keys_type
+  ADD VALUE IF NOT EXISTS 'nagios';
+
+ALTER TYPE enum_integration_keys_type
+  ADD VALUE IF NOT EXISTS 'icinga';
+
+ALTER TYPE enum_alert_source
+  ADD VALUE IF NOT EXISTS 'zabbix';
+
+ALTER TYPE enum_alert_source
+  ADD VALUE IF NOT EXISTS 'nagios';
+
+ALTER TYPE enum_alert_source
+  ADD VALUE IF NOT EXISTS 'icinga';
+
+-- +migrate Down
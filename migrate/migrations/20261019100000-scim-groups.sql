@@ -0,0 +1,11 @@
+-- +migrate Up
+-- Rotations created via SCIM before groups were tracked can't be reliably identified, so they
+-- are left as regular rotations. To manage them via SCIM again, rename or delete the rotation
+-- and re-provision the group.
+CREATE TABLE scim_groups(
+    rotation_id uuid PRIMARY KEY REFERENCES rotations(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+DROP TABLE scim_groups;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE rotation_participants
+    ADD COLUMN shift_length integer,
+    ADD CONSTRAINT rotation_participants_shift_length_check CHECK (shift_length > 0);
+
+-- +migrate Down
+ALTER TABLE rotation_participants
+    DROP CONSTRAINT rotation_participants_shift_length_check,
+    DROP COLUMN shift_length;
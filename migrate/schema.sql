@@ -51,13 +51,18 @@ CREATE TYPE enum_alert_log_subject_type AS ENUM (
 );
 
 CREATE TYPE enum_alert_source AS ENUM (
+	'amazonSNS',
+	'cloudEvents',
 	'email',
 	'generic',
 	'grafana',
+	'icinga',
 	'manual',
+	'nagios',
 	'prometheusAlertmanager',
 	'site24x7',
-	'universal'
+	'universal',
+	'zabbix'
 );
 
 CREATE TYPE enum_alert_status AS ENUM (
@@ -72,13 +77,23 @@ CREATE TYPE enum_heartbeat_state AS ENUM (
 	'unhealthy'
 );
 
+CREATE TYPE enum_holiday_mode AS ENUM (
+	'substitute',
+	'suppress'
+);
+
 CREATE TYPE enum_integration_keys_type AS ENUM (
+	'amazonSNS',
+	'cloudEvents',
 	'email',
 	'generic',
 	'grafana',
+	'icinga',
+	'nagios',
 	'prometheusAlertmanager',
 	'site24x7',
-	'universal'
+	'universal',
+	'zabbix'
 );
 
 CREATE TYPE enum_limit_type AS ENUM (
@@ -121,7 +136,10 @@ CREATE TYPE enum_outgoing_messages_type AS ENUM (
 	'alert_notification_bundle',
 	'alert_status_update',
 	'alert_status_update_bundle',
+	'schedule_coverage_gap',
+	'schedule_handoff_report',
 	'schedule_on_call_notification',
+	'shift_swap_request',
 	'signal_message',
 	'test_notification',
 	'verification_message'
@@ -131,9 +149,18 @@ CREATE TYPE enum_rotation_type AS ENUM (
 	'daily',
 	'hourly',
 	'monthly',
+	'participant',
+	'pattern',
 	'weekly'
 );
 
+CREATE TYPE enum_shift_swap_status AS ENUM (
+	'accepted',
+	'cancelled',
+	'declined',
+	'pending'
+);
+
 CREATE TYPE enum_switchover_state AS ENUM (
 	'idle',
 	'in_progress',
@@ -1689,6 +1716,17 @@ CREATE TABLE auth_nonce (
 CREATE UNIQUE INDEX auth_nonce_pkey ON public.auth_nonce USING btree (id);
 
 
+CREATE TABLE auth_saml_keys (
+	certificate bytea NOT NULL,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	id text NOT NULL,
+	private_key bytea NOT NULL,
+	CONSTRAINT auth_saml_keys_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX auth_saml_keys_pkey ON public.auth_saml_keys USING btree (id);
+
+
 CREATE TABLE auth_subjects (
 	cm_id uuid,
 	id bigint DEFAULT nextval('auth_subjects_id_seq'::regclass) NOT NULL,
@@ -1800,10 +1838,10 @@ CREATE TABLE escalation_policy_actions (
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
 	rotation_id uuid,
 	schedule_id uuid,
+	schedule_tier text,
 	user_id uuid,
 	CONSTRAINT epa_no_duplicate_channels UNIQUE (escalation_policy_step_id, channel_id),
 	CONSTRAINT epa_no_duplicate_rotations UNIQUE (escalation_policy_step_id, rotation_id),
-	CONSTRAINT epa_no_duplicate_schedules UNIQUE (escalation_policy_step_id, schedule_id),
 	CONSTRAINT epa_no_duplicate_users UNIQUE (escalation_policy_step_id, user_id),
 	CONSTRAINT epa_there_can_only_be_one CHECK ((
 CASE
@@ -1827,12 +1865,13 @@ END) = 1),
 	CONSTRAINT escalation_policy_actions_pkey PRIMARY KEY (id),
 	CONSTRAINT escalation_policy_actions_rotation_id_fkey FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE,
 	CONSTRAINT escalation_policy_actions_schedule_id_fkey1 FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+	CONSTRAINT escalation_policy_actions_schedule_tier_fkey FOREIGN KEY (schedule_id, schedule_tier) REFERENCES schedule_tiers(schedule_id, name) ON UPDATE CASCADE ON DELETE CASCADE,
 	CONSTRAINT escalation_policy_actions_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
 CREATE UNIQUE INDEX epa_no_duplicate_channels ON public.escalation_policy_actions USING btree (escalation_policy_step_id, channel_id);
 CREATE UNIQUE INDEX epa_no_duplicate_rotations ON public.escalation_policy_actions USING btree (escalation_policy_step_id, rotation_id);
-CREATE UNIQUE INDEX epa_no_duplicate_schedules ON public.escalation_policy_actions USING btree (escalation_policy_step_id, schedule_id);
+CREATE UNIQUE INDEX epa_no_duplicate_schedules ON public.escalation_policy_actions USING btree (escalation_policy_step_id, schedule_id, COALESCE(schedule_tier, ''::text));
 CREATE UNIQUE INDEX epa_no_duplicate_users ON public.escalation_policy_actions USING btree (escalation_policy_step_id, user_id);
 CREATE UNIQUE INDEX escalation_policy_actions_pkey ON public.escalation_policy_actions USING btree (id);
 CREATE INDEX idx_ep_action_steps ON public.escalation_policy_actions USING btree (escalation_policy_step_id);
@@ -1959,6 +1998,34 @@ CREATE INDEX idx_heartbeat_monitor_service ON public.heartbeat_monitors USING bt
 CREATE CONSTRAINT TRIGGER trg_enforce_heartbeat_monitor_limit AFTER INSERT ON public.heartbeat_monitors NOT DEFERRABLE INITIALLY IMMEDIATE FOR EACH ROW EXECUTE FUNCTION fn_enforce_heartbeat_limit();
 
 
+CREATE TABLE holiday_calendars (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	description text DEFAULT ''::text NOT NULL,
+	id uuid NOT NULL,
+	name text NOT NULL,
+	CONSTRAINT holiday_calendars_name_key UNIQUE (name),
+	CONSTRAINT holiday_calendars_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX holiday_calendars_name_key ON public.holiday_calendars USING btree (name);
+CREATE UNIQUE INDEX holiday_calendars_pkey ON public.holiday_calendars USING btree (id);
+
+
+CREATE TABLE holidays (
+	calendar_id uuid NOT NULL,
+	end_date date NOT NULL,
+	id uuid NOT NULL,
+	name text NOT NULL,
+	start_date date NOT NULL,
+	CONSTRAINT holidays_calendar_id_fkey FOREIGN KEY (calendar_id) REFERENCES holiday_calendars(id) ON DELETE CASCADE,
+	CONSTRAINT holidays_date_range CHECK (start_date <= end_date),
+	CONSTRAINT holidays_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX holidays_pkey ON public.holidays USING btree (id);
+CREATE UNIQUE INDEX idx_holidays_calendar_date_name ON public.holidays USING btree (calendar_id, start_date, name);
+
+
 CREATE TABLE integration_keys (
 	external_system_name text,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
@@ -2071,6 +2138,58 @@ CREATE INDEX idx_np_cycle_alert_id ON public.notification_policy_cycles USING bt
 CREATE UNIQUE INDEX notification_policy_cycles_pkey ON public.notification_policy_cycles USING btree (id);
 
 
+CREATE TABLE oauth_auth_codes (
+	client_id uuid NOT NULL,
+	code_challenge text NOT NULL,
+	code_hash bytea NOT NULL,
+	expires_at timestamp with time zone NOT NULL,
+	redirect_uri text NOT NULL,
+	scopes text[] NOT NULL,
+	user_id uuid NOT NULL,
+	CONSTRAINT oauth_auth_codes_client_id_fkey FOREIGN KEY (client_id) REFERENCES oauth_clients(id) ON DELETE CASCADE,
+	CONSTRAINT oauth_auth_codes_pkey PRIMARY KEY (code_hash),
+	CONSTRAINT oauth_auth_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX oauth_auth_codes_pkey ON public.oauth_auth_codes USING btree (code_hash);
+
+
+CREATE TABLE oauth_clients (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	created_by uuid,
+	deleted_at timestamp with time zone,
+	description text NOT NULL,
+	id uuid NOT NULL,
+	name text NOT NULL,
+	redirect_uris text[] NOT NULL,
+	secret_hash bytea,
+	CONSTRAINT oauth_clients_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+	CONSTRAINT oauth_clients_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX oauth_clients_name_key ON public.oauth_clients USING btree (name) WHERE (deleted_at IS NULL);
+CREATE UNIQUE INDEX oauth_clients_pkey ON public.oauth_clients USING btree (id);
+
+
+CREATE TABLE oauth_grants (
+	client_id uuid NOT NULL,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	expires_at timestamp with time zone NOT NULL,
+	id uuid NOT NULL,
+	last_used_at timestamp with time zone,
+	refresh_token_hash bytea NOT NULL,
+	revoked_at timestamp with time zone,
+	scopes text[] NOT NULL,
+	user_id uuid NOT NULL,
+	CONSTRAINT oauth_grants_client_id_fkey FOREIGN KEY (client_id) REFERENCES oauth_clients(id) ON DELETE CASCADE,
+	CONSTRAINT oauth_grants_pkey PRIMARY KEY (id),
+	CONSTRAINT oauth_grants_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_oauth_grants_user_id ON public.oauth_grants USING btree (user_id);
+CREATE UNIQUE INDEX oauth_grants_pkey ON public.oauth_grants USING btree (id);
+
+
 CREATE TABLE outgoing_messages (
 	alert_id bigint,
 	alert_log_id bigint,
@@ -2088,10 +2207,12 @@ CREATE TABLE outgoing_messages (
 	provider_msg_id text,
 	provider_seq integer DEFAULT 0 NOT NULL,
 	retry_count integer DEFAULT 0 NOT NULL,
+	schedule_handoff_report_id uuid,
 	schedule_id uuid,
 	sending_deadline timestamp with time zone,
 	sent_at timestamp with time zone,
 	service_id uuid,
+	shift_swap_request_id uuid,
 	src_value text,
 	status_alert_ids bigint[],
 	status_details text DEFAULT ''::text NOT NULL,
@@ -2103,6 +2224,8 @@ CREATE TABLE outgoing_messages (
 	CONSTRAINT om_processed_no_fired_sent CHECK ((last_status = ANY (ARRAY['pending'::enum_outgoing_messages_status, 'sending'::enum_outgoing_messages_status, 'failed'::enum_outgoing_messages_status, 'bundled'::enum_outgoing_messages_status])) OR fired_at IS NULL AND sent_at IS NOT NULL),
 	CONSTRAINT om_sending_deadline_reqd CHECK (last_status <> 'sending'::enum_outgoing_messages_status OR sending_deadline IS NOT NULL),
 	CONSTRAINT om_sending_fired_no_sent CHECK (last_status <> 'sending'::enum_outgoing_messages_status OR fired_at IS NOT NULL AND sent_at IS NULL),
+	CONSTRAINT om_schedule_handoff_report_id CHECK (message_type <> 'schedule_handoff_report'::enum_outgoing_messages_type OR schedule_handoff_report_id IS NOT NULL),
+	CONSTRAINT om_shift_swap_request_id CHECK (message_type <> 'shift_swap_request'::enum_outgoing_messages_type OR shift_swap_request_id IS NOT NULL),
 	CONSTRAINT om_status_alert_ids CHECK (message_type <> 'alert_status_update_bundle'::enum_outgoing_messages_type OR status_alert_ids IS NOT NULL),
 	CONSTRAINT om_status_update_log_id CHECK (message_type <> 'alert_status_update'::enum_outgoing_messages_type OR alert_log_id IS NOT NULL),
 	CONSTRAINT om_user_cm_or_channel CHECK (user_id IS NOT NULL AND contact_method_id IS NOT NULL AND channel_id IS NULL OR channel_id IS NOT NULL AND contact_method_id IS NULL AND user_id IS NULL),
@@ -2113,8 +2236,10 @@ CREATE TABLE outgoing_messages (
 	CONSTRAINT outgoing_messages_cycle_id_fkey FOREIGN KEY (cycle_id) REFERENCES notification_policy_cycles(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_escalation_policy_id_fkey FOREIGN KEY (escalation_policy_id) REFERENCES escalation_policies(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_pkey PRIMARY KEY (id),
+	CONSTRAINT outgoing_messages_schedule_handoff_report_id_fkey FOREIGN KEY (schedule_handoff_report_id) REFERENCES schedule_handoff_reports(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE,
+	CONSTRAINT outgoing_messages_shift_swap_request_id_fkey FOREIGN KEY (shift_swap_request_id) REFERENCES shift_swap_requests(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_user_verification_code_id_fkey FOREIGN KEY (user_verification_code_id) REFERENCES user_verification_codes(id) ON DELETE CASCADE,
 	CONSTRAINT verify_needs_id CHECK (message_type <> 'verification_message'::enum_outgoing_messages_type OR user_verification_code_id IS NOT NULL)
@@ -2125,7 +2250,9 @@ CREATE INDEX idx_om_alert_sent ON public.outgoing_messages USING btree (alert_id
 CREATE INDEX idx_om_cm_sent ON public.outgoing_messages USING btree (contact_method_id, sent_at);
 CREATE INDEX idx_om_ep_sent ON public.outgoing_messages USING btree (escalation_policy_id, sent_at);
 CREATE INDEX idx_om_last_status_sent ON public.outgoing_messages USING btree (last_status, sent_at);
+CREATE INDEX idx_om_schedule_handoff_report_id ON public.outgoing_messages USING btree (schedule_handoff_report_id);
 CREATE INDEX idx_om_service_sent ON public.outgoing_messages USING btree (service_id, sent_at);
+CREATE INDEX idx_om_shift_swap_request_id ON public.outgoing_messages USING btree (shift_swap_request_id);
 CREATE INDEX idx_om_user_sent ON public.outgoing_messages USING btree (user_id, sent_at);
 CREATE INDEX idx_om_vcode_id ON public.outgoing_messages USING btree (user_verification_code_id);
 CREATE INDEX idx_outgoing_messages_notif_cycle ON public.outgoing_messages USING btree (cycle_id);
@@ -2276,10 +2403,12 @@ CREATE TABLE rotation_participants (
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
 	position integer NOT NULL,
 	rotation_id uuid NOT NULL,
+	shift_length integer,
 	user_id uuid NOT NULL,
 	CONSTRAINT rotation_participants_pkey PRIMARY KEY (id),
 	CONSTRAINT rotation_participants_rotation_id_fkey FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE,
 	CONSTRAINT rotation_participants_rotation_id_position_key UNIQUE (rotation_id, "position") DEFERRABLE INITIALLY DEFERRED,
+	CONSTRAINT rotation_participants_shift_length_check CHECK (shift_length > 0),
 	CONSTRAINT rotation_participants_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
@@ -2326,12 +2455,14 @@ CREATE TABLE rotations (
 	name text NOT NULL,
 	participant_count integer DEFAULT 0 NOT NULL,
 	shift_length bigint DEFAULT 1 NOT NULL,
+	shift_pattern integer[] DEFAULT '{}'::integer[] NOT NULL,
 	start_time timestamp with time zone DEFAULT now() NOT NULL,
 	time_zone text NOT NULL,
 	type enum_rotation_type NOT NULL,
 	CONSTRAINT rotations_name_unique UNIQUE (name),
 	CONSTRAINT rotations_pkey PRIMARY KEY (id),
-	CONSTRAINT rotations_shift_length_check CHECK (shift_length > 0)
+	CONSTRAINT rotations_shift_length_check CHECK (shift_length > 0),
+	CONSTRAINT rotations_shift_pattern_check CHECK (type <> 'pattern'::enum_rotation_type OR cardinality(shift_pattern) > 0)
 );
 
 CREATE INDEX idx_search_rotations_desc_eng ON public.rotations USING gin (to_tsvector('english'::regconfig, replace(lower(description), '.'::text, ' '::text)));
@@ -2343,6 +2474,19 @@ CREATE UNIQUE INDEX rotations_pkey ON public.rotations USING btree (id);
 CREATE TRIGGER trg_track_rotation_updates AFTER INSERT OR UPDATE ON public.rotations FOR EACH ROW EXECUTE FUNCTION fn_track_rotation_updates();
 
 
+CREATE TABLE schedule_coverage_gaps (
+	detected_at timestamp with time zone DEFAULT now() NOT NULL,
+	end_time timestamp with time zone NOT NULL,
+	schedule_id uuid NOT NULL,
+	start_time timestamp with time zone NOT NULL,
+	CONSTRAINT schedule_coverage_gaps_check CHECK (start_time < end_time),
+	CONSTRAINT schedule_coverage_gaps_pkey PRIMARY KEY (schedule_id, start_time),
+	CONSTRAINT schedule_coverage_gaps_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX schedule_coverage_gaps_pkey ON public.schedule_coverage_gaps USING btree (schedule_id, start_time);
+
+
 CREATE TABLE schedule_data (
 	data jsonb NOT NULL,
 	id bigint DEFAULT nextval('schedule_data_id_seq'::regclass) NOT NULL,
@@ -2357,6 +2501,22 @@ CREATE UNIQUE INDEX schedule_data_id_key ON public.schedule_data USING btree (id
 CREATE UNIQUE INDEX schedule_data_pkey ON public.schedule_data USING btree (schedule_id);
 
 
+CREATE TABLE schedule_handoff_reports (
+	id uuid NOT NULL,
+	incoming_user_ids uuid[] DEFAULT '{}'::uuid[] NOT NULL,
+	outgoing_user_ids uuid[] DEFAULT '{}'::uuid[] NOT NULL,
+	schedule_id uuid NOT NULL,
+	shift_end timestamp with time zone DEFAULT now() NOT NULL,
+	shift_start timestamp with time zone NOT NULL,
+	CONSTRAINT schedule_handoff_reports_check CHECK (shift_start <= shift_end),
+	CONSTRAINT schedule_handoff_reports_pkey PRIMARY KEY (id),
+	CONSTRAINT schedule_handoff_reports_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_schedule_handoff_reports_schedule ON public.schedule_handoff_reports USING btree (schedule_id, shift_end);
+CREATE UNIQUE INDEX schedule_handoff_reports_pkey ON public.schedule_handoff_reports USING btree (id);
+
+
 CREATE TABLE schedule_on_call_users (
 	end_time timestamp with time zone,
 	id bigint DEFAULT nextval('schedule_on_call_users_id_seq'::regclass) NOT NULL,
@@ -2378,6 +2538,8 @@ CREATE TABLE schedule_rules (
 	created_at timestamp with time zone DEFAULT now() NOT NULL,
 	end_time time without time zone DEFAULT '23:59:59'::time without time zone NOT NULL,
 	friday boolean DEFAULT true NOT NULL,
+	holiday_calendar_id uuid,
+	holiday_mode enum_holiday_mode,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
 	is_active boolean DEFAULT false NOT NULL,
 	monday boolean DEFAULT true NOT NULL,
@@ -2388,16 +2550,21 @@ CREATE TABLE schedule_rules (
 	tgt_rotation_id uuid,
 	tgt_user_id uuid,
 	thursday boolean DEFAULT true NOT NULL,
+	tier text,
 	tuesday boolean DEFAULT true NOT NULL,
 	wednesday boolean DEFAULT true NOT NULL,
 	CONSTRAINT schedule_rules_check CHECK (tgt_user_id IS NULL AND tgt_rotation_id IS NOT NULL OR tgt_user_id IS NOT NULL AND tgt_rotation_id IS NULL),
+	CONSTRAINT schedule_rules_holiday_calendar_id_fkey FOREIGN KEY (holiday_calendar_id) REFERENCES holiday_calendars(id),
+	CONSTRAINT schedule_rules_holiday_mode CHECK ((holiday_calendar_id IS NULL) = (holiday_mode IS NULL)),
 	CONSTRAINT schedule_rules_pkey PRIMARY KEY (id),
 	CONSTRAINT schedule_rules_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
 	CONSTRAINT schedule_rules_tgt_rotation_id_fkey FOREIGN KEY (tgt_rotation_id) REFERENCES rotations(id) ON DELETE CASCADE,
-	CONSTRAINT schedule_rules_tgt_user_id_fkey FOREIGN KEY (tgt_user_id) REFERENCES users(id) ON DELETE CASCADE
+	CONSTRAINT schedule_rules_tgt_user_id_fkey FOREIGN KEY (tgt_user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT schedule_rules_tier_fkey FOREIGN KEY (schedule_id, tier) REFERENCES schedule_tiers(schedule_id, name) ON UPDATE CASCADE ON DELETE CASCADE
 );
 
 CREATE INDEX idx_rule_schedule ON public.schedule_rules USING btree (schedule_id);
+CREATE INDEX idx_schedule_rules_holiday_calendar ON public.schedule_rules USING btree (holiday_calendar_id);
 CREATE INDEX idx_target_schedule ON public.schedule_rules USING btree (schedule_id, tgt_rotation_id, tgt_user_id);
 CREATE UNIQUE INDEX schedule_rules_pkey ON public.schedule_rules USING btree (id);
 
@@ -2405,6 +2572,37 @@ CREATE CONSTRAINT TRIGGER trg_enforce_schedule_rule_limit AFTER INSERT ON public
 CREATE CONSTRAINT TRIGGER trg_enforce_schedule_target_limit AFTER INSERT ON public.schedule_rules NOT DEFERRABLE INITIALLY IMMEDIATE FOR EACH ROW EXECUTE FUNCTION fn_enforce_schedule_target_limit();
 
 
+CREATE TABLE schedule_tier_on_call_users (
+	end_time timestamp with time zone,
+	id uuid DEFAULT gen_random_uuid() NOT NULL,
+	schedule_id uuid NOT NULL,
+	start_time timestamp with time zone DEFAULT now() NOT NULL,
+	tier text NOT NULL,
+	user_id uuid NOT NULL,
+	CONSTRAINT schedule_tier_on_call_users_check CHECK (end_time IS NULL OR end_time > start_time),
+	CONSTRAINT schedule_tier_on_call_users_pkey PRIMARY KEY (id),
+	CONSTRAINT schedule_tier_on_call_users_schedule_id_tier_fkey FOREIGN KEY (schedule_id, tier) REFERENCES schedule_tiers(schedule_id, name) ON UPDATE CASCADE ON DELETE CASCADE,
+	CONSTRAINT schedule_tier_on_call_users_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_schedule_tier_on_call_once ON public.schedule_tier_on_call_users USING btree (schedule_id, tier, user_id) WHERE (end_time IS NULL);
+CREATE UNIQUE INDEX schedule_tier_on_call_users_pkey ON public.schedule_tier_on_call_users USING btree (id);
+
+
+CREATE TABLE schedule_tiers (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	name text NOT NULL,
+	rotation_offset integer DEFAULT 0 NOT NULL,
+	schedule_id uuid NOT NULL,
+	CONSTRAINT schedule_tiers_name CHECK (name ~ '^[a-z][a-z0-9-]{0,31}$'::text AND name <> 'primary'::text),
+	CONSTRAINT schedule_tiers_pkey PRIMARY KEY (schedule_id, name),
+	CONSTRAINT schedule_tiers_rotation_offset CHECK (rotation_offset >= 0),
+	CONSTRAINT schedule_tiers_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX schedule_tiers_pkey ON public.schedule_tiers USING btree (schedule_id, name);
+
+
 CREATE TABLE schedules (
 	description text DEFAULT ''::text NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
@@ -2422,6 +2620,35 @@ CREATE UNIQUE INDEX schedules_name_key ON public.schedules USING btree (name);
 CREATE UNIQUE INDEX schedules_pkey ON public.schedules USING btree (id);
 
 
+CREATE TABLE scim_api_keys (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	created_by uuid,
+	deleted_at timestamp with time zone,
+	deleted_by uuid,
+	description text NOT NULL,
+	expires_at timestamp with time zone NOT NULL,
+	id uuid NOT NULL,
+	last_used_at timestamp with time zone,
+	name text NOT NULL,
+	CONSTRAINT scim_api_keys_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+	CONSTRAINT scim_api_keys_deleted_by_fkey FOREIGN KEY (deleted_by) REFERENCES users(id) ON DELETE SET NULL,
+	CONSTRAINT scim_api_keys_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX scim_api_keys_name_key ON public.scim_api_keys USING btree (name) WHERE (deleted_at IS NULL);
+CREATE UNIQUE INDEX scim_api_keys_pkey ON public.scim_api_keys USING btree (id);
+
+
+CREATE TABLE scim_groups (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	rotation_id uuid NOT NULL,
+	CONSTRAINT scim_groups_pkey PRIMARY KEY (rotation_id),
+	CONSTRAINT scim_groups_rotation_id_fkey FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX scim_groups_pkey ON public.scim_groups USING btree (rotation_id);
+
+
 CREATE TABLE services (
 	description text DEFAULT ''::text NOT NULL,
 	escalation_policy_id uuid NOT NULL,
@@ -2444,6 +2671,33 @@ CREATE UNIQUE INDEX svc_ep_uniq ON public.services USING btree (id, escalation_p
 CREATE TRIGGER trg_10_clear_ep_state_on_svc_ep_change AFTER UPDATE ON public.services FOR EACH ROW WHEN ((old.escalation_policy_id <> new.escalation_policy_id)) EXECUTE FUNCTION fn_clear_ep_state_on_svc_ep_change();
 
 
+CREATE TABLE shift_swap_requests (
+	counterpart_id uuid NOT NULL,
+	counterpart_shift_end timestamp with time zone,
+	counterpart_shift_start timestamp with time zone,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	id uuid NOT NULL,
+	message text DEFAULT ''::text NOT NULL,
+	requester_id uuid NOT NULL,
+	requester_shift_end timestamp with time zone NOT NULL,
+	requester_shift_start timestamp with time zone NOT NULL,
+	responded_at timestamp with time zone,
+	schedule_id uuid NOT NULL,
+	status enum_shift_swap_status DEFAULT 'pending'::enum_shift_swap_status NOT NULL,
+	CONSTRAINT shift_swap_counterpart_shift CHECK (counterpart_shift_start IS NULL AND counterpart_shift_end IS NULL OR counterpart_shift_start < counterpart_shift_end),
+	CONSTRAINT shift_swap_distinct_users CHECK (requester_id <> counterpart_id),
+	CONSTRAINT shift_swap_requester_shift CHECK (requester_shift_start < requester_shift_end),
+	CONSTRAINT shift_swap_requests_counterpart_id_fkey FOREIGN KEY (counterpart_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT shift_swap_requests_pkey PRIMARY KEY (id),
+	CONSTRAINT shift_swap_requests_requester_id_fkey FOREIGN KEY (requester_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT shift_swap_requests_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_shift_swap_requests_counterpart ON public.shift_swap_requests USING btree (counterpart_id);
+CREATE INDEX idx_shift_swap_requests_requester ON public.shift_swap_requests USING btree (requester_id);
+CREATE UNIQUE INDEX shift_swap_requests_pkey ON public.shift_swap_requests USING btree (id);
+
+
 CREATE TABLE switchover_log (
 	data jsonb NOT NULL,
 	id bigint NOT NULL,
@@ -2528,19 +2782,60 @@ CREATE UNIQUE INDEX uik_config_primary_token_key ON public.uik_config USING btre
 CREATE UNIQUE INDEX uik_config_secondary_token_key ON public.uik_config USING btree (secondary_token);
 
 
+CREATE TABLE uik_request_log (
+	body bytea NOT NULL,
+	body_truncated boolean DEFAULT false NOT NULL,
+	headers jsonb DEFAULT '{}'::jsonb NOT NULL,
+	id uuid NOT NULL,
+	key_id uuid NOT NULL,
+	query text DEFAULT ''::text NOT NULL,
+	received_at timestamp with time zone DEFAULT now() NOT NULL,
+	remote_addr text DEFAULT ''::text NOT NULL,
+	user_agent text DEFAULT ''::text NOT NULL,
+	CONSTRAINT uik_request_log_key_id_fkey FOREIGN KEY (key_id) REFERENCES integration_keys(id) ON DELETE CASCADE,
+	CONSTRAINT uik_request_log_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_uik_request_log_key ON public.uik_request_log USING btree (key_id, received_at);
+CREATE UNIQUE INDEX uik_request_log_pkey ON public.uik_request_log USING btree (id);
+
+
+CREATE TABLE uik_webhook_failures (
+	attempts integer NOT NULL,
+	body text DEFAULT ''::text NOT NULL,
+	content_type text DEFAULT ''::text NOT NULL,
+	error text NOT NULL,
+	failed_at timestamp with time zone DEFAULT now() NOT NULL,
+	id uuid NOT NULL,
+	key_id uuid NOT NULL,
+	status_code integer DEFAULT 0 NOT NULL,
+	url text NOT NULL,
+	CONSTRAINT uik_webhook_failures_key_id_fkey FOREIGN KEY (key_id) REFERENCES integration_keys(id) ON DELETE CASCADE,
+	CONSTRAINT uik_webhook_failures_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_uik_webhook_failures_key ON public.uik_webhook_failures USING btree (key_id, failed_at);
+CREATE UNIQUE INDEX uik_webhook_failures_pkey ON public.uik_webhook_failures USING btree (id);
+
+
 CREATE TABLE user_calendar_subscriptions (
 	config jsonb NOT NULL,
 	created_at timestamp with time zone DEFAULT now() NOT NULL,
 	disabled boolean DEFAULT false NOT NULL,
+	escalation_policy_id uuid,
 	id uuid NOT NULL,
 	last_access timestamp with time zone,
 	last_update timestamp with time zone DEFAULT now() NOT NULL,
 	name text NOT NULL,
-	schedule_id uuid NOT NULL,
+	schedule_id uuid,
+	service_id uuid,
 	user_id uuid NOT NULL,
+	CONSTRAINT user_calendar_subscriptions_escalation_policy_id_fkey FOREIGN KEY (escalation_policy_id) REFERENCES escalation_policies(id) ON DELETE CASCADE,
 	CONSTRAINT user_calendar_subscriptions_name_schedule_id_user_id_key UNIQUE (name, schedule_id, user_id),
 	CONSTRAINT user_calendar_subscriptions_pkey PRIMARY KEY (id),
 	CONSTRAINT user_calendar_subscriptions_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+	CONSTRAINT user_calendar_subscriptions_scope_check CHECK (num_nonnulls(schedule_id, service_id, escalation_policy_id) = 1),
+	CONSTRAINT user_calendar_subscriptions_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE,
 	CONSTRAINT user_calendar_subscriptions_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
@@ -2640,16 +2935,19 @@ CREATE TABLE user_overrides (
 	remove_user_id uuid,
 	start_time timestamp with time zone NOT NULL,
 	tgt_schedule_id uuid NOT NULL,
+	unavailability_id uuid,
 	CONSTRAINT user_overrides_add_user_id_fkey FOREIGN KEY (add_user_id) REFERENCES users(id) ON DELETE CASCADE,
 	CONSTRAINT user_overrides_check CHECK (end_time > start_time),
 	CONSTRAINT user_overrides_check1 CHECK (COALESCE(add_user_id, remove_user_id) IS NOT NULL),
 	CONSTRAINT user_overrides_check2 CHECK (add_user_id <> remove_user_id),
 	CONSTRAINT user_overrides_pkey PRIMARY KEY (id),
 	CONSTRAINT user_overrides_remove_user_id_fkey FOREIGN KEY (remove_user_id) REFERENCES users(id) ON DELETE CASCADE,
-	CONSTRAINT user_overrides_tgt_schedule_id_fkey FOREIGN KEY (tgt_schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+	CONSTRAINT user_overrides_tgt_schedule_id_fkey FOREIGN KEY (tgt_schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+	CONSTRAINT user_overrides_unavailability_id_fkey FOREIGN KEY (unavailability_id) REFERENCES user_unavailability(id) ON DELETE SET NULL
 );
 
 CREATE INDEX idx_user_overrides_schedule ON public.user_overrides USING btree (tgt_schedule_id, end_time);
+CREATE INDEX idx_user_overrides_unavailability ON public.user_overrides USING btree (unavailability_id);
 CREATE UNIQUE INDEX user_overrides_pkey ON public.user_overrides USING btree (id);
 
 CREATE CONSTRAINT TRIGGER trg_enforce_user_overide_no_conflict AFTER INSERT OR UPDATE ON public.user_overrides NOT DEFERRABLE INITIALLY IMMEDIATE FOR EACH ROW EXECUTE FUNCTION fn_enforce_user_overide_no_conflict();
@@ -2666,6 +2964,22 @@ CREATE TABLE user_slack_data (
 CREATE UNIQUE INDEX user_slack_data_pkey ON public.user_slack_data USING btree (id);
 
 
+CREATE TABLE user_unavailability (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	end_time timestamp with time zone NOT NULL,
+	id uuid NOT NULL,
+	reason text DEFAULT ''::text NOT NULL,
+	start_time timestamp with time zone NOT NULL,
+	user_id uuid NOT NULL,
+	CONSTRAINT user_unavailability_check CHECK (start_time < end_time),
+	CONSTRAINT user_unavailability_pkey PRIMARY KEY (id),
+	CONSTRAINT user_unavailability_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_unavailability_user ON public.user_unavailability USING btree (user_id, end_time);
+CREATE UNIQUE INDEX user_unavailability_pkey ON public.user_unavailability USING btree (id);
+
+
 CREATE TABLE user_verification_codes (
 	code integer NOT NULL,
 	contact_method_id uuid NOT NULL,
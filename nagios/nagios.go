@@ -0,0 +1,243 @@
+// Package nagios accepts notifications from Nagios-style monitoring systems (Nagios, Icinga, and Zabbix).
+//
+// Notification commands send the standard notification macros as form values or a JSON object, e.g.:
+//
+//	curl -d NOTIFICATIONTYPE="$NOTIFICATIONTYPE$" -d HOSTNAME="$HOSTNAME$" -d HOSTSTATE="$HOSTSTATE$" -d HOSTOUTPUT="$HOSTOUTPUT$" \
+//		-d SERVICEDESC="$SERVICEDESC$" -d SERVICESTATE="$SERVICESTATE$" -d SERVICEOUTPUT="$SERVICEOUTPUT$" "<key URL>"
+//
+// Host notifications omit the service values. Alerts are deduplicated by host and service.
+//
+// Zabbix media types may instead send the EVENT.STATUS, HOST.NAME, TRIGGER.NAME, TRIGGER.SEVERITY,
+// and ALERT.MESSAGE macros.
+package nagios
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Notification is a host or service notification.
+type Notification struct {
+	Type string
+
+	Host       string
+	HostState  string
+	HostOutput string
+	Service    string
+	State      string
+	Output     string
+	LongOutput string
+	Comment    string
+
+	// Dedup, if set, overrides the default dedup key of host and service.
+	Dedup string
+}
+
+// fields maps the accepted (upper-cased) macro names to the notification value they set, in order of precedence.
+var fields = []struct {
+	names []string
+	val   func(n *Notification) *string
+}{
+	{[]string{"NOTIFICATIONTYPE", "EVENT.STATUS"}, func(n *Notification) *string { return &n.Type }},
+	{[]string{"HOSTNAME", "HOST.NAME", "HOSTALIAS"}, func(n *Notification) *string { return &n.Host }},
+	{[]string{"HOSTSTATE"}, func(n *Notification) *string { return &n.HostState }},
+	{[]string{"HOSTOUTPUT"}, func(n *Notification) *string { return &n.HostOutput }},
+	{[]string{"SERVICEDESC", "SERVICENAME", "TRIGGER.NAME"}, func(n *Notification) *string { return &n.Service }},
+	{[]string{"SERVICESTATE", "TRIGGER.SEVERITY"}, func(n *Notification) *string { return &n.State }},
+	{[]string{"SERVICEOUTPUT", "ALERT.MESSAGE"}, func(n *Notification) *string { return &n.Output }},
+	{[]string{"LONGSERVICEOUTPUT"}, func(n *Notification) *string { return &n.LongOutput }},
+	{[]string{"DEDUP"}, func(n *Notification) *string { return &n.Dedup }},
+	{[]string{"NOTIFICATIONCOMMENT"}, func(n *Notification) *string { return &n.Comment }},
+}
+
+// newNotification creates a Notification from macro values. Macro names are case-insensitive,
+// and may include the NAGIOS_ or ICINGA_ prefix used by environment macros.
+func newNotification(values map[string]string) *Notification {
+	norm := make(map[string]string, len(values))
+	for k, v := range values {
+		k = strings.ToUpper(strings.TrimSpace(k))
+		k = strings.TrimPrefix(k, "NAGIOS_")
+		k = strings.TrimPrefix(k, "ICINGA_")
+		norm[k] = strings.TrimSpace(v)
+	}
+
+	var n Notification
+	for _, f := range fields {
+		for _, name := range f.names {
+			if v := norm[name]; v != "" {
+				*f.val(&n) = v
+				break
+			}
+		}
+	}
+
+	return &n
+}
+
+// parseRequest reads the notification macros from a form or JSON request body.
+func parseRequest(r *http.Request) (*Notification, error) {
+	values := make(map[string]string)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		var data map[string]any
+		err := json.NewDecoder(io.LimitReader(r.Body, 256*1024)).Decode(&data)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range data {
+			if v == nil {
+				continue
+			}
+			values[k] = fmt.Sprint(v)
+		}
+	} else {
+		err := r.ParseForm()
+		if err != nil {
+			return nil, err
+		}
+		for k := range r.Form {
+			values[k] = r.Form.Get(k)
+		}
+	}
+
+	return newNotification(values), nil
+}
+
+// isRecovered returns true if the state indicates the host or service is healthy.
+func isRecovered(state string) bool {
+	switch strings.ToUpper(state) {
+	case "OK", "UP", "RESOLVED":
+		return true
+	}
+	return false
+}
+
+// Status returns the alert status for the notification, or an empty status if it should be ignored
+// (e.g., flapping or downtime notifications).
+func (n Notification) Status() alert.Status {
+	state := n.State
+	if n.Service == "" {
+		state = n.HostState
+	}
+
+	switch strings.ToUpper(n.Type) {
+	case "PROBLEM":
+		if isRecovered(state) {
+			return alert.StatusClosed
+		}
+		return alert.StatusTriggered
+	case "RECOVERY", "RESOLVED", "OK":
+		return alert.StatusClosed
+	case "ACKNOWLEDGEMENT":
+		return alert.StatusActive
+	}
+
+	return ""
+}
+
+// Alert returns the alert for the notification.
+func (n Notification) Alert(serviceID string, src alert.Source) *alert.Alert {
+	dedup := n.Dedup
+	if dedup == "" {
+		dedup = n.Host
+		if n.Service != "" {
+			dedup += "/" + n.Service
+		}
+	}
+
+	var summary, details string
+	if n.Service == "" {
+		summary = fmt.Sprintf("%s is %s", n.Host, n.HostState)
+		details = n.HostOutput
+	} else {
+		summary = fmt.Sprintf("%s on %s is %s", n.Service, n.Host, n.State)
+		details = strings.TrimSpace(n.Output + "\n\n" + n.LongOutput)
+	}
+	if n.Comment != "" {
+		details = strings.TrimSpace(details + "\n\n" + n.Comment)
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(details, alert.MaxDetailsLength),
+		Status:    n.Status(),
+		Source:    src,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup(dedup),
+	}
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// EventsAPI returns a handler that accepts notifications, creating alerts with the given source.
+func EventsAPI(aDB *alert.Store, src alert.Source) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		n, err := parseRequest(r)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from %s: %v", src, err)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"NotificationType": n.Type,
+			"Host":             n.Host,
+			"Service":          n.Service,
+		})
+
+		if n.Host == "" {
+			log.Logf(ctx, "bad request from %s: missing host name", src)
+			http.Error(w, "missing host name", http.StatusBadRequest)
+			return
+		}
+
+		if n.Status() == "" {
+			// flapping, downtime, and custom notifications don't change alert state
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		msg := n.Alert(serviceID, src)
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrapf(err, "create or update alert for %s", src)) {
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
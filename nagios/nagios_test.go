@@ -0,0 +1,102 @@
+package nagios
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestNotification_Alert(t *testing.T) {
+	check := func(desc string, values map[string]string, status alert.Status, dedup, summary string) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			n := newNotification(values)
+			a := n.Alert("svc", alert.SourceNagios)
+			assert.Equal(t, status, a.Status)
+			assert.Equal(t, alert.NewUserDedup(dedup), a.Dedup)
+			if summary != "" {
+				assert.Equal(t, summary, a.Summary)
+			}
+		})
+	}
+
+	check("service problem", map[string]string{
+		"NOTIFICATIONTYPE": "PROBLEM",
+		"HOSTNAME":         "db-1",
+		"SERVICEDESC":      "Disk /var",
+		"SERVICESTATE":     "CRITICAL",
+		"SERVICEOUTPUT":    "DISK CRITICAL - 2% free",
+	}, alert.StatusTriggered, "db-1/Disk /var", "Disk /var on db-1 is CRITICAL")
+
+	check("service recovery", map[string]string{
+		"NOTIFICATIONTYPE": "RECOVERY",
+		"HOSTNAME":         "db-1",
+		"SERVICEDESC":      "Disk /var",
+		"SERVICESTATE":     "OK",
+	}, alert.StatusClosed, "db-1/Disk /var", "")
+
+	check("service ack", map[string]string{
+		"notificationtype": "ACKNOWLEDGEMENT",
+		"hostname":         "db-1",
+		"servicedesc":      "Disk /var",
+		"servicestate":     "CRITICAL",
+	}, alert.StatusActive, "db-1/Disk /var", "")
+
+	check("host down", map[string]string{
+		"NAGIOS_NOTIFICATIONTYPE": "PROBLEM",
+		"NAGIOS_HOSTNAME":         "web-3",
+		"NAGIOS_HOSTSTATE":        "DOWN",
+		"NAGIOS_HOSTOUTPUT":       "PING CRITICAL - Packet loss = 100%",
+	}, alert.StatusTriggered, "web-3", "web-3 is DOWN")
+
+	check("icinga service name", map[string]string{
+		"NOTIFICATIONTYPE": "PROBLEM",
+		"HOSTNAME":         "web-3",
+		"SERVICENAME":      "http",
+		"SERVICESTATE":     "WARNING",
+	}, alert.StatusTriggered, "web-3/http", "http on web-3 is WARNING")
+
+	check("zabbix resolved", map[string]string{
+		"EVENT.STATUS":     "RESOLVED",
+		"HOST.NAME":        "db-2",
+		"TRIGGER.NAME":     "Zabbix agent is not available",
+		"TRIGGER.SEVERITY": "High",
+	}, alert.StatusClosed, "db-2/Zabbix agent is not available", "")
+
+	check("custom dedup", map[string]string{
+		"NOTIFICATIONTYPE": "PROBLEM",
+		"HOSTNAME":         "db-1",
+		"HOSTSTATE":        "DOWN",
+		"DEDUP":            "rack-7",
+	}, alert.StatusTriggered, "rack-7", "")
+
+	n := newNotification(map[string]string{"NOTIFICATIONTYPE": "FLAPPINGSTART", "HOSTNAME": "db-1"})
+	assert.Empty(t, n.Status(), "flapping notifications should be ignored")
+}
+
+func TestParseRequest(t *testing.T) {
+	form := url.Values{"NOTIFICATIONTYPE": {"PROBLEM"}, "HOSTNAME": {"db-1"}, "HOSTSTATE": {"DOWN"}}
+	req := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	n, err := parseRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, "db-1", n.Host)
+	assert.Equal(t, "DOWN", n.HostState)
+
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{"NOTIFICATIONTYPE":"PROBLEM","HOSTNAME":"db-1","SERVICEDESC":"load","SERVICESTATE":"WARNING","ATTEMPT":3}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	n, err = parseRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, "load", n.Service)
+	assert.Equal(t, "WARNING", n.State)
+
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{`))
+	req.Header.Set("Content-Type", "application/json")
+	_, err = parseRequest(req)
+	assert.Error(t, err)
+}
@@ -3,14 +3,20 @@ package notification
 import "github.com/target/goalert/notification/nfymsg"
 
 type (
-	Alert               = nfymsg.Alert
-	AlertStatus         = nfymsg.AlertStatus
-	AlertBundle         = nfymsg.AlertBundle
-	Message             = nfymsg.Message
-	Test                = nfymsg.Test
-	Verification        = nfymsg.Verification
-	SignalMessage       = nfymsg.SignalMessage
-	ScheduleOnCallUsers = nfymsg.ScheduleOnCallUsers
+	Alert                 = nfymsg.Alert
+	AlertStatus           = nfymsg.AlertStatus
+	AlertBundle           = nfymsg.AlertBundle
+	Message               = nfymsg.Message
+	Test                  = nfymsg.Test
+	Verification          = nfymsg.Verification
+	SignalMessage         = nfymsg.SignalMessage
+	ScheduleOnCallUsers   = nfymsg.ScheduleOnCallUsers
+	ShiftSwapRequest      = nfymsg.ShiftSwapRequest
+	ScheduleCoverageGap   = nfymsg.ScheduleCoverageGap
+	CoverageGap           = nfymsg.CoverageGap
+	ScheduleHandoffReport = nfymsg.ScheduleHandoffReport
+	HandoffService        = nfymsg.HandoffService
+	HandoffAlert          = nfymsg.HandoffAlert
 
 	State = nfymsg.State
 	User  = nfymsg.User
@@ -64,6 +64,30 @@ func (s *Sender) SendMessage(ctx context.Context, msg notification.Message) (*no
 			Instructions: "Click the REACTIVATE link on your profile page and enter the verification code.",
 			InviteCode:   m.Code,
 		}}
+	case notification.ShiftSwapRequest:
+		subject = fmt.Sprintf("Shift Swap Request: %s", m.ScheduleName)
+		e.Body.Title = "Shift Swap Request"
+		e.Body.Intros = []string{m.Summary()}
+		if m.Message != "" {
+			e.Body.Intros = append(e.Body.Intros, m.Message)
+		}
+		e.Body.Actions = []hermes.Action{{
+			Instructions: "Review the request to accept or decline it.",
+			Button: hermes.Button{
+				Text: "Open Schedule Shifts",
+				Link: m.URL,
+			},
+		}}
+	case notification.ScheduleHandoffReport:
+		subject = fmt.Sprintf("Handoff Report: %s", m.ScheduleName)
+		e.Body.Title = "Handoff Report"
+		e.Body.Intros = strings.Split(m.Summary(), "\n")
+		e.Body.Actions = []hermes.Action{{
+			Button: hermes.Button{
+				Text: "Open Schedule",
+				Link: m.ScheduleURL,
+			},
+		}}
 	case notification.Alert:
 		subject = fmt.Sprintf("Alert #%d: %s", m.AlertID, m.Summary)
 		e.Body.Title = fmt.Sprintf("Alert #%d", m.AlertID)
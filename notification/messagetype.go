@@ -21,4 +21,7 @@ const (
 	MessageTypeScheduleOnCallUsers = gadb.EnumOutgoingMessagesTypeScheduleOnCallNotification
 
 	MessageTypeSignalMessage = gadb.EnumOutgoingMessagesTypeSignalMessage
+	MessageTypeShiftSwap     = gadb.EnumOutgoingMessagesTypeShiftSwapRequest
+	MessageTypeCoverageGap   = gadb.EnumOutgoingMessagesTypeScheduleCoverageGap
+	MessageTypeHandoffReport = gadb.EnumOutgoingMessagesTypeScheduleHandoffReport
 )
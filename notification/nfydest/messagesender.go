@@ -48,12 +48,12 @@ func (r *Registry) SendMessage(ctx context.Context, msg nfymsg.Message) (*nfymsg
 		if !info.SupportsUserVerification {
 			return nil, ErrUnsupported
 		}
-	case nfymsg.Test:
+	case nfymsg.Test, nfymsg.ShiftSwapRequest, nfymsg.ScheduleHandoffReport:
 	case nfymsg.SignalMessage:
 		if !info.SupportsSignals {
 			return nil, ErrUnsupported
 		}
-	case nfymsg.ScheduleOnCallUsers:
+	case nfymsg.ScheduleOnCallUsers, nfymsg.ScheduleCoverageGap:
 		if !info.SupportsOnCallNotify {
 			return nil, ErrUnsupported
 		}
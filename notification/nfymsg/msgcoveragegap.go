@@ -0,0 +1,42 @@
+package nfymsg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CoverageGap is a span of time where nobody is on call.
+type CoverageGap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// ScheduleCoverageGap is a Message that indicates a Schedule has upcoming
+// periods with nobody on call.
+type ScheduleCoverageGap struct {
+	Base
+
+	ScheduleID   string
+	ScheduleName string
+	ScheduleURL  string
+
+	Gaps []CoverageGap
+}
+
+// Summary returns a plain-text description of the gaps.
+func (t ScheduleCoverageGap) Summary() string {
+	var b strings.Builder
+	if len(t.Gaps) == 1 {
+		fmt.Fprintf(&b, "Schedule '%s' has nobody on call from %s.", t.ScheduleName, fmtShift(t.Gaps[0].Start, t.Gaps[0].End))
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Schedule '%s' has %d upcoming periods with nobody on call:", t.ScheduleName, len(t.Gaps))
+	for _, g := range t.Gaps {
+		b.WriteString("\n- ")
+		b.WriteString(fmtShift(g.Start, g.End))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package nfymsg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HandoffService is a service that generated alerts during a shift.
+type HandoffService struct {
+	ID         string
+	Name       string
+	AlertCount int
+}
+
+// HandoffAlert is an alert that was not yet closed at the time of a handoff.
+type HandoffAlert struct {
+	ID          int
+	Summary     string
+	ServiceName string
+	URL         string
+
+	// Acknowledged is true if the alert is acknowledged, otherwise it is still unacknowledged.
+	Acknowledged bool
+}
+
+// ScheduleHandoffReport is a Message summarizing the alerts of the previous
+// shift, sent when the on-call users of a Schedule change.
+type ScheduleHandoffReport struct {
+	Base
+
+	ReportID     string
+	ScheduleID   string
+	ScheduleName string
+	ScheduleURL  string
+
+	ShiftStart time.Time
+	ShiftEnd   time.Time
+
+	OutgoingUsers []User
+	IncomingUsers []User
+
+	// OpenCount, AckedCount, and ClosedCount are the number of alerts created
+	// during the shift, by their status when the report was sent.
+	OpenCount   int
+	AckedCount  int
+	ClosedCount int
+
+	// TopServices are the services with the most alerts created during the shift.
+	TopServices []HandoffService
+
+	// Unresolved are the oldest alerts not yet closed, out of UnresolvedCount total.
+	Unresolved      []HandoffAlert
+	UnresolvedCount int
+}
+
+func userNames(users []User) string {
+	if len(users) == 0 {
+		return "nobody"
+	}
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// Headline returns a one-line, plain-text description of the handoff.
+func (t ScheduleHandoffReport) Headline() string {
+	return fmt.Sprintf("Handoff for %s: %s → %s.", t.ScheduleName, userNames(t.OutgoingUsers), userNames(t.IncomingUsers))
+}
+
+// Brief returns a short, plain-text description of the report suitable for SMS and push notifications.
+func (t ScheduleHandoffReport) Brief() string {
+	return fmt.Sprintf("%s %d alerts last shift, %d unresolved.", t.Headline(), t.OpenCount+t.AckedCount+t.ClosedCount, t.UnresolvedCount)
+}
+
+// Summary returns a plain-text description of the report.
+func (t ScheduleHandoffReport) Summary() string {
+	var b strings.Builder
+	b.WriteString(t.Headline())
+	fmt.Fprintf(&b, "\nShift %s: %d alerts (%d open, %d acknowledged, %d closed).",
+		fmtShift(t.ShiftStart, t.ShiftEnd), t.OpenCount+t.AckedCount+t.ClosedCount, t.OpenCount, t.AckedCount, t.ClosedCount)
+
+	if len(t.TopServices) > 0 {
+		b.WriteString("\nTop services:")
+		for _, s := range t.TopServices {
+			fmt.Fprintf(&b, "\n- %s (%d)", s.Name, s.AlertCount)
+		}
+	}
+
+	if t.UnresolvedCount == 0 {
+		b.WriteString("\nNo unresolved alerts.")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "\nUnresolved alerts (%d):", t.UnresolvedCount)
+	for _, a := range t.Unresolved {
+		status := "unacknowledged"
+		if a.Acknowledged {
+			status = "acknowledged"
+		}
+		fmt.Fprintf(&b, "\n- #%d %s: %s (%s)", a.ID, a.ServiceName, a.Summary, status)
+	}
+	if n := t.UnresolvedCount - len(t.Unresolved); n > 0 {
+		fmt.Fprintf(&b, "\n- and %d more", n)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package nfymsg
+
+import (
+	"fmt"
+	"time"
+)
+
+// ShiftSwapRequest is a Message asking a user to accept or decline trading
+// on-call shifts with another user.
+type ShiftSwapRequest struct {
+	Base
+
+	RequestID     string
+	ScheduleName  string
+	RequesterName string
+	Message       string
+
+	// URL is where the request can be reviewed and answered.
+	URL string
+
+	RequesterShiftStart time.Time
+	RequesterShiftEnd   time.Time
+
+	// CounterpartShiftStart and CounterpartShiftEnd are zero if the requester
+	// is only asking for their shift to be covered.
+	CounterpartShiftStart time.Time
+	CounterpartShiftEnd   time.Time
+}
+
+const shiftSwapTimeFmt = "Mon Jan 2 15:04 MST"
+
+func fmtShift(start, end time.Time) string {
+	return start.UTC().Format(shiftSwapTimeFmt) + " to " + end.UTC().Format(shiftSwapTimeFmt)
+}
+
+// Summary returns a one-line, plain-text description of the request.
+func (t ShiftSwapRequest) Summary() string {
+	if t.CounterpartShiftStart.IsZero() {
+		return fmt.Sprintf("%s asked you to cover their on-call shift for %s from %s.",
+			t.RequesterName, t.ScheduleName, fmtShift(t.RequesterShiftStart, t.RequesterShiftEnd))
+	}
+
+	return fmt.Sprintf("%s asked to trade their on-call shift for %s from %s for your shift from %s.",
+		t.RequesterName, t.ScheduleName,
+		fmtShift(t.RequesterShiftStart, t.RequesterShiftEnd),
+		fmtShift(t.CounterpartShiftStart, t.CounterpartShiftEnd),
+	)
+}
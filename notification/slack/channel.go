@@ -451,6 +451,12 @@ func (s *ChannelSender) SendMessage(ctx context.Context, msg notification.Messag
 		opts = append(opts, slack.MsgOptionText("This is a test message.", false))
 	case notification.Verification:
 		opts = append(opts, slack.MsgOptionText(fmt.Sprintf("Your verification code is: %s", t.Code), false))
+	case notification.ShiftSwapRequest:
+		text := slackutilsx.EscapeMessage(t.Summary())
+		if t.Message != "" {
+			text += "\n\n> " + slackutilsx.EscapeMessage(t.Message)
+		}
+		opts = append(opts, slack.MsgOptionText(fmt.Sprintf("%s\n\n<%s|Review request>", text, t.URL), false))
 	case notification.Alert:
 		if t.OriginalStatus != nil {
 			var ts string
@@ -482,6 +488,10 @@ func (s *ChannelSender) SendMessage(ctx context.Context, msg notification.Messag
 		opts = append(opts, slack.MsgOptionText(t.Param("message"), false))
 	case notification.ScheduleOnCallUsers:
 		opts = append(opts, slack.MsgOptionText(s.onCallNotificationText(ctx, t), false))
+	case notification.ScheduleCoverageGap:
+		opts = append(opts, slack.MsgOptionText(fmt.Sprintf("%s\n\n<%s|View shifts>", slackutilsx.EscapeMessage(t.Summary()), t.ScheduleURL), false))
+	case notification.ScheduleHandoffReport:
+		opts = append(opts, slack.MsgOptionText(fmt.Sprintf("%s\n\n<%s|View schedule>", slackutilsx.EscapeMessage(t.Summary()), t.ScheduleURL), false))
 	default:
 		return nil, errors.Errorf("unsupported message type: %T", t)
 	}
@@ -223,7 +223,8 @@ func (voice *VoiceOptions) setMsgParams(msg notification.Message) (err error) {
 	case notification.AlertStatus:
 		voice.CallType = CallTypeAlertStatus
 		subID = t.AlertID
-	case notification.Test:
+	case notification.Test, notification.ShiftSwapRequest:
+		// informational only, no response is gathered over the phone
 		voice.CallType = CallTypeTest
 	case notification.Verification:
 		voice.CallType = CallTypeVerify
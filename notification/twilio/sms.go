@@ -139,6 +139,16 @@ func (s *SMS) SendMessage(ctx context.Context, msg notification.Message) (*notif
 		message = fmt.Sprintf("%s: Test message.", cfg.ApplicationName())
 	case notification.Verification:
 		message = fmt.Sprintf("%s: Verification code: %s", cfg.ApplicationName(), t.Code)
+	case notification.ShiftSwapRequest:
+		message = fmt.Sprintf("%s: %s", cfg.ApplicationName(), t.Summary())
+		if canContainURL(ctx, destNumber) {
+			message += " Respond at " + t.URL
+		}
+	case notification.ScheduleHandoffReport:
+		message = fmt.Sprintf("%s: %s", cfg.ApplicationName(), t.Brief())
+		if canContainURL(ctx, destNumber) {
+			message += " " + t.ScheduleURL
+		}
 	default:
 		return nil, errors.Errorf("unhandled message type %T", t)
 	}
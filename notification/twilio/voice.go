@@ -625,6 +625,8 @@ func buildMessage(prefix string, msg notification.Message) (message string, err
 		message = fmt.Sprintf("%s with a status update for alert '%s'. %s", prefix, t.Summary, message)
 	case notification.Test:
 		message = fmt.Sprintf("%s with a test message.", prefix)
+	case notification.ShiftSwapRequest:
+		message = fmt.Sprintf("%s with a shift swap request. %s Please sign in to accept or decline.", prefix, t.Summary())
 	case notification.Verification:
 		message = fmt.Sprintf(
 			"%s with your %d-digit verification code. The code is: %s. Again, your %d-digit verification code is: %s.",
@@ -3,6 +3,7 @@ package twilio
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/target/goalert/notification"
@@ -76,6 +77,20 @@ func TestBuildMessage(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("%s with your 4-digit verification code. The code is: %s. Again, your 4-digit verification code is: %s.", prefix, spellCode("1234"), spellCode("1234")), result)
 	assert.NoError(t, err)
 
+	// Shift Swap Request
+	result, err = buildMessage(
+		prefix,
+		notification.ShiftSwapRequest{
+			Base:                nfymsg.Base{ID: "2"},
+			ScheduleName:        "Primary",
+			RequesterName:       "Bob",
+			RequesterShiftStart: time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC),
+			RequesterShiftEnd:   time.Date(2026, 1, 2, 17, 0, 0, 0, time.UTC),
+		},
+	)
+	assert.Equal(t, fmt.Sprintf("%s with a shift swap request. Bob asked you to cover their on-call shift for Primary from Fri Jan 2 09:00 UTC to Fri Jan 2 17:00 UTC. Please sign in to accept or decline.", prefix), result)
+	assert.NoError(t, err)
+
 	// Bad Type
 	result, err = buildMessage(
 		prefix,
@@ -79,6 +79,73 @@ type POSTDataOnCallNotification struct {
 	ScheduleURL  string
 }
 
+// POSTDataShiftSwapRequest represents fields in outgoing shift swap request notification.
+type POSTDataShiftSwapRequest struct {
+	AppName               string
+	Type                  string
+	RequestID             string
+	ScheduleName          string
+	RequesterName         string
+	Message               string
+	URL                   string
+	RequesterShiftStart   time.Time
+	RequesterShiftEnd     time.Time
+	CounterpartShiftStart *time.Time `json:",omitempty"`
+	CounterpartShiftEnd   *time.Time `json:",omitempty"`
+}
+
+// POSTDataCoverageGap represents a gap in outgoing coverage gap notification.
+type POSTDataCoverageGap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// POSTDataCoverageGapNotification represents fields in outgoing coverage gap notification.
+type POSTDataCoverageGapNotification struct {
+	AppName      string
+	Type         string
+	Gaps         []POSTDataCoverageGap
+	ScheduleID   string
+	ScheduleName string
+	ScheduleURL  string
+}
+
+// POSTDataHandoffService represents a service in outgoing handoff report notification.
+type POSTDataHandoffService struct {
+	ID         string
+	Name       string
+	AlertCount int
+}
+
+// POSTDataHandoffAlert represents an unresolved alert in outgoing handoff report notification.
+type POSTDataHandoffAlert struct {
+	ID           int
+	Summary      string
+	ServiceName  string
+	URL          string
+	Acknowledged bool
+}
+
+// POSTDataHandoffReport represents fields in outgoing handoff report notification.
+type POSTDataHandoffReport struct {
+	AppName         string
+	Type            string
+	ReportID        string
+	ScheduleID      string
+	ScheduleName    string
+	ScheduleURL     string
+	ShiftStart      time.Time
+	ShiftEnd        time.Time
+	OutgoingUsers   []POSTDataOnCallUser
+	IncomingUsers   []POSTDataOnCallUser
+	OpenCount       int
+	AckedCount      int
+	ClosedCount     int
+	TopServices     []POSTDataHandoffService
+	Unresolved      []POSTDataHandoffAlert
+	UnresolvedCount int
+}
+
 // POSTDataTest represents fields in outgoing test notification.
 type POSTDataTest struct {
 	AppName string
@@ -150,6 +217,69 @@ func (s *Sender) SendMessage(ctx context.Context, msg notification.Message) (*no
 			ScheduleName: m.ScheduleName,
 			ScheduleURL:  m.ScheduleURL,
 		}
+	case notification.ScheduleCoverageGap:
+		gaps := make([]POSTDataCoverageGap, len(m.Gaps))
+		for i, g := range m.Gaps {
+			gaps[i] = POSTDataCoverageGap(g)
+		}
+		payload = POSTDataCoverageGapNotification{
+			AppName:      cfg.ApplicationName(),
+			Type:         "ScheduleCoverageGap",
+			Gaps:         gaps,
+			ScheduleID:   m.ScheduleID,
+			ScheduleName: m.ScheduleName,
+			ScheduleURL:  m.ScheduleURL,
+		}
+	case notification.ScheduleHandoffReport:
+		users := func(src []notification.User) []POSTDataOnCallUser {
+			result := make([]POSTDataOnCallUser, len(src))
+			for i, u := range src {
+				result[i] = POSTDataOnCallUser(u)
+			}
+			return result
+		}
+		data := POSTDataHandoffReport{
+			AppName:         cfg.ApplicationName(),
+			Type:            "ScheduleHandoffReport",
+			ReportID:        m.ReportID,
+			ScheduleID:      m.ScheduleID,
+			ScheduleName:    m.ScheduleName,
+			ScheduleURL:     m.ScheduleURL,
+			ShiftStart:      m.ShiftStart,
+			ShiftEnd:        m.ShiftEnd,
+			OutgoingUsers:   users(m.OutgoingUsers),
+			IncomingUsers:   users(m.IncomingUsers),
+			OpenCount:       m.OpenCount,
+			AckedCount:      m.AckedCount,
+			ClosedCount:     m.ClosedCount,
+			TopServices:     make([]POSTDataHandoffService, len(m.TopServices)),
+			Unresolved:      make([]POSTDataHandoffAlert, len(m.Unresolved)),
+			UnresolvedCount: m.UnresolvedCount,
+		}
+		for i, s := range m.TopServices {
+			data.TopServices[i] = POSTDataHandoffService(s)
+		}
+		for i, a := range m.Unresolved {
+			data.Unresolved[i] = POSTDataHandoffAlert(a)
+		}
+		payload = data
+	case notification.ShiftSwapRequest:
+		data := POSTDataShiftSwapRequest{
+			AppName:             cfg.ApplicationName(),
+			Type:                "ShiftSwapRequest",
+			RequestID:           m.RequestID,
+			ScheduleName:        m.ScheduleName,
+			RequesterName:       m.RequesterName,
+			Message:             m.Message,
+			URL:                 m.URL,
+			RequesterShiftStart: m.RequesterShiftStart,
+			RequesterShiftEnd:   m.RequesterShiftEnd,
+		}
+		if !m.CounterpartShiftStart.IsZero() {
+			data.CounterpartShiftStart = &m.CounterpartShiftStart
+			data.CounterpartShiftEnd = &m.CounterpartShiftEnd
+		}
+		payload = data
 	default:
 		return nil, fmt.Errorf("message type '%T' not supported", m)
 	}
@@ -271,6 +271,20 @@ func buildPayload(cfg config.Config, msg notification.Message) (pushPayload, err
 			Body:  "This is a test notification.",
 			URL:   "/profile",
 		}, nil
+	case notification.ShiftSwapRequest:
+		return pushPayload{
+			Type:  "shift-swap",
+			Title: fmt.Sprintf("Shift Swap Request · %s", m.ScheduleName),
+			Body:  m.Summary(),
+			URL:   m.URL,
+		}, nil
+	case notification.ScheduleHandoffReport:
+		return pushPayload{
+			Type:  "handoff-report",
+			Title: fmt.Sprintf("Handoff Report · %s", m.ScheduleName),
+			Body:  m.Brief(),
+			URL:   "/schedules/" + m.ScheduleID,
+		}, nil
 	case notification.Verification:
 		return pushPayload{
 			Type:  "verification",
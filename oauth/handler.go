@@ -0,0 +1,541 @@
+package oauth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+)
+
+// protocolError is an OAuth error response (RFC 6749 section 5.2).
+type protocolError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *protocolError) Error() string { return e.Code + ": " + e.Description }
+
+func newError(code, desc string) *protocolError { return &protocolError{Code: code, Description: desc} }
+
+// authRequest is a validated authorization request.
+type authRequest struct {
+	Client        gadb.OauthClient
+	RedirectURI   string
+	Scopes        []string
+	State         string
+	CodeChallenge string
+}
+
+// parseAuthRequest validates the parameters of an authorization request.
+//
+// Errors about the client or redirect URI are returned as validation errors, and must
+// not be sent to the redirect URI. All other errors are a *protocolError.
+func (s *Store) parseAuthRequest(ctx context.Context, q url.Values) (*authRequest, error) {
+	clientID, err := uuid.Parse(q.Get("client_id"))
+	if err != nil {
+		return nil, validation.NewFieldError("client_id", "invalid client ID")
+	}
+	client, err := gadb.New(s.db).OAuthClientFind(ctx, clientID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("client_id", "unknown client")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	req := &authRequest{
+		Client:        client,
+		RedirectURI:   q.Get("redirect_uri"),
+		State:         q.Get("state"),
+		CodeChallenge: q.Get("code_challenge"),
+	}
+	if req.RedirectURI == "" && len(client.RedirectUris) == 1 {
+		req.RedirectURI = client.RedirectUris[0]
+	}
+	if !slices.Contains(client.RedirectUris, req.RedirectURI) {
+		return nil, validation.NewFieldError("redirect_uri", "does not match a registered redirect URI for this client")
+	}
+
+	if q.Get("response_type") != "code" {
+		return req, newError("unsupported_response_type", "only the authorization code flow is supported")
+	}
+	if q.Get("code_challenge_method") != "S256" || !codeChallengeRx.MatchString(req.CodeChallenge) {
+		return req, newError("invalid_request", "a PKCE code_challenge using the S256 method is required")
+	}
+	req.Scopes, err = parseScopes(q.Get("scope"))
+	if err != nil {
+		return req, newError("invalid_scope", err.Error())
+	}
+
+	return req, nil
+}
+
+// redirect sends the user-agent back to the client with the given parameters.
+func (r *authRequest) redirect(w http.ResponseWriter, req *http.Request, params url.Values) {
+	u, err := url.Parse(r.RedirectURI)
+	if errutil.HTTPError(req.Context(), w, err) {
+		return
+	}
+
+	q := u.Query()
+	for k, v := range params {
+		q[k] = v
+	}
+	if r.State != "" {
+		q.Set("state", r.State)
+	}
+	u.RawQuery = q.Encode()
+
+	http.Redirect(w, req, u.String(), http.StatusSeeOther)
+}
+
+// handleAuthRequestError responds to an error from parseAuthRequest.
+func handleAuthRequestError(w http.ResponseWriter, req *http.Request, r *authRequest, err error) {
+	var pErr *protocolError
+	if r != nil && errors.As(err, &pErr) {
+		r.redirect(w, req, url.Values{"error": {pErr.Code}, "error_description": {pErr.Description}})
+		return
+	}
+
+	errutil.HTTPError(req.Context(), w, err)
+}
+
+// ServeAuthorize handles the authorization endpoint, sending the user to the consent screen.
+func (s *Store) ServeAuthorize(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	if !cfg.OAuthServer.Enable {
+		http.NotFound(w, req)
+		return
+	}
+
+	r, err := s.parseAuthRequest(ctx, req.URL.Query())
+	if err != nil {
+		handleAuthRequestError(w, req, r, err)
+		return
+	}
+
+	// The session cookie is not sent on cross-site navigation, so the consent
+	// screen (a UI route) will load the request details and submit the response.
+	http.Redirect(w, req, cfg.CallbackURL("/oauth/consent", req.URL.Query()), http.StatusFound)
+}
+
+// requireSession ensures the request is from a logged-in user (not an API key or token).
+func requireSession(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	src := permission.Source(ctx)
+	if src == nil || src.Type != permission.SourceTypeAuthProvider {
+		return permission.NewAccessDenied("consent requires an interactive session")
+	}
+
+	return nil
+}
+
+// ConsentInfo describes an authorization request for display on the consent screen.
+type ConsentInfo struct {
+	ClientName        string
+	ClientDescription string
+	RedirectHost      string
+	Scopes            []ScopeInfo
+}
+
+// ScopeInfo describes a requested scope.
+type ScopeInfo struct {
+	Name        string
+	Description string
+}
+
+// ServeConsentInfo returns the details of an authorization request for the consent screen.
+func (s *Store) ServeConsentInfo(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if !config.FromContext(ctx).OAuthServer.Enable {
+		http.NotFound(w, req)
+		return
+	}
+	err := requireSession(ctx)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	r, err := s.parseAuthRequest(ctx, req.URL.Query())
+	var pErr *protocolError
+	if errors.As(err, &pErr) {
+		err = validation.NewGenericError(pErr.Description)
+	}
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	u, err := url.Parse(r.RedirectURI)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	info := ConsentInfo{
+		ClientName:        r.Client.Name,
+		ClientDescription: r.Client.Description,
+		RedirectHost:      u.Host,
+	}
+	for _, sc := range r.Scopes {
+		info.Scopes = append(info.Scopes, ScopeInfo{Name: sc, Description: scopeDescriptions[sc]})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	err = json.NewEncoder(w).Encode(info)
+	if err != nil {
+		log.Log(ctx, err)
+	}
+}
+
+// ServeConsent handles the response from the consent screen, issuing an authorization code if approved.
+func (s *Store) ServeConsent(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	if !cfg.OAuthServer.Enable {
+		http.NotFound(w, req)
+		return
+	}
+	err := requireSession(ctx)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	if !cfg.ValidReferer(req.URL.String(), req.Referer()) {
+		errutil.HTTPError(ctx, w, validation.NewFieldError("referer", "wrong host/path"))
+		return
+	}
+
+	err = req.ParseForm()
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	r, err := s.parseAuthRequest(ctx, req.PostForm)
+	if err != nil {
+		handleAuthRequestError(w, req, r, err)
+		return
+	}
+
+	if req.PostForm.Get("approve") != "true" {
+		r.redirect(w, req, url.Values{"error": {"access_denied"}, "error_description": {"the user denied the request"}})
+		return
+	}
+
+	userID, err := uuid.Parse(permission.UserID(ctx))
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	code, codeHash := randomSecret()
+	db := gadb.New(s.db)
+	err = db.OAuthCodeDeleteExpired(ctx)
+	if err != nil {
+		// not critical, expired codes are never accepted
+		log.Log(ctx, err)
+	}
+	err = db.OAuthCodeInsert(ctx, gadb.OAuthCodeInsertParams{
+		CodeHash:      codeHash,
+		ClientID:      r.Client.ID,
+		UserID:        userID,
+		RedirectUri:   r.RedirectURI,
+		Scopes:        r.Scopes,
+		CodeChallenge: r.CodeChallenge,
+		ExpiresAt:     time.Now().Add(codeTTL),
+	})
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	r.redirect(w, req, url.Values{"code": {code}})
+}
+
+// TokenResponse is a successful response from the token endpoint.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+}
+
+func writeJSON(ctx context.Context, w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		log.Log(ctx, err)
+	}
+}
+
+// tokenError responds to an error from the token or revocation endpoints.
+func tokenError(ctx context.Context, w http.ResponseWriter, err error) {
+	var pErr *protocolError
+	if !errors.As(err, &pErr) {
+		log.Log(ctx, err)
+		writeJSON(ctx, w, http.StatusInternalServerError, newError("server_error", ""))
+		return
+	}
+
+	status := http.StatusBadRequest
+	if pErr.Code == "invalid_client" {
+		status = http.StatusUnauthorized
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	writeJSON(ctx, w, status, pErr)
+}
+
+// authenticateClient identifies the client from HTTP basic auth or form parameters, verifying the secret of confidential clients.
+func (s *Store) authenticateClient(ctx context.Context, req *http.Request) (*gadb.OauthClient, error) {
+	idStr, secret, ok := req.BasicAuth()
+	if ok {
+		// credentials are form-encoded before being used for basic auth (RFC 6749 section 2.3.1)
+		idStr, _ = url.QueryUnescape(idStr)
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		idStr = req.PostForm.Get("client_id")
+		secret = req.PostForm.Get("client_secret")
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, newError("invalid_client", "unknown client")
+	}
+	client, err := gadb.New(s.db).OAuthClientFind(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, newError("invalid_client", "unknown client")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if client.SecretHash != nil && !secretMatches(secret, client.SecretHash) {
+		return nil, newError("invalid_client", "invalid client credentials")
+	}
+
+	return &client, nil
+}
+
+// ServeToken handles the token endpoint for the authorization_code and refresh_token grant types.
+func (s *Store) ServeToken(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if !config.FromContext(ctx).OAuthServer.Enable {
+		http.NotFound(w, req)
+		return
+	}
+
+	err := req.ParseForm()
+	if err != nil {
+		tokenError(ctx, w, newError("invalid_request", err.Error()))
+		return
+	}
+
+	client, err := s.authenticateClient(ctx, req)
+	if err != nil {
+		tokenError(ctx, w, err)
+		return
+	}
+
+	var resp *TokenResponse
+	switch req.PostForm.Get("grant_type") {
+	case "authorization_code":
+		resp, err = s.exchangeCode(ctx, client, req.PostForm)
+	case "refresh_token":
+		resp, err = s.refresh(ctx, client, req.PostForm)
+	default:
+		err = newError("unsupported_grant_type", "")
+	}
+	if err != nil {
+		tokenError(ctx, w, err)
+		return
+	}
+
+	writeJSON(ctx, w, http.StatusOK, resp)
+}
+
+func (s *Store) newTokenResponse(grantID, clientID, userID uuid.UUID, scopes []string, refreshToken string) (*TokenResponse, error) {
+	claims := newAccessClaims(grantID, clientID, userID, scopes)
+	tok, err := s.key.SignJWT(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenResponse{
+		AccessToken:  tok,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(accessTokenTTL.Seconds()),
+		RefreshToken: refreshToken,
+		Scope:        claims.Scope,
+	}, nil
+}
+
+func (s *Store) exchangeCode(ctx context.Context, client *gadb.OauthClient, form url.Values) (*TokenResponse, error) {
+	code, err := gadb.New(s.db).OAuthCodeConsume(ctx, hashSecret(form.Get("code")))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, newError("invalid_grant", "invalid authorization code")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case time.Now().After(code.ExpiresAt):
+		return nil, newError("invalid_grant", "authorization code expired")
+	case code.ClientID != client.ID:
+		return nil, newError("invalid_grant", "authorization code was issued to another client")
+	case code.RedirectUri != form.Get("redirect_uri"):
+		return nil, newError("invalid_grant", "redirect_uri does not match the authorization request")
+	case !verifyPKCE(code.CodeChallenge, form.Get("code_verifier")):
+		return nil, newError("invalid_grant", "invalid code_verifier")
+	}
+
+	grantID := uuid.New()
+	refreshToken, refreshHash := newRefreshToken(grantID)
+	err = gadb.New(s.db).OAuthGrantInsert(ctx, gadb.OAuthGrantInsertParams{
+		ID:               grantID,
+		ClientID:         client.ID,
+		UserID:           code.UserID,
+		Scopes:           code.Scopes,
+		RefreshTokenHash: refreshHash,
+		ExpiresAt:        time.Now().Add(refreshTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.newTokenResponse(grantID, client.ID, code.UserID, code.Scopes, refreshToken)
+}
+
+func (s *Store) refresh(ctx context.Context, client *gadb.OauthClient, form url.Values) (*TokenResponse, error) {
+	grantID, secret, err := parseRefreshToken(form.Get("refresh_token"))
+	if err != nil {
+		return nil, newError("invalid_grant", "invalid refresh token")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlutil.Rollback(ctx, "oauth: refresh token", tx)
+
+	db := gadb.New(tx)
+	grant, err := db.OAuthGrantForUpdate(ctx, grantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, newError("invalid_grant", "invalid refresh token")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if grant.ClientID != client.ID {
+		return nil, newError("invalid_grant", "refresh token was issued to another client")
+	}
+	if !secretMatches(secret, grant.RefreshTokenHash) {
+		// A previously used refresh token indicates it may have been stolen,
+		// so the entire grant is revoked (RFC 6819 section 5.2.2.3).
+		log.Logf(ctx, "oauth: refresh token reuse detected for grant %s, revoking", grant.ID)
+		err = db.OAuthGrantRevoke(ctx, grant.ID)
+		if err != nil {
+			return nil, err
+		}
+		err = tx.Commit()
+		if err != nil {
+			return nil, err
+		}
+		return nil, newError("invalid_grant", "invalid refresh token")
+	}
+
+	scopes := grant.Scopes
+	if form.Has("scope") {
+		scopes, err = parseScopes(form.Get("scope"))
+		if err != nil {
+			return nil, newError("invalid_scope", err.Error())
+		}
+		for _, sc := range scopes {
+			if !slices.Contains(grant.Scopes, sc) {
+				return nil, newError("invalid_scope", "scope exceeds the original grant")
+			}
+		}
+	}
+
+	refreshToken, refreshHash := newRefreshToken(grant.ID)
+	err = db.OAuthGrantRotate(ctx, gadb.OAuthGrantRotateParams{
+		ID:               grant.ID,
+		RefreshTokenHash: refreshHash,
+		ExpiresAt:        time.Now().Add(refreshTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.newTokenResponse(grant.ID, client.ID, grant.UserID, scopes, refreshToken)
+}
+
+// ServeRevoke handles the revocation endpoint (RFC 7009).
+//
+// Revoking either an access or refresh token revokes the entire grant.
+func (s *Store) ServeRevoke(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if !config.FromContext(ctx).OAuthServer.Enable {
+		http.NotFound(w, req)
+		return
+	}
+
+	err := req.ParseForm()
+	if err != nil {
+		tokenError(ctx, w, newError("invalid_request", err.Error()))
+		return
+	}
+
+	client, err := s.authenticateClient(ctx, req)
+	if err != nil {
+		tokenError(ctx, w, err)
+		return
+	}
+
+	tok := req.PostForm.Get("token")
+	var grantID uuid.UUID
+	if IsAccessToken(tok) {
+		var claims AccessClaims
+		_, err = s.key.VerifyJWT(tok, &claims, Issuer, Audience)
+		if err == nil && claims.ClientID == client.ID.String() {
+			grantID, _ = uuid.Parse(claims.GrantID)
+		}
+	} else if id, secret, err := parseRefreshToken(tok); err == nil {
+		grant, err := gadb.New(s.db).OAuthGrantForUpdate(ctx, id)
+		if err == nil && grant.ClientID == client.ID && secretMatches(secret, grant.RefreshTokenHash) {
+			grantID = grant.ID
+		}
+	}
+
+	// invalid tokens do not cause an error response
+	if grantID != uuid.Nil {
+		err = gadb.New(s.db).OAuthGrantRevoke(ctx, grantID)
+		if err != nil {
+			tokenError(ctx, w, err)
+			return
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,147 @@
+-- name: OAuthClientInsert :exec
+INSERT INTO oauth_clients(id, name, description, redirect_uris, secret_hash, created_by)
+    VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: OAuthClientFind :one
+SELECT
+    *
+FROM
+    oauth_clients
+WHERE
+    id = $1
+    AND deleted_at IS NULL;
+
+-- name: OAuthClientList :many
+SELECT
+    *
+FROM
+    oauth_clients
+WHERE
+    deleted_at IS NULL
+ORDER BY
+    name;
+
+-- name: OAuthClientDelete :exec
+UPDATE
+    oauth_clients
+SET
+    deleted_at = now()
+WHERE
+    id = $1;
+
+-- name: OAuthCodeInsert :exec
+INSERT INTO oauth_auth_codes(code_hash, client_id, user_id, redirect_uri, scopes, code_challenge, expires_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: OAuthCodeConsume :one
+-- OAuthCodeConsume deletes and returns an authorization code, ensuring it can only be used once.
+DELETE FROM oauth_auth_codes
+WHERE code_hash = $1
+RETURNING
+    *;
+
+-- name: OAuthCodeDeleteExpired :exec
+DELETE FROM oauth_auth_codes
+WHERE expires_at < now();
+
+-- name: OAuthGrantInsert :exec
+INSERT INTO oauth_grants(id, client_id, user_id, scopes, refresh_token_hash, expires_at)
+    VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: OAuthGrantForUpdate :one
+-- OAuthGrantForUpdate returns an active grant for refreshing.
+SELECT
+    oauth_grants.*
+FROM
+    oauth_grants
+    JOIN oauth_clients c ON c.id = oauth_grants.client_id
+        AND c.deleted_at IS NULL
+WHERE
+    oauth_grants.id = $1
+    AND oauth_grants.revoked_at IS NULL
+    AND oauth_grants.expires_at > now()
+FOR UPDATE
+    OF oauth_grants;
+
+-- name: OAuthGrantRotate :exec
+UPDATE
+    oauth_grants
+SET
+    refresh_token_hash = $2,
+    expires_at = $3,
+    last_used_at = now()
+WHERE
+    id = $1;
+
+-- name: OAuthGrantRevoke :exec
+UPDATE
+    oauth_grants
+SET
+    revoked_at = now()
+WHERE
+    id = $1
+    AND revoked_at IS NULL;
+
+-- name: OAuthGrantRevokeByUser :execrows
+UPDATE
+    oauth_grants
+SET
+    revoked_at = now()
+WHERE
+    id = $1
+    AND user_id = $2
+    AND revoked_at IS NULL;
+
+-- name: OAuthGrantAuth :one
+-- OAuthGrantAuth returns true if the grant is active for the given user and client.
+-- The last used time is updated at most once per minute.
+WITH valid AS (
+    SELECT
+        g.id
+    FROM
+        oauth_grants g
+        JOIN oauth_clients c ON c.id = g.client_id
+            AND c.deleted_at IS NULL
+    WHERE
+        g.id = $1
+        AND g.user_id = $2
+        AND g.client_id = $3
+        AND g.revoked_at IS NULL
+        AND g.expires_at > now()
+),
+_update AS (
+    UPDATE
+        oauth_grants
+    SET
+        last_used_at = now()
+    WHERE
+        id IN (
+            SELECT
+                id
+            FROM
+                valid)
+            AND (last_used_at IS NULL
+                OR last_used_at < now() - '1 minute'::interval))
+SELECT
+    TRUE
+FROM
+    valid;
+
+-- name: OAuthGrantListByUser :many
+SELECT
+    g.id,
+    g.scopes,
+    g.created_at,
+    g.last_used_at,
+    c.id AS client_id,
+    c.name AS client_name
+FROM
+    oauth_grants g
+    JOIN oauth_clients c ON c.id = g.client_id
+        AND c.deleted_at IS NULL
+WHERE
+    g.user_id = $1
+    AND g.revoked_at IS NULL
+    AND g.expires_at > now()
+ORDER BY
+    g.created_at DESC;
@@ -0,0 +1,74 @@
+package oauth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+)
+
+const (
+	// ScopeRead allows GraphQL queries.
+	ScopeRead = "read"
+
+	// ScopeWrite allows GraphQL mutations.
+	ScopeWrite = "write"
+)
+
+var scopeDescriptions = map[string]string{
+	ScopeRead:  "View alerts, services, schedules, and other data you have access to.",
+	ScopeWrite: "Make changes on your behalf, such as acknowledging alerts or updating schedules.",
+}
+
+// parseScopes parses a space-separated scope string, defaulting to read-only.
+func parseScopes(s string) ([]string, error) {
+	scopes := strings.Fields(s)
+	if len(scopes) == 0 {
+		return []string{ScopeRead}, nil
+	}
+
+	for _, sc := range scopes {
+		if _, ok := scopeDescriptions[sc]; !ok {
+			return nil, fmt.Errorf("unknown scope '%s'", sc)
+		}
+	}
+
+	slices.Sort(scopes)
+	return slices.Compact(scopes), nil
+}
+
+var rootFields = sync.OnceValues(func() (query, mutation []string) {
+	for _, f := range graphql2.SchemaFields() {
+		switch {
+		case strings.HasPrefix(f, "Query."):
+			query = append(query, f)
+		case strings.HasPrefix(f, "Mutation."):
+			mutation = append(mutation, f)
+		}
+	}
+	return query, mutation
+})
+
+// policy returns the GraphQL policy for an access token with the given scopes.
+func policy(userID string, scopes []string) *apikey.GQLPolicy {
+	query, mutation := rootFields()
+
+	var fields []string
+	if slices.Contains(scopes, ScopeRead) {
+		fields = append(fields, query...)
+	}
+	if slices.Contains(scopes, ScopeWrite) {
+		fields = append(fields, mutation...)
+	}
+
+	return &apikey.GQLPolicy{
+		Version:       2,
+		Role:          permission.RoleUser,
+		UserID:        userID,
+		AllowedFields: fields,
+	}
+}
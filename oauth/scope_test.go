@@ -0,0 +1,32 @@
+package oauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScopes(t *testing.T) {
+	scopes, err := parseScopes("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{ScopeRead}, scopes, "default")
+
+	scopes, err = parseScopes("write read write")
+	require.NoError(t, err)
+	assert.Equal(t, []string{ScopeRead, ScopeWrite}, scopes)
+
+	_, err = parseScopes("read admin")
+	assert.Error(t, err)
+}
+
+func TestPolicy(t *testing.T) {
+	p := policy("user-id", []string{ScopeRead})
+	assert.Equal(t, "user-id", p.UserID)
+	assert.Contains(t, p.AllowedFields, "Query.alerts")
+	assert.NotContains(t, p.AllowedFields, "Mutation.updateAlerts")
+
+	p = policy("user-id", []string{ScopeRead, ScopeWrite})
+	assert.Contains(t, p.AllowedFields, "Query.alerts")
+	assert.Contains(t, p.AllowedFields, "Mutation.updateAlerts")
+}
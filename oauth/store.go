@@ -0,0 +1,275 @@
+// Package oauth implements an OAuth 2.0 authorization server, allowing third-party
+// applications to access the GraphQL API on behalf of users.
+//
+// Only the authorization code flow with PKCE (RFC 7636) is supported. Access tokens
+// are short-lived JWTs, and refresh tokens are rotated on every use.
+package oauth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages OAuth clients and grants.
+type Store struct {
+	db  *sql.DB
+	key keyring.Keyring
+}
+
+// NewStore will create a new Store using the given keyring to sign access tokens.
+func NewStore(db *sql.DB, key keyring.Keyring) *Store {
+	return &Store{db: db, key: key}
+}
+
+// Client contains information about a registered OAuth client application.
+type Client struct {
+	ID           uuid.UUID
+	Name         string
+	Description  string
+	RedirectURIs []string
+	Confidential bool
+	CreatedAt    time.Time
+	CreatedBy    *uuid.UUID
+}
+
+// NewClientOpts is used to register a new OAuth client.
+type NewClientOpts struct {
+	Name         string
+	Desc         string
+	RedirectURIs []string
+
+	// Confidential clients are issued a secret that must be provided when exchanging tokens.
+	Confidential bool
+}
+
+// validateRedirectURI requires an absolute URL without a fragment, using https unless it refers to the local machine.
+func validateRedirectURI(fname, s string) error {
+	err := validate.AbsoluteURL(fname, s)
+	if err != nil {
+		return err
+	}
+
+	u, _ := url.Parse(s)
+	if u.Fragment != "" {
+		return validation.NewFieldError(fname, "must not contain a fragment")
+	}
+	if u.Scheme == "https" {
+		return nil
+	}
+	if u.Scheme == "http" && (u.Hostname() == "localhost" || net.ParseIP(u.Hostname()).IsLoopback()) {
+		return nil
+	}
+
+	return validation.NewFieldError(fname, "must use https (http is only allowed for localhost)")
+}
+
+// CreateClient registers a new OAuth client, returning the ID and secret (if confidential).
+func (s *Store) CreateClient(ctx context.Context, opt NewClientOpts) (uuid.UUID, string, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	err = validate.Many(
+		validate.IDName("Name", opt.Name),
+		validate.Text("Description", opt.Desc, 0, 255),
+		validate.Len("RedirectURIs", opt.RedirectURIs, 1, 10),
+	)
+	for _, u := range opt.RedirectURIs {
+		err = validate.Many(err, validateRedirectURI("RedirectURIs", u))
+	}
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	var secret string
+	var secretHash []byte
+	if opt.Confidential {
+		secret, secretHash = randomSecret()
+	}
+
+	id := uuid.New()
+	err = gadb.New(s.db).OAuthClientInsert(ctx, gadb.OAuthClientInsertParams{
+		ID:           id,
+		Name:         opt.Name,
+		Description:  opt.Desc,
+		RedirectUris: opt.RedirectURIs,
+		SecretHash:   secretHash,
+		CreatedBy:    permission.UserNullUUID(ctx),
+	})
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	return id, secret, nil
+}
+
+// FindAllClients returns all registered OAuth clients.
+func (s *Store) FindAllClients(ctx context.Context) ([]Client, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).OAuthClientList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Client, len(rows))
+	for i, r := range rows {
+		res[i] = Client{
+			ID:           r.ID,
+			Name:         r.Name,
+			Description:  r.Description,
+			RedirectURIs: r.RedirectUris,
+			Confidential: r.SecretHash != nil,
+			CreatedAt:    r.CreatedAt,
+		}
+		if r.CreatedBy.Valid {
+			res[i].CreatedBy = &r.CreatedBy.UUID
+		}
+	}
+
+	return res, nil
+}
+
+// DeleteClient removes an OAuth client, invalidating all tokens issued to it.
+func (s *Store) DeleteClient(ctx context.Context, id uuid.UUID) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(s.db).OAuthClientDelete(ctx, id)
+}
+
+// Grant is an application the current user has authorized.
+type Grant struct {
+	ID         uuid.UUID
+	ClientID   uuid.UUID
+	ClientName string
+	Scopes     []string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+// FindAllUserGrants returns all active grants for the current user.
+func (s *Store) FindAllUserGrants(ctx context.Context) ([]Grant, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(permission.UserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).OAuthGrantListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Grant, len(rows))
+	for i, r := range rows {
+		res[i] = Grant{
+			ID:         r.ID,
+			ClientID:   r.ClientID,
+			ClientName: r.ClientName,
+			Scopes:     r.Scopes,
+			CreatedAt:  r.CreatedAt,
+		}
+		if r.LastUsedAt.Valid {
+			res[i].LastUsedAt = &r.LastUsedAt.Time
+		}
+	}
+
+	return res, nil
+}
+
+// RevokeUserGrant revokes a grant belonging to the current user, invalidating all of its tokens.
+func (s *Store) RevokeUserGrant(ctx context.Context, id uuid.UUID) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+	userID, err := uuid.Parse(permission.UserID(ctx))
+	if err != nil {
+		return err
+	}
+
+	n, err := gadb.New(s.db).OAuthGrantRevokeByUser(ctx, gadb.OAuthGrantRevokeByUserParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return validation.NewFieldError("ID", "grant not found")
+	}
+
+	return nil
+}
+
+// AuthorizeAccessToken will validate an access token, returning a context authorized as the user with the token's scopes.
+func (s *Store) AuthorizeAccessToken(ctx context.Context, tok string) (context.Context, error) {
+	if !config.FromContext(ctx).OAuthServer.Enable {
+		return ctx, permission.Unauthorized()
+	}
+
+	var claims AccessClaims
+	_, err := s.key.VerifyJWT(tok, &claims, Issuer, Audience)
+	if err != nil {
+		return ctx, permission.Unauthorized()
+	}
+
+	grantID, gErr := uuid.Parse(claims.GrantID)
+	userID, uErr := uuid.Parse(claims.Subject)
+	clientID, cErr := uuid.Parse(claims.ClientID)
+	if err := errors.Join(gErr, uErr, cErr); err != nil {
+		log.Log(ctx, fmt.Errorf("oauth: invalid access token claims: %w", err))
+		return ctx, permission.Unauthorized()
+	}
+	scopes, err := parseScopes(claims.Scope)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("oauth: invalid access token scope: %w", err))
+		return ctx, permission.Unauthorized()
+	}
+
+	_, err = gadb.New(s.db).OAuthGrantAuth(ctx, gadb.OAuthGrantAuthParams{
+		ID:       grantID,
+		UserID:   userID,
+		ClientID: clientID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// grant was revoked, expired, or the client was removed
+		return ctx, permission.Unauthorized()
+	}
+	if err != nil {
+		return ctx, err
+	}
+
+	ctx = permission.UserSourceContext(ctx, userID.String(), permission.RoleUser, &permission.SourceInfo{
+		Type: permission.SourceTypeOAuthToken,
+		ID:   grantID.String(),
+	})
+	ctx = apikey.ContextWithPolicy(ctx, policy(userID.String(), scopes))
+
+	return ctx, nil
+}
@@ -0,0 +1,122 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// Issuer is the JWT issuer for OAuth access tokens.
+	Issuer = "goalert"
+
+	// Audience is the JWT audience for OAuth access tokens.
+	Audience = "oauth-v1/access-token"
+
+	accessTokenTTL = time.Hour
+	refreshTTL     = 90 * 24 * time.Hour
+	codeTTL        = 5 * time.Minute
+)
+
+var b64enc = base64.RawURLEncoding
+
+// codeChallengeRx matches a valid S256 PKCE challenge (a base64url-encoded SHA-256 hash).
+var codeChallengeRx = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`)
+
+// codeVerifierRx matches a valid PKCE code verifier (RFC 7636 section 4.1).
+var codeVerifierRx = regexp.MustCompile(`^[A-Za-z0-9._~-]{43,128}$`)
+
+// AccessClaims are the claims of an OAuth access token.
+type AccessClaims struct {
+	jwt.RegisteredClaims
+	ClientID string `json:"client_id"`
+	GrantID  string `json:"gid"`
+	Scope    string `json:"scope"`
+}
+
+func newAccessClaims(grantID, clientID, userID uuid.UUID, scopes []string) *AccessClaims {
+	n := time.Now()
+	return &AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   userID.String(),
+			ExpiresAt: jwt.NewNumericDate(n.Add(accessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(n),
+			NotBefore: jwt.NewNumericDate(n.Add(-time.Minute)),
+			Issuer:    Issuer,
+			Audience:  []string{Audience},
+		},
+		ClientID: clientID.String(),
+		GrantID:  grantID.String(),
+		Scope:    strings.Join(scopes, " "),
+	}
+}
+
+// IsAccessToken returns true if the token looks like an OAuth access token.
+//
+// The signature is NOT validated.
+func IsAccessToken(tok string) bool {
+	var claims jwt.RegisteredClaims
+	_, _, err := jwt.NewParser().ParseUnverified(tok, &claims)
+	if err != nil {
+		return false
+	}
+
+	return slices.Contains(claims.Audience, Audience)
+}
+
+// randomSecret returns a new random value and its hash.
+func randomSecret() (string, []byte) {
+	buf := make([]byte, 32)
+	_, _ = rand.Read(buf)
+	s := b64enc.EncodeToString(buf)
+	return s, hashSecret(s)
+}
+
+func hashSecret(s string) []byte {
+	h := sha256.Sum256([]byte(s))
+	return h[:]
+}
+
+func secretMatches(s string, hash []byte) bool {
+	return subtle.ConstantTimeCompare(hashSecret(s), hash) == 1
+}
+
+// newRefreshToken returns a refresh token for the grant and the hash of its secret.
+func newRefreshToken(grantID uuid.UUID) (string, []byte) {
+	secret, hash := randomSecret()
+	return grantID.String() + "." + secret, hash
+}
+
+// parseRefreshToken returns the grant ID and secret of a refresh token.
+func parseRefreshToken(tok string) (uuid.UUID, string, error) {
+	idStr, secret, ok := strings.Cut(tok, ".")
+	if !ok || secret == "" {
+		return uuid.Nil, "", errors.New("malformed refresh token")
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	return id, secret, nil
+}
+
+// verifyPKCE checks the code verifier against an S256 code challenge.
+func verifyPKCE(challenge, verifier string) bool {
+	if !codeVerifierRx.MatchString(verifier) {
+		return false
+	}
+
+	h := sha256.Sum256([]byte(verifier))
+	return subtle.ConstantTimeCompare([]byte(b64enc.EncodeToString(h[:])), []byte(challenge)) == 1
+}
@@ -0,0 +1,52 @@
+package oauth
+
+import (
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPKCE(t *testing.T) {
+	verifier := strings.Repeat("a1-._~", 8)
+	h := sha256.Sum256([]byte(verifier))
+	challenge := b64enc.EncodeToString(h[:])
+	require.True(t, codeChallengeRx.MatchString(challenge))
+
+	assert.True(t, verifyPKCE(challenge, verifier))
+	assert.False(t, verifyPKCE(challenge, verifier+"x"), "wrong verifier")
+	assert.False(t, verifyPKCE(challenge, ""), "missing verifier")
+	assert.False(t, verifyPKCE(challenge[:10], verifier), "wrong challenge")
+}
+
+func TestRefreshToken(t *testing.T) {
+	id := uuid.New()
+	tok, hash := newRefreshToken(id)
+
+	gotID, secret, err := parseRefreshToken(tok)
+	require.NoError(t, err)
+	assert.Equal(t, id, gotID)
+	assert.True(t, secretMatches(secret, hash))
+	assert.False(t, secretMatches(secret+"x", hash))
+
+	_, _, err = parseRefreshToken(id.String())
+	assert.Error(t, err, "missing secret")
+	_, _, err = parseRefreshToken("foo.bar")
+	assert.Error(t, err, "invalid ID")
+}
+
+func TestIsAccessToken(t *testing.T) {
+	sign := func(c jwt.Claims) string {
+		tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte("secret"))
+		require.NoError(t, err)
+		return tok
+	}
+
+	assert.True(t, IsAccessToken(sign(newAccessClaims(uuid.New(), uuid.New(), uuid.New(), []string{ScopeRead}))))
+	assert.False(t, IsAccessToken(sign(jwt.RegisteredClaims{Audience: []string{"apikey-v1/graphql-v1"}})))
+	assert.False(t, IsAccessToken("not-a-jwt"))
+}
@@ -0,0 +1,223 @@
+// Package fairness reports on how on-call load is distributed between users.
+//
+// Load is calculated from the schedule shift history recorded by the engine
+// and from alert notifications sent to each user. Since both are subject to
+// the configured cleanup/retention settings, reports can only cover the
+// period for which that history is still available.
+package fairness
+
+import (
+	"sort"
+	"time"
+
+	"github.com/target/goalert/util/timeutil"
+)
+
+// Row contains the on-call load of a single user for a single period.
+type Row struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+
+	UserID   string
+	UserName string
+
+	// OnCall is the total time the user was on call for any schedule. Time
+	// spent on call for multiple schedules at once is only counted once.
+	OnCall time.Duration
+
+	// AfterHours is the portion of OnCall that falls on a weekday, outside of
+	// working hours.
+	AfterHours time.Duration
+
+	// Weekend is the portion of OnCall that falls on a Saturday or Sunday.
+	Weekend time.Duration
+
+	// Pages is the number of alerts the user was notified of.
+	Pages int
+}
+
+// Period is a single reporting interval.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+type shift struct {
+	UserID   string
+	UserName string
+	Start    time.Time
+	End      time.Time
+
+	// Loc is the time zone of the schedule the shift belongs to, used to
+	// determine weekends and working hours.
+	Loc *time.Location
+}
+
+type page struct {
+	UserID   string
+	UserName string
+	SentAt   time.Time
+}
+
+// periods splits the time between start and end into consecutive periods of
+// the given duration. The last period is truncated to end.
+func periods(start, end time.Time, dur timeutil.ISODuration) []Period {
+	var result []Period
+	for t := start; t.Before(end); {
+		next := dur.AddTo(t)
+		if next.After(end) {
+			next = end
+		}
+		result = append(result, Period{Start: t, End: next})
+		t = next
+	}
+
+	return result
+}
+
+// mergeShifts combines overlapping or adjacent shifts for the same user, so
+// that simultaneous shifts on different schedules are not counted twice. When
+// merging, the time zone of the earliest shift is kept.
+func mergeShifts(shifts []shift) []shift {
+	sorted := make([]shift, len(shifts))
+	copy(sorted, shifts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].UserID != sorted[j].UserID {
+			return sorted[i].UserID < sorted[j].UserID
+		}
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	var result []shift
+	for _, s := range sorted {
+		if !s.Start.Before(s.End) {
+			continue
+		}
+		if len(result) > 0 {
+			last := &result[len(result)-1]
+			if last.UserID == s.UserID && !s.Start.After(last.End) {
+				if s.End.After(last.End) {
+					last.End = s.End
+				}
+				continue
+			}
+		}
+		result = append(result, s)
+	}
+
+	return result
+}
+
+// classify returns the portion of the time between start and end that is
+// after-hours (weekdays, outside of the working hours) and on a weekend,
+// evaluated in the provided location.
+func classify(start, end time.Time, loc *time.Location, workStart, workEnd timeutil.Clock) (afterHours, weekend time.Duration) {
+	t := start.In(loc)
+	for t.Before(end) {
+		y, m, d := t.Date()
+		segEnd := time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		if segEnd.After(end) {
+			segEnd = end
+		}
+
+		switch t.Weekday() {
+		case time.Saturday, time.Sunday:
+			weekend += segEnd.Sub(t)
+		default:
+			ws := time.Date(y, m, d, workStart.Hour(), workStart.Minute(), 0, 0, loc)
+			we := time.Date(y, m, d, workEnd.Hour(), workEnd.Minute(), 0, 0, loc)
+			afterHours += segEnd.Sub(t) - overlap(t, segEnd, ws, we)
+		}
+
+		t = segEnd
+	}
+
+	return afterHours, weekend
+}
+
+// overlap returns the length of the intersection of [aStart, aEnd) and [bStart, bEnd).
+func overlap(aStart, aEnd, bStart, bEnd time.Time) time.Duration {
+	if bStart.After(aStart) {
+		aStart = bStart
+	}
+	if bEnd.Before(aEnd) {
+		aEnd = bEnd
+	}
+	if !aStart.Before(aEnd) {
+		return 0
+	}
+
+	return aEnd.Sub(aStart)
+}
+
+// build aggregates shifts and pages into per-user rows for each period,
+// ordered by period and then user name.
+func build(opts Opts, shifts []shift, pages []page) []Row {
+	pers := periods(opts.Start, opts.End, opts.Period)
+
+	type key struct {
+		period int
+		userID string
+	}
+	rows := make(map[key]*Row)
+	get := func(p int, userID, userName string) *Row {
+		k := key{period: p, userID: userID}
+		r := rows[k]
+		if r == nil {
+			r = &Row{
+				PeriodStart: pers[p].Start,
+				PeriodEnd:   pers[p].End,
+				UserID:      userID,
+				UserName:    userName,
+			}
+			rows[k] = r
+		}
+		return r
+	}
+
+	for _, s := range mergeShifts(shifts) {
+		for i, p := range pers {
+			start, end := s.Start, s.End
+			if p.Start.After(start) {
+				start = p.Start
+			}
+			if p.End.Before(end) {
+				end = p.End
+			}
+			if !start.Before(end) {
+				continue
+			}
+
+			r := get(i, s.UserID, s.UserName)
+			r.OnCall += end.Sub(start)
+			ah, we := classify(start, end, s.Loc, opts.WorkdayStart, opts.WorkdayEnd)
+			r.AfterHours += ah
+			r.Weekend += we
+		}
+	}
+
+	for _, pg := range pages {
+		idx := sort.Search(len(pers), func(i int) bool { return pers[i].End.After(pg.SentAt) })
+		if idx == len(pers) || pg.SentAt.Before(pers[idx].Start) {
+			continue
+		}
+		get(idx, pg.UserID, pg.UserName).Pages++
+	}
+
+	result := make([]Row, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, *r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if !a.PeriodStart.Equal(b.PeriodStart) {
+			return a.PeriodStart.Before(b.PeriodStart)
+		}
+		if a.UserName != b.UserName {
+			return a.UserName < b.UserName
+		}
+		return a.UserID < b.UserID
+	})
+
+	return result
+}
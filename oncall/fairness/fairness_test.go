@@ -0,0 +1,115 @@
+package fairness
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestPeriods(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	p := periods(start, end, timeutil.ISODuration{WeekPart: 1})
+	require.Len(t, p, 3)
+	assert.Equal(t, start, p[0].Start)
+	assert.Equal(t, start.AddDate(0, 0, 7), p[0].End)
+	assert.Equal(t, start.AddDate(0, 0, 14), p[2].Start)
+	assert.Equal(t, end, p[2].End, "last period should be truncated")
+}
+
+func TestMergeShifts(t *testing.T) {
+	ts := func(h int) time.Time { return time.Date(2026, 1, 1, h, 0, 0, 0, time.UTC) }
+
+	merged := mergeShifts([]shift{
+		{UserID: "b", Start: ts(1), End: ts(2)},
+		{UserID: "a", Start: ts(3), End: ts(5)},
+		{UserID: "a", Start: ts(1), End: ts(4)},
+		{UserID: "a", Start: ts(5), End: ts(6)},
+		{UserID: "a", Start: ts(8), End: ts(9)},
+		{UserID: "a", Start: ts(10), End: ts(10)},
+	})
+
+	assert.Equal(t, []shift{
+		{UserID: "a", Start: ts(1), End: ts(6)},
+		{UserID: "a", Start: ts(8), End: ts(9)},
+		{UserID: "b", Start: ts(1), End: ts(2)},
+	}, merged)
+}
+
+func TestClassify(t *testing.T) {
+	nine, five := timeutil.NewClock(9, 0), timeutil.NewClock(17, 0)
+
+	// Fri 2026-01-02 12:00 UTC to Mon 2026-01-05 12:00 UTC
+	start := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 3)
+
+	ah, we := classify(start, end, time.UTC, nine, five)
+	assert.Equal(t, 7*time.Hour+9*time.Hour, ah, "Friday evening and Monday morning")
+	assert.Equal(t, 48*time.Hour, we)
+
+	// same instant, evaluated in a schedule 6 hours behind UTC
+	central := time.FixedZone("CST", -6*3600)
+	ah, we = classify(start, end, central, nine, five)
+	// local: Fri 06:00 to Mon 06:00
+	assert.Equal(t, 3*time.Hour+7*time.Hour+6*time.Hour, ah)
+	assert.Equal(t, 48*time.Hour, we)
+}
+
+func TestBuild(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2026, 1, d, h, 0, 0, 0, time.UTC) }
+	opts := Opts{
+		Start:        day(5, 0), // Monday
+		End:          day(19, 0),
+		Period:       timeutil.ISODuration{WeekPart: 1},
+		WorkdayStart: timeutil.NewClock(9, 0),
+		WorkdayEnd:   timeutil.NewClock(17, 0),
+	}
+
+	rows := build(opts,
+		[]shift{
+			// spans both periods, counted per-period
+			{UserID: "u1", UserName: "Bob", Start: day(11, 12), End: day(12, 12), Loc: time.UTC},
+			// overlapping shift on a second schedule is not double counted
+			{UserID: "u1", UserName: "Bob", Start: day(11, 18), End: day(12, 6), Loc: time.UTC},
+			{UserID: "u2", UserName: "Alice", Start: day(6, 9), End: day(6, 17), Loc: time.UTC},
+		},
+		[]page{
+			{UserID: "u1", UserName: "Bob", SentAt: day(12, 1)},
+			{UserID: "u1", UserName: "Bob", SentAt: day(12, 2)},
+			{UserID: "u3", UserName: "Carol", SentAt: day(7, 1)},
+			{UserID: "u3", UserName: "Carol", SentAt: day(19, 0)}, // outside of range
+		},
+	)
+
+	assert.Equal(t, []Row{
+		{PeriodStart: day(5, 0), PeriodEnd: day(12, 0), UserID: "u2", UserName: "Alice", OnCall: 8 * time.Hour},
+		{PeriodStart: day(5, 0), PeriodEnd: day(12, 0), UserID: "u1", UserName: "Bob", OnCall: 12 * time.Hour, Weekend: 12 * time.Hour},
+		{PeriodStart: day(5, 0), PeriodEnd: day(12, 0), UserID: "u3", UserName: "Carol", Pages: 1},
+		{PeriodStart: day(12, 0), PeriodEnd: day(19, 0), UserID: "u1", UserName: "Bob", OnCall: 12 * time.Hour, AfterHours: 9 * time.Hour, Pages: 2},
+	}, rows)
+}
+
+func TestOpts_Normalize(t *testing.T) {
+	now := time.Date(2026, 1, 29, 0, 0, 0, 0, time.UTC)
+
+	opts, err := Opts{}.normalize(now)
+	require.NoError(t, err)
+	assert.Equal(t, now, opts.End)
+	assert.Equal(t, now.AddDate(0, 0, -28), opts.Start)
+	assert.Equal(t, timeutil.ISODuration{WeekPart: 1}, opts.Period)
+	assert.Equal(t, timeutil.NewClock(9, 0), opts.WorkdayStart)
+	assert.Equal(t, timeutil.NewClock(17, 0), opts.WorkdayEnd)
+
+	_, err = Opts{Start: now, End: now}.normalize(now)
+	assert.Error(t, err, "empty range")
+
+	_, err = Opts{End: now, Period: timeutil.ISODuration{HourPart: 1}}.normalize(now)
+	assert.Error(t, err, "too many periods")
+
+	_, err = Opts{WorkdayStart: timeutil.NewClock(17, 0), WorkdayEnd: timeutil.NewClock(9, 0)}.normalize(now)
+	assert.Error(t, err, "inverted working hours")
+}
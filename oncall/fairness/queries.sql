@@ -0,0 +1,51 @@
+-- name: FairnessShifts :many
+-- FairnessShifts returns schedule shifts overlapping the given time range, clipped to it, along with the schedule time zone.
+SELECT
+    oc.user_id,
+    u.name AS user_name,
+    sched.time_zone,
+    greatest(oc.start_time, @start_time::timestamptz)::timestamptz AS start_time,
+    least(coalesce(oc.end_time, now()), @end_time::timestamptz)::timestamptz AS end_time
+FROM
+    schedule_on_call_users oc
+    JOIN schedules sched ON sched.id = oc.schedule_id
+    JOIN users u ON u.id = oc.user_id
+WHERE
+    tstzrange(oc.start_time, oc.end_time) && tstzrange(@start_time, @end_time)
+    AND (cardinality(@schedule_ids::uuid[]) = 0
+        OR oc.schedule_id = ANY (@schedule_ids::uuid[]))
+ORDER BY
+    oc.start_time;
+
+-- name: FairnessPages :many
+-- FairnessPages returns, for each alert a user was notified of, the time the first notification was delivered,
+-- if it falls within the given time range. Notifications for the same alert across contact methods, repeats,
+-- and bundles count once.
+SELECT
+    u.id AS user_id,
+    u.name AS user_name,
+    min(coalesce(om.sent_at, bundle.sent_at))::timestamptz AS sent_at
+FROM
+    outgoing_messages om
+    LEFT JOIN outgoing_messages bundle ON om.last_status = 'bundled'
+        AND bundle.id::text = om.status_details
+    JOIN users u ON u.id = om.user_id
+WHERE
+    om.message_type = 'alert_notification'
+    AND coalesce(om.sent_at, bundle.sent_at) < @end_time::timestamptz
+    AND (cardinality(@schedule_ids::uuid[]) = 0
+        OR om.user_id IN (
+            SELECT
+                oc.user_id
+            FROM
+                schedule_on_call_users oc
+            WHERE
+                oc.schedule_id = ANY (@schedule_ids::uuid[])
+                AND tstzrange(oc.start_time, oc.end_time) && tstzrange(@start_time, @end_time)))
+GROUP BY
+    u.id,
+    om.alert_id
+HAVING
+    min(coalesce(om.sent_at, bundle.sent_at)) >= @start_time::timestamptz
+ORDER BY
+    min(coalesce(om.sent_at, bundle.sent_at));
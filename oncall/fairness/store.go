@@ -0,0 +1,159 @@
+package fairness
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Limits on the size of a single report.
+const (
+	MaxPeriods     = 400
+	MaxScheduleIDs = 50
+	MaxRange       = 2 * 366 * 24 * time.Hour
+)
+
+// Opts configures a fairness report.
+type Opts struct {
+	// Start and End define the time range of the report. If End is zero, the
+	// current time is used. If Start is zero, it defaults to 4 weeks before End.
+	Start time.Time
+	End   time.Time
+
+	// Period is the length of each reporting interval, defaulting to 1 week.
+	Period timeutil.ISODuration
+
+	// ScheduleIDs limits the report to shifts from the given schedules, and to
+	// pages sent to users with shifts on them. If empty, all schedules are
+	// included.
+	ScheduleIDs []string
+
+	// WorkdayStart and WorkdayEnd define working hours in the time zone of
+	// each schedule. If both are zero, they default to 09:00-17:00.
+	WorkdayStart timeutil.Clock
+	WorkdayEnd   timeutil.Clock
+}
+
+// Store generates fairness reports.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore creates a new Store.
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+func (opts Opts) normalize(now time.Time) (Opts, error) {
+	if opts.End.IsZero() {
+		opts.End = now
+	}
+	if opts.Start.IsZero() {
+		opts.Start = opts.End.AddDate(0, 0, -28)
+	}
+	if opts.Period.IsZero() {
+		opts.Period = timeutil.ISODuration{WeekPart: 1}
+	}
+	if opts.WorkdayStart == 0 && opts.WorkdayEnd == 0 {
+		opts.WorkdayStart = timeutil.NewClock(9, 0)
+		opts.WorkdayEnd = timeutil.NewClock(17, 0)
+	}
+
+	if !opts.End.After(opts.Start) {
+		return opts, validation.NewFieldError("End", "must be after start")
+	}
+	if opts.End.Sub(opts.Start) > MaxRange {
+		return opts, validation.NewFieldError("End", "report range is too long")
+	}
+	if !opts.Period.AddTo(opts.Start).After(opts.Start) {
+		return opts, validation.NewFieldError("Period", "must be positive")
+	}
+	if opts.WorkdayStart >= opts.WorkdayEnd {
+		return opts, validation.NewFieldError("WorkdayEnd", "must be after workday start")
+	}
+	if len(periods(opts.Start, opts.End, opts.Period)) > MaxPeriods {
+		return opts, validation.NewFieldError("Period", fmt.Sprintf("too many periods, must not exceed %d", MaxPeriods))
+	}
+
+	return opts, nil
+}
+
+// Report calculates per-user on-call load for each period in the given range.
+// Only users that were on call or paged during a period are included.
+func (s *Store) Report(ctx context.Context, opts Opts) ([]Row, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err = opts.normalize(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	schedIDs, err := validate.ParseManyUUID("ScheduleIDs", opts.ScheduleIDs, MaxScheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	if schedIDs == nil {
+		// must be non-nil so it is passed as an empty array, not NULL
+		schedIDs = []uuid.UUID{}
+	}
+
+	q := gadb.New(s.db)
+	shiftRows, err := q.FairnessShifts(ctx, gadb.FairnessShiftsParams{
+		StartTime:   opts.Start,
+		EndTime:     opts.End,
+		ScheduleIds: schedIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch shifts: %w", err)
+	}
+	pageRows, err := q.FairnessPages(ctx, gadb.FairnessPagesParams{
+		StartTime:   opts.Start,
+		EndTime:     opts.End,
+		ScheduleIds: schedIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch pages: %w", err)
+	}
+
+	locs := make(map[string]*time.Location)
+	shifts := make([]shift, len(shiftRows))
+	for i, r := range shiftRows {
+		loc, ok := locs[r.TimeZone]
+		if !ok {
+			loc, err = util.LoadLocation(r.TimeZone)
+			if err != nil {
+				return nil, fmt.Errorf("load time zone '%s': %w", r.TimeZone, err)
+			}
+			locs[r.TimeZone] = loc
+		}
+		shifts[i] = shift{
+			UserID:   r.UserID.String(),
+			UserName: r.UserName,
+			Start:    r.StartTime,
+			End:      r.EndTime,
+			Loc:      loc,
+		}
+	}
+
+	pages := make([]page, len(pageRows))
+	for i, r := range pageRows {
+		pages[i] = page{
+			UserID:   r.UserID.String(),
+			UserName: r.UserName,
+			SentAt:   r.SentAt,
+		}
+	}
+
+	return build(opts, shifts, pages), nil
+}
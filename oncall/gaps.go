@@ -0,0 +1,58 @@
+package oncall
+
+import (
+	"sort"
+	"time"
+)
+
+// A Gap is a span of time where nobody is on call.
+type Gap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Gaps returns the spans of time between start and end that are not covered
+// by any of the provided shifts. Shifts with a zero End are treated as
+// continuing indefinitely.
+func Gaps(shifts []Shift, start, end time.Time) []Gap {
+	sorted := make([]Shift, len(shifts))
+	copy(sorted, shifts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var gaps []Gap
+	t := start
+	for _, s := range sorted {
+		if !t.Before(end) {
+			break
+		}
+		if s.Start.After(t) {
+			gapEnd := s.Start
+			if gapEnd.After(end) {
+				gapEnd = end
+			}
+			gaps = append(gaps, Gap{Start: t, End: gapEnd})
+		}
+		if s.End.IsZero() {
+			return gaps
+		}
+		if s.End.After(t) {
+			t = s.End
+		}
+	}
+	if t.Before(end) {
+		gaps = append(gaps, Gap{Start: t, End: end})
+	}
+
+	return gaps
+}
+
+// Overlaps returns true if the gap overlaps any of the provided gaps.
+func (g Gap) Overlaps(gaps []Gap) bool {
+	for _, o := range gaps {
+		if g.Start.Before(o.End) && o.Start.Before(g.End) {
+			return true
+		}
+	}
+
+	return false
+}
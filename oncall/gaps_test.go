@@ -0,0 +1,56 @@
+package oncall_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/oncall"
+)
+
+func TestGaps(t *testing.T) {
+	ts := func(h int) time.Time { return time.Date(2026, 1, 1, h, 0, 0, 0, time.UTC) }
+	start, end := ts(0), ts(12)
+
+	check := func(desc string, shifts []oncall.Shift, expected []oncall.Gap) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			assert.Equal(t, expected, oncall.Gaps(shifts, start, end))
+		})
+	}
+
+	check("none", nil, []oncall.Gap{{Start: ts(0), End: ts(12)}})
+	check("covered",
+		[]oncall.Shift{{UserID: "a", Start: ts(0), End: ts(12)}},
+		nil,
+	)
+	check("open-ended",
+		[]oncall.Shift{{UserID: "a", Start: ts(2)}},
+		[]oncall.Gap{{Start: ts(0), End: ts(2)}},
+	)
+	check("overlapping and unordered",
+		[]oncall.Shift{
+			{UserID: "b", Start: ts(7), End: ts(9)},
+			{UserID: "a", Start: ts(1), End: ts(4)},
+			{UserID: "c", Start: ts(3), End: ts(5)},
+		},
+		[]oncall.Gap{
+			{Start: ts(0), End: ts(1)},
+			{Start: ts(5), End: ts(7)},
+			{Start: ts(9), End: ts(12)},
+		},
+	)
+	check("beyond range",
+		[]oncall.Shift{{UserID: "a", Start: ts(0), End: ts(6)}, {UserID: "a", Start: ts(14), End: ts(16)}},
+		[]oncall.Gap{{Start: ts(6), End: ts(12)}},
+	)
+}
+
+func TestGap_Overlaps(t *testing.T) {
+	ts := func(h int) time.Time { return time.Date(2026, 1, 1, h, 0, 0, 0, time.UTC) }
+	g := oncall.Gap{Start: ts(2), End: ts(4)}
+
+	assert.True(t, g.Overlaps([]oncall.Gap{{Start: ts(3), End: ts(5)}}))
+	assert.False(t, g.Overlaps([]oncall.Gap{{Start: ts(4), End: ts(5)}}), "adjacent")
+	assert.False(t, g.Overlaps(nil))
+}
@@ -0,0 +1,106 @@
+package oncall
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// maxRotationShifts limits the number of shifts calculated for a single rotation.
+const maxRotationShifts = 10000
+
+// Shifts calculates the shifts of the rotation between start and end, starting
+// from the current position. Shifts are clipped to start and end, and a shift
+// still in progress at end is marked as truncated.
+func (r *ResolvedRotation) Shifts(start, end time.Time) []Shift {
+	if r == nil || len(r.Users) == 0 || !start.Before(end) {
+		return nil
+	}
+	if len(r.Users) == 1 {
+		return []Shift{{UserID: r.Users[0], Start: start, End: end, Truncated: true}}
+	}
+
+	var shifts []Shift
+	t := start
+	for i := 0; t.Before(end) && i < maxRotationShifts; i++ {
+		userID := r.UserID(t)
+		next := r.CurrentEnd
+		if !next.After(t) {
+			break
+		}
+
+		if n := len(shifts); n > 0 && shifts[n-1].UserID == userID {
+			shifts[n-1].End = next
+		} else {
+			shifts = append(shifts, Shift{UserID: userID, Start: t, End: next})
+		}
+		t = next
+	}
+	if n := len(shifts); n > 0 && shifts[n-1].End.After(end) {
+		shifts[n-1].End = end
+		shifts[n-1].Truncated = true
+	}
+
+	return shifts
+}
+
+// ShiftsByRotation will return the upcoming shifts of a rotation between start and end. Unlike
+// HistoryBySchedule, past shifts are not available, so start should not be before the current time.
+func (s *Store) ShiftsByRotation(ctx context.Context, rotationID string, start, end time.Time) ([]Shift, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("RotationID", rotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rot ResolvedRotation
+	var rotTZ string
+	err = s.rotInfo.QueryRowContext(ctx, rotationID).Scan(&rot.ID, &rot.Type, &rot.Start, &rot.ShiftLength, (*sqlutil.IntArray)(&rot.ShiftPattern), &rotTZ, &rot.CurrentIndex, &rot.CurrentStart)
+	if errors.Is(err, sql.ErrNoRows) {
+		// no participants
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	loc, err := util.LoadLocation(rotTZ)
+	if err != nil {
+		return nil, err
+	}
+	rot.Start = rot.Start.In(loc)
+
+	rows, err := s.rotParts.QueryContext(ctx, sqlutil.UUIDArray{rotationID})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rotID, userID string
+		var shiftLength int
+		err = rows.Scan(&rotID, &userID, &shiftLength)
+		if err != nil {
+			return nil, err
+		}
+		rot.Users = append(rot.Users, userID)
+		rot.ParticipantShiftLengths = append(rot.ParticipantShiftLengths, shiftLength)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	err = s.loadUnavailable(ctx, nil, map[string]*ResolvedRotation{rot.ID: &rot}, time.Now(), end)
+	if err != nil {
+		return nil, err
+	}
+
+	return rot.Shifts(start.Truncate(time.Minute), end.Truncate(time.Minute)), nil
+}
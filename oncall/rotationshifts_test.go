@@ -0,0 +1,62 @@
+package oncall
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/user/unavailability"
+)
+
+func TestResolvedRotation_Shifts(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2026, 1, d, h, 0, 0, 0, time.UTC) }
+
+	rot := &ResolvedRotation{
+		Rotation: rotation.Rotation{
+			Type:        rotation.TypeDaily,
+			ShiftLength: 1,
+			Start:       day(1, 9),
+		},
+		Users:        []string{"a", "b", "c"},
+		CurrentIndex: 1,
+		CurrentStart: day(5, 9),
+	}
+
+	assert.Equal(t, []Shift{
+		{UserID: "b", Start: day(5, 12), End: day(6, 9)},
+		{UserID: "c", Start: day(6, 9), End: day(7, 9)},
+		{UserID: "a", Start: day(7, 9), End: day(8, 9)},
+		{UserID: "b", Start: day(8, 9), End: day(8, 12), Truncated: true},
+	}, rot.Shifts(day(5, 12), day(8, 12)))
+
+	single := &ResolvedRotation{Rotation: rot.Rotation, Users: []string{"a"}}
+	assert.Equal(t, []Shift{
+		{UserID: "a", Start: day(5, 12), End: day(8, 12), Truncated: true},
+	}, single.Shifts(day(5, 12), day(8, 12)))
+
+	repeat := &ResolvedRotation{Rotation: rot.Rotation, Users: []string{"a", "a", "b"}, CurrentStart: day(5, 9)}
+	assert.Equal(t, []Shift{
+		{UserID: "a", Start: day(5, 12), End: day(7, 9)},
+		{UserID: "b", Start: day(7, 9), End: day(7, 12), Truncated: true},
+	}, repeat.Shifts(day(5, 12), day(7, 12)))
+
+	skip := &ResolvedRotation{
+		Rotation:     rot.Rotation,
+		Users:        []string{"a", "b", "c"},
+		CurrentIndex: 1,
+		CurrentStart: day(5, 9),
+		Unavailable: []unavailability.Window{
+			// c is out for part of their next shift, so a takes it and the rotation continues from there
+			{UserID: "c", Start: day(6, 18), End: day(6, 20)},
+		},
+	}
+	assert.Equal(t, []Shift{
+		{UserID: "b", Start: day(5, 12), End: day(6, 9)},
+		{UserID: "a", Start: day(6, 9), End: day(7, 9)},
+		{UserID: "b", Start: day(7, 9), End: day(8, 9)},
+		{UserID: "c", Start: day(8, 9), End: day(8, 12), Truncated: true},
+	}, skip.Shifts(day(5, 12), day(8, 12)))
+
+	assert.Nil(t, (&ResolvedRotation{Rotation: rot.Rotation}).Shifts(day(5, 12), day(8, 12)), "no participants")
+}
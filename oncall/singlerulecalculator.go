@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/schedule/holiday"
 )
 
 // SingleRuleCalculator will calculate the currently active user.
@@ -11,6 +12,7 @@ type SingleRuleCalculator struct {
 	*TimeIterator
 
 	act     *ActiveCalculator
+	hol     *ActiveCalculator
 	rot     *UserCalculator
 	loc     *time.Location
 	rule    ResolvedRule
@@ -50,6 +52,14 @@ func (t *TimeIterator) NewSingleRuleCalculator(loc *time.Location, rule Resolved
 	}
 	calc.act.Init()
 
+	if rule.HolidayMode != "" {
+		calc.hol = t.NewActiveCalculator()
+		for _, span := range holiday.Spans(rule.Holidays, loc) {
+			calc.hol.SetSpan(span.Start, span.End)
+		}
+		calc.hol.Init()
+	}
+
 	if rule.Rotation != nil {
 		calc.rot = t.NewUserCalculator()
 		switch len(rule.Rotation.Users) {
@@ -78,7 +88,11 @@ func (t *TimeIterator) NewSingleRuleCalculator(loc *time.Location, rule Resolved
 // Process implements the SubIterator.Process method.
 func (rCalc *SingleRuleCalculator) Process(int64) int64 {
 	var newUserID string
-	if rCalc.act.Active() {
+	active := rCalc.act.Active()
+	if rCalc.hol != nil {
+		active = active && rCalc.rule.HolidayMode.Allows(rCalc.hol.Active())
+	}
+	if active {
 		if rCalc.rot != nil {
 			usrs := rCalc.rot.ActiveUsers()
 			if len(usrs) > 0 {
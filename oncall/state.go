@@ -7,13 +7,18 @@ import (
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/holiday"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/user/unavailability"
 )
 
 type ResolvedRule struct {
 	rule.Rule
 	Rotation *ResolvedRotation
+
+	// Holidays are the holidays of the rule's calendar, if any.
+	Holidays []holiday.Holiday
 }
 type ResolvedRotation struct {
 	rotation.Rotation
@@ -21,6 +26,11 @@ type ResolvedRotation struct {
 	CurrentStart time.Time
 	CurrentEnd   time.Time
 	Users        []string
+
+	// Unavailable are the unavailability windows of the rotation's users. Participants
+	// unavailable for part of an upcoming shift are skipped, as the rotation manager will
+	// do at handoff.
+	Unavailable []unavailability.Window
 }
 
 type state struct {
@@ -57,11 +67,20 @@ func (r *ResolvedRotation) UserID(t time.Time) string {
 		r.CurrentStart = r.CurrentEnd
 		r.CurrentEnd = r.EndTime(r.CurrentStart)
 		r.CurrentIndex++
+		if pos, ok := r.ParticipantPosition(r.CurrentStart); ok {
+			r.CurrentIndex = pos
+		}
+		if len(r.Unavailable) > 0 {
+			r.CurrentIndex = unavailability.NextAvailable(r.CurrentIndex%len(r.Users), len(r.Users), r.isUnavailable)
+		}
 	}
 	for t.Before(r.CurrentStart) {
 		r.CurrentEnd = r.CurrentStart
 		r.CurrentStart = r.StartTime(r.CurrentStart.Add(-1))
 		r.CurrentIndex--
+		if pos, ok := r.ParticipantPosition(r.CurrentStart); ok {
+			r.CurrentIndex = pos
+		}
 	}
 	r.CurrentIndex %= len(r.Users)
 	if r.CurrentIndex < 0 {
@@ -70,8 +89,20 @@ func (r *ResolvedRotation) UserID(t time.Time) string {
 
 	return r.Users[r.CurrentIndex]
 }
+
+// isUnavailable returns true if the participant at pos is unavailable for part of the current shift.
+func (r *ResolvedRotation) isUnavailable(pos int) bool {
+	for _, w := range r.Unavailable {
+		if w.UserID == r.Users[pos] && w.Overlaps(r.CurrentStart, r.CurrentEnd) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (r ResolvedRule) UserID(t time.Time) string {
-	if !r.IsActive(t) {
+	if !r.IsActive(t) || !r.HolidayMode.Allows(holiday.Contains(r.Holidays, t)) {
 		return ""
 	}
 	switch r.Target.TargetType() {
@@ -7,6 +7,7 @@ import (
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/holiday"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/util/timeutil"
@@ -174,6 +175,41 @@ func TestResolvedRotation_UserID(t *testing.T) {
 	}
 }
 
+func TestResolvedRotation_UserID_Participant(t *testing.T) {
+	start := time.Date(2018, 4, 2, 8, 0, 0, 0, time.UTC)
+	rot := &ResolvedRotation{
+		Rotation: rotation.Rotation{
+			ID:                      "rot",
+			Type:                    rotation.TypeParticipant,
+			Start:                   start,
+			ShiftLength:             24,
+			ParticipantShiftLengths: []int{24, 72},
+		},
+		CurrentIndex: 1,
+		CurrentStart: start.AddDate(0, 0, 1),
+		Users:        []string{"a", "b"},
+	}
+
+	// cycle is a: 1 day, b: 3 days
+	for _, c := range []struct {
+		days int
+		id   string
+	}{
+		{3, "b"},
+		{4, "a"},
+		{5, "b"},
+		{7, "b"},
+		{8, "a"},
+		{2, "b"},
+		{0, "a"},
+	} {
+		id := rot.UserID(start.AddDate(0, 0, c.days))
+		if id != c.id {
+			t.Errorf("day %d: got '%s'; want '%s'", c.days, id, c.id)
+		}
+	}
+}
+
 func TestState_CalculateShifts(t *testing.T) {
 	check := func(name string, start, end time.Time, s *state, exp []Shift) {
 		t.Helper()
@@ -404,6 +440,53 @@ func TestState_CalculateShifts(t *testing.T) {
 		},
 	)
 
+	newYears := []holiday.Holiday{{
+		Name:  "New Year's Day",
+		Start: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	check("Holidays",
+		time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 1, 3, 0, 0, 0, 0, time.UTC),
+		&state{
+			loc: time.UTC,
+			rules: []ResolvedRule{
+				{
+					Rule: rule.Rule{
+						WeekdayFilter:     timeutil.WeekdayFilter{1, 1, 1, 1, 1, 1, 1},
+						Start:             timeutil.NewClock(8, 0),
+						End:               timeutil.NewClock(9, 0),
+						Target:            assignment.UserTarget("foobar"),
+						HolidayCalendarID: "cal",
+						HolidayMode:       holiday.ModeSuppress,
+					},
+					Holidays: newYears,
+				},
+				{
+					Rule: rule.Rule{
+						WeekdayFilter:     timeutil.WeekdayFilter{1, 1, 1, 1, 1, 1, 1},
+						Target:            assignment.UserTarget("binbaz"),
+						HolidayCalendarID: "cal",
+						HolidayMode:       holiday.ModeSubstitute,
+					},
+					Holidays: newYears,
+				},
+			},
+		},
+		[]Shift{
+			{
+				Start:  time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC),
+				UserID: "binbaz",
+			},
+			{
+				Start:  time.Date(2018, 1, 2, 8, 0, 0, 0, time.UTC),
+				End:    time.Date(2018, 1, 2, 9, 0, 0, 0, time.UTC),
+				UserID: "foobar",
+			},
+		},
+	)
+
 	check("ReplaceOverride",
 		time.Date(2018, 1, 1, 8, 0, 0, 0, time.UTC), // 8:00AM
 		time.Date(2018, 1, 1, 9, 0, 0, 0, time.UTC), // 9:00AM
@@ -12,7 +12,9 @@ import (
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/holiday"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/user/unavailability"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/sqlutil"
 	"github.com/target/goalert/validation/validate"
@@ -49,27 +51,37 @@ type Store struct {
 
 	onCallUsersSvc      *sql.Stmt
 	onCallUsersSchedule *sql.Stmt
+	onCallUsersTier     *sql.Stmt
 	schedOverrides      *sql.Stmt
 
 	schedOnCall *sql.Stmt
 	schedTZ     *sql.Stmt
-	schedRot    *sql.Stmt
-	rotParts    *sql.Stmt
+
+	tierOnCall   *sql.Stmt
+	tierInfo     *sql.Stmt
+	tierSchedRot *sql.Stmt
+	schedRot     *sql.Stmt
+	rotParts     *sql.Stmt
+	rotInfo      *sql.Stmt
 
 	ruleStore  *rule.Store
 	schedStore *schedule.Store
+	holStore   *holiday.Store
+	unavStore  *unavailability.Store
 
 	histLim chan struct{}
 }
 
 // NewStore will create a new DB, preparing required statements using the provided context.
-func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore *schedule.Store) (*Store, error) {
+func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore *schedule.Store, holStore *holiday.Store, unavStore *unavailability.Store) (*Store, error) {
 	p := &util.Prepare{DB: db, Ctx: ctx}
 
 	return &Store{
 		db:         db,
 		ruleStore:  ruleStore,
 		schedStore: schedStore,
+		holStore:   holStore,
+		unavStore:  unavStore,
 
 		histLim: make(chan struct{}, 3), // limit concurrent history queries to 3
 
@@ -101,6 +113,12 @@ func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore
 			JOIN users u ON u.id = s.user_id
 			WHERE s.schedule_id = $1 AND s.end_time IS NULL
 		`),
+		onCallUsersTier: p.P(`
+			SELECT s.user_id, u.name
+			FROM schedule_tier_on_call_users s
+			JOIN users u ON u.id = s.user_id
+			WHERE s.schedule_id = $1 AND s.tier = $2 AND s.end_time IS NULL
+		`),
 		schedOnCall: p.P(`
 			select
 				user_id,
@@ -113,26 +131,76 @@ func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore
 				(end_time isnull or (end_time - start_time) > '1 minute'::interval)
 		`),
 		schedTZ: p.P(`select time_zone, now() from schedules where id = $1`),
+		tierOnCall: p.P(`
+			select
+				user_id,
+				start_time,
+				end_time
+			from schedule_tier_on_call_users
+			where
+				schedule_id = $1 and
+				tier = $2 and
+				tstzrange($3, $4) && tstzrange(start_time, end_time) and
+				(end_time isnull or (end_time - start_time) > '1 minute'::interval)
+		`),
+		tierInfo: p.P(`
+			select sched.time_zone, tier.rotation_offset, now()
+			from schedule_tiers tier
+			join schedules sched on sched.id = tier.schedule_id
+			where tier.schedule_id = $1 and tier.name = $2
+		`),
+		tierSchedRot: p.P(`
+			select distinct
+				rot.id,
+				rot.type,
+				rot.start_time,
+				rot.shift_length,
+				rot.shift_pattern,
+				rot.time_zone,
+				state.position,
+				state.shift_start
+			from schedule_rules rule
+			join rotations rot on rot.id = rule.tgt_rotation_id
+			join rotation_state state on state.rotation_id = rule.tgt_rotation_id
+			where rule.schedule_id = $1 and rule.tgt_rotation_id notnull and (rule.tier isnull or rule.tier = $2)
+		`),
 		schedRot: p.P(`
 			select distinct
 				rot.id,
 				rot.type,
 				rot.start_time,
 				rot.shift_length,
+				rot.shift_pattern,
 				rot.time_zone,
 				state.position,
 				state.shift_start
 			from schedule_rules rule
 			join rotations rot on rot.id = rule.tgt_rotation_id
 			join rotation_state state on state.rotation_id = rule.tgt_rotation_id
-			where rule.schedule_id = $1 and rule.tgt_rotation_id notnull
+			where rule.schedule_id = $1 and rule.tgt_rotation_id notnull and rule.tier isnull
+		`),
+		rotInfo: p.P(`
+			select
+				rot.id,
+				rot.type,
+				rot.start_time,
+				rot.shift_length,
+				rot.shift_pattern,
+				rot.time_zone,
+				state.position,
+				state.shift_start
+			from rotations rot
+			join rotation_state state on state.rotation_id = rot.id
+			where rot.id = $1
 		`),
 		rotParts: p.P(`
 			select
-				rotation_id,
-				user_id
-			from rotation_participants
-			where rotation_id = any($1)
+				part.rotation_id,
+				part.user_id,
+				coalesce(part.shift_length, rot.shift_length)
+			from rotation_participants part
+			join rotations rot on rot.id = part.rotation_id
+			where part.rotation_id = any($1)
 			order by
 				rotation_id,
 				position
@@ -196,8 +264,8 @@ func (s *Store) OnCallUsersBySchedule(ctx context.Context, scheduleID string) ([
 	return result, nil
 }
 
-// HistoryBySchedule will return the list of shifts that overlap the start and end time for the given schedule.
-func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start, end time.Time) ([]Shift, error) {
+// OnCallUsersByScheduleTier will return the current set of users who are on-call for the given schedule tier.
+func (s *Store) OnCallUsersByScheduleTier(ctx context.Context, scheduleID, tier string) ([]ScheduleOnCallUser, error) {
 	err := permission.LimitCheckAny(ctx, permission.User)
 	if err != nil {
 		return nil, err
@@ -206,34 +274,70 @@ func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start,
 	if err != nil {
 		return nil, err
 	}
+	rows, err := s.onCallUsersTier.QueryContext(ctx, scheduleID, tier)
+	if err != nil {
+		return nil, fmt.Errorf("fetch on-call users for schedule '%s' tier '%s': %w", scheduleID, tier, err)
+	}
+	defer rows.Close()
 
-	// Since this operation is expensive, and holds open a transaction for a long time,
-	// for several queries, we limit the number of concurrent operations to prevent
-	// exhausting the database connection pool.
-	select {
-	case s.histLim <- struct{}{}:
-		defer func() { <-s.histLim }()
-	case <-ctx.Done():
-		return nil, ctx.Err()
+	var result []ScheduleOnCallUser
+	for rows.Next() {
+		var u ScheduleOnCallUser
+		err = rows.Scan(&u.ID, &u.Name)
+		if err != nil {
+			return nil, fmt.Errorf("scan on-call user entry #%d for schedule '%s' tier '%s': %w", len(result), scheduleID, tier, err)
+		}
+
+		result = append(result, u)
 	}
 
-	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{
-		ReadOnly:  true,
-		Isolation: sql.LevelRepeatableRead,
-	})
-	if err != nil {
-		return nil, errors.Wrap(err, "begin transaction")
+	return result, nil
+}
+
+// loadUnavailable will populate the unavailability windows of the rotations' users
+// that could affect shifts after now, up to the shift in progress at end.
+func (s *Store) loadUnavailable(ctx context.Context, tx *sql.Tx, rots map[string]*ResolvedRotation, now, end time.Time) error {
+	if !end.After(now) {
+		// past shifts come from history, so skips do not need to be calculated
+		return nil
 	}
-	defer sqlutil.Rollback(ctx, "oncall: fetch schedule history", tx)
 
-	var schedTZ string
-	var now time.Time
-	err = tx.StmtContext(ctx, s.schedTZ).QueryRowContext(ctx, scheduleID).Scan(&schedTZ, &now)
+	var userIDs []string
+	seen := make(map[string]bool)
+	maxEnd := end
+	for _, rot := range rots {
+		if len(rot.Users) < 2 {
+			continue
+		}
+		for _, id := range rot.Users {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			userIDs = append(userIDs, id)
+		}
+		if e := rot.EndTime(end); e.After(maxEnd) {
+			maxEnd = e
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	windows, err := s.unavStore.FindManyTx(ctx, tx, userIDs, now, maxEnd)
 	if err != nil {
-		return nil, errors.Wrap(err, "lookup schedule time zone")
+		return err
+	}
+	for _, rot := range rots {
+		rot.Unavailable = windows
 	}
 
-	rows, err := tx.StmtContext(ctx, s.schedRot).QueryContext(ctx, scheduleID)
+	return nil
+}
+
+// loadRotations will load the rotations (with participants and unavailability) returned by stmt, by ID.
+func (s *Store) loadRotations(ctx context.Context, tx *sql.Tx, stmt *sql.Stmt, now, end time.Time, args ...interface{}) (map[string]*ResolvedRotation, error) {
+	rows, err := tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "lookup schedule rotations")
 	}
@@ -243,7 +347,7 @@ func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start,
 	for rows.Next() {
 		var rot ResolvedRotation
 		var rotTZ string
-		err = rows.Scan(&rot.ID, &rot.Type, &rot.Start, &rot.ShiftLength, &rotTZ, &rot.CurrentIndex, &rot.CurrentStart)
+		err = rows.Scan(&rot.ID, &rot.Type, &rot.Start, &rot.ShiftLength, (*sqlutil.IntArray)(&rot.ShiftPattern), &rotTZ, &rot.CurrentIndex, &rot.CurrentStart)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan rotation info")
 		}
@@ -263,31 +367,111 @@ func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start,
 	defer rows.Close()
 	for rows.Next() {
 		var rotID, userID string
-		err = rows.Scan(&rotID, &userID)
+		var shiftLength int
+		err = rows.Scan(&rotID, &userID, &shiftLength)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan rotation participant info")
 		}
 		rots[rotID].Users = append(rots[rotID].Users, userID)
+		rots[rotID].ParticipantShiftLengths = append(rots[rotID].ParticipantShiftLengths, shiftLength)
 	}
 
-	rawRules, err := s.ruleStore.FindAllTx(ctx, tx, scheduleID)
+	err = s.loadUnavailable(ctx, tx, rots, now, end)
 	if err != nil {
-		return nil, errors.Wrap(err, "lookup schedule rules")
+		return nil, errors.Wrap(err, "lookup unavailability")
+	}
+
+	return rots, nil
+}
+
+// resolveRules will resolve the rotation and holidays of each rule.
+func (s *Store) resolveRules(ctx context.Context, tx *sql.Tx, rawRules []rule.Rule, rots map[string]*ResolvedRotation, now, start, end time.Time) ([]ResolvedRule, error) {
+	var calIDs []uuid.UUID
+	for _, r := range rawRules {
+		if r.HolidayCalendarID == "" {
+			continue
+		}
+		calIDs = append(calIDs, uuid.MustParse(r.HolidayCalendarID))
+	}
+	// holiday dates are local to the schedule, so include an extra day on either side
+	holStart := start
+	if now.Before(holStart) {
+		holStart = now
+	}
+	holidays, err := s.holStore.FindByCalendarsTx(ctx, tx, calIDs, holStart.AddDate(0, 0, -1), end.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup holidays")
+	}
+	holsByCal := make(map[string][]holiday.Holiday)
+	for _, h := range holidays {
+		holsByCal[h.CalendarID.String()] = append(holsByCal[h.CalendarID.String()], h)
 	}
 
 	var rules []ResolvedRule
 	for _, r := range rawRules {
+		res := ResolvedRule{Rule: r, Holidays: holsByCal[r.HolidayCalendarID]}
 		if r.Target.TargetType() == assignment.TargetTypeRotation {
-			rules = append(rules, ResolvedRule{
-				Rule:     r,
-				Rotation: rots[r.Target.TargetID()],
-			})
-		} else {
-			rules = append(rules, ResolvedRule{Rule: r})
+			res.Rotation = rots[r.Target.TargetID()]
 		}
+		rules = append(rules, res)
+	}
+
+	return rules, nil
+}
+
+// HistoryBySchedule will return the list of shifts that overlap the start and end time for the given schedule.
+func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start, end time.Time) ([]Shift, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ScheduleID", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Since this operation is expensive, and holds open a transaction for a long time,
+	// for several queries, we limit the number of concurrent operations to prevent
+	// exhausting the database connection pool.
+	select {
+	case s.histLim <- struct{}{}:
+		defer func() { <-s.histLim }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{
+		ReadOnly:  true,
+		Isolation: sql.LevelRepeatableRead,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "begin transaction")
+	}
+	defer sqlutil.Rollback(ctx, "oncall: fetch schedule history", tx)
+
+	var schedTZ string
+	var now time.Time
+	err = tx.StmtContext(ctx, s.schedTZ).QueryRowContext(ctx, scheduleID).Scan(&schedTZ, &now)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup schedule time zone")
+	}
+
+	rots, err := s.loadRotations(ctx, tx, s.schedRot, now, end, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	rawRules, err := s.ruleStore.FindAllTx(ctx, tx, scheduleID)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup schedule rules")
+	}
+
+	rules, err := s.resolveRules(ctx, tx, rawRules, rots, now, start, end)
+	if err != nil {
+		return nil, err
 	}
 
-	rows, err = tx.StmtContext(ctx, s.schedOnCall).QueryContext(ctx, scheduleID, start, end)
+	rows, err := tx.StmtContext(ctx, s.schedOnCall).QueryContext(ctx, scheduleID, start, end)
 	if err != nil {
 		return nil, errors.Wrap(err, "lookup on-call history")
 	}
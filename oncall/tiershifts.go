@@ -0,0 +1,144 @@
+package oncall
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// offsetRotation returns a copy of rot with each participant replaced by the one offset positions after it.
+func offsetRotation(rot *ResolvedRotation, offset int) *ResolvedRotation {
+	cpy := *rot
+	cpy.Users = make([]string, len(rot.Users))
+	for i := range rot.Users {
+		cpy.Users[i] = rot.Users[(i+offset)%len(rot.Users)]
+	}
+
+	return &cpy
+}
+
+// HistoryByScheduleTier will return the list of shifts that overlap the start and end time for the given schedule tier.
+//
+// Overrides and temporary schedules only apply to the primary tier, so only the tier's rules (and primary
+// rotations, if the tier is offset from them) are used.
+func (s *Store) HistoryByScheduleTier(ctx context.Context, scheduleID, tier string, start, end time.Time) ([]Shift, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.Many(
+		validate.UUID("ScheduleID", scheduleID),
+		validate.Text("Tier", tier, 1, 32),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.histLim <- struct{}{}:
+		defer func() { <-s.histLim }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{
+		ReadOnly:  true,
+		Isolation: sql.LevelRepeatableRead,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "begin transaction")
+	}
+	defer sqlutil.Rollback(ctx, "oncall: fetch schedule tier history", tx)
+
+	var schedTZ string
+	var offset int
+	var now time.Time
+	err = tx.StmtContext(ctx, s.tierInfo).QueryRowContext(ctx, scheduleID, tier).Scan(&schedTZ, &offset, &now)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("Tier", "not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup schedule tier")
+	}
+
+	rots, err := s.loadRotations(ctx, tx, s.tierSchedRot, now, end, scheduleID, tier)
+	if err != nil {
+		return nil, err
+	}
+
+	rawRules, err := s.ruleStore.FindAllByTierTx(ctx, tx, scheduleID, tier)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup schedule tier rules")
+	}
+	if offset > 0 {
+		primary, err := s.ruleStore.FindAllTx(ctx, tx, scheduleID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup schedule rules")
+		}
+		for _, r := range primary {
+			if r.Target.TargetType() == assignment.TargetTypeRotation {
+				rawRules = append(rawRules, r)
+			}
+		}
+	}
+
+	rules, err := s.resolveRules(ctx, tx, rawRules, rots, now, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// the tier follows the primary rotations, offset by the configured number of participants
+	offsetRots := make(map[string]*ResolvedRotation)
+	for i, r := range rules {
+		if r.Tier != "" || r.Rotation == nil || len(r.Rotation.Users) == 0 {
+			continue
+		}
+		if offsetRots[r.Rotation.ID] == nil {
+			offsetRots[r.Rotation.ID] = offsetRotation(r.Rotation, offset)
+		}
+		rules[i].Rotation = offsetRots[r.Rotation.ID]
+	}
+
+	rows, err := tx.StmtContext(ctx, s.tierOnCall).QueryContext(ctx, scheduleID, tier, start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup on-call history")
+	}
+	defer rows.Close()
+	var userHistory []Shift
+	for rows.Next() {
+		var s Shift
+		var end sqlutil.NullTime
+		err = rows.Scan(&s.UserID, &s.Start, &end)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan on-call history info")
+		}
+		s.End = end.Time
+		userHistory = append(userHistory, s)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		// Can't use the data we read (e.g. serialization error)
+		return nil, errors.Wrap(err, "commit tx")
+	}
+	tz, err := util.LoadLocation(schedTZ)
+	if err != nil {
+		return nil, errors.Wrap(err, "load time zone info")
+	}
+	st := state{
+		rules:   rules,
+		history: userHistory,
+		now:     now,
+		loc:     tz,
+	}
+
+	return st.CalculateShifts(start, end), nil
+}
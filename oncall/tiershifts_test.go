@@ -0,0 +1,32 @@
+package oncall
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/schedule/rotation"
+)
+
+func TestOffsetRotation(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2026, 1, d, h, 0, 0, 0, time.UTC) }
+
+	rot := &ResolvedRotation{
+		Rotation: rotation.Rotation{
+			Type:        rotation.TypeDaily,
+			ShiftLength: 1,
+			Start:       day(1, 9),
+		},
+		Users:        []string{"a", "b", "c"},
+		CurrentIndex: 1,
+		CurrentStart: day(5, 9),
+	}
+
+	off := offsetRotation(rot, 1)
+	assert.Equal(t, []string{"a", "b", "c"}, rot.Users, "original should be unchanged")
+	assert.Equal(t, "c", off.UserID(day(5, 12)), "should be one participant after the primary")
+	assert.Equal(t, "a", off.UserID(day(6, 12)))
+	assert.Equal(t, "b", rot.UserID(day(5, 12)), "primary should be unaffected")
+
+	assert.Equal(t, []string{"a", "b", "c"}, offsetRotation(rot, 3).Users, "should wrap around")
+}
@@ -16,6 +16,10 @@ type UserOverride struct {
 	Start        time.Time `json:"start_time,omitempty"`
 	End          time.Time `json:"end_time,omitempty"`
 	Target       assignment.Target
+
+	// UnavailabilityID is set if the override was generated automatically because
+	// the removed user was unavailable for their rotation shift.
+	UnavailabilityID string `json:"unavailability_id,omitempty"`
 }
 
 const debugTimeFmt = "MonJan2_2006@3:04pm"
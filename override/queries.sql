@@ -15,7 +15,8 @@ SELECT
     o.end_time,
     add_user_id,
     remove_user_id,
-    tgt_schedule_id
+    tgt_schedule_id,
+    unavailability_id
 FROM
     user_overrides o
     LEFT JOIN AFTER ON TRUE
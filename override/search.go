@@ -114,6 +114,9 @@ func (s *Store) Search(ctx context.Context, db gadb.DBTX, opts *SearchOptions) (
 			RemoveUserID: rem,
 			Target:       assignment.ScheduleTarget(r.TgtScheduleID.String()),
 		}
+		if r.UnavailabilityID.Valid {
+			result[i].UnavailabilityID = r.UnavailabilityID.UUID.String()
+		}
 	}
 
 	return result, nil
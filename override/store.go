@@ -46,7 +46,8 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			remove_user_id,
 			start_time,
 			end_time,
-			tgt_schedule_id
+			tgt_schedule_id,
+			unavailability_id
 		from user_overrides
 		where id = $1
 		for update
@@ -58,7 +59,8 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				remove_user_id,
 				start_time,
 				end_time,
-				tgt_schedule_id
+				tgt_schedule_id,
+				unavailability_id
 			from user_overrides
 			where id = $1
 		`),
@@ -140,7 +142,7 @@ func (s *Store) FindOneUserOverrideTx(ctx context.Context, tx *sql.Tx, id string
 	}
 
 	var o UserOverride
-	var add, rem, schedTgt sql.NullString
+	var add, rem, schedTgt, unavailID sql.NullString
 	err = s.withTx(ctx, tx, func(tx *sql.Tx) error {
 		var row *sql.Row
 		if forUpdate {
@@ -149,7 +151,7 @@ func (s *Store) FindOneUserOverrideTx(ctx context.Context, tx *sql.Tx, id string
 			row = tx.StmtContext(ctx, s.findUO).QueryRowContext(ctx, id)
 		}
 
-		return row.Scan(&o.ID, &add, &rem, &o.Start, &o.End, &schedTgt)
+		return row.Scan(&o.ID, &add, &rem, &o.Start, &o.End, &schedTgt, &unavailID)
 	})
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
@@ -159,6 +161,7 @@ func (s *Store) FindOneUserOverrideTx(ctx context.Context, tx *sql.Tx, id string
 	}
 	o.AddUserID = add.String
 	o.RemoveUserID = rem.String
+	o.UnavailabilityID = unavailID.String
 	if schedTgt.Valid {
 		o.Target = assignment.ScheduleTarget(schedTgt.String)
 	}
@@ -32,6 +32,12 @@ const (
 
 	// SourceTypeUIK is set when a context is authorized for use of a universal integration key.
 	SourceTypeUIK
+
+	// SourceTypeSCIMAPIKey is set when a context is authorized for use of the SCIM provisioning API.
+	SourceTypeSCIMAPIKey
+
+	// SourceTypeOAuthToken is set when a context is authorized by an OAuth access token issued to a third-party application.
+	SourceTypeOAuthToken
 )
 
 // SourceInfo provides information about the source of a context's authorization.
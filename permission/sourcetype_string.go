@@ -17,11 +17,13 @@ func _() {
 	_ = x[SourceTypeCalendarSubscription-6]
 	_ = x[SourceTypeGQLAPIKey-7]
 	_ = x[SourceTypeUIK-8]
+	_ = x[SourceTypeSCIMAPIKey-9]
+	_ = x[SourceTypeOAuthToken-10]
 }
 
-const _SourceType_name = "SourceTypeNotificationCallbackSourceTypeIntegrationKeySourceTypeAuthProviderSourceTypeContactMethodSourceTypeHeartbeatSourceTypeNotificationChannelSourceTypeCalendarSubscriptionSourceTypeGQLAPIKeySourceTypeUIK"
+const _SourceType_name = "SourceTypeNotificationCallbackSourceTypeIntegrationKeySourceTypeAuthProviderSourceTypeContactMethodSourceTypeHeartbeatSourceTypeNotificationChannelSourceTypeCalendarSubscriptionSourceTypeGQLAPIKeySourceTypeUIKSourceTypeSCIMAPIKeySourceTypeOAuthToken"
 
-var _SourceType_index = [...]uint8{0, 30, 54, 76, 99, 118, 147, 177, 196, 209}
+var _SourceType_index = [...]uint8{0, 30, 54, 76, 99, 118, 147, 177, 196, 209, 229, 249}
 
 func (i SourceType) String() string {
 	if i < 0 || i >= SourceType(len(_SourceType_index)-1) {
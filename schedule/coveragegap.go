@@ -0,0 +1,40 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+)
+
+// A CoverageGap is an upcoming period of time where nobody is on call for a schedule.
+//
+// Gaps are detected periodically by the engine, looking ahead the number of
+// days configured by `Schedules.CoverageGapDays`.
+type CoverageGap struct {
+	Start      time.Time
+	End        time.Time
+	DetectedAt time.Time
+}
+
+// CoverageGaps will return the current and upcoming coverage gaps for the provided scheduleID.
+func (store *Store) CoverageGaps(ctx context.Context, scheduleID uuid.UUID) ([]CoverageGap, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(store.db).ScheduleCoverageGaps(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	gaps := make([]CoverageGap, len(rows))
+	for i, r := range rows {
+		gaps[i] = CoverageGap{Start: r.StartTime, End: r.EndTime, DetectedAt: r.DetectedAt}
+	}
+
+	return gaps, nil
+}
@@ -11,6 +11,7 @@ type Data struct {
 	V1 struct {
 		TemporarySchedules      []TemporarySchedule
 		OnCallNotificationRules []OnCallNotificationRule
+		HandoffReport           HandoffReportConfig
 	}
 }
 
@@ -0,0 +1,48 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+)
+
+// HandoffReportConfig configures the handoff reports sent each time the
+// on-call users of a schedule change. A report summarizes the alerts of the
+// previous shift.
+//
+// Reports are sent to notification channels by on-change
+// OnCallNotificationRules with HandoffReport set.
+type HandoffReportConfig struct {
+	// NotifyUsers will send the report to the outgoing and incoming users.
+	NotifyUsers bool
+}
+
+// HandoffReportConfig returns the handoff report configuration for the provided scheduleID.
+func (store *Store) HandoffReportConfig(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID) (*HandoffReportConfig, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := store.scheduleData(ctx, tx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &data.V1.HandoffReport, nil
+}
+
+// SetHandoffReportConfig will set the handoff report configuration for the provided scheduleID.
+func (store *Store) SetHandoffReportConfig(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, cfg HandoffReportConfig) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	return store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		data.V1.HandoffReport = cfg
+		return nil
+	})
+}
@@ -0,0 +1,128 @@
+// Package holiday implements admin-managed holiday calendars.
+//
+// Schedule rules may reference a calendar to either suppress their coverage
+// on holidays, or to provide substitute coverage only on holidays.
+package holiday
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+)
+
+// Mode determines how a schedule rule is affected by the holidays of its calendar.
+type Mode string
+
+// Possible holiday modes of a schedule rule.
+const (
+	// ModeSuppress indicates the rule is inactive on holidays.
+	ModeSuppress Mode = "suppress"
+
+	// ModeSubstitute indicates the rule is only active on holidays.
+	ModeSubstitute Mode = "substitute"
+)
+
+// Allows returns true if a rule using the mode may be active, given whether or not it is a holiday.
+//
+// An empty Mode always allows the rule to be active.
+func (m Mode) Allows(isHoliday bool) bool {
+	switch m {
+	case ModeSuppress:
+		return !isHoliday
+	case ModeSubstitute:
+		return isHoliday
+	}
+
+	return true
+}
+
+// Calendar is a named set of holidays.
+type Calendar struct {
+	ID          string
+	Name        string
+	Description string
+	CreatedAt   time.Time
+}
+
+// Holiday is one or more consecutive days on a calendar.
+type Holiday struct {
+	ID         uuid.UUID
+	CalendarID uuid.UUID
+	Name       string
+
+	// Start and End are the first and last days of the holiday, as midnight UTC.
+	Start time.Time
+	End   time.Time
+}
+
+// Span is a range of time covered by holidays.
+type Span struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Date returns the calendar date of t, in t's location, as midnight UTC.
+func Date(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func fromDB(h gadb.Holiday) Holiday {
+	return Holiday{
+		ID:         h.ID,
+		CalendarID: h.CalendarID,
+		Name:       h.Name,
+		Start:      Date(h.StartDate),
+		End:        Date(h.EndDate),
+	}
+}
+
+// Contains returns true if t falls on the holiday, using the date in t's location.
+func (h Holiday) Contains(t time.Time) bool {
+	d := Date(t)
+	return !d.Before(h.Start) && !d.After(h.End)
+}
+
+// Span returns the time range of the holiday, from midnight of the first day to midnight after the last, in loc.
+func (h Holiday) Span(loc *time.Location) Span {
+	return Span{
+		Start: time.Date(h.Start.Year(), h.Start.Month(), h.Start.Day(), 0, 0, 0, 0, loc),
+		End:   time.Date(h.End.Year(), h.End.Month(), h.End.Day()+1, 0, 0, 0, 0, loc),
+	}
+}
+
+// Contains returns true if t falls on any of the holidays, using the date in t's location.
+func Contains(holidays []Holiday, t time.Time) bool {
+	for _, h := range holidays {
+		if h.Contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Spans returns the time ranges covered by the holidays in loc, in order.
+// Overlapping and back-to-back holidays are merged into a single span.
+func Spans(holidays []Holiday, loc *time.Location) []Span {
+	spans := make([]Span, 0, len(holidays))
+	for _, h := range holidays {
+		spans = append(spans, h.Span(loc))
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Start.Before(spans[j].Start) })
+
+	var result []Span
+	for _, s := range spans {
+		if len(result) > 0 && !s.Start.After(result[len(result)-1].End) {
+			if s.End.After(result[len(result)-1].End) {
+				result[len(result)-1].End = s.End
+			}
+			continue
+		}
+		result = append(result, s)
+	}
+
+	return result
+}
@@ -0,0 +1,55 @@
+package holiday
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMode_Allows(t *testing.T) {
+	assert.True(t, Mode("").Allows(true))
+	assert.True(t, Mode("").Allows(false))
+	assert.False(t, ModeSuppress.Allows(true))
+	assert.True(t, ModeSuppress.Allows(false))
+	assert.True(t, ModeSubstitute.Allows(true))
+	assert.False(t, ModeSubstitute.Allows(false))
+}
+
+func TestHoliday_Contains(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	h := Holiday{
+		Start: time.Date(2020, 12, 24, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC),
+	}
+
+	assert.False(t, h.Contains(time.Date(2020, 12, 23, 23, 59, 0, 0, loc)))
+	assert.True(t, h.Contains(time.Date(2020, 12, 24, 0, 0, 0, 0, loc)))
+	assert.True(t, h.Contains(time.Date(2020, 12, 25, 23, 59, 0, 0, loc)))
+	assert.False(t, h.Contains(time.Date(2020, 12, 26, 0, 0, 0, 0, loc)))
+
+	// dates are evaluated in the location of t
+	assert.True(t, h.Contains(time.Date(2020, 12, 26, 3, 0, 0, 0, time.UTC).In(loc)))
+	assert.False(t, h.Contains(time.Date(2020, 12, 26, 3, 0, 0, 0, time.UTC)))
+}
+
+func TestSpans(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	day := func(d int) time.Time { return time.Date(2020, 12, d, 0, 0, 0, 0, time.UTC) }
+	spans := Spans([]Holiday{
+		{Start: day(31), End: day(31)},
+		{Start: day(24), End: day(24)},
+		{Start: day(25), End: day(26)}, // back-to-back with the 24th
+		{Start: day(26), End: day(26)}, // overlaps
+	}, loc)
+
+	assert.Equal(t, []Span{
+		{Start: time.Date(2020, 12, 24, 0, 0, 0, 0, loc), End: time.Date(2020, 12, 27, 0, 0, 0, 0, loc)},
+		{Start: time.Date(2020, 12, 31, 0, 0, 0, 0, loc), End: time.Date(2021, 1, 1, 0, 0, 0, 0, loc)},
+	}, spans)
+}
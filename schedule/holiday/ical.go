@@ -0,0 +1,198 @@
+package holiday
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxYearlyOccurrences limits how many times a single recurring event is expanded.
+const maxYearlyOccurrences = 100
+
+type icalEvent struct {
+	line int
+
+	Name      string
+	Start     time.Time
+	End       time.Time
+	Cancelled bool
+
+	Yearly   bool
+	Interval int
+	Count    int
+	Until    time.Time
+}
+
+// parseICalDate parses a DATE or DATE-TIME value, returning the date and
+// whether or not a time other than midnight was specified.
+func parseICalDate(value string) (time.Time, bool, error) {
+	if len(value) < 8 {
+		return time.Time{}, false, fmt.Errorf("invalid date '%s'", value)
+	}
+	d, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date '%s'", value)
+	}
+	hasTime := len(value) > 9 && strings.TrimRight(value[9:], "0Z") != ""
+
+	return d, hasTime, nil
+}
+
+func unescapeText(value string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
+
+func (e *icalEvent) setProp(name, value string) error {
+	var err error
+	switch name {
+	case "SUMMARY":
+		e.Name = strings.TrimSpace(unescapeText(value))
+	case "DTSTART":
+		e.Start, _, err = parseICalDate(value)
+	case "DTEND":
+		var hasTime bool
+		e.End, hasTime, err = parseICalDate(value)
+		if err == nil && !hasTime {
+			// all-day events end at the start of the following day
+			e.End = e.End.AddDate(0, 0, -1)
+		}
+	case "STATUS":
+		e.Cancelled = strings.EqualFold(value, "CANCELLED")
+	case "RRULE":
+		for _, part := range strings.Split(value, ";") {
+			key, val, _ := strings.Cut(part, "=")
+			switch strings.ToUpper(key) {
+			case "FREQ":
+				e.Yearly = strings.EqualFold(val, "YEARLY")
+			case "INTERVAL":
+				e.Interval, err = strconv.Atoi(val)
+			case "COUNT":
+				e.Count, err = strconv.Atoi(val)
+			case "UNTIL":
+				e.Until, _, err = parseICalDate(val)
+			}
+			if err != nil {
+				return fmt.Errorf("invalid RRULE '%s'", value)
+			}
+		}
+	}
+
+	return err
+}
+
+// holidays returns the occurrences of the event through the until date.
+func (e icalEvent) holidays(until time.Time) []Holiday {
+	if e.End.Before(e.Start) {
+		e.End = e.Start
+	}
+	h := Holiday{Name: e.Name, Start: e.Start, End: e.End}
+	if !e.Yearly {
+		return []Holiday{h}
+	}
+	if e.Interval < 1 {
+		e.Interval = 1
+	}
+	if !e.Until.IsZero() && e.Until.Before(until) {
+		until = e.Until
+	}
+
+	var result []Holiday
+	for i := 0; i < maxYearlyOccurrences; i++ {
+		if e.Count > 0 && i >= e.Count {
+			break
+		}
+		n := h
+		n.Start = h.Start.AddDate(i*e.Interval, 0, 0)
+		n.End = h.End.AddDate(i*e.Interval, 0, 0)
+		if n.Start.After(until) {
+			break
+		}
+		result = append(result, n)
+	}
+
+	return result
+}
+
+// ParseICal parses events from an iCalendar (RFC 5545) file, such as those
+// exported by calendar applications or published as public holiday feeds.
+// Each event is treated as a holiday covering the dates it spans.
+//
+// Yearly recurring events are expanded through the until date; other
+// recurrence rules are ignored and only the first occurrence is returned.
+// Cancelled events are skipped.
+func ParseICal(r io.Reader, until time.Time) ([]Holiday, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 4096), 1024*1024)
+
+	// unfold content lines, which may be wrapped with a leading space or tab
+	var lines []string
+	var lineNums []int
+	var n int
+	for sc.Scan() {
+		n++
+		line := strings.TrimRight(sc.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+		lineNums = append(lineNums, n)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read calendar: %w", err)
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("not an iCalendar file")
+	}
+
+	var result []Holiday
+	var cur *icalEvent
+	var depth int // nested components (e.g., VALARM) within the current event
+	for i, line := range lines {
+		nameParams, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: invalid content line", lineNums[i])
+		}
+		name, _, _ := strings.Cut(nameParams, ";")
+		name = strings.ToUpper(name)
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			cur = &icalEvent{line: lineNums[i]}
+			depth = 0
+		case cur != nil && name == "BEGIN":
+			depth++
+		case cur != nil && name == "END" && depth > 0:
+			depth--
+		case depth > 0:
+			// ignore properties of nested components
+		case name == "END" && strings.EqualFold(value, "VEVENT") && cur != nil:
+			if cur.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: event is missing DTSTART", cur.line)
+			}
+			if cur.End.IsZero() {
+				cur.End = cur.Start
+			}
+			if cur.Name == "" {
+				cur.Name = "Holiday"
+			}
+			if !cur.Cancelled {
+				result = append(result, cur.holidays(until)...)
+			}
+			cur = nil
+		case cur != nil:
+			err := cur.setProp(name, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNums[i], err)
+			}
+		}
+	}
+
+	return result, nil
+}
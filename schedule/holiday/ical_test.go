@@ -0,0 +1,63 @@
+package holiday
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseICal(t *testing.T) {
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20201224",
+		"DTEND;VALUE=DATE:20201226",
+		"SUMMARY:Winter\\, Break",
+		"BEGIN:VALARM",
+		"SUMMARY:Reminder",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20200704",
+		"RRULE:FREQ=YEARLY;COUNT=3",
+		"SUMMARY:Independence",
+		"  Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20201126T000000Z",
+		"SUMMARY:Thanksgiving",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20201231T090000Z",
+		"DTEND:20201231T170000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	day := func(y, m, d int) time.Time { return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC) }
+	hol, err := ParseICal(strings.NewReader(data), day(2030, 1, 1))
+	require.NoError(t, err)
+	assert.Equal(t, []Holiday{
+		{Name: "Winter, Break", Start: day(2020, 12, 24), End: day(2020, 12, 25)},
+		{Name: "Independence Day", Start: day(2020, 7, 4), End: day(2020, 7, 4)},
+		{Name: "Independence Day", Start: day(2021, 7, 4), End: day(2021, 7, 4)},
+		{Name: "Independence Day", Start: day(2022, 7, 4), End: day(2022, 7, 4)},
+		{Name: "Holiday", Start: day(2020, 12, 31), End: day(2020, 12, 31)},
+	}, hol)
+
+	// yearly events are limited by the until date
+	hol, err = ParseICal(strings.NewReader(strings.Replace(data, ";COUNT=3", "", 1)), day(2021, 12, 31))
+	require.NoError(t, err)
+	assert.Len(t, hol, 4)
+
+	_, err = ParseICal(strings.NewReader("not a calendar"), day(2030, 1, 1))
+	assert.Error(t, err)
+
+	_, err = ParseICal(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT\nEND:VCALENDAR"), day(2030, 1, 1))
+	assert.ErrorContains(t, err, "DTSTART")
+}
@@ -0,0 +1,57 @@
+-- name: HolidayCalendarInsert :exec
+INSERT INTO holiday_calendars(id, name, description)
+    VALUES ($1, $2, $3);
+
+-- name: HolidayCalendarUpdate :execrows
+UPDATE
+    holiday_calendars
+SET
+    name = $2,
+    description = $3
+WHERE
+    id = $1;
+
+-- name: HolidayCalendarDelete :execrows
+DELETE FROM holiday_calendars
+WHERE id = $1;
+
+-- name: HolidayCalendarFind :one
+SELECT
+    *
+FROM
+    holiday_calendars
+WHERE
+    id = $1;
+
+-- name: HolidayCalendarFindAll :many
+SELECT
+    *
+FROM
+    holiday_calendars
+ORDER BY
+    lower(name);
+
+-- name: HolidayInsert :execrows
+-- HolidayInsert adds a holiday, ignoring duplicates with the same name and start date.
+INSERT INTO holidays(id, calendar_id, name, start_date, end_date)
+    VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (calendar_id, start_date, name)
+    DO NOTHING;
+
+-- name: HolidayDelete :execrows
+DELETE FROM holidays
+WHERE id = $1;
+
+-- name: HolidayFindByCalendars :many
+-- HolidayFindByCalendars returns holidays from any of the calendars that overlap the given dates.
+SELECT
+    *
+FROM
+    holidays
+WHERE
+    calendar_id = ANY (@calendar_ids::uuid[])
+    AND end_date >= @start_date::date
+    AND start_date <= @end_date::date
+ORDER BY
+    start_date,
+    name;
@@ -0,0 +1,348 @@
+package holiday
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxImportHolidays is the maximum number of holidays that can be imported at once.
+const MaxImportHolidays = 1000
+
+// importYears is how far into the future yearly recurring events are expanded during import.
+const importYears = 5
+
+// Store manages holiday calendars.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore creates a new Store.
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+func (c Calendar) normalize() (*Calendar, error) {
+	err := validate.Many(
+		validate.Name("Name", c.Name),
+		validate.Text("Description", c.Description, 0, 255),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func (h Holiday) validate() error {
+	err := validate.Text("Name", h.Name, 1, 255)
+	if err != nil {
+		return err
+	}
+	if h.Start.IsZero() {
+		return validation.NewFieldError("Start", "is required")
+	}
+	if h.End.Before(h.Start) {
+		return validation.NewFieldError("End", "must not be before start")
+	}
+	if h.End.Sub(h.Start) > 366*24*time.Hour {
+		return validation.NewFieldError("End", "must be within a year of start")
+	}
+
+	return nil
+}
+
+// CreateCalendar will create a new, empty holiday calendar.
+func (s *Store) CreateCalendar(ctx context.Context, c Calendar) (*Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	n, err := c.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New()
+	err = gadb.New(s.db).HolidayCalendarInsert(ctx, gadb.HolidayCalendarInsertParams{
+		ID:          id,
+		Name:        n.Name,
+		Description: n.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	n.ID = id.String()
+	return n, nil
+}
+
+// UpdateCalendar will update the name and description of a holiday calendar.
+func (s *Store) UpdateCalendar(ctx context.Context, c Calendar) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+	id, err := validate.ParseUUID("ID", c.ID)
+	if err != nil {
+		return err
+	}
+	n, err := c.normalize()
+	if err != nil {
+		return err
+	}
+
+	count, err := gadb.New(s.db).HolidayCalendarUpdate(ctx, gadb.HolidayCalendarUpdateParams{
+		ID:          id,
+		Name:        n.Name,
+		Description: n.Description,
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return validation.NewFieldError("ID", "holiday calendar not found")
+	}
+
+	return nil
+}
+
+// DeleteCalendar will delete a holiday calendar and all of its holidays.
+// Calendars referenced by schedule rules cannot be deleted.
+func (s *Store) DeleteCalendar(ctx context.Context, id string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+	calID, err := validate.ParseUUID("ID", id)
+	if err != nil {
+		return err
+	}
+
+	_, err = gadb.New(s.db).HolidayCalendarDelete(ctx, calID)
+	return err
+}
+
+func calendarFromDB(c gadb.HolidayCalendar) Calendar {
+	return Calendar{
+		ID:          c.ID.String(),
+		Name:        c.Name,
+		Description: c.Description,
+		CreatedAt:   c.CreatedAt,
+	}
+}
+
+// FindOneCalendar returns the holiday calendar with the given ID.
+func (s *Store) FindOneCalendar(ctx context.Context, id string) (*Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	calID, err := validate.ParseUUID("ID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := gadb.New(s.db).HolidayCalendarFind(ctx, calID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("ID", "holiday calendar not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := calendarFromDB(row)
+	return &c, nil
+}
+
+// FindAllCalendars returns all holiday calendars, ordered by name.
+func (s *Store) FindAllCalendars(ctx context.Context) ([]Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).HolidayCalendarFindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Calendar, len(rows))
+	for i, r := range rows {
+		result[i] = calendarFromDB(r)
+	}
+
+	return result, nil
+}
+
+// FindHolidays returns the holidays of a calendar that overlap the given dates.
+func (s *Store) FindHolidays(ctx context.Context, calendarID string, start, end time.Time) ([]Holiday, error) {
+	id, err := validate.ParseUUID("CalendarID", calendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.FindByCalendarsTx(ctx, nil, []uuid.UUID{id}, start, end)
+}
+
+// FindByCalendarsTx returns holidays from any of the calendars that overlap the dates of start and end.
+func (s *Store) FindByCalendarsTx(ctx context.Context, tx *sql.Tx, calendarIDs []uuid.UUID, start, end time.Time) ([]Holiday, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	if len(calendarIDs) == 0 {
+		return nil, nil
+	}
+
+	var db gadb.DBTX = s.db
+	if tx != nil {
+		db = tx
+	}
+	rows, err := gadb.New(db).HolidayFindByCalendars(ctx, gadb.HolidayFindByCalendarsParams{
+		CalendarIds: calendarIDs,
+		StartDate:   Date(start),
+		EndDate:     Date(end),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Holiday, len(rows))
+	for i, r := range rows {
+		result[i] = fromDB(r)
+	}
+
+	return result, nil
+}
+
+func insertHoliday(ctx context.Context, q *gadb.Queries, calID uuid.UUID, h Holiday) (bool, error) {
+	n, err := q.HolidayInsert(ctx, gadb.HolidayInsertParams{
+		ID:         h.ID,
+		CalendarID: calID,
+		Name:       h.Name,
+		StartDate:  Date(h.Start),
+		EndDate:    Date(h.End),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// AddHoliday will add a holiday to a calendar. The Start and End dates are inclusive.
+func (s *Store) AddHoliday(ctx context.Context, calendarID string, h Holiday) (*Holiday, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	calID, err := validate.ParseUUID("CalendarID", calendarID)
+	if err != nil {
+		return nil, err
+	}
+	h.ID = uuid.New()
+	h.Start, h.End = Date(h.Start), Date(h.End)
+	err = h.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := insertHoliday(ctx, gadb.New(s.db), calID, h)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, validation.NewFieldError("Name", "holiday already exists on that date")
+	}
+
+	h.CalendarID = calID
+	return &h, nil
+}
+
+// DeleteHoliday will remove a holiday from its calendar.
+func (s *Store) DeleteHoliday(ctx context.Context, id string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+	holID, err := validate.ParseUUID("ID", id)
+	if err != nil {
+		return err
+	}
+
+	_, err = gadb.New(s.db).HolidayDelete(ctx, holID)
+	return err
+}
+
+// ImportICal will add the events of an iCalendar file to a calendar as holidays,
+// returning the number of holidays added. Events already on the calendar, with
+// the same name and start date, are skipped.
+func (s *Store) ImportICal(ctx context.Context, calendarID string, data []byte) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return 0, err
+	}
+	calID, err := validate.ParseUUID("CalendarID", calendarID)
+	if err != nil {
+		return 0, err
+	}
+
+	holidays, err := ParseICal(bytes.NewReader(data), time.Now().AddDate(importYears, 0, 0))
+	if err != nil {
+		return 0, validation.NewFieldError("Data", err.Error())
+	}
+	if len(holidays) > MaxImportHolidays {
+		return 0, validation.NewFieldError("Data", fmt.Sprintf("cannot import more than %d holidays at once", MaxImportHolidays))
+	}
+	for i, h := range holidays {
+		err = h.validate()
+		if err != nil {
+			return 0, validation.AddPrefix(fmt.Sprintf("Data[%d].", i), err)
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer sqlutil.Rollback(ctx, "holiday: import calendar", tx)
+
+	q := gadb.New(tx)
+	_, err = q.HolidayCalendarFind(ctx, calID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, validation.NewFieldError("CalendarID", "holiday calendar not found")
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var added int
+	for _, h := range holidays {
+		h.ID = uuid.New()
+		ok, err := insertHoliday(ctx, q, calID, h)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			added++
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return added, nil
+}
@@ -0,0 +1,156 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/util"
+)
+
+var emailRx = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+type icsEvent struct {
+	line int
+
+	Start, End time.Time
+	Attendee   string
+	Text       string
+	Cancelled  bool
+}
+
+// parseICSTime parses a DATE or DATE-TIME value, using the TZID parameter if
+// present. Floating times and dates are interpreted in loc.
+func parseICSTime(params []string, value string, loc *time.Location) (time.Time, error) {
+	for _, p := range params {
+		key, val, _ := strings.Cut(p, "=")
+		if !strings.EqualFold(key, "TZID") {
+			continue
+		}
+		var err error
+		loc, err = util.LoadLocation(strings.Trim(val, `"`))
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse("20060102T150405Z", value)
+	case len(value) == 8:
+		return time.ParseInLocation("20060102", value, loc)
+	default:
+		return time.ParseInLocation("20060102T150405", value, loc)
+	}
+}
+
+func (e *icsEvent) setProp(name string, params []string, value string, loc *time.Location) error {
+	var err error
+	switch name {
+	case "DTSTART":
+		e.Start, err = parseICSTime(params, value, loc)
+	case "DTEND":
+		e.End, err = parseICSTime(params, value, loc)
+	case "ATTENDEE":
+		if e.Attendee == "" && strings.HasPrefix(strings.ToLower(value), "mailto:") {
+			e.Attendee = value[len("mailto:"):]
+		}
+	case "SUMMARY", "DESCRIPTION":
+		e.Text += " " + value
+	case "STATUS":
+		e.Cancelled = strings.EqualFold(value, "CANCELLED")
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s '%s'", name, value)
+	}
+
+	return nil
+}
+
+func (e icsEvent) email() string {
+	if e.Attendee != "" {
+		return e.Attendee
+	}
+
+	return emailRx.FindString(e.Text)
+}
+
+// parseICS parses shifts from the events of an iCalendar (RFC 5545) file.
+// Recurrence rules are ignored, so only the first occurrence of a recurring
+// event is imported.
+func parseICS(data []byte, loc *time.Location) (*Import, error) {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 4096), 1024*1024)
+
+	// unfold content lines, which may be wrapped with a leading space or tab
+	var lines []string
+	var lineNums []int
+	var n int
+	for sc.Scan() {
+		n++
+		line := strings.TrimRight(sc.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+		lineNums = append(lineNums, n)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read calendar: %w", err)
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("not an iCalendar file")
+	}
+
+	var imp Import
+	var cur *icsEvent
+	var depth int // nested components (e.g., VALARM) within the current event
+	for i, line := range lines {
+		nameParams, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: invalid content line", lineNums[i])
+		}
+		params := strings.Split(nameParams, ";")
+		name := strings.ToUpper(params[0])
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			cur = &icsEvent{line: lineNums[i]}
+			depth = 0
+		case cur != nil && name == "BEGIN":
+			depth++
+		case cur != nil && name == "END" && depth > 0:
+			depth--
+		case depth > 0:
+			// ignore properties of nested components
+		case name == "END" && strings.EqualFold(value, "VEVENT") && cur != nil:
+			if cur.Cancelled {
+				cur = nil
+				continue
+			}
+			if cur.Start.IsZero() || cur.End.IsZero() {
+				return nil, fmt.Errorf("line %d: event must have DTSTART and DTEND", cur.line)
+			}
+			email := cur.email()
+			if email == "" {
+				return nil, fmt.Errorf("line %d: event has no attendee or email address", cur.line)
+			}
+			imp.Shifts = append(imp.Shifts, Shift{Email: email, Start: cur.Start, End: cur.End})
+			cur = nil
+		case cur != nil:
+			err := cur.setProp(name, params[1:], value, loc)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNums[i], err)
+			}
+		}
+	}
+
+	return &imp, nil
+}
@@ -0,0 +1,247 @@
+// Package importer reads schedules exported from calendars and other on-call
+// tools so they can be recreated in GoAlert.
+//
+// Two formats are supported:
+//
+// An iCalendar (ICS) feed, where each event is a shift. The on-call user is
+// identified by the email address of the first ATTENDEE, or, if there are no
+// attendees, by the first email address found in the SUMMARY or DESCRIPTION.
+//
+// A JSON document of the following form, where times are RFC3339 timestamps:
+//
+//	{
+//	  "shifts": [
+//	    {"email": "alice@example.com", "start": "2026-11-02T09:00:00Z", "end": "2026-11-09T09:00:00Z"}
+//	  ],
+//	  "rotations": [
+//	    {
+//	      "name": "Primary",
+//	      "description": "Weekly primary rotation",
+//	      "type": "weekly",
+//	      "shiftLength": 1,
+//	      "start": "2026-11-02T09:00:00Z",
+//	      "timeZone": "America/Chicago",
+//	      "participants": ["alice@example.com", "bob@example.com"]
+//	    }
+//	  ]
+//	}
+//
+// Shifts become temporary schedules, and each rotation is created along with
+// an always-active rule on the schedule.
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/validation"
+)
+
+// Limits on the size of a single import.
+const (
+	MaxShifts    = 2000
+	MaxRotations = 20
+)
+
+// Format is the format of the data being imported.
+type Format string
+
+// Supported formats.
+const (
+	FormatICS  Format = "ics"
+	FormatJSON Format = "json"
+)
+
+// A Shift is a single imported on-call shift.
+type Shift struct {
+	Email string
+
+	// UserID is the ID of the user matching Email, if one was found.
+	UserID     string
+	Start, End time.Time
+}
+
+// A Participant is an imported rotation participant.
+type Participant struct {
+	Email string
+
+	// UserID is the ID of the user matching Email, if one was found.
+	UserID string
+}
+
+// A Rotation is an imported rotation.
+type Rotation struct {
+	rotation.Rotation
+
+	Participants []Participant
+}
+
+// An Import is the result of parsing imported schedule data.
+type Import struct {
+	Shifts    []Shift
+	Rotations []Rotation
+}
+
+// Parse will parse data in the given format. Times without a time zone are
+// interpreted in loc.
+func Parse(format Format, data []byte, loc *time.Location) (*Import, error) {
+	var imp *Import
+	var err error
+	switch format {
+	case FormatICS:
+		imp, err = parseICS(data, loc)
+	case FormatJSON:
+		imp, err = parseJSON(data, loc)
+	default:
+		return nil, validation.NewFieldError("Format", "unsupported format")
+	}
+	if err != nil {
+		return nil, validation.NewFieldError("Data", err.Error())
+	}
+	if len(imp.Shifts) > MaxShifts {
+		return nil, validation.NewFieldError("Data", fmt.Sprintf("must not contain more than %d shifts", MaxShifts))
+	}
+	if len(imp.Rotations) > MaxRotations {
+		return nil, validation.NewFieldError("Data", fmt.Sprintf("must not contain more than %d rotations", MaxRotations))
+	}
+
+	for i, s := range imp.Shifts {
+		if !s.End.After(s.Start) {
+			return nil, validation.NewFieldError(fmt.Sprintf("Shifts[%d]", i), "must end after it starts")
+		}
+	}
+	for i, r := range imp.Rotations {
+		n, err := r.Normalize()
+		if err != nil {
+			return nil, validation.AddPrefix(fmt.Sprintf("Rotations[%d].", i), err)
+		}
+		imp.Rotations[i].Rotation = *n
+	}
+
+	sort.SliceStable(imp.Shifts, func(i, j int) bool { return imp.Shifts[i].Start.Before(imp.Shifts[j].Start) })
+
+	return imp, nil
+}
+
+// Emails returns the unique, lower-cased email addresses referenced by the import.
+func (imp *Import) Emails() []string {
+	m := make(map[string]struct{})
+	for _, s := range imp.Shifts {
+		m[strings.ToLower(s.Email)] = struct{}{}
+	}
+	for _, r := range imp.Rotations {
+		for _, p := range r.Participants {
+			m[strings.ToLower(p.Email)] = struct{}{}
+		}
+	}
+
+	result := make([]string, 0, len(m))
+	for e := range m {
+		result = append(result, e)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// MapUsers will set the UserID of each shift and participant to the user with
+// a matching email address, returning the sorted list of addresses that did
+// not match exactly one user.
+//
+// Email addresses are not unique, so an address shared by more than one user
+// is treated as unmatched rather than guessing which user was meant.
+func (imp *Import) MapUsers(users []user.User) []string {
+	byEmail := make(map[string]string, len(users))
+	for _, u := range users {
+		e := strings.ToLower(u.Email)
+		if _, ok := byEmail[e]; ok {
+			// ambiguous
+			byEmail[e] = ""
+			continue
+		}
+		byEmail[e] = u.ID
+	}
+
+	unmatched := make(map[string]struct{})
+	lookup := func(email string) string {
+		id := byEmail[strings.ToLower(email)]
+		if id == "" {
+			unmatched[strings.ToLower(email)] = struct{}{}
+		}
+		return id
+	}
+	for i, s := range imp.Shifts {
+		imp.Shifts[i].UserID = lookup(s.Email)
+	}
+	for _, r := range imp.Rotations {
+		for i, p := range r.Participants {
+			r.Participants[i].UserID = lookup(p.Email)
+		}
+	}
+
+	result := make([]string, 0, len(unmatched))
+	for e := range unmatched {
+		result = append(result, e)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// TemporarySchedules returns the imported shifts as temporary schedules. Shifts
+// that have already ended by now are dropped.
+//
+// Since a temporary schedule is limited in the number of shifts it may hold,
+// shifts are split into multiple back-to-back temporary schedules as needed.
+// Splits only happen between shifts that do not overlap.
+func (imp *Import) TemporarySchedules(now time.Time) ([]schedule.TemporarySchedule, error) {
+	var shifts []Shift
+	for _, s := range imp.Shifts {
+		if !s.End.After(now) {
+			continue
+		}
+		shifts = append(shifts, s)
+	}
+	if len(shifts) == 0 {
+		return nil, nil
+	}
+
+	var result []schedule.TemporarySchedule
+	for start := 0; start < len(shifts); {
+		var maxEnd time.Time
+		brk := -1
+		for i := start; i < len(shifts) && i-start < schedule.FixedShiftsPerTemporaryScheduleLimit; i++ {
+			if shifts[i].End.After(maxEnd) {
+				maxEnd = shifts[i].End
+			}
+			if i+1 == len(shifts) || !maxEnd.After(shifts[i+1].Start) {
+				brk = i + 1
+			}
+		}
+		if brk == -1 {
+			return nil, validation.NewFieldError("Data", fmt.Sprintf("too many overlapping shifts starting at %s", shifts[start].Start.Format(time.RFC3339)))
+		}
+
+		tmp := schedule.TemporarySchedule{Start: shifts[start].Start}
+		for _, s := range shifts[start:brk] {
+			tmp.Shifts = append(tmp.Shifts, schedule.FixedShift{Start: s.Start, End: s.End, UserID: s.UserID})
+			if s.End.After(tmp.End) {
+				tmp.End = s.End
+			}
+		}
+		if brk < len(shifts) {
+			// extend to the next temporary schedule so the imported range is
+			// contiguous, with gaps between shifts meaning nobody is on call
+			tmp.End = shifts[brk].Start
+		}
+		result = append(result, tmp)
+		start = brk
+	}
+
+	return result, nil
+}
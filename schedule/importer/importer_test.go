@@ -0,0 +1,151 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/user"
+)
+
+func TestParse_ICS(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	const data = "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20261102T150000Z\r\n" +
+		"DTEND:20261103T150000Z\r\n" +
+		"SUMMARY:On call\r\n" +
+		"ATTENDEE;CN=Alice:mailto:Alice@example.com\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"DESCRIPTION:reminder\r\n" +
+		"END:VALARM\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;TZID=America/New_York:20261101T090000\r\n" +
+		"DTEND;TZID=America/New_York:20261101T170000\r\n" +
+		"SUMMARY:On call: bob@exam\r\n" +
+		" ple.com\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20261104T090000\r\n" +
+		"DTEND:20261105T090000\r\n" +
+		"DESCRIPTION:carol@example.com\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20261106T090000\r\n" +
+		"DTEND:20261107T090000\r\n" +
+		"STATUS:CANCELLED\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	imp, err := Parse(FormatICS, []byte(data), loc)
+	require.NoError(t, err)
+
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	require.Len(t, imp.Shifts, 3)
+
+	// sorted by start time
+	assert.Equal(t, "bob@example.com", imp.Shifts[0].Email)
+	assert.True(t, imp.Shifts[0].Start.Equal(time.Date(2026, 11, 1, 9, 0, 0, 0, ny)))
+	assert.Equal(t, "Alice@example.com", imp.Shifts[1].Email)
+	assert.True(t, imp.Shifts[1].Start.Equal(time.Date(2026, 11, 2, 15, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "carol@example.com", imp.Shifts[2].Email)
+	assert.True(t, imp.Shifts[2].Start.Equal(time.Date(2026, 11, 4, 9, 0, 0, 0, loc)), "floating time in default location")
+
+	assert.Equal(t, []string{"alice@example.com", "bob@example.com", "carol@example.com"}, imp.Emails())
+
+	_, err = Parse(FormatICS, []byte("BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20261101T090000Z\nDTEND:20261101T100000Z\nEND:VEVENT\nEND:VCALENDAR\n"), loc)
+	assert.ErrorContains(t, err, "no attendee")
+
+	_, err = Parse(FormatICS, []byte("not a calendar"), loc)
+	assert.Error(t, err)
+}
+
+func TestParse_JSON(t *testing.T) {
+	const data = `{
+		"shifts": [{"email": "alice@example.com", "start": "2026-11-02T09:00:00Z", "end": "2026-11-09T09:00:00Z"}],
+		"rotations": [{
+			"name": "Primary",
+			"type": "weekly",
+			"start": "2026-11-02T09:00:00Z",
+			"timeZone": "America/Chicago",
+			"participants": ["alice@example.com", "Bob@example.com"]
+		}]
+	}`
+
+	imp, err := Parse(FormatJSON, []byte(data), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, imp.Shifts, 1)
+	require.Len(t, imp.Rotations, 1)
+
+	r := imp.Rotations[0]
+	assert.Equal(t, "Primary", r.Name)
+	assert.Equal(t, rotation.TypeWeekly, r.Type)
+	assert.Equal(t, 1, r.ShiftLength, "default shift length")
+	assert.Equal(t, "America/Chicago", r.Start.Location().String())
+	assert.Equal(t, []Participant{{Email: "alice@example.com"}, {Email: "Bob@example.com"}}, r.Participants)
+
+	unmatched := imp.MapUsers([]user.User{{ID: "a", Email: "ALICE@example.com"}})
+	assert.Equal(t, []string{"bob@example.com"}, unmatched)
+	assert.Equal(t, "a", imp.Shifts[0].UserID)
+	assert.Equal(t, "a", imp.Rotations[0].Participants[0].UserID)
+	assert.Empty(t, imp.Rotations[0].Participants[1].UserID)
+
+	unmatched = imp.MapUsers([]user.User{{ID: "a", Email: "alice@example.com"}, {ID: "b", Email: "bob@example.com"}, {ID: "c", Email: "BOB@example.com"}})
+	assert.Equal(t, []string{"bob@example.com"}, unmatched, "shared addresses are ambiguous")
+	assert.Equal(t, "a", imp.Rotations[0].Participants[0].UserID)
+	assert.Empty(t, imp.Rotations[0].Participants[1].UserID)
+
+	_, err = Parse(FormatJSON, []byte(`{"rotations": [{"name": "x", "type": "bogus", "start": "2026-11-02T09:00:00Z"}]}`), time.UTC)
+	assert.Error(t, err)
+
+	_, err = Parse(FormatJSON, []byte(`{"shifts": [{"email": "a@example.com", "start": "2026-11-02T09:00:00Z", "end": "2026-11-01T09:00:00Z"}]}`), time.UTC)
+	assert.Error(t, err)
+}
+
+func TestImport_TemporarySchedules(t *testing.T) {
+	ts := func(h int) time.Time {
+		return time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(h) * time.Hour)
+	}
+
+	var imp Import
+	imp.Shifts = append(imp.Shifts, Shift{UserID: "old", Start: ts(0), End: ts(1)})
+	for i := 0; i < schedule.FixedShiftsPerTemporaryScheduleLimit+10; i++ {
+		imp.Shifts = append(imp.Shifts, Shift{UserID: "a", Start: ts(10 + i*2), End: ts(11 + i*2)})
+	}
+
+	tmps, err := imp.TemporarySchedules(ts(5))
+	require.NoError(t, err)
+	require.Len(t, tmps, 2)
+
+	assert.Equal(t, ts(10), tmps[0].Start)
+	assert.Len(t, tmps[0].Shifts, schedule.FixedShiftsPerTemporaryScheduleLimit)
+	assert.Equal(t, tmps[1].Start, tmps[0].End, "contiguous")
+	assert.Len(t, tmps[1].Shifts, 10)
+	assert.Equal(t, imp.Shifts[len(imp.Shifts)-1].End, tmps[1].End)
+
+	// overlapping shifts can't be split
+	imp.Shifts = nil
+	for i := 0; i < schedule.FixedShiftsPerTemporaryScheduleLimit+1; i++ {
+		imp.Shifts = append(imp.Shifts, Shift{UserID: "a", Start: ts(10 + i), End: ts(1000)})
+	}
+	_, err = imp.TemporarySchedules(ts(5))
+	assert.Error(t, err)
+
+	imp.Shifts = []Shift{{UserID: "old", Start: ts(0), End: ts(1)}}
+	tmps, err = imp.TemporarySchedules(ts(5))
+	require.NoError(t, err)
+	assert.Empty(t, tmps)
+}
+
+func TestParse_Format(t *testing.T) {
+	_, err := Parse(Format("csv"), []byte(strings.Repeat("x", 10)), time.UTC)
+	assert.Error(t, err)
+}
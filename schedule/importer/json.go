@@ -0,0 +1,81 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/util"
+)
+
+type jsonImport struct {
+	Shifts []struct {
+		Email string
+		Start time.Time
+		End   time.Time
+	}
+	Rotations []struct {
+		Name         string
+		Description  string
+		Type         rotation.Type
+		ShiftLength  int
+		ShiftPattern []int
+		Start        time.Time
+		TimeZone     string
+		Participants []string
+	}
+}
+
+func parseJSON(data []byte, loc *time.Location) (*Import, error) {
+	var doc jsonImport
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var imp Import
+	for i, s := range doc.Shifts {
+		if s.Email == "" {
+			return nil, fmt.Errorf("shifts[%d]: email is required", i)
+		}
+		if s.Start.IsZero() || s.End.IsZero() {
+			return nil, fmt.Errorf("shifts[%d]: start and end are required", i)
+		}
+		imp.Shifts = append(imp.Shifts, Shift{Email: s.Email, Start: s.Start, End: s.End})
+	}
+
+	for i, r := range doc.Rotations {
+		if r.Start.IsZero() {
+			return nil, fmt.Errorf("rotations[%d]: start is required", i)
+		}
+		rotLoc := loc
+		if r.TimeZone != "" {
+			rotLoc, err = util.LoadLocation(r.TimeZone)
+			if err != nil {
+				return nil, fmt.Errorf("rotations[%d]: %w", i, err)
+			}
+		}
+
+		rot := Rotation{Rotation: rotation.Rotation{
+			Name:         r.Name,
+			Description:  r.Description,
+			Type:         r.Type,
+			ShiftLength:  r.ShiftLength,
+			ShiftPattern: r.ShiftPattern,
+			Start:        r.Start.In(rotLoc),
+		}}
+		for j, email := range r.Participants {
+			if email == "" {
+				return nil, fmt.Errorf("rotations[%d].participants[%d]: email is required", i, j)
+			}
+			rot.Participants = append(rot.Participants, Participant{Email: email})
+		}
+		imp.Rotations = append(imp.Rotations, rot)
+	}
+
+	return &imp, nil
+}
@@ -2,6 +2,11 @@ package schedule
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
 
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/gadb"
@@ -12,8 +17,9 @@ import (
 )
 
 const (
-	DestTypeSchedule = "builtin-schedule"
-	FieldScheduleID  = "schedule_id"
+	DestTypeSchedule  = "builtin-schedule"
+	FieldScheduleID   = "schedule_id"
+	FieldScheduleTier = "tier"
 
 	FallbackIconURL = "builtin://schedule"
 )
@@ -21,6 +27,7 @@ const (
 var (
 	_ nfydest.Provider      = (*Store)(nil)
 	_ nfydest.FieldSearcher = (*Store)(nil)
+	_ nfydest.DestValidator = (*Store)(nil)
 )
 
 func DestFromID(scheduleID string) gadb.DestV1 {
@@ -30,6 +37,18 @@ func DestFromID(scheduleID string) gadb.DestV1 {
 	}
 }
 
+// DestFromTier returns the destination for a tier of a schedule, an empty tier refers to the primary tier.
+func DestFromTier(scheduleID, tier string) gadb.DestV1 {
+	if tier == "" {
+		return DestFromID(scheduleID)
+	}
+
+	return gadb.DestV1{
+		Type: DestTypeSchedule,
+		Args: map[string]string{FieldScheduleID: scheduleID, FieldScheduleTier: tier},
+	}
+}
+
 func (s *Store) ID() string { return DestTypeSchedule }
 func (s *Store) TypeInfo(ctx context.Context) (*nfydest.TypeInfo, error) {
 	return &nfydest.TypeInfo{
@@ -42,10 +61,49 @@ func (s *Store) TypeInfo(ctx context.Context) (*nfydest.TypeInfo, error) {
 			Label:          "Schedule",
 			InputType:      "text",
 			SupportsSearch: true,
+		}, {
+			FieldID:   FieldScheduleTier,
+			Label:     "Tier",
+			Hint:      "Leave empty for the primary tier.",
+			InputType: "text",
 		}},
 	}, nil
 }
 
+// ValidateDest ensures the schedule, and tier if provided, exist.
+func (s *Store) ValidateDest(ctx context.Context, dest gadb.DestV1) error {
+	for field := range dest.Args {
+		if field != FieldScheduleID && field != FieldScheduleTier {
+			return &nfydest.DestArgError{FieldID: field, Err: fmt.Errorf("unexpected field")}
+		}
+	}
+
+	sched, err := s.FindOne(ctx, dest.Arg(FieldScheduleID))
+	if errors.Is(err, sql.ErrNoRows) {
+		err = validation.NewGenericError("does not exist")
+	}
+	if validation.IsClientError(err) {
+		return &nfydest.DestArgError{FieldID: FieldScheduleID, Err: err}
+	}
+	if err != nil {
+		return fmt.Errorf("validate field %s: %w", FieldScheduleID, err)
+	}
+
+	tier := dest.Arg(FieldScheduleTier)
+	if tier == "" {
+		return nil
+	}
+	ok, err := s.TierExists(ctx, nil, uuid.MustParse(sched.ID), tier)
+	if err != nil {
+		return fmt.Errorf("validate field %s: %w", FieldScheduleTier, err)
+	}
+	if !ok {
+		return &nfydest.DestArgError{FieldID: FieldScheduleTier, Err: validation.NewGenericError("does not exist")}
+	}
+
+	return nil
+}
+
 func (s *Store) DisplayInfo(ctx context.Context, args map[string]string) (*nfydest.DisplayInfo, error) {
 	cfg := config.FromContext(ctx)
 
@@ -54,11 +112,16 @@ func (s *Store) DisplayInfo(ctx context.Context, args map[string]string) (*nfyde
 		return nil, err
 	}
 
+	text := sched.Name
+	if tier := args[FieldScheduleTier]; tier != "" {
+		text += " (" + tier + ")"
+	}
+
 	return &nfydest.DisplayInfo{
 		IconURL:     FallbackIconURL,
 		IconAltText: "Schedule",
 		LinkURL:     cfg.CallbackURL("/schedules/" + sched.ID),
-		Text:        sched.Name,
+		Text:        text,
 	}, nil
 }
 
@@ -67,6 +130,11 @@ func (s *Store) ValidateField(ctx context.Context, fieldID, value string) error
 	case FieldScheduleID:
 		_, err := s.FindOne(ctx, value)
 		return err
+	case FieldScheduleTier:
+		if value == "" {
+			return nil
+		}
+		return ValidateTierName(fieldID, value)
 	}
 
 	return validation.NewGenericError("unknown field ID")
@@ -80,6 +148,11 @@ func (s *Store) FieldLabel(ctx context.Context, fieldID, value string) (string,
 			return "", err
 		}
 		return sched.Name, nil
+	case FieldScheduleTier:
+		if value == "" {
+			return PrimaryTier, nil
+		}
+		return value, nil
 	}
 
 	return "", validation.NewGenericError("unknown field ID")
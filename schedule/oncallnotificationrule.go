@@ -25,6 +25,10 @@ type OnCallNotificationRule struct {
 	Time          *timeutil.Clock
 	WeekdayFilter *timeutil.WeekdayFilter
 
+	// HandoffReport indicates a handoff report should also be sent to the channel
+	// each time the on-call users change. Only valid for rules without Time.
+	HandoffReport bool
+
 	NextNotification *time.Time
 }
 
@@ -104,3 +104,55 @@ WHERE s.id = ANY($1::uuid[]);
 DELETE FROM schedules
 WHERE id = ANY($1::uuid[]);
 
+
+-- name: ScheduleCoverageGaps :many
+-- Returns the current and upcoming coverage gaps detected for a schedule.
+SELECT
+    start_time,
+    end_time,
+    detected_at
+FROM
+    schedule_coverage_gaps
+WHERE
+    schedule_id = $1
+    AND end_time > now()
+ORDER BY
+    start_time;
+
+-- name: ScheduleTiers :many
+-- Returns the tiers of a schedule.
+SELECT
+    name,
+    rotation_offset
+FROM
+    schedule_tiers
+WHERE
+    schedule_id = $1
+ORDER BY
+    created_at,
+    name;
+
+-- name: ScheduleSetTier :exec
+-- Creates or updates a schedule tier.
+INSERT INTO schedule_tiers(schedule_id, name, rotation_offset)
+    VALUES ($1, $2, $3)
+ON CONFLICT (schedule_id, name)
+    DO UPDATE SET
+        rotation_offset = $3;
+
+-- name: ScheduleDeleteTier :exec
+-- Deletes a schedule tier, along with its rules and escalation policy actions.
+DELETE FROM schedule_tiers
+WHERE schedule_id = $1
+    AND name = $2;
+
+-- name: ScheduleTierExists :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            schedule_tiers
+        WHERE
+            schedule_id = $1
+            AND name = $2);
@@ -10,6 +10,10 @@ type Participant struct {
 	Position   int    `json:"position"`
 	RotationID string `json:"rotation_id"`
 	Target     assignment.Target
+
+	// ShiftLength is the participant's shift length, in hours, for participant
+	// rotations. If zero, the rotation's ShiftLength is used.
+	ShiftLength int `json:"shift_length,omitempty"`
 }
 
 func (p Participant) Normalize() (*Participant, error) {
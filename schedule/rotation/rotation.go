@@ -1,6 +1,7 @@
 package rotation
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/target/goalert/util/timeutil"
@@ -8,14 +9,32 @@ import (
 	"github.com/target/goalert/validation/validate"
 )
 
+// MaxPatternShifts is the maximum number of shifts in a pattern rotation cycle.
+const MaxPatternShifts = 50
+
 type Rotation struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 
-	Type           Type      `json:"type"`
-	Start          time.Time `json:"start"`
-	ShiftLength    int       `json:"shift_length"`
+	Type        Type      `json:"type"`
+	Start       time.Time `json:"start"`
+	ShiftLength int       `json:"shift_length"`
+
+	// ShiftPattern is the repeating sequence of shift lengths, in hours, for
+	// pattern-type rotations (e.g., 12,12 for day/night or 48,48,72 for 2-2-3).
+	//
+	// Lengths belong to the shift, not the participant: the Nth shift since
+	// Start is pattern[N%len(pattern)] hours, whoever takes it. Participant
+	// rotations are used to give each participant their own length instead.
+	ShiftPattern []int `json:"shift_pattern,omitempty"`
+
+	// ParticipantShiftLengths is the shift length, in hours, of each participant
+	// (by position) for participant-type rotations. It is not loaded with the
+	// rotation, and must be set to calculate shifts; if empty, every participant
+	// uses ShiftLength.
+	ParticipantShiftLengths []int `json:"-"`
+
 	isUserFavorite bool
 }
 
@@ -89,6 +108,70 @@ func (r Rotation) monthEndTime(t time.Time, n int) time.Time {
 	return r.monthEndTime(t, n+1)
 }
 
+// pattern returns the repeating sequence of shift lengths, in hours, for
+// pattern and participant rotations.
+func (r Rotation) pattern() []int {
+	if r.Type == TypeParticipant {
+		if len(r.ParticipantShiftLengths) == 0 {
+			return []int{r.ShiftLength}
+		}
+		return r.ParticipantShiftLengths
+	}
+	if len(r.ShiftPattern) == 0 {
+		return []int{1}
+	}
+
+	return r.ShiftPattern
+}
+
+// patternShift calculates the start and end of the pattern shift active at t,
+// along with its index in the pattern.
+//
+// The pattern repeats from the rotation start time, so the offset into the
+// current cycle determines which shift length applies.
+func (r Rotation) patternShift(t time.Time) (start, end time.Time, idx int) {
+	pattern := r.pattern()
+
+	var cycleLen timeutil.Clock
+	for _, h := range pattern {
+		cycleLen += timeutil.NewClock(h, 0)
+	}
+
+	rem := timeutil.ClockDiff(r.Start, t) % cycleLen
+	if rem < 0 {
+		rem += cycleLen
+	}
+
+	var offset timeutil.Clock
+	for i, h := range pattern {
+		shiftLen := timeutil.NewClock(h, 0)
+		if rem < offset+shiftLen {
+			return timeutil.AddClock(t, offset-rem), timeutil.AddClock(t, offset+shiftLen-rem), i
+		}
+		offset += shiftLen
+	}
+
+	panic("unreachable: offset outside of pattern cycle")
+}
+
+// ParticipantPosition returns the position of the participant whose shift is
+// active at t, for participant rotations.
+//
+// Each shift of a participant rotation belongs to the participant whose length
+// it uses, so rather than simply advancing to the next participant, a handoff
+// must move to the position returned here. It returns false for other types,
+// or if ParticipantShiftLengths is not set.
+func (r Rotation) ParticipantPosition(t time.Time) (int, bool) {
+	if r.Type != TypeParticipant || len(r.ParticipantShiftLengths) == 0 {
+		return 0, false
+	}
+
+	t = t.In(r.Start.Location()).Truncate(time.Minute)
+	r.Start = r.Start.Truncate(time.Minute)
+	_, _, idx := r.patternShift(t)
+	return idx, true
+}
+
 // StartTime calculates the start of the "shift" that started at (or was active) at t.
 // For daily, weekly, and monthly rotations, start time will be the previous handoff time (from start).
 // For monthly rotations, the monthStartTime function is used to recursively handle calculations as the length of months vary.
@@ -102,6 +185,10 @@ func (r Rotation) StartTime(t time.Time) time.Time {
 	if r.Type == TypeMonthly {
 		return r.monthStartTime(t, 1)
 	}
+	if r.Type == TypePattern || r.Type == TypeParticipant {
+		start, _, _ := r.patternShift(t)
+		return start
+	}
 
 	shiftClockLen := r.shiftClock()
 	rem := timeutil.ClockDiff(r.Start, t) % shiftClockLen
@@ -127,6 +214,10 @@ func (r Rotation) EndTime(t time.Time) time.Time {
 	if r.Type == TypeMonthly {
 		return r.monthEndTime(t, 1)
 	}
+	if r.Type == TypePattern || r.Type == TypeParticipant {
+		_, end, _ := r.patternShift(t)
+		return end
+	}
 
 	shiftClockLen := r.shiftClock()
 	rem := timeutil.ClockDiff(r.Start, t) % shiftClockLen
@@ -138,6 +229,25 @@ func (r Rotation) EndTime(t time.Time) time.Time {
 	return timeutil.AddClock(t, shiftClockLen-rem)
 }
 
+// ValidateParticipantShiftLengths will validate the length, in hours, of each participant's shift.
+func ValidateParticipantShiftLengths(fname string, lengths []int) error {
+	var err error
+	for i, h := range lengths {
+		err = validate.Many(err, validate.Range(fmt.Sprintf("%s[%d]", fname, i), h, 1, 9000))
+	}
+	return err
+}
+
+// ValidateShiftPattern will validate the number of shifts in a pattern and the length, in hours, of each.
+func ValidateShiftPattern(fname string, pattern []int) error {
+	err := validate.Range(fname, len(pattern), 1, MaxPatternShifts)
+	for i, h := range pattern {
+		err = validate.Many(err, validate.Range(fmt.Sprintf("%s[%d]", fname, i), h, 1, 9000))
+	}
+
+	return err
+}
+
 func (r Rotation) Normalize() (*Rotation, error) {
 	if r.ShiftLength == 0 {
 		// default to 1
@@ -151,9 +261,16 @@ func (r Rotation) Normalize() (*Rotation, error) {
 	err := validate.Many(
 		validate.IDName("Name", r.Name),
 		validate.Range("ShiftLength", r.ShiftLength, 1, 9000),
-		validate.OneOf("Type", r.Type, TypeMonthly, TypeWeekly, TypeDaily, TypeHourly),
+		validate.OneOf("Type", r.Type, TypeMonthly, TypeWeekly, TypeDaily, TypeHourly, TypePattern, TypeParticipant),
 		validate.Text("Description", r.Description, 1, 255),
 	)
+	if r.Type == TypePattern {
+		err = validate.Many(err, ValidateShiftPattern("ShiftPattern", r.ShiftPattern))
+		// shift length is unused by pattern rotations
+		r.ShiftLength = 1
+	} else if len(r.ShiftPattern) > 0 {
+		err = validate.Many(err, validation.NewFieldError("ShiftPattern", "only allowed for pattern rotations"))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -548,9 +548,15 @@ func TestRotation_Normalize(t *testing.T) {
 
 	valid := []Rotation{
 		{Name: "Default", ShiftLength: 1, Type: TypeWeekly, Description: "Default Rotation"},
+		{Name: "Pattern", Type: TypePattern, ShiftPattern: []int{12, 12}},
+		{Name: "Participant", Type: TypeParticipant, ShiftLength: 12},
 	}
 	invalid := []Rotation{
 		{Name: "D", ShiftLength: -100, Type: TypeWeekly, Description: "Default Rotation"},
+		{Name: "Pattern", Type: TypePattern},
+		{Name: "Pattern", Type: TypePattern, ShiftPattern: []int{12, 0}},
+		{Name: "Pattern", Type: TypeWeekly, ShiftLength: 1, ShiftPattern: []int{12}},
+		{Name: "Participant", Type: TypeParticipant, ShiftLength: 12, ShiftPattern: []int{12}},
 	}
 	for _, r := range valid {
 		test(true, r)
@@ -560,6 +566,72 @@ func TestRotation_Normalize(t *testing.T) {
 	}
 }
 
+func TestRotation_Pattern(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	rot := Rotation{
+		Type:         TypePattern,
+		ShiftPattern: []int{48, 48, 72},
+		Start:        time.Date(2020, time.October, 1, 8, 0, 0, 0, loc),
+	}
+
+	// walk through two full cycles, including the start of the next
+	handoffs := []time.Time{rot.Start}
+	for _, days := range []int{2, 2, 3, 2, 2, 3} {
+		handoffs = append(handoffs, handoffs[len(handoffs)-1].AddDate(0, 0, days))
+	}
+	for i := 0; i < len(handoffs)-1; i++ {
+		assert.Equal(t, handoffs[i+1].String(), rot.EndTime(handoffs[i]).String(), "EndTime at %s", handoffs[i])
+		assert.Equal(t, handoffs[i].String(), rot.StartTime(handoffs[i]).String(), "StartTime at %s", handoffs[i])
+
+		mid := handoffs[i].Add(time.Hour)
+		assert.Equal(t, handoffs[i+1].String(), rot.EndTime(mid).String(), "EndTime at %s", mid)
+		assert.Equal(t, handoffs[i].String(), rot.StartTime(mid).String(), "StartTime at %s", mid)
+	}
+
+	// before the rotation start the pattern runs backwards
+	before := rot.Start.Add(-time.Hour)
+	assert.Equal(t, rot.Start.AddDate(0, 0, -3).String(), rot.StartTime(before).String())
+	assert.Equal(t, rot.Start.String(), rot.EndTime(before).String())
+}
+
+func TestRotation_Participant(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	rot := Rotation{
+		Type:                    TypeParticipant,
+		ShiftLength:             24,
+		ParticipantShiftLengths: []int{24, 72, 48},
+		Start:                   time.Date(2020, time.October, 1, 8, 0, 0, 0, loc),
+	}
+
+	// each participant keeps their own length every cycle
+	handoffs := []time.Time{rot.Start}
+	for _, days := range []int{1, 3, 2, 1, 3, 2} {
+		handoffs = append(handoffs, handoffs[len(handoffs)-1].AddDate(0, 0, days))
+	}
+	for i := 0; i < len(handoffs)-1; i++ {
+		mid := handoffs[i].Add(time.Hour)
+		assert.Equal(t, handoffs[i+1].String(), rot.EndTime(mid).String(), "EndTime at %s", mid)
+		assert.Equal(t, handoffs[i].String(), rot.StartTime(mid).String(), "StartTime at %s", mid)
+
+		pos, ok := rot.ParticipantPosition(mid)
+		assert.True(t, ok)
+		assert.Equal(t, i%3, pos, "ParticipantPosition at %s", mid)
+	}
+
+	// without lengths, every participant uses the default
+	rot.ParticipantShiftLengths = nil
+	assert.Equal(t, rot.Start.AddDate(0, 0, 1).String(), rot.EndTime(rot.Start).String())
+	_, ok := rot.ParticipantPosition(rot.Start)
+	assert.False(t, ok)
+
+	_, ok = Rotation{Type: TypePattern, ShiftPattern: []int{12}, Start: rot.Start}.ParticipantPosition(rot.Start)
+	assert.False(t, ok, "only participant rotations have a position")
+}
+
 func TestRotation_FutureStart(t *testing.T) {
 	rot := Rotation{
 		Type:        TypeDaily,
@@ -48,6 +48,7 @@ var searchTemplate = template.Must(template.New("search").Funcs(search.Helpers()
 		rot.type, 
 		rot.start_time, 
 		rot.shift_length, 
+		rot.shift_pattern, 
 		rot.time_zone, 
 		fav IS DISTINCT FROM NULL
 	FROM rotations rot
@@ -153,7 +154,7 @@ func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]Rotation, er
 	var r Rotation
 	var tz string
 	for rows.Next() {
-		err = rows.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, &tz, &r.isUserFavorite)
+		err = rows.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, (*sqlutil.IntArray)(&r.ShiftPattern), &tz, &r.isUserFavorite)
 		if err != nil {
 			return nil, err
 		}
@@ -3,6 +3,7 @@ package rotation
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"sort"
 
 	"github.com/google/uuid"
@@ -38,6 +39,7 @@ type Store struct {
 
 	deleteParticipants      *sql.Stmt
 	updateParticipantUserID *sql.Stmt
+	setShiftLengths         *sql.Stmt
 	setActiveIndex          *sql.Stmt
 
 	findPartCount *sql.Stmt
@@ -51,14 +53,14 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 
 		lockPart: p.P(`lock rotation_participants, rotation_state in exclusive mode`),
 
-		createRotation: p.P(`INSERT INTO rotations (id, name, description, type, start_time, shift_length, time_zone) VALUES ($1, $2, $3, $4, $5, $6, $7)`),
+		createRotation: p.P(`INSERT INTO rotations (id, name, description, type, start_time, shift_length, time_zone, shift_pattern) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`),
 		updateRotation: p.P(`
 			WITH set_shift_start AS (
 				UPDATE rotation_state
 				SET shift_start = now()
 				WHERE rotation_id = $1
 			)
-			UPDATE rotations SET name = $2, description = $3, type = $4, start_time = $5, shift_length = $6, time_zone = $7 WHERE id = $1
+			UPDATE rotations SET name = $2, description = $3, type = $4, start_time = $5, shift_length = $6, time_zone = $7, shift_pattern = $8 WHERE id = $1
 		`),
 		findRotation: p.P(`
 			SELECT 
@@ -68,6 +70,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				r.type, 
 				r.start_time, 
 				r.shift_length, 
+				r.shift_pattern, 
 				r.time_zone, 
 				fav IS DISTINCT FROM NULL 
 			FROM rotations r 
@@ -75,7 +78,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			AND fav.user_id = $2 
 			WHERE r.id = $1
 		`),
-		findRotationForUpdate: p.P(`SELECT id, name, description, type, start_time, shift_length, time_zone FROM rotations WHERE id = $1 FOR UPDATE`),
+		findRotationForUpdate: p.P(`SELECT id, name, description, type, start_time, shift_length, shift_pattern, time_zone FROM rotations WHERE id = $1 FOR UPDATE`),
 		deleteRotation:        p.P(`DELETE FROM rotations WHERE id = ANY($1)`),
 
 		findMany: p.P(`
@@ -86,6 +89,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				r.type, 
 				r.start_time, 
 				r.shift_length, 
+				r.shift_pattern, 
 				r.time_zone,
 				fav IS DISTINCT FROM NULL 
 			FROM rotations r 
@@ -105,7 +109,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			RETURNING position
 		`),
 
-		findAllParticipants: p.P(`SELECT id, rotation_id, position, user_id FROM rotation_participants WHERE rotation_id = $1 ORDER BY position`),
+		findAllParticipants: p.P(`SELECT id, rotation_id, position, user_id, shift_length FROM rotation_participants WHERE rotation_id = $1 ORDER BY position`),
 
 		findParticipant: p.P(`SELECT rotation_id, position, user_id FROM rotation_participants WHERE id = $1`),
 
@@ -128,6 +132,12 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		updateParticipantUserID: p.P(`
 			UPDATE rotation_participants SET user_id = $2 WHERE id = $1
 		`),
+		setShiftLengths: p.P(`
+			UPDATE rotation_participants p
+			SET shift_length = nullif(l.shift_length, 0)
+			FROM unnest($2::int[]) WITH ORDINALITY l(shift_length, pos)
+			WHERE p.rotation_id = $1 AND p.position = l.pos - 1
+		`),
 
 		setActiveIndex: p.P(`
 			UPDATE rotation_state SET rotation_participant_id = (SELECT id FROM rotation_participants WHERE rotation_id = $1 AND position = $2),
@@ -189,7 +199,7 @@ func (s *Store) CreateRotationTx(ctx context.Context, tx *sql.Tx, r *Rotation) (
 
 	n.ID = uuid.New().String()
 
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Type, n.Start, n.ShiftLength, n.Start.Location().String())
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Type, n.Start, n.ShiftLength, n.Start.Location().String(), sqlutil.IntArray(n.ShiftPattern))
 	if err != nil {
 		return nil, err
 	}
@@ -216,7 +226,7 @@ func (s *Store) UpdateRotationTx(ctx context.Context, tx *sql.Tx, r *Rotation) e
 		stmt = tx.StmtContext(ctx, stmt)
 	}
 
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Type, n.Start, n.ShiftLength, n.Start.Location().String())
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Type, n.Start, n.ShiftLength, n.Start.Location().String(), sqlutil.IntArray(n.ShiftPattern))
 	return err
 }
 
@@ -243,7 +253,7 @@ func (s *Store) FindMany(ctx context.Context, ids []string) ([]Rotation, error)
 	var tz string
 	result := make([]Rotation, 0, len(ids))
 	for rows.Next() {
-		err = rows.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, &tz, &r.isUserFavorite)
+		err = rows.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, (*sqlutil.IntArray)(&r.ShiftPattern), &tz, &r.isUserFavorite)
 		if err != nil {
 			return nil, err
 		}
@@ -271,7 +281,7 @@ func (s *Store) FindRotation(ctx context.Context, id string) (*Rotation, error)
 	row := s.findRotation.QueryRowContext(ctx, id, permission.UserNullUUID(ctx))
 	var r Rotation
 	var tz string
-	err = row.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, &tz, &r.isUserFavorite)
+	err = row.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, (*sqlutil.IntArray)(&r.ShiftPattern), &tz, &r.isUserFavorite)
 	if err != nil {
 		return nil, err
 	}
@@ -328,7 +338,7 @@ func (s *Store) FindRotationForUpdateTx(ctx context.Context, tx *sql.Tx, rotatio
 	row := stmt.QueryRowContext(ctx, rotationID)
 	var r Rotation
 	var tz string
-	err = row.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, &tz)
+	err = row.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, (*sqlutil.IntArray)(&r.ShiftPattern), &tz)
 	if err != nil {
 		return nil, err
 	}
@@ -379,12 +389,14 @@ func (s *Store) FindAllParticipantsTx(ctx context.Context, tx *sql.Tx, rotationI
 
 	var p Participant
 	var userID sql.NullString
+	var shiftLength sql.NullInt32
 	var res []Participant
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.RotationID, &p.Position, &userID)
+		err = rows.Scan(&p.ID, &p.RotationID, &p.Position, &userID, &shiftLength)
 		if err != nil {
 			return nil, err
 		}
+		p.ShiftLength = int(shiftLength.Int32)
 		if userID.Valid {
 			p.Target = assignment.UserTarget(userID.String)
 		} else {
@@ -540,6 +552,41 @@ func (s *Store) UpdateParticipantUserIDTx(ctx context.Context, tx *sql.Tx, partI
 	})
 }
 
+// SetParticipantShiftLengthsTx will set the shift length, in hours, of each participant of a rotation by position.
+//
+// A length of zero means the participant uses the rotation's shift length.
+func (s *Store) SetParticipantShiftLengthsTx(ctx context.Context, tx *sql.Tx, rotationID string, lengths []int) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("RotationID", rotationID)
+	for i, h := range lengths {
+		err = validate.Many(err, validate.Range(fmt.Sprintf("ShiftLengths[%d]", i), h, 0, 9000))
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.withTxLock(ctx, tx, func(tx *sql.Tx) error {
+		var count int
+		err := tx.StmtContext(ctx, s.findPartCount).QueryRowContext(ctx, rotationID).Scan(&count)
+		if errors.Is(err, sql.ErrNoRows) {
+			return validation.NewFieldError("RotationID", "not found")
+		}
+		if err != nil {
+			return err
+		}
+		if count != len(lengths) {
+			return validation.NewFieldError("ShiftLengths", "must have one length per participant")
+		}
+
+		_, err = tx.StmtContext(ctx, s.setShiftLengths).ExecContext(ctx, rotationID, sqlutil.IntArray(lengths))
+		return err
+	})
+}
+
 func (s *Store) DeleteStateTx(ctx context.Context, tx *sql.Tx, rotationID string) error {
 	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
 	if err != nil {
@@ -555,3 +602,107 @@ func (s *Store) DeleteStateTx(ctx context.Context, tx *sql.Tx, rotationID string
 		return err
 	})
 }
+
+// SetParticipantUsersTx will update the participants of a rotation to match the provided list of user IDs, in order.
+//
+// Existing participants are updated in place by position, extras are removed, and new users are appended.
+// If updateActive is true and the active participant is removed, the first participant becomes active.
+// Shift lengths move with their user, so reordering participants keeps each user's length.
+func (s *Store) SetParticipantUsersTx(ctx context.Context, tx *sql.Tx, rotationID string, userIDs []string, updateActive bool) (err error) {
+	// Get current participants
+	currentParticipants, err := s.FindAllParticipantsTx(ctx, tx, rotationID)
+	if err != nil {
+		return err
+	}
+
+	// queue each user's lengths, as a user may appear more than once
+	userLengths := make(map[string][]int)
+	var hasLengths bool
+	for _, c := range currentParticipants {
+		if c.ShiftLength != 0 {
+			hasLengths = true
+		}
+		id := c.Target.TargetID()
+		userLengths[id] = append(userLengths[id], c.ShiftLength)
+	}
+	defer func() {
+		if err != nil || !hasLengths {
+			return
+		}
+
+		lengths := make([]int, len(userIDs))
+		for i, id := range userIDs {
+			q := userLengths[id]
+			if len(q) == 0 {
+				continue
+			}
+			lengths[i] = q[0]
+			userLengths[id] = q[1:]
+		}
+		err = s.SetParticipantShiftLengthsTx(ctx, tx, rotationID, lengths)
+	}()
+
+	var participantIDsToRemove []string
+
+	for i, c := range currentParticipants {
+		if i >= len(userIDs) {
+			participantIDsToRemove = append(participantIDsToRemove, c.ID)
+			continue
+		}
+
+		if c.Target.TargetID() == userIDs[i] {
+			// nothing to update
+			continue
+		}
+
+		// Update
+		err = s.UpdateParticipantUserIDTx(ctx, tx, c.ID, userIDs[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(userIDs) > len(currentParticipants) {
+		// Add users
+		err = s.AddRotationUsersTx(ctx, tx, rotationID, userIDs[len(currentParticipants):])
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(participantIDsToRemove) == 0 {
+		return nil
+	}
+
+	if len(userIDs) == 0 {
+		// Delete rotation state if all users are going to be deleted as per new input
+		err = s.DeleteStateTx(ctx, tx, rotationID)
+		if err != nil {
+			return err
+		}
+	} else if updateActive {
+		// get current active participant
+		st, err := s.StateTx(ctx, tx, rotationID)
+		if errors.Is(err, ErrNoState) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// if currently active user is going to be deleted
+		// then set to first user before we actually delete any users
+		if st.Position >= len(userIDs) {
+			err = s.SetActiveIndexTx(ctx, tx, rotationID, 0)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = s.DeleteRotationParticipantsTx(ctx, tx, participantIDsToRemove)
+	if err != nil {
+		return err
+	}
+	return nil
+}
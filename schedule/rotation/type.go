@@ -17,6 +17,12 @@ const (
 	TypeWeekly  Type = "weekly"
 	TypeDaily   Type = "daily"
 	TypeHourly  Type = "hourly"
+
+	// TypePattern rotations hand off according to a repeating sequence of shift lengths.
+	TypePattern Type = "pattern"
+
+	// TypeParticipant rotations hand off after each participant's own shift length.
+	TypeParticipant Type = "participant"
 )
 
 // Scan handles reading a Role from the DB format
@@ -36,7 +42,7 @@ func (r *Type) Scan(value interface{}) error {
 // Value converts the Role to the DB representation
 func (r Type) Value() (driver.Value, error) {
 	switch r {
-	case TypeMonthly, TypeWeekly, TypeDaily, TypeHourly:
+	case TypeMonthly, TypeWeekly, TypeDaily, TypeHourly, TypePattern, TypeParticipant:
 		return string(r), nil
 	default:
 		return nil, fmt.Errorf("unknown rotation type specified '%s'", r)
@@ -58,6 +64,10 @@ func (t *Type) UnmarshalGQL(v interface{}) error {
 		*t = TypeDaily
 	case "hourly":
 		*t = TypeHourly
+	case "pattern":
+		*t = TypePattern
+	case "participant":
+		*t = TypeParticipant
 	default:
 		return validation.NewFieldError("Type", "unknown rotation type "+str)
 	}
@@ -76,5 +86,9 @@ func (t Type) MarshalGQL(w io.Writer) {
 		graphql.MarshalString("hourly").MarshalGQL(w)
 	case TypeDaily:
 		graphql.MarshalString("daily").MarshalGQL(w)
+	case TypePattern:
+		graphql.MarshalString("pattern").MarshalGQL(w)
+	case TypeParticipant:
+		graphql.MarshalString("participant").MarshalGQL(w)
 	}
 }
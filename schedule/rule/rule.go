@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/schedule/holiday"
 	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -19,6 +21,13 @@ type Rule struct {
 	End       timeutil.Clock `json:"end"`
 	CreatedAt time.Time      `json:"created_at"`
 	Target    assignment.Target
+
+	// HolidayCalendarID, if set, is the holiday calendar that HolidayMode applies to.
+	HolidayCalendarID string       `json:"holiday_calendar_id,omitempty"`
+	HolidayMode       holiday.Mode `json:"holiday_mode,omitempty"`
+
+	// Tier is the name of the schedule tier the rule belongs to, empty for the primary tier.
+	Tier string `json:"tier,omitempty"`
 }
 
 func NewAlwaysActive(scheduleID string, tgt assignment.Target) *Rule {
@@ -34,6 +43,19 @@ func (r Rule) Normalize() (*Rule, error) {
 	if err != nil {
 		return nil, err
 	}
+	if r.HolidayCalendarID == "" {
+		if r.HolidayMode != "" {
+			return nil, validation.NewFieldError("HolidayMode", "requires a holiday calendar")
+		}
+	} else {
+		err = validate.Many(
+			validate.UUID("HolidayCalendarID", r.HolidayCalendarID),
+			validate.OneOf("HolidayMode", r.HolidayMode, holiday.ModeSuppress, holiday.ModeSubstitute),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
 	r.Start = timeutil.Clock(time.Duration(r.Start).Truncate(time.Minute))
 	r.End = timeutil.Clock(time.Duration(r.End).Truncate(time.Minute))
 	return &r, nil
@@ -53,12 +75,15 @@ func (r *Rule) scanFrom(s scanner) error {
 		&r.Start,
 		&r.End,
 	}
-	var usr, rot sql.NullString
-	f = append(f, &usr, &rot)
+	var usr, rot, cal, mode, tier sql.NullString
+	f = append(f, &usr, &rot, &cal, &mode, &tier)
 	err := s.Scan(f...)
 	if err != nil {
 		return err
 	}
+	r.Tier = tier.String
+	r.HolidayCalendarID = cal.String
+	r.HolidayMode = holiday.Mode(mode.String)
 
 	switch {
 	case usr.Valid:
@@ -88,7 +113,16 @@ func (r Rule) readFields() []interface{} {
 		rot.Valid = true
 		rot.String = r.Target.TargetID()
 	}
-	return append(f, usr, rot)
+	var cal, mode sql.NullString
+	if r.HolidayCalendarID != "" {
+		cal = sql.NullString{Valid: true, String: r.HolidayCalendarID}
+		mode = sql.NullString{Valid: true, String: string(r.HolidayMode)}
+	}
+	var tier sql.NullString
+	if r.Tier != "" {
+		tier = sql.NullString{Valid: true, String: r.Tier}
+	}
+	return append(f, usr, rot, cal, mode, tier)
 }
 
 // StartTime will return the next time the rule would be active.
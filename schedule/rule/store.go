@@ -44,8 +44,11 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				start_time,
 				end_time,
 				tgt_user_id,
-				tgt_rotation_id
-			) values ($1, $2, ($3::Bool[])[1], ($3::Bool[])[2], ($3::Bool[])[3], ($3::Bool[])[4], ($3::Bool[])[5], ($3::Bool[])[6], ($3::Bool[])[7], $4, $5, $6, $7)
+				tgt_rotation_id,
+				holiday_calendar_id,
+				holiday_mode,
+				tier
+			) values ($1, $2, ($3::Bool[])[1], ($3::Bool[])[2], ($3::Bool[])[3], ($3::Bool[])[4], ($3::Bool[])[5], ($3::Bool[])[6], ($3::Bool[])[7], $4, $5, $6, $7, $8, $9, $10)
 		`),
 		update: p.P(`
 			update schedule_rules
@@ -61,7 +64,10 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				start_time = $4,
 				end_time = $5,
 				tgt_user_id = $6,
-				tgt_rotation_id = $7
+				tgt_rotation_id = $7,
+				holiday_calendar_id = $8,
+				holiday_mode = $9,
+				tier = $10
 			where id = $1
 		`),
 		delete: p.P(`delete from schedule_rules where id = any($1)`),
@@ -82,9 +88,12 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				start_time,
 				end_time,
 				tgt_user_id,
-				tgt_rotation_id
+				tgt_rotation_id,
+				holiday_calendar_id,
+				holiday_mode,
+				tier
 			from schedule_rules
-			where schedule_id = $1
+			where schedule_id = $1 AND tier IS NOT DISTINCT FROM nullif($2, '')
 			order by created_at, id
 		`),
 		findTgt: p.P(`
@@ -103,9 +112,12 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				start_time,
 				end_time,
 				tgt_user_id,
-				tgt_rotation_id
+				tgt_rotation_id,
+				holiday_calendar_id,
+				holiday_mode,
+				tier
 			from schedule_rules
-			where schedule_id = $1 AND (tgt_user_id = $2 OR tgt_rotation_id = $3)
+			where schedule_id = $1 AND (tgt_user_id = $2 OR tgt_rotation_id = $3) AND tier IS NOT DISTINCT FROM nullif($4, '')
 			order by created_at, id
 		`),
 	}, p.Err
@@ -146,7 +158,8 @@ func (s *Store) CreateRuleTx(ctx context.Context, tx *sql.Tx, r *Rule) (*Rule, e
 	return s._Add(ctx, tx.Stmt(s.add), r)
 }
 
-func (s *Store) FindByTargetTx(ctx context.Context, tx *sql.Tx, scheduleID string, target assignment.Target) ([]Rule, error) {
+// FindByTargetTx returns the rules of the given schedule tier for the target. An empty tier refers to the primary tier.
+func (s *Store) FindByTargetTx(ctx context.Context, tx *sql.Tx, scheduleID, tier string, target assignment.Target) ([]Rule, error) {
 	err := permission.LimitCheckAny(ctx, permission.All)
 	if err != nil {
 		return nil, err
@@ -175,7 +188,7 @@ func (s *Store) FindByTargetTx(ctx context.Context, tx *sql.Tx, scheduleID strin
 		tgtRot.String = target.TargetID()
 	}
 
-	rows, err := stmt.QueryContext(ctx, scheduleID, tgtUser, tgtRot)
+	rows, err := stmt.QueryContext(ctx, scheduleID, tgtUser, tgtRot, tier)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -242,11 +255,18 @@ func (s *Store) UpdateTx(ctx context.Context, tx *sql.Tx, r *Rule) error {
 	return nil
 }
 
+// FindAll returns the rules of the primary tier of the given schedule.
 func (s *Store) FindAll(ctx context.Context, scheduleID string) ([]Rule, error) {
 	return s.FindAllTx(ctx, nil, scheduleID)
 }
 
+// FindAllTx returns the rules of the primary tier of the given schedule.
 func (s *Store) FindAllTx(ctx context.Context, tx *sql.Tx, scheduleID string) ([]Rule, error) {
+	return s.FindAllByTierTx(ctx, tx, scheduleID, "")
+}
+
+// FindAllByTierTx returns the rules of the given schedule tier. An empty tier refers to the primary tier.
+func (s *Store) FindAllByTierTx(ctx context.Context, tx *sql.Tx, scheduleID, tier string) ([]Rule, error) {
 	err := validate.UUID("ScheduleID", scheduleID)
 	if err != nil {
 		return nil, err
@@ -259,7 +279,7 @@ func (s *Store) FindAllTx(ctx context.Context, tx *sql.Tx, scheduleID string) ([
 	if tx != nil {
 		stmt = tx.StmtContext(ctx, stmt)
 	}
-	rows, err := stmt.QueryContext(ctx, scheduleID)
+	rows, err := stmt.QueryContext(ctx, scheduleID, tier)
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +42,9 @@ func (store *Store) SetOnCallNotificationRules(ctx context.Context, tx *sql.Tx,
 		if r.WeekdayFilter == nil && r.Time != nil {
 			return validation.NewFieldError("Rules[%d].WeekdayFilter", "Weekday filter is required with Time.")
 		}
+		if r.HandoffReport && r.Time != nil {
+			return validation.NewFieldError(fmt.Sprintf("Rules[%d].HandoffReport", i), "Handoff reports may only be sent by on-change rules.")
+		}
 		key := dupkey{
 			HasTime: r.Time != nil,
 			Channel: r.ChannelID,
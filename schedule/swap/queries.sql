@@ -0,0 +1,64 @@
+-- name: SwapRequestInsert :exec
+INSERT INTO shift_swap_requests(id, schedule_id, requester_id, counterpart_id, requester_shift_start, requester_shift_end, counterpart_shift_start, counterpart_shift_end, message)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: SwapRequestNotify :execrows
+-- SwapRequestNotify queues a swap request notification to each contact method the counterpart has set to be notified immediately.
+INSERT INTO outgoing_messages(message_type, contact_method_id, user_id, shift_swap_request_id)
+SELECT
+    'shift_swap_request',
+    cm.id,
+    cm.user_id,
+    @request_id
+FROM
+    user_contact_methods cm
+WHERE
+    cm.user_id = @user_id
+    AND NOT cm.disabled
+    AND EXISTS (
+        SELECT
+        FROM
+            user_notification_rules r
+        WHERE
+            r.contact_method_id = cm.id
+            AND r.delay_minutes = 0);
+
+-- name: SwapRequestFind :one
+SELECT
+    *
+FROM
+    shift_swap_requests
+WHERE
+    id = $1;
+
+-- name: SwapRequestForUpdate :one
+SELECT
+    *
+FROM
+    shift_swap_requests
+WHERE
+    id = $1
+FOR UPDATE;
+
+-- name: SwapRequestSetStatus :exec
+UPDATE
+    shift_swap_requests
+SET
+    status = $2,
+    responded_at = now()
+WHERE
+    id = $1;
+
+-- name: SwapRequestListByUser :many
+-- SwapRequestListByUser returns the most recent swap requests sent or received by a user.
+SELECT
+    *
+FROM
+    shift_swap_requests
+WHERE (requester_id = @user_id
+    OR counterpart_id = @user_id)
+AND (NOT @pending_only::bool
+    OR status = 'pending')
+ORDER BY
+    created_at DESC
+LIMIT 100;
@@ -0,0 +1,338 @@
+package swap
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/override"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages shift swap requests.
+type Store struct {
+	db *sql.DB
+
+	oc *oncall.Store
+	ov *override.Store
+}
+
+// NewStore creates a new Store, using the on-call store to verify shifts and
+// the override store to apply accepted swaps.
+func NewStore(db *sql.DB, oc *oncall.Store, ov *override.Store) *Store {
+	return &Store{db: db, oc: oc, ov: ov}
+}
+
+// CreateOpts is used to propose a new swap.
+type CreateOpts struct {
+	ScheduleID    string
+	CounterpartID string
+
+	// RequesterShift is the shift, belonging to the current user, that the counterpart would take.
+	RequesterShift Shift
+
+	// CounterpartShift, if set, is the shift belonging to the counterpart that the current user would take in exchange.
+	CounterpartShift *Shift
+
+	Message string
+}
+
+// verifyShifts ensures each user is still on call for the shifts they are giving up.
+func (s *Store) verifyShifts(ctx context.Context, schedID uuid.UUID, req Request) error {
+	check := func(fname string, userID uuid.UUID, sh Shift) error {
+		shifts, err := s.oc.HistoryBySchedule(ctx, schedID.String(), sh.Start, sh.End)
+		if err != nil {
+			return fmt.Errorf("calculate shifts: %w", err)
+		}
+		if !sh.coveredBy(userID.String(), shifts) {
+			return validation.NewFieldError(fname, "user is not on call for the entire shift")
+		}
+		return nil
+	}
+
+	err := check("RequesterShift", req.RequesterID, req.RequesterShift)
+	if err != nil {
+		return err
+	}
+	if req.CounterpartShift == nil {
+		return nil
+	}
+
+	return check("CounterpartShift", req.CounterpartID, *req.CounterpartShift)
+}
+
+// Create will propose a swap on behalf of the current user and notify the counterpart.
+func (s *Store) Create(ctx context.Context, opts CreateOpts) (*Request, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	schedID, err := validate.ParseUUID("ScheduleID", opts.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	cpID, err := validate.ParseUUID("CounterpartID", opts.CounterpartID)
+	if err != nil {
+		return nil, err
+	}
+	reqID, err := uuid.Parse(permission.UserID(ctx))
+	if err != nil {
+		return nil, permission.NewAccessDenied("shift swaps require a user")
+	}
+	if cpID == reqID {
+		return nil, validation.NewFieldError("CounterpartID", "cannot swap shifts with yourself")
+	}
+	err = validate.Many(
+		opts.RequesterShift.validate("RequesterShift", now),
+		validate.Text("Message", opts.Message, 0, 255),
+	)
+	if opts.CounterpartShift != nil {
+		err = validate.Many(err, opts.CounterpartShift.validate("CounterpartShift", now))
+		if err == nil && opts.CounterpartShift.overlaps(opts.RequesterShift) {
+			err = validation.NewFieldError("CounterpartShift", "must not overlap the requester's shift")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	req := Request{
+		ID:               uuid.New(),
+		ScheduleID:       schedID,
+		RequesterID:      reqID,
+		CounterpartID:    cpID,
+		RequesterShift:   opts.RequesterShift,
+		CounterpartShift: opts.CounterpartShift,
+		Message:          opts.Message,
+		Status:           StatusPending,
+		CreatedAt:        now,
+	}
+	err = s.verifyShifts(ctx, schedID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var cpStart, cpEnd sql.NullTime
+	if req.CounterpartShift != nil {
+		cpStart = sql.NullTime{Valid: true, Time: req.CounterpartShift.Start}
+		cpEnd = sql.NullTime{Valid: true, Time: req.CounterpartShift.End}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlutil.Rollback(ctx, "swap: create request", tx)
+
+	q := gadb.New(tx)
+	err = q.SwapRequestInsert(ctx, gadb.SwapRequestInsertParams{
+		ID:                    req.ID,
+		ScheduleID:            schedID,
+		RequesterID:           reqID,
+		CounterpartID:         cpID,
+		RequesterShiftStart:   req.RequesterShift.Start,
+		RequesterShiftEnd:     req.RequesterShift.End,
+		CounterpartShiftStart: cpStart,
+		CounterpartShiftEnd:   cpEnd,
+		Message:               req.Message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := q.SwapRequestNotify(ctx, gadb.SwapRequestNotifyParams{
+		RequestID: uuid.NullUUID{Valid: true, UUID: req.ID},
+		UserID:    cpID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("queue notifications: %w", err)
+	}
+	if n == 0 {
+		// not fatal, the request is still visible to the counterpart
+		log.Logf(ctx, "swap: counterpart %s has no immediate contact methods to notify", cpID)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// FindOne returns the swap request with the given ID. Only the requester, counterpart, or an admin may view it.
+func (s *Store) FindOne(ctx context.Context, id string) (*Request, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	reqID, err := validate.ParseUUID("ID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := gadb.New(s.db).SwapRequestFind(ctx, reqID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("ID", "swap request not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(row.RequesterID.String()), permission.MatchUser(row.CounterpartID.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	req := fromDB(row)
+	return &req, nil
+}
+
+// FindAllForUser returns recent swap requests sent or received by the current user.
+func (s *Store) FindAllForUser(ctx context.Context, pendingOnly bool) ([]Request, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(permission.UserID(ctx))
+	if err != nil {
+		return nil, permission.NewAccessDenied("shift swaps require a user")
+	}
+
+	rows, err := gadb.New(s.db).SwapRequestListByUser(ctx, gadb.SwapRequestListByUserParams{
+		UserID:      userID,
+		PendingOnly: pendingOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Request, len(rows))
+	for i, r := range rows {
+		result[i] = fromDB(r)
+	}
+
+	return result, nil
+}
+
+// respond locks a pending request and calls fn within the same transaction before updating its status.
+func (s *Store) respond(ctx context.Context, id string, status Status, fn func(*sql.Tx, Request) error) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+	reqID, err := validate.ParseUUID("ID", id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlutil.Rollback(ctx, "swap: respond to request", tx)
+
+	q := gadb.New(tx)
+	row, err := q.SwapRequestForUpdate(ctx, reqID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("ID", "swap request not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	userID := permission.UserID(ctx)
+	switch status {
+	case StatusCancelled:
+		if row.RequesterID.String() != userID {
+			return permission.NewAccessDenied("only the requester may cancel a swap request")
+		}
+	default:
+		if row.CounterpartID.String() != userID {
+			return permission.NewAccessDenied("only the counterpart may respond to a swap request")
+		}
+	}
+	if row.Status != StatusPending {
+		return validation.NewFieldError("ID", "swap request was already "+string(row.Status))
+	}
+
+	if fn != nil {
+		err = fn(tx, fromDB(row))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = q.SwapRequestSetStatus(ctx, gadb.SwapRequestSetStatusParams{ID: reqID, Status: status})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Accept will accept a pending swap request for the current user, creating
+// the overrides that move each shift to the other user.
+func (s *Store) Accept(ctx context.Context, id string) error {
+	req, err := s.FindOne(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Shift calculation holds its own transaction, so verify before locking the request.
+	err = s.verifyShifts(ctx, req.ScheduleID, *req)
+	if err != nil {
+		return err
+	}
+
+	return s.respond(ctx, id, StatusAccepted, func(tx *sql.Tx, req Request) error {
+		tgt := assignment.ScheduleTarget(req.ScheduleID.String())
+		_, err := s.ov.CreateUserOverrideTx(ctx, tx, &override.UserOverride{
+			AddUserID:    req.CounterpartID.String(),
+			RemoveUserID: req.RequesterID.String(),
+			Start:        req.RequesterShift.Start,
+			End:          req.RequesterShift.End,
+			Target:       tgt,
+		})
+		if err != nil {
+			return fmt.Errorf("override requester shift: %w", err)
+		}
+		if req.CounterpartShift == nil {
+			return nil
+		}
+
+		_, err = s.ov.CreateUserOverrideTx(ctx, tx, &override.UserOverride{
+			AddUserID:    req.RequesterID.String(),
+			RemoveUserID: req.CounterpartID.String(),
+			Start:        req.CounterpartShift.Start,
+			End:          req.CounterpartShift.End,
+			Target:       tgt,
+		})
+		if err != nil {
+			return fmt.Errorf("override counterpart shift: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Decline will decline a pending swap request for the current user.
+func (s *Store) Decline(ctx context.Context, id string) error {
+	return s.respond(ctx, id, StatusDeclined, nil)
+}
+
+// Cancel will withdraw a pending swap request made by the current user.
+func (s *Store) Cancel(ctx context.Context, id string) error {
+	return s.respond(ctx, id, StatusCancelled, nil)
+}
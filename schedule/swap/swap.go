@@ -0,0 +1,115 @@
+// Package swap implements shift swap requests, allowing a user to propose
+// trading on-call shifts with another user on the same schedule.
+//
+// The counterpart is notified through their contact methods and may accept or
+// decline. Accepting a request creates the matching pair of user overrides.
+package swap
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/validation"
+)
+
+// Status indicates the state of a swap request.
+type Status = gadb.EnumShiftSwapStatus
+
+// Possible statuses of a swap request.
+const (
+	StatusPending   = gadb.EnumShiftSwapStatusPending
+	StatusAccepted  = gadb.EnumShiftSwapStatusAccepted
+	StatusDeclined  = gadb.EnumShiftSwapStatusDeclined
+	StatusCancelled = gadb.EnumShiftSwapStatusCancelled
+)
+
+// Shift is a span of on-call time offered as part of a swap.
+type Shift struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Request is a proposal to trade on-call shifts between two users.
+type Request struct {
+	ID            uuid.UUID
+	ScheduleID    uuid.UUID
+	RequesterID   uuid.UUID
+	CounterpartID uuid.UUID
+
+	RequesterShift Shift
+
+	// CounterpartShift is nil if the requester is only asking for their shift
+	// to be covered.
+	CounterpartShift *Shift
+
+	Message     string
+	Status      Status
+	CreatedAt   time.Time
+	RespondedAt *time.Time
+}
+
+func fromDB(r gadb.ShiftSwapRequest) Request {
+	req := Request{
+		ID:             r.ID,
+		ScheduleID:     r.ScheduleID,
+		RequesterID:    r.RequesterID,
+		CounterpartID:  r.CounterpartID,
+		RequesterShift: Shift{Start: r.RequesterShiftStart, End: r.RequesterShiftEnd},
+		Message:        r.Message,
+		Status:         r.Status,
+		CreatedAt:      r.CreatedAt,
+	}
+	if r.CounterpartShiftStart.Valid {
+		req.CounterpartShift = &Shift{Start: r.CounterpartShiftStart.Time, End: r.CounterpartShiftEnd.Time}
+	}
+	if r.RespondedAt.Valid {
+		req.RespondedAt = &r.RespondedAt.Time
+	}
+
+	return req
+}
+
+func (s Shift) overlaps(o Shift) bool { return s.Start.Before(o.End) && o.Start.Before(s.End) }
+
+func (s Shift) validate(fname string, now time.Time) error {
+	if !s.Start.Before(s.End) {
+		return validation.NewFieldError(fname, "end must occur after start")
+	}
+	if !s.End.After(now) {
+		return validation.NewFieldError(fname, "must end in the future")
+	}
+
+	return nil
+}
+
+// coveredBy returns true if userID is on call for the entirety of the shift.
+//
+// Consecutive shifts are merged, so a swap may span multiple calculated shifts
+// (e.g., two back-to-back rotation turns) as long as there is no gap.
+func (s Shift) coveredBy(userID string, shifts []oncall.Shift) bool {
+	t := s.Start
+	for {
+		var advanced bool
+		for _, sh := range shifts {
+			if sh.UserID != userID || sh.Start.After(t) {
+				continue
+			}
+			if sh.End.IsZero() {
+				// still on call, no end
+				return true
+			}
+			if sh.End.After(t) {
+				t = sh.End
+				advanced = true
+			}
+		}
+		if !t.Before(s.End) {
+			return true
+		}
+		if !advanced {
+			return false
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package swap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/oncall"
+)
+
+func TestShift_CoveredBy(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, 1, 1, h, 0, 0, 0, time.UTC) }
+	sh := Shift{Start: at(8), End: at(16)}
+
+	assert.True(t, sh.coveredBy("a", []oncall.Shift{
+		{UserID: "a", Start: at(6), End: at(18)},
+	}), "single covering shift")
+	assert.True(t, sh.coveredBy("a", []oncall.Shift{
+		{UserID: "a", Start: at(12), End: at(20)},
+		{UserID: "a", Start: at(0), End: at(12)},
+	}), "consecutive shifts")
+	assert.True(t, sh.coveredBy("a", []oncall.Shift{
+		{UserID: "a", Start: at(4)},
+	}), "open-ended shift")
+
+	assert.False(t, sh.coveredBy("a", []oncall.Shift{
+		{UserID: "a", Start: at(8), End: at(11)},
+		{UserID: "a", Start: at(12), End: at(16)},
+	}), "gap between shifts")
+	assert.False(t, sh.coveredBy("a", []oncall.Shift{
+		{UserID: "b", Start: at(0), End: at(20)},
+	}), "different user")
+	assert.False(t, sh.coveredBy("a", []oncall.Shift{
+		{UserID: "a", Start: at(9), End: at(16)},
+	}), "starts late")
+	assert.False(t, sh.coveredBy("a", nil), "no shifts")
+}
+
+func TestShift_Validate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, Shift{Start: now.Add(-time.Hour), End: now.Add(time.Hour)}.validate("Shift", now))
+	assert.Error(t, Shift{Start: now.Add(time.Hour), End: now.Add(time.Hour)}.validate("Shift", now), "zero length")
+	assert.Error(t, Shift{Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)}.validate("Shift", now), "in the past")
+}
+
+func TestShift_Overlaps(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, 1, 1, h, 0, 0, 0, time.UTC) }
+
+	assert.True(t, Shift{Start: at(8), End: at(12)}.overlaps(Shift{Start: at(11), End: at(14)}))
+	assert.False(t, Shift{Start: at(8), End: at(12)}.overlaps(Shift{Start: at(12), End: at(14)}), "adjacent")
+}
@@ -0,0 +1,152 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+const (
+	// PrimaryTier is the name of the implicit tier made up of the schedule's own rules, overrides, and temporary schedules.
+	PrimaryTier = "primary"
+
+	// MaxTiersPerSchedule is the maximum number of additional tiers a schedule may have.
+	MaxTiersPerSchedule = 5
+
+	// MaxTierRotationOffset is the maximum number of positions a tier may be offset from the primary rotations.
+	MaxTierRotationOffset = 100
+)
+
+var tierNameRx = regexp.MustCompile(`^[a-z][a-z0-9-]{0,31}$`)
+
+// A Tier is an additional, named set of on-call users of a schedule (e.g., "secondary").
+//
+// A tier has its own rules, and escalation policy steps may target it
+// instead of the primary tier. If RotationOffset is set, the tier also
+// includes the participants of the primary tier's rotations, shifted by that
+// many positions, so a secondary automatically follows the primary.
+type Tier struct {
+	ScheduleID     string
+	Name           string
+	RotationOffset int
+}
+
+// ValidateTierName will validate the name of a non-primary tier.
+func ValidateTierName(fname, name string) error {
+	if name == PrimaryTier {
+		return validation.NewFieldError(fname, "the primary tier is implicit and cannot be named")
+	}
+	if !tierNameRx.MatchString(name) {
+		return validation.NewFieldError(fname, "must start with a lower-case letter and contain only lower-case letters, numbers, and hyphens (up to 32 characters)")
+	}
+
+	return nil
+}
+
+// Normalize will validate the tier and return a normalized copy.
+func (t Tier) Normalize() (*Tier, error) {
+	err := validate.Many(
+		ValidateTierName("Name", t.Name),
+		validate.Range("RotationOffset", t.RotationOffset, 0, MaxTierRotationOffset),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func (store *Store) tierDB(tx *sql.Tx) *gadb.Queries {
+	db := gadb.New(store.db)
+	if tx != nil {
+		db = db.WithTx(tx)
+	}
+	return db
+}
+
+// Tiers returns the additional tiers of the provided scheduleID.
+func (store *Store) Tiers(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID) ([]Tier, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := store.tierDB(tx).ScheduleTiers(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	tiers := make([]Tier, len(rows))
+	for i, r := range rows {
+		tiers[i] = Tier{ScheduleID: scheduleID.String(), Name: r.Name, RotationOffset: int(r.RotationOffset)}
+	}
+
+	return tiers, nil
+}
+
+// TierExists returns true if the provided scheduleID has a tier with the given name.
+func (store *Store) TierExists(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, name string) (bool, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return false, err
+	}
+
+	return store.tierDB(tx).ScheduleTierExists(ctx, gadb.ScheduleTierExistsParams{
+		ScheduleID: scheduleID,
+		Name:       name,
+	})
+}
+
+// SetTier will create or update a tier of the provided scheduleID.
+func (store *Store) SetTier(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, t Tier) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := t.Normalize()
+	if err != nil {
+		return err
+	}
+
+	db := store.tierDB(tx)
+	tiers, err := db.ScheduleTiers(ctx, scheduleID)
+	if err != nil {
+		return err
+	}
+	exists := false
+	for _, r := range tiers {
+		if r.Name == n.Name {
+			exists = true
+			break
+		}
+	}
+	if !exists && len(tiers) >= MaxTiersPerSchedule {
+		return validation.NewFieldError("Name", "schedule already has the maximum number of tiers")
+	}
+
+	return db.ScheduleSetTier(ctx, gadb.ScheduleSetTierParams{
+		ScheduleID:     scheduleID,
+		Name:           n.Name,
+		RotationOffset: int32(n.RotationOffset),
+	})
+}
+
+// DeleteTier will delete a tier of the provided scheduleID, along with its rules and any escalation policy actions targeting it.
+func (store *Store) DeleteTier(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, name string) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	return store.tierDB(tx).ScheduleDeleteTier(ctx, gadb.ScheduleDeleteTierParams{
+		ScheduleID: scheduleID,
+		Name:       name,
+	})
+}
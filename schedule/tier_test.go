@@ -0,0 +1,36 @@
+package schedule
+
+import (
+	"testing"
+)
+
+func TestTier_Normalize(t *testing.T) {
+	test := func(valid bool, name string, tier Tier) {
+		t.Run(name, func(t *testing.T) {
+			_, err := tier.Normalize()
+			if valid && err != nil {
+				t.Errorf("err = %v; want nil", err)
+			} else if !valid && err == nil {
+				t.Errorf("err = nil; want != nil")
+			}
+		})
+	}
+
+	data := []struct {
+		v bool
+		n string
+		t Tier
+	}{
+		{true, "secondary", Tier{Name: "secondary"}},
+		{true, "offset", Tier{Name: "follow-the-sun-2", RotationOffset: 1}},
+		{false, "missing name", Tier{}},
+		{false, "primary", Tier{Name: "primary"}},
+		{false, "upper case", Tier{Name: "Secondary"}},
+		{false, "leading number", Tier{Name: "2nd"}},
+		{false, "too long", Tier{Name: "abcdefghijklmnopqrstuvwxyzabcdefg"}},
+		{false, "negative offset", Tier{Name: "secondary", RotationOffset: -1}},
+	}
+	for _, d := range data {
+		test(d.v, d.n, d.t)
+	}
+}
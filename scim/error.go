@@ -0,0 +1,80 @@
+package scim
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+// SCIM error types, as defined in RFC 7644 Section 3.12.
+const (
+	TypeInvalidFilter = "invalidFilter"
+	TypeUniqueness    = "uniqueness"
+	TypeInvalidSyntax = "invalidSyntax"
+	TypeInvalidPath   = "invalidPath"
+	TypeNoTarget      = "noTarget"
+	TypeInvalidValue  = "invalidValue"
+	TypeMutability    = "mutability"
+)
+
+// Error is a SCIM protocol error with an HTTP status and optional SCIM error type.
+type Error struct {
+	Status int
+	Type   string
+	Detail string
+}
+
+func (e *Error) Error() string { return e.Detail }
+
+func errNotFound(resource, id string) error {
+	return &Error{Status: http.StatusNotFound, Detail: resource + " " + id + " not found"}
+}
+
+type errorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// writeError will write a SCIM error response if err is non-nil, returning true if it did.
+func writeError(ctx context.Context, w http.ResponseWriter, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var scimErr *Error
+	err = errutil.MapDBError(err)
+	switch {
+	case errors.As(err, &scimErr):
+	case errors.Is(err, sql.ErrNoRows):
+		scimErr = &Error{Status: http.StatusNotFound, Detail: "resource not found"}
+	case permission.IsUnauthorized(err):
+		scimErr = &Error{Status: http.StatusUnauthorized, Detail: err.Error()}
+	case permission.IsPermissionError(err):
+		scimErr = &Error{Status: http.StatusForbidden, Detail: err.Error()}
+	case validation.IsClientError(err):
+		scimErr = &Error{Status: http.StatusBadRequest, Type: TypeInvalidValue, Detail: err.Error()}
+	case errutil.IsLimitError(err):
+		scimErr = &Error{Status: http.StatusConflict, Detail: err.Error()}
+	default:
+		log.Log(ctx, err)
+		scimErr = &Error{Status: http.StatusInternalServerError, Detail: http.StatusText(http.StatusInternalServerError)}
+	}
+
+	writeJSON(w, scimErr.Status, errorResponse{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(scimErr.Status),
+		ScimType: scimErr.Type,
+		Detail:   scimErr.Detail,
+	})
+
+	return true
+}
@@ -0,0 +1,49 @@
+package scim
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// filter is a parsed SCIM filter expression.
+//
+// Only simple equality comparisons (e.g. `userName eq "bob"`) are supported,
+// which covers the lookups performed by common identity providers.
+type filter struct {
+	Attr  string
+	Value string
+}
+
+// parseFilter parses a SCIM filter of the form `attr eq "value"`.
+//
+// An empty string returns a nil filter.
+func parseFilter(s string) (*filter, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	attr, rest, ok := strings.Cut(s, " ")
+	if !ok {
+		return nil, &Error{Status: http.StatusBadRequest, Type: TypeInvalidFilter, Detail: "invalid filter: " + s}
+	}
+	op, val, ok := strings.Cut(strings.TrimSpace(rest), " ")
+	if !ok || !strings.EqualFold(op, "eq") {
+		return nil, &Error{Status: http.StatusBadRequest, Type: TypeInvalidFilter, Detail: "unsupported filter (only 'eq' is supported): " + s}
+	}
+
+	val = strings.TrimSpace(val)
+	if strings.HasPrefix(val, `"`) {
+		unq, err := strconv.Unquote(val)
+		if err != nil {
+			return nil, &Error{Status: http.StatusBadRequest, Type: TypeInvalidFilter, Detail: "invalid filter value: " + val}
+		}
+		val = unq
+	}
+
+	return &filter{Attr: attr, Value: val}, nil
+}
+
+// Is returns true if the filter applies to the given attribute name (case-insensitive).
+func (f filter) Is(attr string) bool { return strings.EqualFold(f.Attr, attr) }
@@ -0,0 +1,456 @@
+package scim
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Group is a SCIM Group resource, backed by a rotation.
+type Group struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []Member `json:"members,omitempty"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+// Member is a member of a SCIM Group.
+type Member struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// memberIDs returns the distinct user IDs of the group members, in order.
+func (g Group) memberIDs() []string {
+	var ids []string
+	for _, m := range g.Members {
+		if slices.Contains(ids, m.Value) {
+			continue
+		}
+		ids = append(ids, m.Value)
+	}
+
+	return ids
+}
+
+// rotationUserIDs returns the distinct user IDs participating in the rotation, in order.
+func (h *Handler) rotationUserIDs(ctx context.Context, tx *sql.Tx, rotationID string) ([]string, error) {
+	parts, err := h.c.RotationStore.FindAllParticipantsTx(ctx, tx, rotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, p := range parts {
+		if p.Target == nil || slices.Contains(ids, p.Target.TargetID()) {
+			continue
+		}
+		ids = append(ids, p.Target.TargetID())
+	}
+
+	return ids, nil
+}
+
+func (h *Handler) newGroupResource(ctx context.Context, r rotation.Rotation, withMembers bool) (*Group, error) {
+	cfg := config.FromContext(ctx)
+	g := &Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          r.ID,
+		DisplayName: r.Name,
+		Meta: &Meta{
+			ResourceType: "Group",
+			Location:     location(cfg, "Groups", r.ID),
+		},
+	}
+	if !withMembers {
+		return g, nil
+	}
+
+	ids, err := h.rotationUserIDs(ctx, nil, r.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		g.Members = []Member{}
+		return g, nil
+	}
+
+	users, err := h.c.UserStore.FindMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Name
+	}
+	for _, id := range ids {
+		g.Members = append(g.Members, Member{
+			Value:   id,
+			Display: names[id],
+			Ref:     location(cfg, "Users", id),
+		})
+	}
+
+	return g, nil
+}
+
+// excludesMembers returns true if the request asks to omit the members attribute.
+func excludesMembers(req *http.Request) bool {
+	for _, a := range strings.Split(req.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(a), "members") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scimRotations returns the rotations created via SCIM, sorted by name. If name is
+// not empty, only rotations with a matching (case-insensitive) name are returned.
+//
+// Only ID and Name are set on the returned rotations.
+func (h *Handler) scimRotations(ctx context.Context, name string) ([]rotation.Rotation, error) {
+	rows, err := gadb.New(h.c.DB).SCIMGroupList(ctx, sql.NullString{String: name, Valid: name != ""})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]rotation.Rotation, len(rows))
+	for i, r := range rows {
+		result[i] = rotation.Rotation{ID: r.ID.String(), Name: r.Name}
+	}
+
+	return result, nil
+}
+
+// findGroup returns the rotation for the given group ID.
+//
+// Only rotations created via SCIM are visible as groups.
+func (h *Handler) findGroup(ctx context.Context, id string) (*rotation.Rotation, error) {
+	rotID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errNotFound("Group", id)
+	}
+
+	isSCIM, err := gadb.New(h.c.DB).SCIMGroupExists(ctx, rotID)
+	if err != nil {
+		return nil, err
+	}
+	if !isSCIM {
+		return nil, errNotFound("Group", id)
+	}
+
+	r, err := h.c.RotationStore.FindRotation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ServeListGroups serves a list of groups (rotations).
+func (h *Handler) ServeListGroups(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+	startIndex, count := pageParams(req)
+
+	f, err := parseFilter(req.URL.Query().Get("filter"))
+	if writeError(ctx, w, err) {
+		return
+	}
+	if f != nil && !f.Is("displayName") {
+		writeError(ctx, w, &Error{Status: http.StatusBadRequest, Type: TypeInvalidFilter, Detail: "unsupported filter attribute: " + f.Attr})
+		return
+	}
+
+	var name string
+	if f != nil {
+		name = f.Value
+	}
+	rots, err := h.scimRotations(ctx, name)
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	start, end := pageBounds(len(rots), startIndex, count)
+	withMembers := !excludesMembers(req)
+	resources := make([]interface{}, 0, end-start)
+	for _, r := range rots[start:end] {
+		g, err := h.newGroupResource(ctx, r, withMembers)
+		if writeError(ctx, w, err) {
+			return
+		}
+		resources = append(resources, g)
+	}
+
+	writeJSON(w, http.StatusOK, newListResponse(len(rots), startIndex, resources))
+}
+
+// ServeGetGroup serves a single group (rotation).
+func (h *Handler) ServeGetGroup(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+
+	r, err := h.findGroup(ctx, req.PathValue("id"))
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	g, err := h.newGroupResource(ctx, *r, !excludesMembers(req))
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, g)
+}
+
+// ServeCreateGroup creates a new weekly rotation for the group, with members as participants.
+func (h *Handler) ServeCreateGroup(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+
+	var g Group
+	if writeError(ctx, w, readJSON(req, &g)) {
+		return
+	}
+	ids := g.memberIDs()
+	if writeError(ctx, w, validate.ManyUUID("members", ids, 50)) {
+		return
+	}
+
+	tx, err := h.c.DB.BeginTx(ctx, nil)
+	if writeError(ctx, w, err) {
+		return
+	}
+	defer sqlutil.Rollback(ctx, "scim: create group", tx)
+
+	r, err := h.c.RotationStore.CreateRotationTx(ctx, tx, &rotation.Rotation{
+		Name:        g.DisplayName,
+		Description: "Provisioned via SCIM.",
+		Type:        rotation.TypeWeekly,
+		Start:       time.Now().UTC(),
+		ShiftLength: 1,
+	})
+	if writeError(ctx, w, err) {
+		return
+	}
+	err = gadb.New(tx).SCIMGroupInsert(ctx, uuid.MustParse(r.ID))
+	if writeError(ctx, w, err) {
+		return
+	}
+	if len(ids) > 0 {
+		err = h.c.RotationStore.AddRotationUsersTx(ctx, tx, r.ID, ids)
+		if writeError(ctx, w, err) {
+			return
+		}
+	}
+	if writeError(ctx, w, tx.Commit()) {
+		return
+	}
+
+	res, err := h.newGroupResource(ctx, *r, true)
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	w.Header().Set("Location", res.Meta.Location)
+	writeJSON(w, http.StatusCreated, res)
+}
+
+// updateGroup renames the rotation (if needed) and sets the participants to the given members.
+//
+// Existing participants keep their order (including repeated users); removed members are dropped
+// and new members are appended.
+func (h *Handler) updateGroup(ctx context.Context, w http.ResponseWriter, id, name string, memberIDs []string) {
+	if writeError(ctx, w, validate.ManyUUID("members", memberIDs, 50)) {
+		return
+	}
+
+	tx, err := h.c.DB.BeginTx(ctx, nil)
+	if writeError(ctx, w, err) {
+		return
+	}
+	defer sqlutil.Rollback(ctx, "scim: update group", tx)
+
+	r, err := h.c.RotationStore.FindRotationForUpdateTx(ctx, tx, id)
+	if writeError(ctx, w, err) {
+		return
+	}
+	if name != r.Name {
+		r.Name = name
+		err = h.c.RotationStore.UpdateRotationTx(ctx, tx, r)
+		if writeError(ctx, w, err) {
+			return
+		}
+	}
+
+	parts, err := h.c.RotationStore.FindAllParticipantsTx(ctx, tx, id)
+	if writeError(ctx, w, err) {
+		return
+	}
+	var userIDs []string
+	for _, p := range parts {
+		if p.Target == nil || !slices.Contains(memberIDs, p.Target.TargetID()) {
+			continue
+		}
+		// repeats are kept, as the order may have been adjusted by hand
+		userIDs = append(userIDs, p.Target.TargetID())
+	}
+	for _, id := range memberIDs {
+		if slices.Contains(userIDs, id) {
+			continue
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	err = h.c.RotationStore.SetParticipantUsersTx(ctx, tx, id, userIDs, true)
+	if writeError(ctx, w, err) {
+		return
+	}
+	if writeError(ctx, w, tx.Commit()) {
+		return
+	}
+
+	r, err = h.c.RotationStore.FindRotation(ctx, id)
+	if writeError(ctx, w, err) {
+		return
+	}
+	res, err := h.newGroupResource(ctx, *r, true)
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// ServeReplaceGroup handles a full update (PUT) of a group.
+func (h *Handler) ServeReplaceGroup(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+
+	r, err := h.findGroup(ctx, req.PathValue("id"))
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	var g Group
+	if writeError(ctx, w, readJSON(req, &g)) {
+		return
+	}
+
+	h.updateGroup(ctx, w, r.ID, g.DisplayName, g.memberIDs())
+}
+
+// ServePatchGroup handles a partial update (PATCH) of a group.
+func (h *Handler) ServePatchGroup(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+
+	r, err := h.findGroup(ctx, req.PathValue("id"))
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	var patch PatchRequest
+	if writeError(ctx, w, readJSON(req, &patch)) {
+		return
+	}
+
+	ids, err := h.rotationUserIDs(ctx, nil, r.ID)
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	cur := Group{DisplayName: r.Name, Members: make([]Member, len(ids))}
+	for i, id := range ids {
+		cur.Members[i] = Member{Value: id}
+	}
+
+	data, err := json.Marshal(cur)
+	if writeError(ctx, w, err) {
+		return
+	}
+	var doc map[string]interface{}
+	if writeError(ctx, w, json.Unmarshal(data, &doc)) {
+		return
+	}
+
+	for _, op := range patch.Operations {
+		// Some providers send `remove` for members with a value list instead of a path filter.
+		if strings.EqualFold(op.Op, "remove") && strings.EqualFold(op.Path, "members") && len(op.Value) > 0 {
+			var rm []Member
+			err = json.Unmarshal(op.Value, &rm)
+			if err != nil {
+				writeError(ctx, w, &Error{Status: http.StatusBadRequest, Type: TypeInvalidValue, Detail: err.Error()})
+				return
+			}
+			var ops []PatchOp
+			for _, m := range rm {
+				ops = append(ops, PatchOp{Op: "remove", Path: `members[value eq "` + m.Value + `"]`})
+			}
+			err = applyPatch(doc, ops)
+		} else {
+			err = applyPatch(doc, []PatchOp{op})
+		}
+		if writeError(ctx, w, err) {
+			return
+		}
+	}
+
+	data, err = json.Marshal(doc)
+	if writeError(ctx, w, err) {
+		return
+	}
+	var g Group
+	err = json.Unmarshal(data, &g)
+	if err != nil {
+		writeError(ctx, w, &Error{Status: http.StatusBadRequest, Type: TypeInvalidValue, Detail: err.Error()})
+		return
+	}
+
+	h.updateGroup(ctx, w, r.ID, g.DisplayName, g.memberIDs())
+}
+
+// ServeDeleteGroup deletes the rotation backing a group.
+func (h *Handler) ServeDeleteGroup(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+
+	r, err := h.findGroup(ctx, req.PathValue("id"))
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	err = h.c.RotationStore.DeleteManyTx(ctx, nil, []string{r.ID})
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,106 @@
+// Package scim implements a SCIM 2.0 (RFC 7643, RFC 7644) provisioning API.
+//
+// Users are mapped to GoAlert users and tracked with an auth subject under the
+// "scim" provider, using the SCIM userName as the subject ID. Groups are mapped
+// to rotations, with group members being the rotation participants. Only rotations
+// created via SCIM are visible as groups.
+package scim
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/user"
+)
+
+// ProviderID is the auth provider ID used to track SCIM-managed users.
+const ProviderID = "scim"
+
+// ContentType is the media type used for SCIM requests and responses.
+const ContentType = "application/scim+json"
+
+// Config contains the dependencies of the SCIM Handler.
+type Config struct {
+	DB            *sql.DB
+	UserStore     *user.Store
+	RotationStore *rotation.Store
+}
+
+// Handler serves SCIM 2.0 requests.
+type Handler struct {
+	c Config
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(c Config) *Handler {
+	return &Handler{c: c}
+}
+
+// authorize checks that SCIM is enabled and the request has admin-level access.
+func authorize(ctx context.Context) error {
+	cfg := config.FromContext(ctx)
+	if !cfg.SCIM.Enable {
+		return permission.NewAccessDenied("SCIM provisioning is disabled")
+	}
+
+	return permission.LimitCheckAny(ctx, permission.Admin)
+}
+
+// readJSON will decode the request body into v.
+func readJSON(req *http.Request, v interface{}) error {
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return &Error{Status: http.StatusBadRequest, Type: TypeInvalidSyntax, Detail: err.Error()}
+	}
+
+	return nil
+}
+
+// writeJSON will write v as a SCIM response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// pageParams returns the 1-based start index and count from the request query.
+func pageParams(req *http.Request) (startIndex, count int) {
+	startIndex, _ = strconv.Atoi(req.URL.Query().Get("startIndex"))
+	if startIndex < 1 {
+		startIndex = 1
+	}
+
+	count, err := strconv.Atoi(req.URL.Query().Get("count"))
+	if err != nil || count > maxPageSize {
+		count = maxPageSize
+	}
+	if count < 0 {
+		count = 0
+	}
+
+	return startIndex, count
+}
+
+// pageBounds returns the slice bounds for a page of total items.
+func pageBounds(total, startIndex, count int) (start, end int) {
+	start = min(startIndex-1, total)
+	end = min(start+count, total)
+	return start, end
+}
@@ -0,0 +1,235 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// PatchOp is a single SCIM PATCH operation.
+type PatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// PatchRequest is a SCIM PATCH request body.
+type PatchRequest struct {
+	Schemas    []string  `json:"schemas"`
+	Operations []PatchOp `json:"Operations"`
+}
+
+// patchPath is a parsed SCIM attribute path, e.g. `emails[type eq "work"].value`.
+type patchPath struct {
+	Attr    string
+	Filter  *filter
+	SubAttr string
+}
+
+func parsePatchPath(s string) (*patchPath, error) {
+	var p patchPath
+	s = strings.TrimSpace(s)
+
+	if attr, rest, ok := strings.Cut(s, "["); ok {
+		expr, sub, ok := strings.Cut(rest, "]")
+		if !ok {
+			return nil, &Error{Status: http.StatusBadRequest, Type: TypeInvalidPath, Detail: "invalid path: " + s}
+		}
+		f, err := parseFilter(expr)
+		if err != nil || f == nil {
+			return nil, &Error{Status: http.StatusBadRequest, Type: TypeInvalidPath, Detail: "invalid path filter: " + s}
+		}
+		p.Attr = attr
+		p.Filter = f
+		p.SubAttr = strings.TrimPrefix(sub, ".")
+		return &p, nil
+	}
+
+	p.Attr, p.SubAttr, _ = strings.Cut(s, ".")
+	if p.Attr == "" {
+		return nil, &Error{Status: http.StatusBadRequest, Type: TypeInvalidPath, Detail: "invalid path: " + s}
+	}
+
+	return &p, nil
+}
+
+// findKey returns the key in m that matches name (case-insensitive).
+func findKey(m map[string]interface{}, name string) string {
+	if _, ok := m[name]; ok {
+		return name
+	}
+	for k := range m {
+		if strings.EqualFold(k, name) {
+			return k
+		}
+	}
+
+	return name
+}
+
+// applyPatch applies SCIM PATCH operations to a resource document.
+//
+// The document is a generic JSON object (as produced by json.Unmarshal) and is modified in place.
+func applyPatch(doc map[string]interface{}, ops []PatchOp) error {
+	for _, op := range ops {
+		var val interface{}
+		if len(op.Value) > 0 {
+			err := json.Unmarshal(op.Value, &val)
+			if err != nil {
+				return &Error{Status: http.StatusBadRequest, Type: TypeInvalidSyntax, Detail: err.Error()}
+			}
+		}
+
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+			err := patchSet(doc, op.Path, val, strings.EqualFold(op.Op, "add"))
+			if err != nil {
+				return err
+			}
+		case "remove":
+			err := patchRemove(doc, op.Path)
+			if err != nil {
+				return err
+			}
+		default:
+			return &Error{Status: http.StatusBadRequest, Type: TypeInvalidSyntax, Detail: "unsupported op: " + op.Op}
+		}
+	}
+
+	return nil
+}
+
+func patchSet(doc map[string]interface{}, path string, val interface{}, isAdd bool) error {
+	if path == "" {
+		m, ok := val.(map[string]interface{})
+		if !ok {
+			return &Error{Status: http.StatusBadRequest, Type: TypeInvalidValue, Detail: "value must be an object when path is omitted"}
+		}
+		for k, v := range m {
+			err := patchSet(doc, k, v, isAdd)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	p, err := parsePatchPath(path)
+	if err != nil {
+		return err
+	}
+	key := findKey(doc, p.Attr)
+
+	if p.Filter != nil {
+		list, _ := doc[key].([]interface{})
+		var matched bool
+		for _, item := range list {
+			m, ok := item.(map[string]interface{})
+			if !ok || !matchFilter(m, p.Filter) {
+				continue
+			}
+			matched = true
+			if p.SubAttr == "" {
+				if vm, ok := val.(map[string]interface{}); ok {
+					for k, v := range vm {
+						m[findKey(m, k)] = v
+					}
+				}
+				continue
+			}
+			m[findKey(m, p.SubAttr)] = val
+		}
+		if !matched {
+			// no existing value, add a new one matching the filter
+			m := map[string]interface{}{p.Filter.Attr: p.Filter.Value}
+			if p.SubAttr == "" {
+				if vm, ok := val.(map[string]interface{}); ok {
+					for k, v := range vm {
+						m[k] = v
+					}
+				}
+			} else {
+				m[p.SubAttr] = val
+			}
+			list = append(list, m)
+		}
+		doc[key] = list
+		return nil
+	}
+
+	if p.SubAttr != "" {
+		m, _ := doc[key].(map[string]interface{})
+		if m == nil {
+			m = make(map[string]interface{})
+		}
+		m[findKey(m, p.SubAttr)] = val
+		doc[key] = m
+		return nil
+	}
+
+	if existing, ok := doc[key].([]interface{}); ok && isAdd {
+		if add, ok := val.([]interface{}); ok {
+			doc[key] = append(existing, add...)
+			return nil
+		}
+	}
+
+	doc[key] = val
+	return nil
+}
+
+func patchRemove(doc map[string]interface{}, path string) error {
+	if path == "" {
+		return &Error{Status: http.StatusBadRequest, Type: TypeNoTarget, Detail: "path is required for remove"}
+	}
+
+	p, err := parsePatchPath(path)
+	if err != nil {
+		return err
+	}
+	key := findKey(doc, p.Attr)
+
+	if p.Filter != nil {
+		list, _ := doc[key].([]interface{})
+		result := list[:0]
+		for _, item := range list {
+			m, ok := item.(map[string]interface{})
+			if !ok || !matchFilter(m, p.Filter) {
+				result = append(result, item)
+				continue
+			}
+			if p.SubAttr != "" {
+				delete(m, findKey(m, p.SubAttr))
+				result = append(result, m)
+			}
+		}
+		doc[key] = result
+		return nil
+	}
+
+	if p.SubAttr != "" {
+		if m, ok := doc[key].(map[string]interface{}); ok {
+			delete(m, findKey(m, p.SubAttr))
+		}
+		return nil
+	}
+
+	delete(doc, key)
+	return nil
+}
+
+func matchFilter(m map[string]interface{}, f *filter) bool {
+	v, ok := m[findKey(m, f.Attr)]
+	if !ok {
+		return false
+	}
+
+	switch v := v.(type) {
+	case string:
+		return v == f.Value
+	case bool:
+		return strings.EqualFold(f.Value, "true") == v
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := parseFilter(`userName eq "bob@example.com"`)
+	require.NoError(t, err)
+	assert.Equal(t, &filter{Attr: "userName", Value: "bob@example.com"}, f)
+	assert.True(t, f.Is("username"))
+
+	f, err = parseFilter("")
+	assert.NoError(t, err)
+	assert.Nil(t, f)
+
+	_, err = parseFilter(`userName co "bob"`)
+	assert.Error(t, err)
+
+	_, err = parseFilter(`userName`)
+	assert.Error(t, err)
+}
+
+func TestApplyPatch(t *testing.T) {
+	check := func(desc, doc, ops, expected string) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			var d map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(doc), &d))
+			var o []PatchOp
+			require.NoError(t, json.Unmarshal([]byte(ops), &o))
+
+			require.NoError(t, applyPatch(d, o))
+
+			data, err := json.Marshal(d)
+			require.NoError(t, err)
+			assert.JSONEq(t, expected, string(data))
+		})
+	}
+
+	check("replace-attr",
+		`{"active":true}`,
+		`[{"op":"Replace","path":"active","value":false}]`,
+		`{"active":false}`,
+	)
+	check("replace-no-path",
+		`{"active":true,"displayName":"Bob"}`,
+		`[{"op":"replace","value":{"active":false}}]`,
+		`{"active":false,"displayName":"Bob"}`,
+	)
+	check("sub-attr",
+		`{"name":{"givenName":"Bob"}}`,
+		`[{"op":"replace","path":"name.familyName","value":"Smith"}]`,
+		`{"name":{"givenName":"Bob","familyName":"Smith"}}`,
+	)
+	check("filter-replace",
+		`{"emails":[{"type":"work","value":"a@example.com"}]}`,
+		`[{"op":"replace","path":"emails[type eq \"work\"].value","value":"b@example.com"}]`,
+		`{"emails":[{"type":"work","value":"b@example.com"}]}`,
+	)
+	check("filter-add-missing",
+		`{}`,
+		`[{"op":"add","path":"emails[type eq \"work\"].value","value":"b@example.com"}]`,
+		`{"emails":[{"type":"work","value":"b@example.com"}]}`,
+	)
+	check("add-members",
+		`{"members":[{"value":"a"}]}`,
+		`[{"op":"add","path":"members","value":[{"value":"b"}]}]`,
+		`{"members":[{"value":"a"},{"value":"b"}]}`,
+	)
+	check("remove-member",
+		`{"members":[{"value":"a"},{"value":"b"}]}`,
+		`[{"op":"remove","path":"members[value eq \"a\"]"}]`,
+		`{"members":[{"value":"b"}]}`,
+	)
+	check("case-insensitive",
+		`{"displayName":"Bob"}`,
+		`[{"op":"replace","path":"DisplayName","value":"Robert"}]`,
+		`{"displayName":"Robert"}`,
+	)
+
+	err := applyPatch(map[string]interface{}{}, []PatchOp{{Op: "move", Path: "foo"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,28 @@
+-- name: SCIMGroupInsert :exec
+INSERT INTO scim_groups(rotation_id)
+    VALUES ($1);
+
+-- name: SCIMGroupExists :one
+-- SCIMGroupExists returns true if the rotation was created via SCIM.
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            scim_groups
+        WHERE
+            rotation_id = $1);
+
+-- name: SCIMGroupList :many
+-- SCIMGroupList returns the rotations created via SCIM, optionally filtered by (case-insensitive) name.
+SELECT
+    r.id,
+    r.name
+FROM
+    scim_groups g
+    JOIN rotations r ON r.id = g.rotation_id
+WHERE
+    sqlc.narg(name)::text IS NULL
+    OR lower(r.name) = lower(sqlc.narg(name))
+ORDER BY
+    r.name;
@@ -0,0 +1,213 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/config"
+)
+
+// Schema URNs used by the SCIM API.
+const (
+	SchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	schemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+)
+
+// maxPageSize is the maximum number of resources returned in a single list response.
+const maxPageSize = 200
+
+// Meta contains resource metadata.
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Location     string     `json:"location,omitempty"`
+	Created      *time.Time `json:"created,omitempty"`
+}
+
+// ListResponse is the response to a SCIM query.
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+func newListResponse(total, startIndex int, resources []interface{}) ListResponse {
+	if resources == nil {
+		resources = []interface{}{}
+	}
+	return ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// Bool is a boolean that also accepts string values (e.g. "True") when decoding, as sent by some identity providers.
+type Bool bool
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		*b = Bool(strings.EqualFold(s, "true"))
+		return nil
+	}
+
+	var v bool
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+	*b = Bool(v)
+	return nil
+}
+
+func location(cfg config.Config, resource, id string) string {
+	return cfg.CallbackURL("/api/v2/scim/" + resource + "/" + id)
+}
+
+// ServeServiceProviderConfig serves the SCIM service provider configuration.
+func (h *Handler) ServeServiceProviderConfig(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+
+	type supported struct {
+		Supported bool `json:"supported"`
+	}
+	type filterSupport struct {
+		Supported  bool `json:"supported"`
+		MaxResults int  `json:"maxResults"`
+	}
+	type bulkSupport struct {
+		Supported      bool `json:"supported"`
+		MaxOperations  int  `json:"maxOperations"`
+		MaxPayloadSize int  `json:"maxPayloadSize"`
+	}
+	type authScheme struct {
+		Type        string `json:"type"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Schemas               []string      `json:"schemas"`
+		Patch                 supported     `json:"patch"`
+		Bulk                  bulkSupport   `json:"bulk"`
+		Filter                filterSupport `json:"filter"`
+		ChangePassword        supported     `json:"changePassword"`
+		Sort                  supported     `json:"sort"`
+		ETag                  supported     `json:"etag"`
+		AuthenticationSchemes []authScheme  `json:"authenticationSchemes"`
+	}{
+		Schemas: []string{schemaServiceProviderConfig},
+		Patch:   supported{Supported: true},
+		Filter:  filterSupport{Supported: true, MaxResults: maxPageSize},
+		AuthenticationSchemes: []authScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "SCIM API Key",
+			Description: "Authentication using a SCIM API key created by an admin.",
+		}},
+	})
+}
+
+type resourceType struct {
+	Schemas  []string `json:"schemas"`
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Endpoint string   `json:"endpoint"`
+	Schema   string   `json:"schema"`
+}
+
+// ServeResourceTypes serves the list of supported SCIM resource types.
+func (h *Handler) ServeResourceTypes(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newListResponse(2, 1, []interface{}{
+		resourceType{Schemas: []string{schemaResourceType}, ID: "User", Name: "User", Endpoint: "/Users", Schema: SchemaUser},
+		resourceType{Schemas: []string{schemaResourceType}, ID: "Group", Name: "Group", Endpoint: "/Groups", Schema: SchemaGroup},
+	}))
+}
+
+type schemaAttr struct {
+	Name          string       `json:"name"`
+	Type          string       `json:"type"`
+	MultiValued   bool         `json:"multiValued"`
+	Required      bool         `json:"required"`
+	Mutability    string       `json:"mutability"`
+	Returned      string       `json:"returned"`
+	Uniqueness    string       `json:"uniqueness"`
+	SubAttributes []schemaAttr `json:"subAttributes,omitempty"`
+}
+
+type schemaDef struct {
+	Schemas    []string     `json:"schemas"`
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Attributes []schemaAttr `json:"attributes"`
+}
+
+func attr(name, typ string, sub ...schemaAttr) schemaAttr {
+	return schemaAttr{Name: name, Type: typ, Mutability: "readWrite", Returned: "default", Uniqueness: "none", SubAttributes: sub}
+}
+
+func multiAttr(name, typ string, sub ...schemaAttr) schemaAttr {
+	a := attr(name, typ, sub...)
+	a.MultiValued = true
+	return a
+}
+
+// ServeSchemas serves the definitions of the supported SCIM schemas.
+func (h *Handler) ServeSchemas(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+
+	userName := attr("userName", "string")
+	userName.Required = true
+	userName.Uniqueness = "server"
+
+	displayName := attr("displayName", "string")
+	displayName.Required = true
+
+	writeJSON(w, http.StatusOK, newListResponse(2, 1, []interface{}{
+		schemaDef{
+			Schemas: []string{schemaSchema},
+			ID:      SchemaUser,
+			Name:    "User",
+			Attributes: []schemaAttr{
+				userName,
+				attr("name", "complex", attr("formatted", "string"), attr("givenName", "string"), attr("familyName", "string")),
+				attr("displayName", "string"),
+				multiAttr("emails", "complex", attr("value", "string"), attr("type", "string"), attr("primary", "boolean")),
+				attr("active", "boolean"),
+			},
+		},
+		schemaDef{
+			Schemas: []string{schemaSchema},
+			ID:      SchemaGroup,
+			Name:    "Group",
+			Attributes: []schemaAttr{
+				displayName,
+				multiAttr("members", "complex", attr("value", "string"), attr("display", "string")),
+			},
+		},
+	}))
+}
@@ -0,0 +1,517 @@
+package scim
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// User is a SCIM User resource.
+type User struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	UserName    string   `json:"userName"`
+	Name        *Name    `json:"name,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Emails      []Email  `json:"emails,omitempty"`
+	Active      *Bool    `json:"active,omitempty"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+// Name is the name of a SCIM User.
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// Email is an email address of a SCIM User.
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary Bool   `json:"primary,omitempty"`
+}
+
+// FullName returns the name to use for the GoAlert user.
+func (u User) FullName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name != nil {
+		if u.Name.Formatted != "" {
+			return u.Name.Formatted
+		}
+		if n := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); n != "" {
+			return n
+		}
+	}
+
+	return u.UserName
+}
+
+// PrimaryEmail returns the primary email address, or the first one if none are marked primary.
+func (u User) PrimaryEmail() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+
+	return ""
+}
+
+// IsActive returns false if the user has been explicitly marked inactive.
+func (u User) IsActive() bool { return u.Active == nil || bool(*u.Active) }
+
+func newUserResource(cfg config.Config, u user.User, userName string) User {
+	active := Bool(true)
+	res := User{
+		Schemas:     []string{SchemaUser},
+		ID:          u.ID,
+		UserName:    userName,
+		Name:        &Name{Formatted: u.Name},
+		DisplayName: u.Name,
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: "User",
+			Location:     location(cfg, "Users", u.ID),
+		},
+	}
+	if u.Email != "" {
+		res.Emails = []Email{{Value: u.Email, Type: "work", Primary: true}}
+	}
+
+	return res
+}
+
+// findUser returns the SCIM-managed user with the given ID, along with their SCIM userName.
+func (h *Handler) findUser(ctx context.Context, id string) (*user.User, string, error) {
+	err := validate.UUID("id", id)
+	if err != nil {
+		return nil, "", errNotFound("User", id)
+	}
+
+	var userName string
+	err = h.c.UserStore.AuthSubjectsFunc(ctx, ProviderID, []string{id}, func(s user.AuthSubject) error {
+		userName = s.SubjectID
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if userName == "" {
+		// only users provisioned via SCIM are visible
+		return nil, "", errNotFound("User", id)
+	}
+
+	u, err := h.c.UserStore.FindOne(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return u, userName, nil
+}
+
+// ServeListUsers serves a list of SCIM-managed users.
+func (h *Handler) ServeListUsers(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+	cfg := config.FromContext(ctx)
+	startIndex, count := pageParams(req)
+
+	f, err := parseFilter(req.URL.Query().Get("filter"))
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	var subjects []user.AuthSubject
+	switch {
+	case f == nil:
+		err = h.c.UserStore.AuthSubjectsFunc(ctx, ProviderID, nil, func(s user.AuthSubject) error {
+			subjects = append(subjects, s)
+			return nil
+		})
+		if writeError(ctx, w, err) {
+			return
+		}
+		sort.Slice(subjects, func(i, j int) bool { return subjects[i].SubjectID < subjects[j].SubjectID })
+	case f.Is("userName"):
+		if validate.SubjectID("userName", f.Value) != nil {
+			break
+		}
+		u, err := h.c.UserStore.FindOneBySubject(ctx, ProviderID, f.Value)
+		if writeError(ctx, w, err) {
+			return
+		}
+		if u != nil {
+			subjects = append(subjects, user.AuthSubject{ProviderID: ProviderID, SubjectID: f.Value, UserID: u.ID})
+		}
+	default:
+		writeError(ctx, w, &Error{Status: http.StatusBadRequest, Type: TypeInvalidFilter, Detail: "unsupported filter attribute: " + f.Attr})
+		return
+	}
+
+	start, end := pageBounds(len(subjects), startIndex, count)
+	page := subjects[start:end]
+	ids := make([]string, len(page))
+	for i, s := range page {
+		ids[i] = s.UserID
+	}
+
+	users := make(map[string]user.User, len(ids))
+	if len(ids) > 0 {
+		found, err := h.c.UserStore.FindMany(ctx, ids)
+		if writeError(ctx, w, err) {
+			return
+		}
+		for _, u := range found {
+			users[u.ID] = u
+		}
+	}
+
+	resources := make([]interface{}, 0, len(page))
+	for _, s := range page {
+		u, ok := users[s.UserID]
+		if !ok {
+			continue
+		}
+		resources = append(resources, newUserResource(cfg, u, s.SubjectID))
+	}
+
+	writeJSON(w, http.StatusOK, newListResponse(len(subjects), startIndex, resources))
+}
+
+// ServeGetUser serves a single SCIM-managed user.
+func (h *Handler) ServeGetUser(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+
+	u, userName, err := h.findUser(ctx, req.PathValue("id"))
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newUserResource(config.FromContext(ctx), *u, userName))
+}
+
+// ServeCreateUser provisions a new user.
+//
+// If SCIM.LinkProviderID is configured and an existing user is already linked to that provider
+// with a matching subject, the existing user is adopted instead of creating a new one.
+func (h *Handler) ServeCreateUser(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+	cfg := config.FromContext(ctx)
+
+	var res User
+	if writeError(ctx, w, readJSON(req, &res)) {
+		return
+	}
+	if writeError(ctx, w, validate.SubjectID("userName", res.UserName)) {
+		return
+	}
+	if !res.IsActive() {
+		writeError(ctx, w, &Error{Status: http.StatusBadRequest, Type: TypeInvalidValue, Detail: "inactive users cannot be provisioned"})
+		return
+	}
+
+	existing, err := h.c.UserStore.FindOneBySubject(ctx, ProviderID, res.UserName)
+	if writeError(ctx, w, err) {
+		return
+	}
+	if existing != nil {
+		writeError(ctx, w, &Error{Status: http.StatusConflict, Type: TypeUniqueness, Detail: "userName already exists"})
+		return
+	}
+
+	var linked *user.User
+	if cfg.SCIM.LinkProviderID != "" {
+		linked, err = h.c.UserStore.FindOneBySubject(ctx, cfg.SCIM.LinkProviderID, res.UserName)
+		if writeError(ctx, w, err) {
+			return
+		}
+	}
+
+	tx, err := h.c.DB.BeginTx(ctx, nil)
+	if writeError(ctx, w, err) {
+		return
+	}
+	defer sqlutil.Rollback(ctx, "scim: create user", tx)
+
+	u := &user.User{
+		Name:  validate.SanitizeName(res.FullName()),
+		Email: res.PrimaryEmail(),
+		Role:  permission.RoleUser,
+	}
+	if linked != nil {
+		u.ID = linked.ID
+		u.Role = linked.Role
+		err = h.c.UserStore.UpdateTx(ctx, tx, u)
+	} else {
+		u, err = h.c.UserStore.InsertTx(ctx, tx, u)
+	}
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	err = h.addSubjects(ctx, tx, u.ID, res.UserName)
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	if writeError(ctx, w, tx.Commit()) {
+		return
+	}
+
+	u, err = h.c.UserStore.FindOne(ctx, u.ID)
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	result := newUserResource(cfg, *u, res.UserName)
+	w.Header().Set("Location", result.Meta.Location)
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// addSubjects adds the SCIM auth subject for the user, as well as the linked provider subject (if configured).
+func (h *Handler) addSubjects(ctx context.Context, tx *sql.Tx, userID, userName string) error {
+	err := h.c.UserStore.AddAuthSubjectTx(ctx, tx, &user.AuthSubject{ProviderID: ProviderID, SubjectID: userName, UserID: userID})
+	if err != nil {
+		return err
+	}
+
+	linkID := config.FromContext(ctx).SCIM.LinkProviderID
+	if linkID == "" {
+		return nil
+	}
+
+	return h.c.UserStore.AddAuthSubjectTx(ctx, tx, &user.AuthSubject{ProviderID: linkID, SubjectID: userName, UserID: userID})
+}
+
+// removeSubjects removes the SCIM auth subject for the user, as well as the linked provider subject (if configured).
+func (h *Handler) removeSubjects(ctx context.Context, tx *sql.Tx, userID, userName string) error {
+	err := h.c.UserStore.DeleteAuthSubjectTx(ctx, tx, &user.AuthSubject{ProviderID: ProviderID, SubjectID: userName, UserID: userID})
+	if err != nil {
+		return err
+	}
+
+	linkID := config.FromContext(ctx).SCIM.LinkProviderID
+	if linkID == "" {
+		return nil
+	}
+
+	return h.c.UserStore.DeleteAuthSubjectTx(ctx, tx, &user.AuthSubject{ProviderID: linkID, SubjectID: userName, UserID: userID})
+}
+
+// updateUser applies the SCIM resource to an existing user.
+//
+// Since GoAlert has no concept of a disabled user, deactivating a user will delete it,
+// but only if SCIM.DeleteInactiveUsers is enabled. Otherwise the request is rejected.
+func (h *Handler) updateUser(ctx context.Context, w http.ResponseWriter, id, oldUserName string, res User) {
+	if writeError(ctx, w, validate.SubjectID("userName", res.UserName)) {
+		return
+	}
+	cfg := config.FromContext(ctx)
+
+	if !res.IsActive() {
+		if !cfg.SCIM.DeleteInactiveUsers {
+			writeError(ctx, w, &Error{Status: http.StatusBadRequest, Type: TypeMutability, Detail: "deactivating users requires SCIM.DeleteInactiveUsers to be enabled, as inactive users are deleted"})
+			return
+		}
+
+		err := h.c.UserStore.DeleteManyTx(ctx, nil, []string{id})
+		if writeError(ctx, w, err) {
+			return
+		}
+
+		res.Schemas = []string{SchemaUser}
+		res.ID = id
+		res.Meta = &Meta{ResourceType: "User", Location: location(cfg, "Users", id)}
+		writeJSON(w, http.StatusOK, res)
+		return
+	}
+
+	if res.UserName != oldUserName {
+		existing, err := h.c.UserStore.FindOneBySubject(ctx, ProviderID, res.UserName)
+		if writeError(ctx, w, err) {
+			return
+		}
+		if existing != nil {
+			writeError(ctx, w, &Error{Status: http.StatusConflict, Type: TypeUniqueness, Detail: "userName already exists"})
+			return
+		}
+	}
+
+	tx, err := h.c.DB.BeginTx(ctx, nil)
+	if writeError(ctx, w, err) {
+		return
+	}
+	defer sqlutil.Rollback(ctx, "scim: update user", tx)
+
+	u, err := h.c.UserStore.FindOneTx(ctx, tx, id, true)
+	if writeError(ctx, w, err) {
+		return
+	}
+	u.Name = validate.SanitizeName(res.FullName())
+	u.Email = res.PrimaryEmail()
+	err = h.c.UserStore.UpdateTx(ctx, tx, u)
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	if res.UserName != oldUserName {
+		err = h.removeSubjects(ctx, tx, id, oldUserName)
+		if writeError(ctx, w, err) {
+			return
+		}
+		err = h.addSubjects(ctx, tx, id, res.UserName)
+		if writeError(ctx, w, err) {
+			return
+		}
+	}
+
+	if writeError(ctx, w, tx.Commit()) {
+		return
+	}
+
+	u, err = h.c.UserStore.FindOne(ctx, id)
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newUserResource(cfg, *u, res.UserName))
+}
+
+// ServeReplaceUser handles a full update (PUT) of a SCIM-managed user.
+func (h *Handler) ServeReplaceUser(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+
+	u, userName, err := h.findUser(ctx, req.PathValue("id"))
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	var res User
+	if writeError(ctx, w, readJSON(req, &res)) {
+		return
+	}
+
+	h.updateUser(ctx, w, u.ID, userName, res)
+}
+
+// ServePatchUser handles a partial update (PATCH) of a SCIM-managed user.
+func (h *Handler) ServePatchUser(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+
+	u, userName, err := h.findUser(ctx, req.PathValue("id"))
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	var patch PatchRequest
+	if writeError(ctx, w, readJSON(req, &patch)) {
+		return
+	}
+
+	// Round-trip the current resource through a generic document so that
+	// patch paths can be applied without special-casing each attribute.
+	data, err := json.Marshal(newUserResource(config.FromContext(ctx), *u, userName))
+	if writeError(ctx, w, err) {
+		return
+	}
+	var doc map[string]interface{}
+	if writeError(ctx, w, json.Unmarshal(data, &doc)) {
+		return
+	}
+	if writeError(ctx, w, applyPatch(doc, patch.Operations)) {
+		return
+	}
+
+	data, err = json.Marshal(doc)
+	if writeError(ctx, w, err) {
+		return
+	}
+	var res User
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		writeError(ctx, w, &Error{Status: http.StatusBadRequest, Type: TypeInvalidValue, Detail: err.Error()})
+		return
+	}
+
+	// The current resource always includes displayName and name.formatted, which take
+	// precedence over givenName/familyName; drop them if only the name parts were patched.
+	if res.Name != nil && (res.Name.GivenName != "" || res.Name.FamilyName != "") && res.DisplayName == u.Name && res.Name.Formatted == u.Name {
+		res.DisplayName = ""
+		res.Name.Formatted = ""
+	}
+
+	h.updateUser(ctx, w, u.ID, userName, res)
+}
+
+// ServeDeleteUser removes a SCIM-managed user.
+//
+// The user is only deleted if SCIM.DeleteInactiveUsers is enabled. Otherwise the SCIM and
+// linked auth subjects are removed, so the user is no longer managed by SCIM (or able to log in
+// via the linked provider) but keeps their alert and on-call history.
+func (h *Handler) ServeDeleteUser(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if writeError(ctx, w, authorize(ctx)) {
+		return
+	}
+
+	u, userName, err := h.findUser(ctx, req.PathValue("id"))
+	if writeError(ctx, w, err) {
+		return
+	}
+
+	if config.FromContext(ctx).SCIM.DeleteInactiveUsers {
+		err = h.c.UserStore.DeleteManyTx(ctx, nil, []string{u.ID})
+		if writeError(ctx, w, err) {
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	tx, err := h.c.DB.BeginTx(ctx, nil)
+	if writeError(ctx, w, err) {
+		return
+	}
+	defer sqlutil.Rollback(ctx, "scim: delete user", tx)
+
+	err = h.removeSubjects(ctx, tx, u.ID, userName)
+	if writeError(ctx, w, err) {
+		return
+	}
+	if writeError(ctx, w, tx.Commit()) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
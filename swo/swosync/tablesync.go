@@ -197,7 +197,7 @@ func (c *TableSync) AddBatchWrites(b *pgx.Batch) {
 			continue
 		}
 		switch t.Name() {
-		case "ep_step_on_call_users", "schedule_on_call_users":
+		case "ep_step_on_call_users", "schedule_on_call_users", "schedule_tier_on_call_users":
 			// due to unique constraint on shifts, we need to sort shift ends before new shifts
 			sortOnCallData(p.upserts)
 		}
@@ -0,0 +1,74 @@
+package smoke
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/calsub"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestCalendarSubscriptionTier tests that escalation policy subscriptions include the users of targeted schedule tiers.
+func TestCalendarSubscriptionTier(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+		insert into users (id, name, email)
+		values
+			({{uuid "user"}}, 'bob', 'bob@example.com'),
+			({{uuid "primary"}}, 'joe', 'joe@example.com'),
+			({{uuid "secondary"}}, 'ann', 'ann@example.com');
+
+		insert into schedules (id, name, time_zone)
+		values
+			({{uuid "sched"}}, 'sched', 'UTC');
+
+		insert into schedule_tiers (schedule_id, name)
+		values
+			({{uuid "sched"}}, 'secondary');
+
+		insert into schedule_rules (schedule_id, sunday, monday, tuesday, wednesday, thursday, friday, saturday, start_time, end_time, tgt_user_id, tier)
+		values
+			({{uuid "sched"}}, true, true, true, true, true, true, true, '00:00:00', '00:00:00', {{uuid "primary"}}, null),
+			({{uuid "sched"}}, true, true, true, true, true, true, true, '00:00:00', '00:00:00', {{uuid "secondary"}}, 'secondary');
+
+		insert into escalation_policies (id, name)
+		values
+			({{uuid "ep"}}, 'esc policy');
+
+		insert into escalation_policy_steps (id, escalation_policy_id, delay)
+		values
+			({{uuid "step"}}, {{uuid "ep"}}, 30);
+
+		insert into escalation_policy_actions (escalation_policy_step_id, schedule_id, schedule_tier)
+		values
+			({{uuid "step"}}, {{uuid "sched"}}, 'secondary');
+	`
+	h := harness.NewHarness(t, sql, "schedule-tiers")
+	defer h.Close()
+
+	resp := h.GraphQLQueryUserT(t, h.UUID("user"), `mutation{createUserCalendarSubscription(input:{name: "test", escalationPolicyID: "`+h.UUID("ep")+`"}){url}}`)
+	var cs struct{ CreateUserCalendarSubscription struct{ URL string } }
+	require.NoError(t, json.Unmarshal(resp.Data, &cs))
+
+	req, err := http.NewRequest("GET", cs.CreateUserCalendarSubscription.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/json")
+	httpResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer httpResp.Body.Close()
+	require.Equal(t, 200, httpResp.StatusCode)
+
+	var data calsub.JSONResponseV1
+	require.NoError(t, json.NewDecoder(httpResp.Body).Decode(&data))
+
+	require.NotEmpty(t, data.Shifts, "tier users should be included")
+	for _, s := range data.Shifts {
+		assert.Equal(t, h.UUID("secondary"), s.UserID.String(), "only the tier's users should be included")
+		assert.Equal(t, "secondary", s.SourceTier)
+		assert.Equal(t, "sched (secondary)", s.SourceName)
+	}
+}
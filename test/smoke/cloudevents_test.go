@@ -0,0 +1,67 @@
+package smoke
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestCloudEventsBatch tests that a batch of CloudEvents returns a result for each event, and
+// that an invalid event does not prevent the others from being processed.
+func TestCloudEventsBatch(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'cloudEvents', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "cloudevents-integration")
+	defer h.Close()
+
+	resp, err := http.Post(h.URL()+"/api/v2/cloudevents/incoming?token="+h.UUID("int_key"), "application/cloudevents-batch+json", strings.NewReader(`[
+		{"specversion": "1.0", "id": "1", "source": "/monitor", "type": "com.goalert.alert.trigger", "data": {"summary": "disk full", "dedup": "db1"}},
+		{"specversion": "1.0", "id": "2", "source": "/monitor", "type": "com.goalert.alert.ack"},
+		{"specversion": "1.0", "id": "3", "source": "/monitor", "type": "com.goalert.alert.trigger", "data": {"summary": "disk full", "dedup": "db1"}}
+	]`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Results []struct {
+			ID        string
+			AlertID   int
+			ServiceID string
+			IsNew     bool
+			Error     string
+		}
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Results, 3)
+
+	assert.Equal(t, "1", body.Results[0].ID)
+	assert.Empty(t, body.Results[0].Error)
+	assert.True(t, body.Results[0].IsNew)
+	assert.Equal(t, h.UUID("sid"), body.Results[0].ServiceID)
+
+	assert.Equal(t, "2", body.Results[1].ID)
+	assert.NotEmpty(t, body.Results[1].Error, "ack without a dedup should fail")
+	assert.Zero(t, body.Results[1].AlertID)
+
+	assert.Equal(t, "3", body.Results[2].ID)
+	assert.Empty(t, body.Results[2].Error)
+	assert.False(t, body.Results[2].IsNew, "should match the alert of the first event")
+	assert.Equal(t, body.Results[0].AlertID, body.Results[2].AlertID)
+}
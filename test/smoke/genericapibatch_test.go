@@ -0,0 +1,99 @@
+package smoke
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/limit"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGenericAPIBatch tests that alerts of a batch request exceeding the unacknowledged alert limit,
+// or failing validation, fail individually while the rest of the batch, across chunks, is committed.
+func TestGenericAPIBatch(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'generic', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "rotation-participant-shift-length")
+	defer h.Close()
+
+	const (
+		total      = 160
+		invalid    = 5
+		maxUnacked = 150
+	)
+	h.SetSystemLimit(limit.UnackedAlertsPerService, maxUnacked)
+
+	type item struct {
+		Summary string `json:"summary"`
+	}
+	items := make([]item, total)
+	for i := range items {
+		if i == invalid {
+			continue
+		}
+		items[i].Summary = "alert " + strconv.Itoa(i)
+	}
+	data, err := json.Marshal(items)
+	require.NoError(t, err)
+
+	resp, err := http.Post(h.URL()+"/api/v2/generic/incoming/batch?token="+h.UUID("int_key"), "application/json", bytes.NewReader(data))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode, "http status code")
+
+	var body struct {
+		Results []struct {
+			AlertID   int
+			ServiceID string
+			IsNew     bool
+			Error     string
+		}
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Results, total, "results")
+
+	var created []int
+	for i, res := range body.Results {
+		switch {
+		case i == invalid:
+			assert.NotEmpty(t, res.Error, "result %d: invalid alert should fail", i)
+			assert.Zero(t, res.AlertID, "result %d: invalid alert ID", i)
+		case len(created) == maxUnacked:
+			assert.NotEmpty(t, res.Error, "result %d: alert over the limit should fail", i)
+			assert.Zero(t, res.AlertID, "result %d: alert over the limit ID", i)
+		default:
+			assert.Empty(t, res.Error, "result %d: error", i)
+			assert.True(t, res.IsNew, "result %d: new", i)
+			assert.Equal(t, h.UUID("sid"), res.ServiceID, "result %d: service ID", i)
+			require.NotZero(t, res.AlertID, "result %d: alert ID", i)
+			created = append(created, res.AlertID)
+		}
+	}
+	require.Len(t, created, maxUnacked, "created alerts")
+
+	// first, last of the first chunk, and last created alert
+	for _, id := range []int{created[0], created[98], created[maxUnacked-1]} {
+		res := h.GraphQLQueryT(t, fmt.Sprintf(`{alert(id: %d){alertID}}`, id))
+		require.Empty(t, res.Errors, "errors")
+		assert.JSONEq(t, fmt.Sprintf(`{"alert":{"alertID":%d}}`, id), string(res.Data), "alert %d committed", id)
+	}
+}
@@ -0,0 +1,90 @@
+package smoke
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLUnavailability tests creating, listing, and deleting unavailability windows, and that
+// the reason is only visible to the user and admins.
+func TestGraphQLUnavailability(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "joe"}}, 'joe', 'joe@example.com', 'user');
+`
+	h := harness.NewHarness(t, sql, "user-unavailability")
+	defer h.Close()
+
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Minute)
+	resp := h.GraphQLQueryUserT(t, h.UUID("bob"), `mutation{createUserUnavailability(input:{userID: "`+h.UUID("bob")+`", start: "`+start.Format(time.RFC3339)+`", end: "`+start.Add(48*time.Hour).Format(time.RFC3339)+`", reason: "medical"}){id}}`)
+	require.Empty(t, resp.Errors)
+	var created struct {
+		CreateUserUnavailability struct{ ID string }
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &created))
+
+	resp = h.GraphQLQueryUserT(t, h.UUID("joe"), `mutation{createUserUnavailability(input:{userID: "`+h.UUID("bob")+`", start: "`+start.Format(time.RFC3339)+`", end: "`+start.Add(time.Hour).Format(time.RFC3339)+`"}){id}}`)
+	assert.NotEmpty(t, resp.Errors, "other users can't create windows")
+
+	query := `query{user(id: "` + h.UUID("bob") + `"){unavailability{id, reason}}}`
+	expected := func(reason string) string {
+		return `{"user":{"unavailability":[{"id":"` + created.CreateUserUnavailability.ID + `","reason":"` + reason + `"}]}}`
+	}
+	resp = h.GraphQLQueryUserT(t, h.UUID("bob"), query)
+	assert.JSONEq(t, expected("medical"), string(resp.Data), "user")
+	resp = h.GraphQLQueryT(t, query)
+	assert.JSONEq(t, expected("medical"), string(resp.Data), "admin")
+	resp = h.GraphQLQueryUserT(t, h.UUID("joe"), query)
+	assert.JSONEq(t, expected(""), string(resp.Data), "other user")
+
+	resp = h.GraphQLQueryUserT(t, h.UUID("joe"), `mutation{deleteUserUnavailability(id: "`+created.CreateUserUnavailability.ID+`")}`)
+	assert.NotEmpty(t, resp.Errors, "other users can't delete windows")
+
+	resp = h.GraphQLQueryUserT(t, h.UUID("bob"), `mutation{deleteUserUnavailability(id: "`+created.CreateUserUnavailability.ID+`")}`)
+	require.Empty(t, resp.Errors)
+	resp = h.GraphQLQueryUserT(t, h.UUID("bob"), query)
+	assert.JSONEq(t, `{"user":{"unavailability":[]}}`, string(resp.Data))
+}
+
+// TestGraphQLUnavailabilityDeleteOverrides tests that deleting an unavailability window removes current and
+// upcoming overrides generated from it, but keeps past ones.
+func TestGraphQLUnavailabilityDeleteOverrides(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "joe"}}, 'joe', 'joe@example.com', 'user');
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'sched', 'UTC');
+	insert into user_unavailability (id, user_id, start_time, end_time)
+	values
+		({{uuid "window"}}, {{uuid "bob"}}, now() - '2 days'::interval, now() + '2 days'::interval);
+	insert into user_overrides (id, tgt_schedule_id, remove_user_id, add_user_id, start_time, end_time, unavailability_id)
+	values
+		({{uuid "past"}}, {{uuid "sched"}}, {{uuid "bob"}}, {{uuid "joe"}}, now() - '2 days'::interval, now() - '1 day'::interval, {{uuid "window"}}),
+		({{uuid "current"}}, {{uuid "sched"}}, {{uuid "bob"}}, {{uuid "joe"}}, now() - '1 hour'::interval, now() + '1 hour'::interval, {{uuid "window"}}),
+		({{uuid "future"}}, {{uuid "sched"}}, {{uuid "bob"}}, {{uuid "joe"}}, now() + '1 day'::interval, now() + '2 days'::interval, {{uuid "window"}});
+`
+	h := harness.NewHarness(t, sql, "user-unavailability")
+	defer h.Close()
+
+	resp := h.GraphQLQueryUserT(t, h.UUID("bob"), `mutation{deleteUserUnavailability(id: "`+h.UUID("window")+`")}`)
+	require.Empty(t, resp.Errors)
+
+	now := time.Now()
+	resp = h.GraphQLQueryT(t, `query{userOverrides(input:{scheduleID: "`+h.UUID("sched")+`", start: "`+now.Add(-72*time.Hour).Format(time.RFC3339)+`", end: "`+now.Add(72*time.Hour).Format(time.RFC3339)+`"}){nodes{id}}}`)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"userOverrides":{"nodes":[{"id":"`+h.UUID("past")+`"}]}}`, string(resp.Data), "only past overrides are kept")
+}
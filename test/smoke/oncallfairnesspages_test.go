@@ -0,0 +1,85 @@
+package smoke
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestOnCallFairnessPages ensures the fairness report counts each alert a user was notified of once,
+// regardless of the number of contact methods, repeat notifications, or bundling.
+func TestOnCallFairnessPages(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'bob@example.com');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "sms"}}, {{uuid "user"}}, 'sms', 'SMS', {{phone "1"}}),
+		({{uuid "voice"}}, {{uuid "user"}}, 'voice', 'VOICE', {{phone "1"}}),
+		({{uuid "email"}}, {{uuid "user"}}, 'email', 'EMAIL', 'bob@example.com');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (id, service_id, summary, status, created_at)
+	values
+		(1, {{uuid "sid"}}, 'multiple contact methods', 'closed', '2022-01-03 00:00:00Z'),
+		(2, {{uuid "sid"}}, 'bundled', 'closed', '2022-01-04 00:00:00Z'),
+		(3, {{uuid "sid"}}, 'bundled', 'closed', '2022-01-04 00:00:00Z'),
+		(4, {{uuid "sid"}}, 'never sent', 'closed', '2022-01-05 00:00:00Z'),
+		(5, {{uuid "sid"}}, 'first sent before range', 'closed', '2021-12-31 00:00:00Z');
+
+	insert into outgoing_messages (id, message_type, created_at, sent_at, contact_method_id, last_status, user_id, alert_id, service_id, escalation_policy_id)
+	values
+		({{uuid "a1sms"}}, 'alert_notification', '2022-01-03 00:00:00Z', '2022-01-03 00:00:01Z', {{uuid "sms"}}, 'delivered', {{uuid "user"}}, 1, {{uuid "sid"}}, {{uuid "eid"}}),
+		({{uuid "a1voice"}}, 'alert_notification', '2022-01-03 00:00:00Z', '2022-01-03 00:00:02Z', {{uuid "voice"}}, 'delivered', {{uuid "user"}}, 1, {{uuid "sid"}}, {{uuid "eid"}}),
+		({{uuid "a1email"}}, 'alert_notification', '2022-01-03 00:00:00Z', '2022-01-03 00:00:03Z', {{uuid "email"}}, 'delivered', {{uuid "user"}}, 1, {{uuid "sid"}}, {{uuid "eid"}}),
+		({{uuid "a1repeat"}}, 'alert_notification', '2022-01-03 00:05:00Z', '2022-01-03 00:05:01Z', {{uuid "sms"}}, 'delivered', {{uuid "user"}}, 1, {{uuid "sid"}}, {{uuid "eid"}}),
+		({{uuid "a4"}}, 'alert_notification', '2022-01-05 00:00:00Z', null, {{uuid "sms"}}, 'failed', {{uuid "user"}}, 4, {{uuid "sid"}}, {{uuid "eid"}}),
+		({{uuid "a5old"}}, 'alert_notification', '2021-12-31 00:00:00Z', '2021-12-31 00:00:01Z', {{uuid "sms"}}, 'delivered', {{uuid "user"}}, 5, {{uuid "sid"}}, {{uuid "eid"}}),
+		({{uuid "a5"}}, 'alert_notification', '2022-01-03 00:00:00Z', '2022-01-03 00:00:01Z', {{uuid "voice"}}, 'delivered', {{uuid "user"}}, 5, {{uuid "sid"}}, {{uuid "eid"}});
+
+	insert into outgoing_messages (id, message_type, created_at, sent_at, contact_method_id, last_status, user_id, service_id)
+	values
+		({{uuid "bundle"}}, 'alert_notification_bundle', '2022-01-04 00:00:00Z', '2022-01-04 00:00:01Z', {{uuid "sms"}}, 'delivered', {{uuid "user"}}, {{uuid "sid"}});
+
+	insert into outgoing_messages (id, message_type, created_at, contact_method_id, last_status, status_details, user_id, alert_id, service_id, escalation_policy_id)
+	values
+		({{uuid "a2"}}, 'alert_notification', '2022-01-04 00:00:00Z', {{uuid "sms"}}, 'bundled', {{uuid "bundle"}}, {{uuid "user"}}, 2, {{uuid "sid"}}, {{uuid "eid"}}),
+		({{uuid "a3"}}, 'alert_notification', '2022-01-04 00:00:00Z', {{uuid "sms"}}, 'bundled', {{uuid "bundle"}}, {{uuid "user"}}, 3, {{uuid "sid"}}, {{uuid "eid"}});
+	`
+
+	h := harness.NewHarness(t, sql, "rotation-participant-shift-length")
+	defer h.Close()
+
+	resp := h.GraphQLQueryT(t, `query {
+		onCallFairnessReport(input: {start: "2022-01-01T00:00:00Z", end: "2022-01-08T00:00:00Z", period: "P1W"}) {
+			userID
+			pages
+		}
+	}`)
+	require.Empty(t, resp.Errors, "errors")
+
+	var data struct {
+		OnCallFairnessReport []struct {
+			UserID string
+			Pages  int
+		}
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &data))
+	require.Len(t, data.OnCallFairnessReport, 1)
+	assert.Equal(t, h.UUID("user"), data.OnCallFairnessReport[0].UserID)
+	// alert 1 once across contact methods and repeats, alerts 2 and 3 from the bundle;
+	// alert 4 was never sent and alert 5 was first sent before the report start
+	assert.Equal(t, 3, data.OnCallFairnessReport[0].Pages, "pages")
+}
@@ -0,0 +1,109 @@
+package smoke
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestSCIM tests updating SCIM-managed users and groups.
+func TestSCIM(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "admin"}}, 'bob', 'bob@example.com', 'admin'),
+		({{uuid "user"}}, 'joe', 'joe@example.com', 'user');
+
+	insert into auth_subjects (provider_id, subject_id, user_id)
+	values
+		('scim', 'bob', {{uuid "admin"}});
+
+	insert into rotations (id, name, type, time_zone)
+	values
+		({{uuid "manual"}}, 'manual rotation', 'daily', 'UTC');
+
+	insert into rotation_participants (rotation_id, position, user_id)
+	values
+		({{uuid "manual"}}, 0, {{uuid "user"}}),
+		({{uuid "manual"}}, 1, {{uuid "admin"}}),
+		({{uuid "manual"}}, 2, {{uuid "user"}});
+`
+	h := harness.NewHarness(t, sql, "scim-groups")
+	defer h.Close()
+
+	h.SetConfigValue("SCIM.Enable", "true")
+
+	resp := h.GraphQLQueryT(t, `mutation{createSCIMAPIKey(input:{name: "test", description: "", expiresAt: "`+time.Now().Add(time.Hour).Format(time.RFC3339)+`"}){token}}`)
+	var keyResp struct {
+		CreateSCIMAPIKey struct{ Token string }
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &keyResp))
+
+	doReq := func(method, path, body string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, h.URL()+"/api/v2/scim"+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+keyResp.CreateSCIMAPIKey.Token)
+		req.Header.Set("Content-Type", "application/scim+json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(data)
+	}
+
+	status, body := doReq("PUT", "/Users/"+h.UUID("admin"), `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"bob","displayName":"Bob Smith","emails":[{"value":"bob.smith@example.com","primary":true}]}`)
+	require.Equal(t, 200, status, body)
+	assert.Contains(t, body, `"displayName":"Bob Smith"`)
+
+	status, body = doReq("PATCH", "/Users/"+h.UUID("admin"), `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","path":"displayName","value":"Robert Smith"}]}`)
+	require.Equal(t, 200, status, body)
+	assert.Contains(t, body, `"displayName":"Robert Smith"`)
+
+	resp = h.GraphQLQueryT(t, `query{user(id: "`+h.UUID("admin")+`"){name, role}}`)
+	assert.JSONEq(t, `{"user":{"name":"Robert Smith","role":"admin"}}`, string(resp.Data), "role should be kept")
+
+	status, body = doReq("PATCH", "/Users/"+h.UUID("admin"), `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","path":"active","value":false}]}`)
+	assert.Equal(t, 400, status, body)
+	status, _ = doReq("GET", "/Users/"+h.UUID("admin"), "")
+	assert.Equal(t, 200, status, "user should not be deleted")
+
+	// rotations not created via SCIM are not visible as groups
+	status, body = doReq("GET", "/Groups", "")
+	require.Equal(t, 200, status, body)
+	assert.NotContains(t, body, h.UUID("manual"))
+	status, _ = doReq("PUT", "/Groups/"+h.UUID("manual"), `{"displayName":"manual rotation","members":[]}`)
+	assert.Equal(t, 404, status)
+	status, _ = doReq("DELETE", "/Groups/"+h.UUID("manual"), "")
+	assert.Equal(t, 404, status)
+
+	status, body = doReq("POST", "/Groups", `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:Group"],"displayName":"scim group","members":[{"value":"`+h.UUID("admin")+`"}]}`)
+	require.Equal(t, 201, status, body)
+	var g struct{ ID string }
+	require.NoError(t, json.Unmarshal([]byte(body), &g))
+
+	status, body = doReq("GET", "/Groups", "")
+	require.Equal(t, 200, status, body)
+	assert.Contains(t, body, g.ID)
+
+	status, body = doReq("DELETE", "/Groups/"+g.ID, "")
+	assert.Equal(t, 204, status, body)
+
+	// without SCIM.DeleteInactiveUsers, deleting a user only unlinks it
+	status, body = doReq("DELETE", "/Users/"+h.UUID("admin"), "")
+	assert.Equal(t, 204, status, body)
+	status, _ = doReq("GET", "/Users/"+h.UUID("admin"), "")
+	assert.Equal(t, 404, status, "user should no longer be managed by SCIM")
+	resp = h.GraphQLQueryT(t, `query{user(id: "`+h.UUID("admin")+`"){name}}`)
+	assert.JSONEq(t, `{"user":{"name":"Robert Smith"}}`, string(resp.Data), "user should not be deleted")
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -28,7 +29,8 @@ type Store struct {
 	setUserRole *sql.Stmt
 	findOne     *sql.Stmt
 
-	findMany *sql.Stmt
+	findMany        *sql.Stmt
+	findManyByEmail *sql.Stmt
 
 	deleteOne          *sql.Stmt
 	userRotations      *sql.Stmt
@@ -118,6 +120,14 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				fav.tgt_user_id = u.id AND fav.user_id = $2
 			WHERE u.id = any($1)
 		`),
+		findManyByEmail: p.P(`
+			SELECT
+				u.id, u.name, u.email, u.avatar_url, u.role, fav is distinct from null
+			FROM users u
+			LEFT JOIN user_favorites fav ON
+				fav.tgt_user_id = u.id AND fav.user_id = $2
+			WHERE lower(u.email) = any($1)
+		`),
 
 		deleteOne:          p.P(`DELETE FROM users WHERE id = $1`),
 		userRotations:      p.P(`SELECT DISTINCT rotation_id FROM rotation_participants WHERE user_id = $1`),
@@ -559,6 +569,49 @@ func (s *Store) FindMany(ctx context.Context, ids []string) ([]User, error) {
 	return result, nil
 }
 
+// FindManyByEmail will return all users with an email address matching one of
+// the provided values. Matching is case-insensitive.
+//
+// Email addresses are not required to be unique, so more than one user may be
+// returned for a single address.
+func (s *Store) FindManyByEmail(ctx context.Context, emails []string) ([]User, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate.Len("Emails", emails, 0, 500)
+	if err != nil {
+		return nil, err
+	}
+
+	lower := make(sqlutil.StringArray, len(emails))
+	for i, e := range emails {
+		lower[i] = strings.ToLower(strings.TrimSpace(e))
+	}
+
+	rows, err := s.findManyByEmail.QueryContext(ctx, lower, permission.UserNullUUID(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []User
+	var u User
+	for rows.Next() {
+		err = u.scanFrom(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, u)
+	}
+
+	return result, rows.Err()
+}
+
 // FindOne is equivalent to calling FindOneTx(ctx, nil, id, false).
 func (s *Store) FindOne(ctx context.Context, id string) (*User, error) {
 	return s.FindOneTx(ctx, nil, id, false)
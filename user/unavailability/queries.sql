@@ -0,0 +1,53 @@
+-- name: UnavailabilityCreate :exec
+INSERT INTO user_unavailability(id, user_id, start_time, end_time, reason)
+    VALUES ($1, $2, $3, $4, $5);
+
+-- name: UnavailabilityFindOne :one
+SELECT
+    id,
+    user_id,
+    start_time,
+    end_time,
+    reason
+FROM
+    user_unavailability
+WHERE
+    id = $1;
+
+-- name: UnavailabilityDeleteOverrides :exec
+-- Deletes current and upcoming overrides generated from an unavailability window; past overrides are kept for history.
+DELETE FROM user_overrides
+WHERE unavailability_id = $1
+    AND end_time > now();
+
+-- name: UnavailabilityDelete :exec
+DELETE FROM user_unavailability
+WHERE id = $1;
+
+-- name: UnavailabilityCount :one
+-- Returns the number of current and upcoming unavailability windows of a user.
+SELECT
+    count(*)
+FROM
+    user_unavailability
+WHERE
+    user_id = $1
+    AND end_time > now();
+
+-- name: UnavailabilityFindMany :many
+-- Returns the unavailability windows of the given users overlapping the time range.
+SELECT
+    id,
+    user_id,
+    start_time,
+    end_time,
+    reason
+FROM
+    user_unavailability
+WHERE
+    user_id = ANY (@user_ids::uuid[])
+    AND start_time < @end_time
+    AND end_time > @start_time
+ORDER BY
+    start_time,
+    id;
@@ -0,0 +1,210 @@
+package unavailability
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxPerUser is the maximum number of current and upcoming unavailability windows a user may have.
+const MaxPerUser = 50
+
+// A Window is a period of time a user is unavailable to take on-call shifts (e.g., PTO).
+//
+// When a rotation hands off to a participant that is unavailable for any part
+// of the new shift, the participant is skipped in favor of the next available
+// one.
+type Window struct {
+	ID     string
+	UserID string
+	Start  time.Time
+	End    time.Time
+	Reason string
+}
+
+// Overlaps returns true if the window overlaps the time range.
+func (w Window) Overlaps(start, end time.Time) bool {
+	return w.Start.Before(end) && w.End.After(start)
+}
+
+// Normalize will validate the window and return a normalized copy.
+func (w Window) Normalize() (*Window, error) {
+	w.Start = w.Start.Truncate(time.Minute)
+	w.End = w.End.Truncate(time.Minute)
+	err := validate.Many(
+		validate.UUID("UserID", w.UserID),
+		validate.Text("Reason", w.Reason, 0, 255),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !w.End.After(w.Start) {
+		return nil, validation.NewFieldError("End", "must be after Start")
+	}
+
+	return &w, nil
+}
+
+// NextAvailable returns the first position, starting at pos and wrapping
+// around n participants, that is not unavailable. If every participant is
+// unavailable, pos is returned.
+func NextAvailable(pos, n int, isUnavailable func(pos int) bool) int {
+	for i := 0; i < n; i++ {
+		p := (pos + i) % n
+		if !isUnavailable(p) {
+			return p
+		}
+	}
+
+	return pos
+}
+
+// Store allows the lookup and management of unavailability windows.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore will create a new Store.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	return &Store{db: db}, nil
+}
+
+func fromRow(id, userID uuid.UUID, start, end time.Time, reason string) Window {
+	return Window{
+		ID:     id.String(),
+		UserID: userID.String(),
+		Start:  start,
+		End:    end,
+		Reason: reason,
+	}
+}
+
+// Create will record a new unavailability window. Must be authorized as an admin or the same user.
+func (s *Store) Create(ctx context.Context, w Window) (*Window, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(w.UserID))
+	if err != nil {
+		return nil, err
+	}
+	n, err := w.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	q := gadb.New(s.db)
+	userID := uuid.MustParse(n.UserID)
+	count, err := q.UnavailabilityCount(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("count unavailability: %w", err)
+	}
+	if count >= MaxPerUser {
+		return nil, validation.NewFieldError("UserID", "user already has the maximum number of upcoming unavailability windows")
+	}
+
+	id := uuid.New()
+	err = q.UnavailabilityCreate(ctx, gadb.UnavailabilityCreateParams{
+		ID:        id,
+		UserID:    userID,
+		StartTime: n.Start,
+		EndTime:   n.End,
+		Reason:    n.Reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create unavailability: %w", err)
+	}
+	n.ID = id.String()
+
+	return n, nil
+}
+
+// Delete will remove an unavailability window, along with any current or upcoming overrides generated from it.
+// Past overrides are kept. Must be authorized as an admin or the same user.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	wID, err := validate.ParseUUID("ID", id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlutil.Rollback(ctx, "unavailability: delete", tx)
+
+	q := gadb.New(tx)
+	row, err := q.UnavailabilityFindOne(ctx, wID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("lookup unavailability: %w", err)
+	}
+	err = permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(row.UserID.String()))
+	if err != nil {
+		return err
+	}
+
+	err = q.UnavailabilityDeleteOverrides(ctx, uuid.NullUUID{UUID: wID, Valid: true})
+	if err != nil {
+		return fmt.Errorf("delete overrides: %w", err)
+	}
+	err = q.UnavailabilityDelete(ctx, wID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindMany is equivalent to calling FindManyTx(ctx, nil, userIDs, start, end).
+func (s *Store) FindMany(ctx context.Context, userIDs []string, start, end time.Time) ([]Window, error) {
+	return s.FindManyTx(ctx, nil, userIDs, start, end)
+}
+
+// FindManyTx returns the unavailability windows of the given users overlapping the time range.
+func (s *Store) FindManyTx(ctx context.Context, tx *sql.Tx, userIDs []string, start, end time.Time) ([]Window, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.ManyUUID("UserIDs", userIDs, 1000)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = uuid.MustParse(id)
+	}
+	db := gadb.New(s.db)
+	if tx != nil {
+		db = db.WithTx(tx)
+	}
+	rows, err := db.UnavailabilityFindMany(ctx, gadb.UnavailabilityFindManyParams{
+		UserIds:   ids,
+		StartTime: start,
+		EndTime:   end,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find unavailability: %w", err)
+	}
+
+	result := make([]Window, len(rows))
+	for i, r := range rows {
+		result[i] = fromRow(r.ID, r.UserID, r.StartTime, r.EndTime, r.Reason)
+	}
+
+	return result, nil
+}
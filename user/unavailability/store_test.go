@@ -0,0 +1,88 @@
+package unavailability
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+)
+
+const testUserID = "01020304-0506-0708-090a-0b0c0d0e0f10"
+
+func TestWindow_Normalize(t *testing.T) {
+	start := time.Date(2024, 1, 1, 8, 0, 30, 0, time.UTC)
+
+	n, err := Window{UserID: testUserID, Start: start, End: start.Add(time.Hour), Reason: "PTO"}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC), n.Start, "start should be truncated to the minute")
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), n.End, "end should be truncated to the minute")
+
+	_, err = Window{UserID: testUserID, Start: start, End: start}.Normalize()
+	assert.True(t, validation.IsClientError(err), "end must be after start")
+
+	// within the same minute, so equal once truncated
+	_, err = Window{UserID: testUserID, Start: start, End: start.Add(10 * time.Second)}.Normalize()
+	assert.True(t, validation.IsClientError(err), "end must be after start once truncated")
+
+	_, err = Window{UserID: "foo", Start: start, End: start.Add(time.Hour)}.Normalize()
+	assert.True(t, validation.IsClientError(err), "invalid user ID")
+}
+
+func TestWindow_Overlaps(t *testing.T) {
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	w := Window{Start: start, End: start.Add(time.Hour)}
+
+	assert.True(t, w.Overlaps(start.Add(-time.Hour), start.Add(time.Minute)))
+	assert.True(t, w.Overlaps(start.Add(30*time.Minute), start.Add(2*time.Hour)))
+	assert.False(t, w.Overlaps(start.Add(-time.Hour), start), "ending at the start")
+	assert.False(t, w.Overlaps(start.Add(time.Hour), start.Add(2*time.Hour)), "starting at the end")
+}
+
+func TestNextAvailable(t *testing.T) {
+	unavail := func(pos ...int) func(int) bool {
+		return func(p int) bool {
+			for _, u := range pos {
+				if u == p {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	assert.Equal(t, 1, NextAvailable(1, 3, unavail()))
+	assert.Equal(t, 2, NextAvailable(1, 3, unavail(1)))
+	assert.Equal(t, 0, NextAvailable(1, 3, unavail(1, 2)), "should wrap around")
+	assert.Equal(t, 1, NextAvailable(1, 3, unavail(0, 1, 2)), "should keep pos if no one is available")
+}
+
+func TestStore_Create(t *testing.T) {
+	// checks happen before the database is used
+	s := &Store{}
+	start := time.Now().Add(time.Hour)
+	w := Window{UserID: testUserID, Start: start, End: start.Add(time.Hour)}
+
+	ctx := permission.UserContext(context.Background(), "11111111-1111-1111-1111-111111111111", permission.RoleUser)
+	_, err := s.Create(ctx, w)
+	assert.True(t, permission.IsPermissionError(err), "other users can't create windows")
+
+	ctx = permission.UserContext(context.Background(), testUserID, permission.RoleUser)
+	w.End = w.Start
+	_, err = s.Create(ctx, w)
+	assert.True(t, validation.IsClientError(err), "window should be validated")
+}
+
+func TestStore_FindMany(t *testing.T) {
+	s := &Store{}
+
+	_, err := s.FindMany(context.Background(), []string{testUserID}, time.Now(), time.Now())
+	assert.True(t, permission.IsPermissionError(err), "auth is required")
+
+	ctx := permission.UserContext(context.Background(), testUserID, permission.RoleUser)
+	_, err = s.FindMany(ctx, []string{"foo"}, time.Now(), time.Now())
+	assert.True(t, validation.IsClientError(err), "user IDs should be validated")
+}
@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/target/goalert/ctxlock"
 	"github.com/target/goalert/permission"
@@ -67,6 +69,11 @@ func HTTPError(ctx context.Context, w http.ResponseWriter, err error) bool {
 		// the possibility to be rate limited, and not sequential requests,
 		// even in the worst case scenario.
 		http.Error(w, "Too many concurrent requests for this key or session", http.StatusTooManyRequests)
+	case IsRateLimitError(err):
+		var rl RateLimitError
+		errors.As(err, &rl)
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rl.RetryAfter().Seconds()))))
+		http.Error(w, unwrapAll(err).Error(), http.StatusTooManyRequests)
 	case errors.Is(err, ctxlock.ErrTimeout):
 		// Similar to above, but that we timed out waiting in the queue.
 		http.Error(w, http.StatusText(http.StatusRequestTimeout), http.StatusRequestTimeout)
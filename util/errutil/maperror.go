@@ -27,6 +27,10 @@ func MapDBError(err error) error {
 			return validation.NewFieldError("UserID", "user does not exist")
 		case "user_calendar_subscriptions_schedule_id_fkey", "schedule_data_schedule_id_fkey":
 			return validation.NewFieldError("ScheduleID", "schedule does not exist")
+		case "user_calendar_subscriptions_service_id_fkey":
+			return validation.NewFieldError("ServiceID", "service does not exist")
+		case "user_calendar_subscriptions_escalation_policy_id_fkey":
+			return validation.NewFieldError("EscalationPolicyID", "escalation policy does not exist")
 		case "user_overrides_add_user_id_fkey":
 			return validation.NewFieldError("AddUserID", "user does not exist")
 		case "user_overrides_remove_user_id_fkey":
@@ -104,6 +108,13 @@ func MapDBError(err error) error {
 		if strings.Contains(dbErr.Detail, "is not present") {
 			return validation.NewFieldError("EscalationPolicyID", "does not exist")
 		}
+	case "schedule_rules_holiday_calendar_id_fkey":
+		if strings.Contains(dbErr.Detail, "is still referenced") {
+			return validation.NewFieldError("ID", "holiday calendar is currently in use by schedule rules")
+		}
+		if strings.Contains(dbErr.Detail, "is not present") {
+			return validation.NewFieldError("HolidayCalendarID", "does not exist")
+		}
 	}
 
 	return err
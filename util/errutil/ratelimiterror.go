@@ -0,0 +1,24 @@
+package errutil
+
+import (
+	"errors"
+	"time"
+)
+
+// RateLimitError represents an error caused by exceeding a request rate limit.
+type RateLimitError interface {
+	error
+	RateLimited() bool
+
+	// RetryAfter is the amount of time to wait before the request may succeed.
+	RetryAfter() time.Duration
+}
+
+// IsRateLimitError will determine if an error's cause is a RateLimitError.
+func IsRateLimitError(err error) bool {
+	var e RateLimitError
+	if errors.As(err, &e) && e.RateLimited() {
+		return true
+	}
+	return false
+}
@@ -102,6 +102,14 @@ export default function LocalDev(): React.JSX.Element {
             'OIDC.UserInfoNamePath': 'preferred_username',
           }}
         />
+        <DevTool
+          name='SAML'
+          desc='Configure SAML using the local test IdP.'
+          config={{
+            'SAML.Enable': 'true',
+            'SAML.IdPMetadataURL': 'http://127.0.0.1:9997/metadata',
+          }}
+        />
         <DevTool
           name='pprof'
           desc='Debug and profile the running server.'
@@ -45,6 +45,7 @@ import AdminSwitchoverGuide from '../admin/switchover/AdminSwitchoverGuide'
 import UniversalKeyPage from '../services/UniversalKey/UniversalKeyPage'
 import { useExpFlag } from '../util/useExpFlag'
 import AdminMaint from '../admin/AdminMaint'
+import OAuthConsent from '../oauth/OAuthConsent'
 
 // ParamRoute will pass route parameters as props to the route's child.
 function ParamRoute(props: RouteProps): React.JSX.Element {
@@ -128,6 +129,8 @@ export const routes: Record<string, JSXElementConstructor<any>> = {
   '/admin/switchover/guide': AdminSwitchoverGuide,
   '/admin/api-keys': AdminAPIKeys,
 
+  '/oauth/consent': OAuthConsent,
+
   '/wizard': WizardRouter,
   '/docs': Documentation,
 }
@@ -0,0 +1,94 @@
+import React, { useEffect, useState } from 'react'
+import {
+  Alert,
+  Button,
+  Card,
+  CardActions,
+  CardContent,
+  CardHeader,
+  Grid,
+  List,
+  ListItem,
+  ListItemText,
+  Typography,
+} from '@mui/material'
+import { pathPrefix } from '../env'
+import Spinner from '../loading/components/Spinner'
+
+const CONSENT_URL = pathPrefix + '/api/v2/oauth/consent'
+
+type ConsentInfo = {
+  ClientName: string
+  ClientDescription: string
+  RedirectHost: string
+  Scopes: { Name: string; Description: string }[]
+}
+
+// OAuthConsent allows the current user to approve or deny a third-party
+// application's request to access their account.
+export default function OAuthConsent(): React.JSX.Element {
+  const [info, setInfo] = useState<ConsentInfo | null>(null)
+  const [error, setError] = useState('')
+  const params = new URLSearchParams(location.search)
+
+  useEffect(() => {
+    fetch(CONSENT_URL + location.search, { credentials: 'same-origin' })
+      .then(async (res) => {
+        if (!res.ok) throw new Error(await res.text())
+        return res.json()
+      })
+      .then(setInfo)
+      .catch((err) => setError(err.message))
+  }, [])
+
+  if (error) return <Alert severity='error'>{error}</Alert>
+  if (!info) return <Spinner />
+
+  return (
+    <Grid container justifyContent='center'>
+      <Grid item xs={12} md={6}>
+        <Card>
+          <CardHeader
+            title={`Authorize ${info.ClientName}`}
+            subheader={info.ClientDescription}
+          />
+          <CardContent>
+            <Typography>
+              {info.ClientName} is requesting access to your account. It will
+              be able to:
+            </Typography>
+            <List dense>
+              {info.Scopes.map((s) => (
+                <ListItem key={s.Name}>
+                  <ListItemText primary={s.Description} />
+                </ListItem>
+              ))}
+            </List>
+            <Typography variant='caption' color='textSecondary'>
+              You will be redirected to {info.RedirectHost}. You can revoke
+              access at any time from your profile.
+            </Typography>
+          </CardContent>
+          <CardActions>
+            <form method='POST' action={CONSENT_URL}>
+              {Array.from(params.entries()).map(([key, value]) => (
+                <input key={key} type='hidden' name={key} value={value} />
+              ))}
+              <Button type='submit' name='approve' value='false'>
+                Deny
+              </Button>
+              <Button
+                type='submit'
+                name='approve'
+                value='true'
+                variant='contained'
+              >
+                Allow
+              </Button>
+            </form>
+          </CardActions>
+        </Card>
+      </Grid>
+    </Grid>
+  )
+}
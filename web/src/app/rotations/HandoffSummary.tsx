@@ -5,6 +5,7 @@ import { Time } from '../util/Time'
 export interface HandoffSummaryProps {
   start: string
   shiftLength: number
+  shiftPattern?: number[]
   type: RotationType
   timeZone: string
 }
@@ -31,11 +32,20 @@ function ts(p: HandoffSummaryProps): React.JSX.Element | string {
         format='weekday-clock'
       />
     )
-  if (p.type === 'monthly')
+  if (
+    p.type === 'monthly' ||
+    p.type === 'pattern' ||
+    p.type === 'participant'
+  )
     return <Time prefix='from ' time={p.start} zone={p.timeZone} />
   throw new Error('unknown rotation type: ' + p.type)
 }
 
+// patternSummary lists the shift lengths of a pattern (e.g. "12h, 12h")
+function patternSummary(p: HandoffSummaryProps): string {
+  return (p.shiftPattern || []).map((h) => `${h}h`).join(', ')
+}
+
 // handoffSummary returns the summary description for the rotation
 export const HandoffSummary: React.FC<HandoffSummaryProps> =
   function HandoffSummary(props: HandoffSummaryProps): React.JSX.Element {
@@ -44,7 +54,17 @@ export const HandoffSummary: React.FC<HandoffSummaryProps> =
       <span>
         Time Zone: {props.timeZone}
         <br />
-        {props.type === 'monthly' ? (
+        {props.type === 'pattern' ? (
+          <React.Fragment>
+            Hands off following a repeating pattern of{' '}
+            {patternSummary(props)} shifts {ts(props)}.
+          </React.Fragment>
+        ) : props.type === 'participant' ? (
+          <React.Fragment>
+            Hands off after each user&apos;s own shift length (
+            {props.shiftLength}h by default) {ts(props)}.
+          </React.Fragment>
+        ) : props.type === 'monthly' ? (
           <React.Fragment>
             Hands off every{' '}
             {props.shiftLength === 1 ? 'month' : `${props.shiftLength} months`}{' '}
@@ -17,6 +17,7 @@ const mutation = gql`
       timeZone
       type
       shiftLength
+      shiftPattern
     }
   }
 `
@@ -28,6 +28,7 @@ const query = gql`
       userIDs
       type
       shiftLength
+      shiftPattern
       timeZone
       start
     }
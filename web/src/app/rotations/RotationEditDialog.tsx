@@ -17,6 +17,7 @@ const query = gql`
       timeZone
       type
       shiftLength
+      shiftPattern
       start
       nextHandoffTimes(num: 1)
     }
@@ -77,6 +78,7 @@ export default function RotationEditDialog(props: {
               timeZone: data.rotation.timeZone,
               type: data.rotation.type,
               shiftLength: data.rotation.shiftLength,
+              shiftPattern: data.rotation.shiftPattern,
               start: data.rotation.nextHandoffTimes[0] || data.rotation.start,
             }
           }
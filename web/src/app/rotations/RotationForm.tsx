@@ -10,6 +10,7 @@ import { FieldError } from '../util/errutil'
 import { CreateRotationInput } from '../../schema'
 import { Time } from '../util/Time'
 import RotationFormHandoffTimes from './RotationFormHandoffTimes'
+import ShiftPatternField from './ShiftPatternField'
 import Spinner from '../loading/components/Spinner'
 
 interface RotationFormProps {
@@ -19,7 +20,14 @@ interface RotationFormProps {
   disabled?: boolean
 }
 
-const rotationTypes = ['hourly', 'daily', 'weekly', 'monthly']
+const rotationTypes = [
+  'hourly',
+  'daily',
+  'weekly',
+  'monthly',
+  'pattern',
+  'participant',
+]
 
 const sameAsLocal = (t: string, z: string): boolean => {
   const inZone = DateTime.fromISO(t, { zone: z })
@@ -74,16 +82,36 @@ export default function RotationForm(
           </FormField>
         </Grid>
         <Grid item xs={6}>
-          <FormField
-            fullWidth
-            component={NumberField}
-            required
-            type='number'
-            name='shiftLength'
-            label='Shift Length'
-            min={1}
-            max={9000}
-          />
+          {value.type === 'pattern' ? (
+            <FormField
+              fullWidth
+              component={ShiftPatternField}
+              required
+              name='shiftPattern'
+              label='Shift Pattern (hours)'
+              hint='Repeating shift lengths, e.g. 12, 12 for day/night.'
+            />
+          ) : (
+            <FormField
+              fullWidth
+              component={NumberField}
+              required
+              type='number'
+              name='shiftLength'
+              label={
+                value.type === 'participant'
+                  ? 'Default Shift Length (hours)'
+                  : 'Shift Length'
+              }
+              hint={
+                value.type === 'participant'
+                  ? 'Each user can be given their own shift length.'
+                  : undefined
+              }
+              min={1}
+              max={9000}
+            />
+          )}
         </Grid>
         <Grid item xs={12}>
           <FormField
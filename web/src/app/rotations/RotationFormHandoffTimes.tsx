@@ -11,8 +11,9 @@ const query = gql`
   }
 `
 
-// getShiftDuration converts a count and one of ['hourly', 'daily', 'weekly', 'monthly']
+// getShiftDuration converts a count and one of ['hourly', 'daily', 'weekly', 'monthly', 'participant']
 // into the shift length to ISODuration.
+// Pattern rotations use shiftPattern instead.
 function getShiftDuration(count: number, type: RotationType): ISODuration {
   switch (type) {
     case 'monthly':
@@ -22,6 +23,8 @@ function getShiftDuration(count: number, type: RotationType): ISODuration {
     case 'daily':
       return `P${count}D`
     case 'hourly':
+    case 'participant':
+      // new participants use the default shift length
       return `PT${count}H`
     default:
       throw new Error('unknown rotation type: ' + type)
@@ -41,7 +44,14 @@ export default function RotationFormHandoffTimes({
       input: {
         handoff: value.start,
         timeZone: value.timeZone,
-        shiftLength: getShiftDuration(value.shiftLength as number, value.type),
+        ...(value.type === 'pattern'
+          ? { shiftPattern: value.shiftPattern || [] }
+          : {
+              shiftLength: getShiftDuration(
+                value.shiftLength as number,
+                value.type,
+              ),
+            }),
         count: 3,
       },
     },
@@ -0,0 +1,103 @@
+import React, { useState } from 'react'
+import { gql, useQuery, useMutation } from 'urql'
+import FormDialog from '../dialogs/FormDialog'
+import Spinner from '../loading/components/Spinner'
+import { GenericError } from '../error-pages'
+import { FormContainer, FormField } from '../forms'
+import NumberField from '../util/NumberField'
+import { fieldErrors, nonFieldErrors } from '../util/errutil'
+
+const query = gql`
+  query ($id: ID!) {
+    rotation(id: $id) {
+      id
+      users {
+        id
+        name
+      }
+      participantShiftLengths
+    }
+  }
+`
+
+const mutation = gql`
+  mutation ($input: UpdateRotationInput!) {
+    updateRotation(input: $input)
+  }
+`
+
+interface Value {
+  shiftLength: number
+}
+
+// RotationSetShiftLengthDialog sets the shift length of a single participant
+// of a participant rotation.
+const RotationSetShiftLengthDialog = (props: {
+  rotationID: string
+  userIndex: number
+  onClose: () => void
+}): React.JSX.Element => {
+  const { rotationID, userIndex, onClose } = props
+  const [{ fetching, data, error }] = useQuery({
+    query,
+    variables: {
+      id: rotationID,
+    },
+  })
+  const [value, setValue] = useState<Value | null>(null)
+  const [{ error: mError }, commit] = useMutation(mutation)
+
+  if (fetching && !data) return <Spinner />
+  if (error) return <GenericError error={error.message} />
+  const { users, participantShiftLengths } = data.rotation
+  const shiftLength = value?.shiftLength ?? participantShiftLengths[userIndex]
+
+  return (
+    <FormDialog
+      title='Set Shift Length'
+      subTitle={`Set the shift length of ${users[userIndex].name} on this rotation.`}
+      errors={nonFieldErrors(mError)}
+      onClose={onClose}
+      onSubmit={() =>
+        commit(
+          {
+            input: {
+              id: rotationID,
+              participantShiftLengths: participantShiftLengths.map(
+                (l: number, i: number) => (i === userIndex ? shiftLength : l),
+              ),
+            },
+          },
+          { additionalTypenames: ['Rotation'] },
+        ).then((res) => {
+          if (res.error) return
+          onClose()
+        })
+      }
+      form={
+        <FormContainer
+          // only this user's length is changed, so any field error is for it
+          errors={fieldErrors(mError).map((e) => ({
+            ...e,
+            field: 'shiftLength',
+          }))}
+          value={{ shiftLength }}
+          onChange={(v: Value) => setValue(v)}
+        >
+          <FormField
+            fullWidth
+            component={NumberField}
+            required
+            type='number'
+            name='shiftLength'
+            label='Shift Length (hours)'
+            min={1}
+            max={9000}
+          />
+        </FormContainer>
+      }
+    />
+  )
+}
+
+export default RotationSetShiftLengthDialog
@@ -10,6 +10,7 @@ import FlatList from '../lists/FlatList'
 import { reorderList, calcNewActiveIndex } from './util'
 import OtherActions from '../util/OtherActions'
 import RotationSetActiveDialog from './RotationSetActiveDialog'
+import RotationSetShiftLengthDialog from './RotationSetShiftLengthDialog'
 import RotationUserDeleteDialog from './RotationUserDeleteDialog'
 import { UserAvatar } from '../util/avatars'
 import { styles as globalStyles } from '../styles/materialStyles'
@@ -30,9 +31,11 @@ const query = gql`
         name
       }
       timeZone
+      type
       activeUserIndex
       nextHandoffTimes
       userIDs
+      participantShiftLengths
     }
   }
 `
@@ -65,6 +68,9 @@ function RotationUserList(props: RotationUserListProps): React.JSX.Element {
   const { rotationID } = props
   const [deleteIndex, setDeleteIndex] = useState<number | null>(null)
   const [setActiveIndex, setSetActiveIndex] = useState<number | null>(null)
+  const [shiftLengthIndex, setShiftLengthIndex] = useState<number | null>(
+    null,
+  )
   const [showAddUser, setShowAddUser] = useState(false)
   const [lastSwap, setLastSwap] = useState<SwapType[]>([])
   const isMobile = useIsWidthDown('md')
@@ -89,7 +95,15 @@ function RotationUserList(props: RotationUserListProps): React.JSX.Element {
   if (qError || mError)
     return <GenericError error={qError?.message || mError?.message} />
 
-  const { users, userIDs, activeUserIndex, nextHandoffTimes } = data.rotation
+  const {
+    users,
+    userIDs,
+    activeUserIndex,
+    nextHandoffTimes,
+    type,
+    participantShiftLengths,
+  } = data.rotation
+  const isParticipant = type === 'participant'
 
   // duplicate first entry
   const _nextHandoffTimes = (nextHandoffTimes || [])
@@ -156,6 +170,13 @@ function RotationUserList(props: RotationUserListProps): React.JSX.Element {
             onClose={() => setSetActiveIndex(null)}
           />
         )}
+        {shiftLengthIndex !== null && (
+          <RotationSetShiftLengthDialog
+            rotationID={rotationID}
+            userIndex={shiftLengthIndex}
+            onClose={() => setShiftLengthIndex(null)}
+          />
+        )}
         {showAddUser && (
           <RotationAddUserDialog
             rotationID={rotationID}
@@ -190,7 +211,15 @@ function RotationUserList(props: RotationUserListProps): React.JSX.Element {
             id: String(listIDs[index]),
             highlight: index === activeUserIndex,
             icon: <UserAvatar userID={u.id} />,
-            subText: handoff[index],
+            subText: isParticipant ? (
+              <React.Fragment>
+                {participantShiftLengths[index]}h shifts
+                {handoff[index] && ', '}
+                {handoff[index]}
+              </React.Fragment>
+            ) : (
+              handoff[index]
+            ),
             secondaryAction: (
               <OtherActions
                 actions={[
@@ -198,6 +227,14 @@ function RotationUserList(props: RotationUserListProps): React.JSX.Element {
                     label: 'Set Active',
                     onClick: () => setSetActiveIndex(index),
                   },
+                  ...(isParticipant
+                    ? [
+                        {
+                          label: 'Set Shift Length',
+                          onClick: () => setShiftLengthIndex(index),
+                        },
+                      ]
+                    : []),
                   {
                     label: 'Remove',
                     onClick: () => setDeleteIndex(index),
@@ -258,6 +295,12 @@ function RotationUserList(props: RotationUserListProps): React.JSX.Element {
                             ? data?.rotation?.activeUserIndex
                             : newActiveIndex,
                         users,
+                        // lengths move with their user
+                        participantShiftLengths: reorderList(
+                          data.rotation.participantShiftLengths || [],
+                          oldIndex,
+                          newIndex,
+                        ),
                       },
                     },
                   })
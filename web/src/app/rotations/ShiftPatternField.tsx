@@ -0,0 +1,39 @@
+import React, { useState } from 'react'
+import { TextField, TextFieldProps } from '@mui/material'
+
+type ShiftPatternFieldProps = Omit<TextFieldProps, 'value' | 'onChange'> & {
+  value: number[] | null | undefined
+  onChange: (value: number[]) => void
+}
+
+// parsePattern converts a list of hours (e.g. "12, 12" or "48 48 72")
+// to numbers, ignoring anything that isn't a whole number.
+export function parsePattern(text: string): number[] {
+  return text
+    .split(/[\s,-]+/)
+    .map((v) => parseInt(v, 10))
+    .filter((v) => !Number.isNaN(v))
+}
+
+// ShiftPatternField allows entering the shift lengths, in hours, of a
+// pattern rotation.
+export default function ShiftPatternField(
+  props: ShiftPatternFieldProps,
+): React.JSX.Element {
+  const { value, onChange, ...rest } = props
+
+  // keep the raw text so separators can be typed freely
+  const [text, setText] = useState((value || []).join(', '))
+
+  return (
+    <TextField
+      {...rest}
+      value={text}
+      placeholder='e.g. 12, 12'
+      onChange={(e) => {
+        setText(e.target.value)
+        onChange(parsePattern(e.target.value))
+      }}
+    />
+  )
+}
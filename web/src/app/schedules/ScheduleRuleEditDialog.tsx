@@ -21,6 +21,8 @@ const query = gql`
           start
           end
           weekdayFilter
+          holidayCalendarID
+          holidayMode
         }
       }
     }
@@ -63,6 +65,8 @@ export default function ScheduleRuleEditDialog(
     rules: data.schedule.target.rules.map((r: ScheduleRuleInput) => ({
       id: r.id,
       weekdayFilter: r.weekdayFilter,
+      holidayCalendarID: r.holidayCalendarID,
+      holidayMode: r.holidayMode,
       start: gqlClockTimeToISO(r.start, zone),
       end: gqlClockTimeToISO(r.end, zone),
     })),
@@ -25,7 +25,7 @@ import { DateTime } from 'luxon'
 import { useScheduleTZ } from './useScheduleTZ'
 import { fmtLocal } from '../util/timeFormat'
 import { useIsWidthDown } from '../util/useWidth'
-import { TargetType } from '../../schema'
+import { HolidayMode, TargetType } from '../../schema'
 import { FieldError } from '../util/errutil'
 
 const days = [
@@ -100,6 +100,10 @@ export type ScheduleRuleFormValue = {
     start: string
     end: string
     weekdayFilter: boolean[]
+
+    // holiday settings are not editable here, but are preserved
+    holidayCalendarID?: string | null
+    holidayMode?: HolidayMode | null
   }[]
 }
 
@@ -23,6 +23,7 @@ const getRulesQuery = gql`
         id
         weekdayFilter
         time
+        handoffReport
         dest {
           type
           args
@@ -53,6 +54,7 @@ export default function ScheduleOnCallNotificationsCreateDialog(
   const [value, setValue] = useState<Value>({
     time: null,
     weekdayFilter: NO_DAY,
+    handoffReport: false,
     dest: {
       type: types[0].type,
       args: {},
@@ -119,7 +121,7 @@ export default function ScheduleOnCallNotificationsCreateDialog(
                         .setZone(sched.timeZone)
                         .toFormat('HH:mm'),
                     }
-                  : { dest: value.dest },
+                  : { dest: value.dest, handoffReport: value.handoffReport },
               ],
             } satisfies SetScheduleOnCallNotificationRulesInput,
           },
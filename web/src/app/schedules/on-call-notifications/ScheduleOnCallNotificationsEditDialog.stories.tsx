@@ -80,6 +80,7 @@ const meta = {
                 onCallNotificationRules: [
                   {
                     id: 'existing-id',
+                    handoffReport: false,
                     dest: {
                       type: 'single-field',
                       args: { phone_number: '+1234567890' },
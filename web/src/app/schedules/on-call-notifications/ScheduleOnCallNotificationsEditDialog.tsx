@@ -21,6 +21,7 @@ const getRulesQuery = gql`
         id
         weekdayFilter
         time
+        handoffReport
         dest {
           type
           args
@@ -61,6 +62,7 @@ export default function ScheduleOnCallNotificationsEditDialog(
   const [value, setValue] = useState<Value>({
     time: rule.time || null,
     weekdayFilter: rule.weekdayFilter || NO_DAY,
+    handoffReport: rule.handoffReport,
     dest: {
       type: rule.dest.type,
       args: rule.dest.args,
@@ -114,7 +116,11 @@ export default function ScheduleOnCallNotificationsEditDialog(
                         .setZone(sched.timeZone)
                         .toFormat('HH:mm'),
                     }
-                  : { id: rule.id, dest: value.dest },
+                  : {
+                      id: rule.id,
+                      dest: value.dest,
+                      handoffReport: value.handoffReport,
+                    },
               ],
             } satisfies SetScheduleOnCallNotificationRulesInput,
           },
@@ -16,6 +16,7 @@ const meta = {
     value: {
       time: null,
       weekdayFilter: [false, false, false, false, false, false, false],
+      handoffReport: false,
       dest: {
         type: 'single-field',
         args: {},
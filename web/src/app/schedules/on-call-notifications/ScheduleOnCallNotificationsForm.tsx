@@ -28,6 +28,7 @@ const days = ['Sun', 'Mon', 'Tue', 'Wed', 'Thu', 'Fri', 'Sat']
 export type Value = {
   time: string | null
   weekdayFilter: WeekdayFilter
+  handoffReport: boolean
   dest: DestinationInput
 }
 
@@ -85,6 +86,7 @@ export default function ScheduleOnCallNotificationsForm(
     setRuleType('time-of-day')
     props.onChange({
       ...props.value,
+      handoffReport: false,
       weekdayFilter: lastTime ? lastFilter : EVERY_DAY,
       time: lastTime || DateTime.fromObject({ hour: 9 }, { zone }).toISO(),
     })
@@ -113,6 +115,23 @@ export default function ScheduleOnCallNotificationsForm(
               value='on-change'
               control={<Radio />}
             />
+            <FormControlLabel
+              data-cy='handoff-report'
+              sx={{ pl: 4 }}
+              disabled={props.disabled || !!props.value.time}
+              label='Include a handoff report summarizing alerts from the previous shift'
+              control={
+                <Checkbox
+                  checked={props.value.handoffReport}
+                  onChange={(e) =>
+                    props.onChange({
+                      ...props.value,
+                      handoffReport: e.target.checked,
+                    })
+                  }
+                />
+              }
+            />
             <FormControlLabel
               data-cy='notify-at-time'
               disabled={props.disabled}
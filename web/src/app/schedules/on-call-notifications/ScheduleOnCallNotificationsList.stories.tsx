@@ -23,6 +23,7 @@ const meta = {
                   schedule: {
                     id: emptyScheduleID,
                     timeZone: 'America/Chicago',
+                    handoffReport: { notifyUsers: false },
                     onCallNotificationRules: [],
                   },
                 },
@@ -39,6 +40,7 @@ const meta = {
                   schedule: {
                     id: manyNotificationsScheduleID,
                     timeZone: 'America/Chicago',
+                    handoffReport: { notifyUsers: false },
                     onCallNotificationRules: [
                       {
                         id: '1',
@@ -52,6 +54,7 @@ const meta = {
                           true,
                           true,
                         ],
+                        handoffReport: false,
                         dest: {
                           displayInfo: {
                             text: 'example.com',
@@ -72,6 +75,7 @@ const meta = {
                           false,
                           false,
                         ],
+                        handoffReport: false,
                         dest: {
                           displayInfo: {
                             text: 'other.example.com',
@@ -1,5 +1,14 @@
 import React, { Suspense, useState } from 'react'
-import { Button, Grid, Card, Typography, Tooltip, Theme } from '@mui/material'
+import {
+  Button,
+  Grid,
+  Card,
+  FormControlLabel,
+  Switch,
+  Typography,
+  Tooltip,
+  Theme,
+} from '@mui/material'
 import FlatList from '../../lists/FlatList'
 import OtherActions from '../../util/OtherActions'
 import { onCallRuleSummary } from './util'
@@ -8,8 +17,8 @@ import CreateFAB from '../../lists/CreateFAB'
 import { useIsWidthDown } from '../../util/useWidth'
 import { Add } from '@mui/icons-material'
 import Error from '@mui/icons-material/Error'
-import { gql, useQuery } from 'urql'
-import { Schedule } from '../../../schema'
+import { gql, useMutation, useQuery } from 'urql'
+import { OnCallNotificationRule, Schedule } from '../../../schema'
 import { DestinationAvatar } from '../../util/DestinationAvatar'
 import { styles as globalStyles } from '../../styles/materialStyles'
 import makeStyles from '@mui/styles/makeStyles'
@@ -25,10 +34,14 @@ const query = gql`
     schedule(id: $scheduleID) {
       id
       timeZone
+      handoffReport {
+        notifyUsers
+      }
       onCallNotificationRules {
         id
         time
         weekdayFilter
+        handoffReport
         dest {
           displayInfo {
             ... on DestinationDisplayInfo {
@@ -46,6 +59,19 @@ const query = gql`
   }
 `
 
+const setHandoffMut = gql`
+  mutation SetScheduleHandoffReport($input: SetScheduleHandoffReportInput!) {
+    setScheduleHandoffReport(input: $input)
+  }
+`
+
+function ruleSubText(timeZone: string, rule: OnCallNotificationRule): string {
+  const text = 'Notifies ' + onCallRuleSummary(timeZone, rule)
+  if (!rule.handoffReport) return text
+
+  return text + ' Includes handoff report.'
+}
+
 const useStyles = makeStyles((theme: Theme) => ({
   ...globalStyles(theme),
 }))
@@ -64,6 +90,7 @@ export default function ScheduleOnCallNotificationsList({
     query,
     variables: { scheduleID },
   })
+  const [handoffStatus, setHandoff] = useMutation(setHandoffMut)
 
   if (q.error || !q.data) {
     return (
@@ -103,7 +130,32 @@ export default function ScheduleOnCallNotificationsList({
         <Grid item xs={12}>
           <Card>
             <FlatList
-              headerNote={`Showing times for schedule in ${timeZone}.`}
+              headerNote={
+                <React.Fragment>
+                  {`Showing times for schedule in ${timeZone}.`}
+                  <br />
+                  <FormControlLabel
+                    label='Send handoff reports to outgoing and incoming on-call users'
+                    disabled={handoffStatus.fetching}
+                    control={
+                      <Switch
+                        checked={schedule.handoffReport.notifyUsers}
+                        onChange={(e) =>
+                          setHandoff(
+                            {
+                              input: {
+                                scheduleID,
+                                notifyUsers: e.target.checked,
+                              },
+                            },
+                            { additionalTypenames: ['Schedule'] },
+                          )
+                        }
+                      />
+                    }
+                  />
+                </React.Fragment>
+              }
               emptyMessage='No notification rules.'
               headerAction={
                 isMobile ? undefined : (
@@ -122,7 +174,7 @@ export default function ScheduleOnCallNotificationsList({
                   return {
                     icon: <DestinationAvatar error />,
                     title: `ERROR: ${display.error}`,
-                    subText: 'Notifies ' + onCallRuleSummary(timeZone, rule),
+                    subText: ruleSubText(timeZone, rule),
                     secondaryAction: (
                       <OtherActions
                         actions={[
@@ -148,7 +200,7 @@ export default function ScheduleOnCallNotificationsList({
                     />
                   ),
                   title: display.text,
-                  subText: 'Notifies ' + onCallRuleSummary(timeZone, rule),
+                  subText: ruleSubText(timeZone, rule),
                   secondaryAction: (
                     <OtherActions
                       actions={[
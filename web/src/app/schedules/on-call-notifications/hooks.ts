@@ -42,6 +42,7 @@ const rulesQuery = gql`
         }
         time
         weekdayFilter
+        handoffReport
       }
     }
   }
@@ -85,6 +85,7 @@ export const onCallRuleToInput = (
     time: v.time,
     id: v.id,
     weekdayFilter: v.weekdayFilter,
+    handoffReport: v.handoffReport,
     target: { type: v.target.type, id: v.target.id },
   }
 }
@@ -27,6 +27,14 @@ export const calendarSubscriptionsQuery = gql`
         schedule {
           name
         }
+        serviceID
+        service {
+          name
+        }
+        escalationPolicyID
+        escalationPolicy {
+          name
+        }
         lastAccess
         disabled
       }
@@ -34,6 +42,30 @@ export const calendarSubscriptionsQuery = gql`
   }
 `
 
+// subscriptionTarget returns the name and URL of the schedule, service, or
+// escalation policy a subscription is for.
+function subscriptionTarget(sub: UserCalendarSubscription): {
+  name: string
+  url: string
+} {
+  if (sub.serviceID) {
+    return {
+      name: sub.service?.name ?? '',
+      url: `/services/${sub.serviceID}`,
+    }
+  }
+  if (sub.escalationPolicyID) {
+    return {
+      name: sub.escalationPolicy?.name ?? '',
+      url: `/escalation-policies/${sub.escalationPolicyID}`,
+    }
+  }
+  return {
+    name: sub.schedule?.name ?? '',
+    url: `/schedules/${sub.scheduleID}`,
+  }
+}
+
 export default function UserCalendarSubscriptionList(props: {
   userID: string
 }): JSX.Element {
@@ -59,12 +91,12 @@ export default function UserCalendarSubscriptionList(props: {
   if (error) return <GenericError error={error.message} />
   if (!_.get(data, 'user.id')) return <ObjectNotFound />
 
-  // sort by schedule names, then subscription names
+  // sort by target names, then subscription names
   const subs: UserCalendarSubscription[] = data.user.calendarSubscriptions
     .slice()
     .sort((a: UserCalendarSubscription, b: UserCalendarSubscription) => {
-      if ((a?.schedule?.name ?? '') < (b?.schedule?.name ?? '')) return -1
-      if ((a?.schedule?.name ?? '') > (b?.schedule?.name ?? '')) return 1
+      if (subscriptionTarget(a).name < subscriptionTarget(b).name) return -1
+      if (subscriptionTarget(a).name > subscriptionTarget(b).name) return 1
 
       if (a.name > b.name) return 1
       if (a.name < b.name) return -1
@@ -90,16 +122,12 @@ export default function UserCalendarSubscriptionList(props: {
     )
   }
 
-  // push schedule names as subheaders now that the array is sorted
+  // push target names as subheaders now that the array is sorted
   subs.forEach((sub: UserCalendarSubscription) => {
-    if (!subheaderDict[sub?.schedule?.name ?? '']) {
-      subheaderDict[sub?.schedule?.name ?? ''] = true
-      items.push(
-        <CompListItemNav
-          subText={sub.schedule?.name}
-          url={`/schedules/${sub.scheduleID}`}
-        />,
-      )
+    const target = subscriptionTarget(sub)
+    if (!subheaderDict[target.url]) {
+      subheaderDict[target.url] = true
+      items.push(<CompListItemNav subText={target.name} url={target.url} />)
     }
 
     // push subscriptions under relevant schedule subheaders
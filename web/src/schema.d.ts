@@ -10,6 +10,13 @@ export interface ActionInput {
   params: ExprStringMap
 }
 
+export interface AddHolidayInput {
+  calendarID: string
+  end?: null | ISODate
+  name: string
+  start: ISODate
+}
+
 export interface Alert {
   alertID: number
   createdAt: ISOTimestamp
@@ -124,6 +131,11 @@ export interface AlertsByStatus {
   unacked: number
 }
 
+export interface ApplyKeyTemplateInput {
+  keyID: string
+  templateID: string
+}
+
 export interface AuthSubject {
   providerID: string
   subjectID: string
@@ -147,8 +159,10 @@ export interface CalcRotationHandoffTimesInput {
   count: number
   from?: null | ISOTimestamp
   handoff: ISOTimestamp
+  participantShiftLengths?: null | number[]
   shiftLength?: null | ISODuration
   shiftLengthHours?: null | number
+  shiftPattern?: null | number[]
   timeZone: string
 }
 
@@ -270,24 +284,45 @@ export interface CreateHeartbeatMonitorInput {
   timeoutMinutes: number
 }
 
+export interface CreateHolidayCalendarInput {
+  description?: null | string
+  name: string
+}
+
 export interface CreateIntegrationKeyInput {
   externalSystemName?: null | string
   name: string
   serviceID?: null | string
+  templateID?: null | string
   type: IntegrationKeyType
 }
 
+export interface CreateOAuthClientInput {
+  confidential: boolean
+  description: string
+  name: string
+  redirectURIs: string[]
+}
+
 export interface CreateRotationInput {
   description?: null | string
   favorite?: null | boolean
   name: string
+  participantShiftLengths?: null | number[]
   shiftLength?: null | number
+  shiftPattern?: null | number[]
   start: ISOTimestamp
   timeZone: string
   type: RotationType
   userIDs?: null | string[]
 }
 
+export interface CreateSCIMAPIKeyInput {
+  description: string
+  expiresAt: ISOTimestamp
+  name: string
+}
+
 export interface CreateScheduleInput {
   description?: null | string
   favorite?: null | boolean
@@ -308,12 +343,23 @@ export interface CreateServiceInput {
   newIntegrationKeys?: null | CreateIntegrationKeyInput[]
 }
 
+export interface CreateShiftSwapRequestInput {
+  counterpartID: string
+  counterpartShift?: null | ShiftSwapShiftInput
+  message?: null | string
+  requesterShift: ShiftSwapShiftInput
+  scheduleID: string
+}
+
 export interface CreateUserCalendarSubscriptionInput {
+  additionalScheduleIDs?: null | string[]
   disabled?: null | boolean
+  escalationPolicyID?: null | string
   fullSchedule?: null | boolean
   name: string
   reminderMinutes?: null | number[]
-  scheduleID: string
+  scheduleID?: null | string
+  serviceID?: null | string
 }
 
 export interface CreateUserContactMethodInput {
@@ -326,6 +372,15 @@ export interface CreateUserContactMethodInput {
   value?: null | string
 }
 
+export interface CreateUserGQLAPIKeyInput {
+  allowedFields: string[]
+  description: string
+  expiresAt: ISOTimestamp
+  name: string
+  rateLimit?: null | number
+  serviceLabels?: null | string[]
+}
+
 export interface CreateUserInput {
   email?: null | string
   favorite?: null | boolean
@@ -349,11 +404,28 @@ export interface CreateUserOverrideInput {
   start: ISOTimestamp
 }
 
+export interface CreateUserUnavailabilityInput {
+  end: ISOTimestamp
+  reason?: null | string
+  start: ISOTimestamp
+  userID: string
+}
+
 export interface CreatedGQLAPIKey {
   id: string
   token: string
 }
 
+export interface CreatedOAuthClient {
+  id: string
+  secret?: null | string
+}
+
+export interface CreatedSCIMAPIKey {
+  id: string
+  token: string
+}
+
 export interface DebugCarrierInfo {
   mobileCountryCode: string
   mobileNetworkCode: string
@@ -409,6 +481,11 @@ export interface DebugSendSMSInput {
   to: string
 }
 
+export interface DeleteScheduleTierInput {
+  name: string
+  scheduleID: string
+}
+
 export interface Destination {
   args: StringMap
   displayInfo: InlineDisplayInfo
@@ -608,14 +685,45 @@ export interface HeartbeatMonitor {
 
 export type HeartbeatMonitorState = 'healthy' | 'inactive' | 'unhealthy'
 
+export interface Holiday {
+  calendarID: string
+  end: ISODate
+  id: string
+  name: string
+  start: ISODate
+}
+
+export interface HolidayCalendar {
+  description: string
+  holidays: Holiday[]
+  id: string
+  name: string
+}
+
+export type HolidayMode = 'substitute' | 'suppress'
+
 export type ID = string
 
+export type ISODate = string
+
 export type ISODuration = string
 
 export type ISORInterval = string
 
 export type ISOTimestamp = string
 
+export interface ImportHolidaysInput {
+  calendarID: string
+  iCal: string
+}
+
+export interface ImportScheduleInput {
+  data: string
+  dryRun?: null | boolean
+  format: ScheduleImportFormat
+  scheduleID: string
+}
+
 export type InlineDisplayInfo =
   | DestinationDisplayInfo
   | DestinationDisplayInfoError
@@ -628,9 +736,11 @@ export interface IntegrationKey {
   href: string
   id: string
   name: string
+  recentRequests: KeyRequest[]
   serviceID: string
   tokenInfo: TokenInfo
   type: IntegrationKeyType
+  webhookFailures: KeyWebhookFailure[]
 }
 
 export interface IntegrationKeyConnection {
@@ -645,13 +755,33 @@ export interface IntegrationKeySearchOptions {
   search?: null | string
 }
 
+export interface IntegrationKeyTemplate {
+  config: KeyConfig
+  description: string
+  id: string
+  name: string
+  samples: IntegrationKeyTemplateSample[]
+  version: number
+}
+
+export interface IntegrationKeyTemplateSample {
+  body: string
+  contentType: string
+  name: string
+}
+
 export type IntegrationKeyType =
+  | 'amazonSNS'
+  | 'cloudEvents'
   | 'email'
   | 'generic'
   | 'grafana'
+  | 'icinga'
+  | 'nagios'
   | 'prometheusAlertmanager'
   | 'site24x7'
   | 'universal'
+  | 'zabbix'
 
 export interface IntegrationKeyTypeInfo {
   enabled: boolean
@@ -664,6 +794,45 @@ export interface KeyConfig {
   defaultActions: Action[]
   oneRule?: null | KeyRule
   rules: KeyRule[]
+  templateID: string
+  templateUpgradeAvailable: boolean
+  templateVersion: number
+}
+
+export interface KeyDryRunAction {
+  dest: Destination
+  params: StringMap
+}
+
+export interface KeyDryRunInput {
+  keyID: string
+  request?: null | KeyRequestInput
+  requestID?: null | string
+}
+
+export interface KeyDryRunResult {
+  actions: KeyDryRunAction[]
+  defaultActions: boolean
+  matchedRules: KeyRule[]
+}
+
+export interface KeyRequest {
+  body: string
+  bodyTruncated: boolean
+  headers: StringMap
+  id: string
+  query: string
+  receivedAt: ISOTimestamp
+  remoteAddr: string
+  userAgent: string
+}
+
+export interface KeyRequestInput {
+  body: string
+  headers?: null | StringMap
+  query?: null | string
+  remoteAddr?: null | string
+  userAgent?: null | string
 }
 
 export interface KeyRule {
@@ -689,6 +858,17 @@ export interface KeyRuleInput {
   name: string
 }
 
+export interface KeyWebhookFailure {
+  attempts: number
+  body: string
+  contentType: string
+  error: string
+  failedAt: ISOTimestamp
+  id: string
+  statusCode: number
+  url: string
+}
+
 export interface Label {
   key: string
   value: string
@@ -754,7 +934,12 @@ export interface MessageStatusHistory {
 }
 
 export interface Mutation {
+  acceptShiftSwapRequest: boolean
   addAuthSubject: boolean
+  addHoliday: Holiday
+  applyKeyTemplate: boolean
+  cancelShiftSwapRequest: boolean
+  clearKeyWebhookFailures: boolean
   clearTemporarySchedules: boolean
   closeMatchingAlert: boolean
   createAlert?: null | Alert
@@ -763,33 +948,52 @@ export interface Mutation {
   createEscalationPolicyStep?: null | EscalationPolicyStep
   createGQLAPIKey: CreatedGQLAPIKey
   createHeartbeatMonitor?: null | HeartbeatMonitor
+  createHolidayCalendar: HolidayCalendar
   createIntegrationKey?: null | IntegrationKey
+  createOAuthClient: CreatedOAuthClient
   createRotation?: null | Rotation
+  createSCIMAPIKey: CreatedSCIMAPIKey
   createSchedule?: null | Schedule
   createService?: null | Service
+  createShiftSwapRequest: ShiftSwapRequest
   createUser?: null | User
   createUserCalendarSubscription: UserCalendarSubscription
   createUserContactMethod?: null | UserContactMethod
+  createUserGQLAPIKey: CreatedGQLAPIKey
   createUserNotificationRule?: null | UserNotificationRule
   createUserOverride?: null | UserOverride
+  createUserUnavailability: UserUnavailability
   debugCarrierInfo: DebugCarrierInfo
   debugSendSMS?: null | DebugSendSMSInfo
+  declineShiftSwapRequest: boolean
   deleteAll: boolean
   deleteAuthSubject: boolean
   deleteGQLAPIKey: boolean
+  deleteHoliday: boolean
+  deleteHolidayCalendar: boolean
+  deleteOAuthClient: boolean
+  deleteSCIMAPIKey: boolean
+  deleteScheduleTier: boolean
   deleteSecondaryToken: boolean
+  deleteUserGQLAPIKey: boolean
+  deleteUserUnavailability: boolean
   endAllAuthSessionsByCurrentUser: boolean
   escalateAlerts?: null | Alert[]
   generateKeyToken: string
+  importHolidays: number
+  importSchedule: ScheduleImportResult
   linkAccount: boolean
   promoteSecondaryToken: boolean
   reEncryptKeyringsAndConfig: boolean
+  revokeOAuthGrant: boolean
   sendContactMethodVerification: boolean
   setAlertNoiseReason: boolean
   setConfig: boolean
   setFavorite: boolean
   setLabel: boolean
+  setScheduleHandoffReport: boolean
   setScheduleOnCallNotificationRules: boolean
+  setScheduleTier: boolean
   setSystemLimits: boolean
   setTemporarySchedule: boolean
   swoAction: boolean
@@ -801,6 +1005,7 @@ export interface Mutation {
   updateEscalationPolicyStep: boolean
   updateGQLAPIKey: boolean
   updateHeartbeatMonitor: boolean
+  updateHolidayCalendar: boolean
   updateKeyConfig: boolean
   updateRotation: boolean
   updateSchedule: boolean
@@ -829,8 +1034,48 @@ export interface NotificationState {
 
 export type NotificationStatus = 'ERROR' | 'OK' | 'WARN'
 
+export interface OAuthClient {
+  confidential: boolean
+  createdAt: ISOTimestamp
+  createdBy?: null | User
+  description: string
+  id: string
+  name: string
+  redirectURIs: string[]
+}
+
+export interface OAuthGrant {
+  clientID: string
+  clientName: string
+  createdAt: ISOTimestamp
+  id: string
+  lastUsedAt?: null | ISOTimestamp
+  scopes: string[]
+}
+
+export interface OnCallFairnessReportInput {
+  end?: null | ISOTimestamp
+  period?: null | ISODuration
+  scheduleIDs?: null | string[]
+  start?: null | ISOTimestamp
+  workdayEnd?: null | ClockTime
+  workdayStart?: null | ClockTime
+}
+
+export interface OnCallFairnessReportRow {
+  afterHoursHours: Float
+  onCallHours: Float
+  pages: number
+  periodEnd: ISOTimestamp
+  periodStart: ISOTimestamp
+  userID: string
+  userName: string
+  weekendHours: Float
+}
+
 export interface OnCallNotificationRule {
   dest: Destination
+  handoffReport: boolean
   id: string
   target: Target
   time?: null | ClockTime
@@ -839,6 +1084,7 @@ export interface OnCallNotificationRule {
 
 export interface OnCallNotificationRuleInput {
   dest?: null | DestinationInput
+  handoffReport?: null | boolean
   id?: null | string
   target?: null | TargetInput
   time?: null | ClockTime
@@ -904,22 +1150,32 @@ export interface Query {
   generateSlackAppManifest: string
   gqlAPIKeys: GQLAPIKey[]
   heartbeatMonitor?: null | HeartbeatMonitor
+  holidayCalendar?: null | HolidayCalendar
+  holidayCalendars: HolidayCalendar[]
   integrationKey?: null | IntegrationKey
+  integrationKeyTemplates: IntegrationKeyTemplate[]
   integrationKeyTypes: IntegrationKeyTypeInfo[]
   integrationKeys: IntegrationKeyConnection
+  keyDryRun: KeyDryRunResult
   labelKeys: StringConnection
   labelValues: StringConnection
   labels: LabelConnection
   linkAccountInfo?: null | LinkAccountInfo
   messageLogs: MessageLogConnection
   messageStatusHistory: MessageStatusHistory[]
+  oauthClients: OAuthClient[]
+  oauthGrants: OAuthGrant[]
+  onCallFairnessReport: OnCallFairnessReportRow[]
   phoneNumberInfo?: null | PhoneNumberInfo
   rotation?: null | Rotation
   rotations: RotationConnection
   schedule?: null | Schedule
   schedules: ScheduleConnection
+  scimAPIKeys: SCIMAPIKey[]
   service?: null | Service
   services: ServiceConnection
+  shiftSwapRequest?: null | ShiftSwapRequest
+  shiftSwapRequests: ShiftSwapRequest[]
   slackChannel?: null | SlackChannel
   slackChannels: SlackChannelConnection
   slackUserGroup?: null | SlackUserGroup
@@ -930,6 +1186,7 @@ export interface Query {
   user?: null | User
   userCalendarSubscription?: null | UserCalendarSubscription
   userContactMethod?: null | UserContactMethod
+  userGQLAPIKeys: UserGQLAPIKey[]
   userOverride?: null | UserOverride
   userOverrides: UserOverrideConnection
   users: UserConnection
@@ -942,7 +1199,9 @@ export interface Rotation {
   isFavorite: boolean
   name: string
   nextHandoffTimes: ISOTimestamp[]
+  participantShiftLengths: number[]
   shiftLength: number
+  shiftPattern: number[]
   start: ISOTimestamp
   timeZone: string
   type: RotationType
@@ -964,7 +1223,23 @@ export interface RotationSearchOptions {
   search?: null | string
 }
 
-export type RotationType = 'daily' | 'hourly' | 'monthly' | 'weekly'
+export type RotationType =
+  | 'daily'
+  | 'hourly'
+  | 'monthly'
+  | 'participant'
+  | 'pattern'
+  | 'weekly'
+
+export interface SCIMAPIKey {
+  createdAt: ISOTimestamp
+  createdBy?: null | User
+  description: string
+  expiresAt: ISOTimestamp
+  id: string
+  lastUsedAt?: null | ISOTimestamp
+  name: string
+}
 
 export type SWOAction = 'execute' | 'reset'
 
@@ -1005,7 +1280,9 @@ export interface SWOStatus {
 
 export interface Schedule {
   assignedTo: Target[]
+  coverageGaps: ScheduleCoverageGap[]
   description: string
+  handoffReport: ScheduleHandoffReport
   id: string
   isFavorite: boolean
   name: string
@@ -1014,6 +1291,7 @@ export interface Schedule {
   target?: null | ScheduleTarget
   targets: ScheduleTarget[]
   temporarySchedules: TemporarySchedule[]
+  tiers: ScheduleTier[]
   timeZone: string
 }
 
@@ -1022,8 +1300,52 @@ export interface ScheduleConnection {
   pageInfo: PageInfo
 }
 
+export interface ScheduleCoverageGap {
+  detectedAt: ISOTimestamp
+  end: ISOTimestamp
+  start: ISOTimestamp
+}
+
+export interface ScheduleHandoffReport {
+  notifyUsers: boolean
+}
+
+export type ScheduleImportFormat = 'ics' | 'json'
+
+export interface ScheduleImportParticipant {
+  email: string
+  userID?: null | string
+}
+
+export interface ScheduleImportResult {
+  applied: boolean
+  rotations: ScheduleImportRotation[]
+  shifts: ScheduleImportShift[]
+  unmatchedEmails: string[]
+}
+
+export interface ScheduleImportRotation {
+  description: string
+  name: string
+  participants: ScheduleImportParticipant[]
+  shiftLength: number
+  start: ISOTimestamp
+  timeZone: string
+  type: RotationType
+}
+
+export interface ScheduleImportShift {
+  email: string
+  end: ISOTimestamp
+  start: ISOTimestamp
+  userID?: null | string
+}
+
 export interface ScheduleRule {
   end: ClockTime
+  holidayCalendar?: null | HolidayCalendar
+  holidayCalendarID?: null | string
+  holidayMode?: null | HolidayMode
   id: string
   scheduleID: string
   start: ClockTime
@@ -1033,6 +1355,8 @@ export interface ScheduleRule {
 
 export interface ScheduleRuleInput {
   end?: null | ClockTime
+  holidayCalendarID?: null | string
+  holidayMode?: null | HolidayMode
   id?: null | string
   start?: null | ClockTime
   weekdayFilter?: null | WeekdayFilter
@@ -1051,6 +1375,7 @@ export interface ScheduleTarget {
   rules: ScheduleRule[]
   scheduleID: string
   target: Target
+  tier?: null | string
 }
 
 export interface ScheduleTargetInput {
@@ -1058,6 +1383,15 @@ export interface ScheduleTargetInput {
   rules: ScheduleRuleInput[]
   scheduleID?: null | string
   target?: null | TargetInput
+  tier?: null | string
+}
+
+export interface ScheduleTier {
+  name: string
+  onCallUsers: User[]
+  rotationOffset: number
+  scheduleID: string
+  targets: ScheduleTarget[]
 }
 
 export interface SendContactMethodVerificationInput {
@@ -1124,6 +1458,11 @@ export interface SetLabelInput {
   value: string
 }
 
+export interface SetScheduleHandoffReportInput {
+  notifyUsers: boolean
+  scheduleID: string
+}
+
 export interface SetScheduleOnCallNotificationRulesInput {
   rules: OnCallNotificationRuleInput[]
   scheduleID: string
@@ -1135,6 +1474,12 @@ export interface SetScheduleShiftInput {
   userID: string
 }
 
+export interface SetScheduleTierInput {
+  name: string
+  rotationOffset?: null | number
+  scheduleID: string
+}
+
 export interface SetTemporaryScheduleInput {
   clearEnd?: null | ISOTimestamp
   clearStart?: null | ISOTimestamp
@@ -1144,6 +1489,32 @@ export interface SetTemporaryScheduleInput {
   start: ISOTimestamp
 }
 
+export interface ShiftSwapRequest {
+  counterpart?: null | User
+  counterpartShift?: null | ShiftSwapShift
+  createdAt: ISOTimestamp
+  id: string
+  message: string
+  requester?: null | User
+  requesterShift: ShiftSwapShift
+  respondedAt?: null | ISOTimestamp
+  schedule?: null | Schedule
+  scheduleID: string
+  status: ShiftSwapStatus
+}
+
+export interface ShiftSwapShift {
+  end: ISOTimestamp
+  start: ISOTimestamp
+}
+
+export interface ShiftSwapShiftInput {
+  end: ISOTimestamp
+  start: ISOTimestamp
+}
+
+export type ShiftSwapStatus = 'accepted' | 'cancelled' | 'declined' | 'pending'
+
 export interface SlackChannel {
   id: string
   name: string
@@ -1335,6 +1706,12 @@ export interface UpdateHeartbeatMonitorInput {
   timeoutMinutes?: null | number
 }
 
+export interface UpdateHolidayCalendarInput {
+  description?: null | string
+  id: string
+  name?: null | string
+}
+
 export interface UpdateKeyConfigInput {
   defaultActions?: null | ActionInput[]
   deleteRule?: null | string
@@ -1350,7 +1727,9 @@ export interface UpdateRotationInput {
   description?: null | string
   id: string
   name?: null | string
+  participantShiftLengths?: null | number[]
   shiftLength?: null | number
+  shiftPattern?: null | number[]
   start?: null | ISOTimestamp
   timeZone?: null | string
   type?: null | RotationType
@@ -1373,6 +1752,7 @@ export interface UpdateServiceInput {
 }
 
 export interface UpdateUserCalendarSubscriptionInput {
+  additionalScheduleIDs?: null | string[]
   disabled?: null | boolean
   fullSchedule?: null | boolean
   id: string
@@ -1418,17 +1798,23 @@ export interface User {
   role: UserRole
   sessions: UserSession[]
   statusUpdateContactMethodID: string
+  unavailability: UserUnavailability[]
 }
 
 export interface UserCalendarSubscription {
+  additionalScheduleIDs: string[]
   disabled: boolean
+  escalationPolicy?: null | EscalationPolicy
+  escalationPolicyID?: null | string
   fullSchedule: boolean
   id: string
   lastAccess: ISOTimestamp
   name: string
   reminderMinutes: number[]
   schedule?: null | Schedule
-  scheduleID: string
+  scheduleID?: null | string
+  service?: null | Service
+  serviceID?: null | string
   url?: null | string
 }
 
@@ -1452,6 +1838,18 @@ export interface UserContactMethod {
   value: string
 }
 
+export interface UserGQLAPIKey {
+  allowedFields: string[]
+  createdAt: ISOTimestamp
+  description: string
+  expiresAt: ISOTimestamp
+  id: string
+  lastUsed?: null | GQLAPIKeyUsage
+  name: string
+  rateLimit: number
+  serviceLabels: string[]
+}
+
 export interface UserNotificationRule {
   contactMethod?: null | UserContactMethod
   contactMethodID: string
@@ -1468,6 +1866,7 @@ export interface UserOverride {
   removeUserID: string
   start: ISOTimestamp
   target: Target
+  unavailabilityID?: null | string
 }
 
 export interface UserOverrideConnection {
@@ -1509,6 +1908,14 @@ export interface UserSession {
   userAgent: string
 }
 
+export interface UserUnavailability {
+  end: ISOTimestamp
+  id: string
+  reason: string
+  start: ISOTimestamp
+  userID: string
+}
+
 export interface VerifyContactMethodInput {
   code: number
   contactMethodID: string
@@ -1622,6 +2029,7 @@ type ConfigID =
   | 'General.DisableLabelCreation'
   | 'General.DisableCalendarSubscriptions'
   | 'Services.RequiredLabels'
+  | 'Schedules.CoverageGapDays'
   | 'Maintenance.AlertCleanupDays'
   | 'Maintenance.AlertAutoCloseDays'
   | 'Maintenance.AutoCloseAckedAlerts'
@@ -1646,6 +2054,33 @@ type ConfigID =
   | 'OIDC.UserInfoEmailPath'
   | 'OIDC.UserInfoEmailVerifiedPath'
   | 'OIDC.UserInfoNamePath'
+  | 'LDAP.Enable'
+  | 'LDAP.NewUsers'
+  | 'LDAP.OverrideName'
+  | 'LDAP.URL'
+  | 'LDAP.StartTLS'
+  | 'LDAP.SkipVerify'
+  | 'LDAP.BindDN'
+  | 'LDAP.BindPassword'
+  | 'LDAP.SearchBase'
+  | 'LDAP.SearchFilter'
+  | 'LDAP.SubjectAttribute'
+  | 'LDAP.NameAttribute'
+  | 'LDAP.EmailAttribute'
+  | 'LDAP.AllowedGroups'
+  | 'SAML.Enable'
+  | 'SAML.NewUsers'
+  | 'SAML.OverrideName'
+  | 'SAML.IdPMetadataURL'
+  | 'SAML.IdPMetadata'
+  | 'SAML.EntityID'
+  | 'SAML.SubjectAttribute'
+  | 'SAML.NameAttribute'
+  | 'SAML.EmailAttribute'
+  | 'SCIM.Enable'
+  | 'SCIM.LinkProviderID'
+  | 'SCIM.DeleteInactiveUsers'
+  | 'OAuthServer.Enable'
   | 'Mailgun.Enable'
   | 'Mailgun.APIKey'
   | 'Mailgun.EmailDomain'